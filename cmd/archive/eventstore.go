@@ -0,0 +1,34 @@
+package archive
+
+import (
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+// mustStartEventstore connects to the database directly,
+// so the archives are handled without a running ZITADEL.
+// The key storage contains the encryption keys of the system.
+func mustStartEventstore(config *Config, masterKey string) (*eventstore.Eventstore, crypto.KeyStorage) {
+	dbClient, err := database.Connect(config.Database, false)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	keyStorage, err := cryptoDB.NewKeyStorage(dbClient.DB, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	personalDataEncryption, err := eventstore.NewPersonalDataEncryption(config.EncryptionKeys.PersonalData, keyStorage, config.Eventstore.PersonalData.Enabled)
+	logging.OnError(err).Fatal("unable to load personal data encryption key")
+
+	config.Eventstore.Client = dbClient
+	config.Eventstore.PersonalDataEncryption = personalDataEncryption
+	es, err := eventstore.Start(config.Eventstore)
+	logging.OnError(err).Fatal("unable to start eventstore")
+	query.RegisterEventMappers(es)
+	quota.RegisterEventMappers(es)
+
+	return es, keyStorage
+}
@@ -0,0 +1,69 @@
+package archive
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	z_archive "github.com/zitadel/zitadel/internal/archive"
+)
+
+func NewExport() *cobra.Command {
+	var (
+		file                     string
+		instanceID               string
+		includeRuntimeAggregates bool
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "exports an instance into an archive",
+		Long: `exports the events and unique constraints of an instance into an archive.
+The archive is a file of newline delimited json which can be imported by zitadel import.
+The personal data of the events is decrypted, store the archive safely.
+Requirements:
+- database of the instance
+- stop writing to the instance during the export, the events pushed meanwhile are not exported consistently`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Panic("No master key provided")
+
+			Export(config, masterKey, &z_archive.ExportConfig{
+				InstanceID:               instanceID,
+				IncludeRuntimeAggregates: includeRuntimeAggregates,
+			}, file)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "path of the archive")
+	cmd.Flags().StringVar(&instanceID, "instance-id", "", "id of the exported instance")
+	cmd.Flags().BoolVar(&includeRuntimeAggregates, "include-runtime-aggregates", false, "export sessions, signing keys and the other aggregates created while the instance is used")
+	logging.OnError(cmd.MarkFlagRequired("file")).Fatal("unable to mark flag required")
+	logging.OnError(cmd.MarkFlagRequired("instance-id")).Fatal("unable to mark flag required")
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+func Export(config *Config, masterKey string, exportConfig *z_archive.ExportConfig, file string) {
+	logging.WithFields("instance", exportConfig.InstanceID, "file", file).Info("export started")
+	es, keyStorage := mustStartEventstore(config, masterKey)
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	logging.WithFields("file", file).OnError(err).Fatal("unable to create archive")
+	defer f.Close()
+
+	result, err := z_archive.Export(context.Background(), es, keyStorage, f, exportConfig)
+	logging.WithFields("instance", exportConfig.InstanceID).OnError(err).Fatal("export failed")
+	logging.OnError(f.Sync()).Fatal("unable to write archive")
+
+	for aggregateType, count := range result.Events {
+		logging.WithFields("aggregate_type", aggregateType, "events", count).Info("events exported")
+	}
+	logging.WithFields("instance", exportConfig.InstanceID, "unique_constraints", result.UniqueConstraints).Info("export done")
+}
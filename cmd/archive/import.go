@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	z_archive "github.com/zitadel/zitadel/internal/archive"
+)
+
+func NewImport() *cobra.Command {
+	var (
+		file         string
+		importConfig = new(z_archive.ImportConfig)
+	)
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "imports an archive as new instance",
+		Long: `imports an archive created by zitadel export as new instance.
+The archive is validated before any event is pushed, use --dry-run to validate it only.
+The ids of the aggregates are kept unless --remap-ids is set, the id of the instance is generated unless --instance-id is set.
+Requirements:
+- database with the eventstore set up by zitadel setup
+- the encryption keys of the exported system for the secrets of the events (e.g. client secrets), the import fails otherwise`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Panic("No master key provided")
+
+			Import(config, masterKey, importConfig, file)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "path of the archive")
+	cmd.Flags().StringVar(&importConfig.InstanceID, "instance-id", "", "id of the imported instance, generated if not set")
+	cmd.Flags().BoolVar(&importConfig.RemapIDs, "remap-ids", false, "generate new ids for all aggregates of the archive and the entities within them")
+	cmd.Flags().StringToStringVar(&importConfig.Domains, "domains", nil, "replaces the domains of the archive and their subdomains, e.g. --domains acme.com=acme.test")
+	cmd.Flags().BoolVar(&importConfig.DryRun, "dry-run", false, "only validate the archive")
+	cmd.Flags().IntVar(&importConfig.BulkLimit, "bulk-limit", 100, "amount of events pushed in a single transaction")
+	logging.OnError(cmd.MarkFlagRequired("file")).Fatal("unable to mark flag required")
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+func Import(config *Config, masterKey string, importConfig *z_archive.ImportConfig, file string) {
+	logging.WithFields("file", file, "dry_run", importConfig.DryRun).Info("import started")
+	es, keyStorage := mustStartEventstore(config, masterKey)
+
+	f, err := os.Open(file)
+	logging.WithFields("file", file).OnError(err).Fatal("unable to open archive")
+	defer f.Close()
+
+	result, err := z_archive.Import(context.Background(), es, keyStorage, f, importConfig)
+	if result != nil {
+		for aggregateType, count := range result.Events {
+			logging.WithFields("aggregate_type", aggregateType, "events", count).Info("events validated")
+		}
+		for _, conflict := range result.Conflicts {
+			logging.WithFields("unique_type", conflict.Type, "unique_field", conflict.Field).Warn("unique constraint already used by another instance")
+		}
+	}
+	logging.WithFields("file", file).OnError(err).Fatal("import failed")
+
+	logging.WithFields(
+		"source_instance", result.SourceInstanceID,
+		"instance", result.InstanceID,
+		"unique_constraints", result.UniqueConstraints,
+		"dry_run", importConfig.DryRun,
+	).Info("import done")
+}
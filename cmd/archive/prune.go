@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	z_archive "github.com/zitadel/zitadel/internal/archive"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func NewPrune() *cobra.Command {
+	var (
+		file        string
+		instanceIDs []string
+	)
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "prunes the expired events from the eventstore",
+		Long: `prunes the aggregates and events which expired according to the retention declared by their event types,
+e.g. device authorizations, idp intents, terminated sessions and expired verification codes.
+The events are moved into the eventstore.events_archive table if Eventstore.Retention.Archive is set, they are deleted otherwise.
+Use --file to additionally write the pruned events into an archive.
+Events are only pruned after all projections reduced them, so the projections keep working.
+Requirements:
+- database with the eventstore set up by zitadel setup`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Panic("No master key provided")
+
+			Prune(config, masterKey, file, instanceIDs)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "path of the archive the pruned events are written to")
+	cmd.Flags().StringSliceVar(&instanceIDs, "instance-ids", nil, "comma separated list of instance ids to prune, all instances are pruned if not set")
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+func Prune(config *Config, masterKey, file string, instanceIDs []string) {
+	logging.WithFields("instances", instanceIDs, "file", file).Info("prune started")
+	es, _ := mustStartEventstore(config, masterKey)
+
+	var (
+		archive eventstore.ArchiveFunc
+		f       *os.File
+		err     error
+	)
+	if file != "" {
+		f, err = os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		logging.WithFields("file", file).OnError(err).Fatal("unable to create archive")
+		defer f.Close()
+
+		archive, err = z_archive.NewPruneArchive(f)
+		logging.WithFields("file", file).OnError(err).Fatal("unable to write archive")
+	}
+
+	result, err := es.Prune(context.Background(), archive, instanceIDs...)
+	if f != nil {
+		// the events pruned before an error are written as well
+		logging.OnError(f.Sync()).Fatal("unable to write archive")
+	}
+	if result != nil {
+		logging.WithFields("aggregates", result.Aggregates, "events", result.Events).Info("events pruned")
+	}
+	logging.OnError(err).Fatal("prune failed")
+	logging.Info("prune done")
+}
@@ -0,0 +1,87 @@
+package projections
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	instanceIDs []string
+)
+
+func newRebuild() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild <projection name>",
+		Short: "rebuilds a projection from the events without downtime",
+		Long: `rebuilds a projection from the events without downtime.
+The events are reduced into shadow tables, the rows of each instance are replaced in a single transaction afterwards.
+The projection keeps serving the current state until the rows are replaced.
+The name of the projection can be passed with or without schema (e.g. projections.users8 or users8).
+Requirements:
+- database with projections set up by zitadel setup`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Panic("No master key provided")
+
+			Rebuild(config, masterKey, args[0], instanceIDs)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&instanceIDs, "instance-ids", nil, "ids of the instances to rebuild, all instances are rebuilt if not set")
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+func Rebuild(config *Config, masterKey, projectionName string, instanceIDs []string) {
+	ctx := context.Background()
+	logging.WithFields("projection", projectionName).Info("rebuild started")
+
+	dbClient, err := database.Connect(config.Database, false)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	keyStorage, err := cryptoDB.NewKeyStorage(dbClient.DB, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keyEncryption, err := crypto.NewAESCrypto(config.EncryptionKeys.OIDC, keyStorage)
+	logging.OnError(err).Fatal("unable to load oidc encryption key")
+	certEncryption, err := crypto.NewAESCrypto(config.EncryptionKeys.SAML, keyStorage)
+	logging.OnError(err).Fatal("unable to load saml encryption key")
+	// the rebuild only decrypts the personal data, so the key isn't created if it's missing
+	personalDataEncryption, err := eventstore.NewPersonalDataEncryption(config.EncryptionKeys.PersonalData, keyStorage, false)
+	logging.OnError(err).Fatal("unable to load personal data encryption key")
+
+	eventstoreClient, err := eventstore.Start(&eventstore.Config{Client: dbClient, PersonalDataEncryption: personalDataEncryption})
+	logging.OnError(err).Fatal("unable to start eventstore")
+	query.RegisterEventMappers(eventstoreClient)
+
+	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, keyEncryption, certEncryption)
+	logging.OnError(err).Fatal("unable to create projections")
+
+	err = projection.Rebuild(ctx, projectionName, instanceIDs, func(progress *crdb.RebuildProgress) {
+		logging.WithFields(
+			"projection", progress.Projection,
+			"instance", progress.InstanceID,
+			"phase", progress.Phase,
+			"sequence", progress.Sequence,
+			"processed", progress.ProcessedEvents,
+		).Info("rebuild progress")
+	})
+	logging.WithFields("projection", projectionName).OnError(err).Fatal("rebuild failed")
+
+	logging.WithFields("projection", projectionName).Info("rebuild done")
+}
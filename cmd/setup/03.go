@@ -88,6 +88,9 @@ func (mig *FirstInstance) Execute(ctx context.Context) error {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	if err != nil {
 		return err
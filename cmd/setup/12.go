@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 12.sql
+	dpopJKTStmts string
+)
+
+type AuthTokensDPoP struct {
+	dbClient *sql.DB
+}
+
+func (mig *AuthTokensDPoP) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, dpopJKTStmts)
+	return err
+}
+
+func (mig *AuthTokensDPoP) String() string {
+	return "12_auth_tokens_dpop"
+}
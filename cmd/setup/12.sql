@@ -0,0 +1,2 @@
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS dpop_jkt TEXT;
+ALTER TABLE auth.refresh_tokens ADD COLUMN IF NOT EXISTS dpop_jkt TEXT;
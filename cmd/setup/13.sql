@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS auth.pushed_auth_requests (
+    id TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    client_id TEXT NOT NULL,
+    request JSONB NOT NULL,
+    creation_date TIMESTAMPTZ NOT NULL,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, id)
+);
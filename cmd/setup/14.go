@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 14.sql
+	rateLimitsTable string
+)
+
+type RateLimitsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *RateLimitsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, rateLimitsTable)
+	return err
+}
+
+func (mig *RateLimitsTable) String() string {
+	return "14_rate_limits_table"
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS auth.rate_limits (
+    instance_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    hits INT8 NOT NULL,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, key, window_start)
+);
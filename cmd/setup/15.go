@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 15.sql
+	eventstoreSnapshotsTable string
+)
+
+type EventstoreSnapshotsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *EventstoreSnapshotsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, eventstoreSnapshotsTable)
+	return err
+}
+
+func (mig *EventstoreSnapshotsTable) String() string {
+	return "15_eventstore_snapshots_table"
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    snapshot_type TEXT NOT NULL,
+    event_sequence INT8 NOT NULL,
+    resource_owner TEXT NOT NULL,
+    change_date TIMESTAMPTZ NOT NULL,
+    creation_date TIMESTAMPTZ NOT NULL,
+    payload JSONB NOT NULL,
+
+    PRIMARY KEY (instance_id, aggregate_type, aggregate_id, snapshot_type, event_sequence)
+);
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 16.sql
+	personalDataKeysTable string
+)
+
+type PersonalDataKeysTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *PersonalDataKeysTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, personalDataKeysTable)
+	return err
+}
+
+func (mig *PersonalDataKeysTable) String() string {
+	return "16_personal_data_keys_table"
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS eventstore.personal_data_keys (
+    instance_id TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    key_id TEXT NOT NULL,
+    key JSONB NOT NULL,
+    creation_date TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, aggregate_type, aggregate_id)
+);
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 17.sql
+	eventsArchiveTable string
+)
+
+type EventsArchiveTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *EventsArchiveTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, eventsArchiveTable)
+	return err
+}
+
+func (mig *EventsArchiveTable) String() string {
+	return "17_events_archive_table"
+}
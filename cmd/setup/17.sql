@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS eventstore.events_archive (
+    id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    aggregate_version TEXT NOT NULL,
+    event_sequence INT8 NOT NULL,
+    previous_aggregate_sequence INT8,
+    previous_aggregate_type_sequence INT8,
+    creation_date TIMESTAMPTZ NOT NULL,
+    event_data JSONB,
+    editor_user TEXT NOT NULL,
+    editor_service TEXT NOT NULL,
+    resource_owner TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    archive_date TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, event_sequence)
+);
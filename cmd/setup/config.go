@@ -15,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query/projection"
 )
@@ -31,6 +32,7 @@ type Config struct {
 	DefaultInstance command.InstanceSetup
 	Machine         *id.Config
 	Projections     projection.Config
+	Eventstore      *eventstore.Config
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
@@ -67,12 +69,19 @@ type Steps struct {
 	s9EventstoreIndexes2 *EventstoreIndexesNew
 	CorrectCreationDate  *CorrectCreationDate
 	AddEventCreatedAt    *AddEventCreatedAt
+	s12AuthTokensDPoP    *AuthTokensDPoP
+	s13PushedAuthRequest *PushedAuthRequestsTable
+	s14RateLimits        *RateLimitsTable
+	s15Snapshots         *EventstoreSnapshotsTable
+	s16PersonalDataKeys  *PersonalDataKeysTable
+	s17EventsArchive     *EventsArchiveTable
 }
 
 type encryptionKeyConfig struct {
-	User *crypto.KeyConfig
-	SMTP *crypto.KeyConfig
-	OIDC *crypto.KeyConfig
+	User         *crypto.KeyConfig
+	SMTP         *crypto.KeyConfig
+	OIDC         *crypto.KeyConfig
+	PersonalData *crypto.KeyConfig
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
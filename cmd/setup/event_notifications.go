@@ -0,0 +1,50 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+const dropEventNotificationTrigger = "DROP TRIGGER IF EXISTS notify_events ON eventstore.events"
+
+var (
+	//go:embed event_notifications.sql
+	createEventNotificationTrigger string
+)
+
+// eventNotificationTrigger notifies the listeners of the inserted events on postgres
+// if the projections are triggered by event notifications,
+// the trigger is removed again as soon as they are disabled.
+// Cockroach streams the inserted events by a changefeed instead
+type eventNotificationTrigger struct {
+	dbClient       *database.DB
+	currentEnabled bool
+
+	Enabled bool `json:"enabled"`
+}
+
+func (mig *eventNotificationTrigger) SetLastExecution(lastRun map[string]interface{}) {
+	mig.currentEnabled, _ = lastRun["enabled"].(bool)
+}
+
+func (mig *eventNotificationTrigger) Check() bool {
+	return mig.currentEnabled != mig.Enabled
+}
+
+func (mig *eventNotificationTrigger) Execute(ctx context.Context) error {
+	if mig.dbClient.Type() == "cockroach" {
+		return nil
+	}
+	stmt := dropEventNotificationTrigger
+	if mig.Enabled {
+		stmt = createEventNotificationTrigger
+	}
+	_, err := mig.dbClient.ExecContext(ctx, stmt)
+	return err
+}
+
+func (mig *eventNotificationTrigger) String() string {
+	return "event_notification_trigger"
+}
@@ -0,0 +1,12 @@
+CREATE OR REPLACE FUNCTION eventstore.notify_events() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('eventstore_events', NEW.instance_id || ':' || NEW.aggregate_type);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notify_events ON eventstore.events;
+
+CREATE TRIGGER notify_events
+    AFTER INSERT ON eventstore.events
+    FOR EACH ROW EXECUTE FUNCTION eventstore.notify_events();
@@ -12,6 +12,8 @@ import (
 	"github.com/zitadel/zitadel/cmd/build"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/tls"
+	"github.com/zitadel/zitadel/internal/crypto"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/migration"
@@ -65,7 +67,14 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	dbClient, err := database.Connect(config.Database, false)
 	logging.OnError(err).Fatal("unable to connect to database")
 
-	eventstoreClient, err := eventstore.Start(&eventstore.Config{Client: dbClient})
+	personalDataEncryption, err := loadPersonalDataEncryption(dbClient, config.EncryptionKeys.PersonalData, config.Eventstore.PersonalData.Enabled, masterKey)
+	logging.OnError(err).Fatal("unable to load personal data encryption key")
+
+	eventstoreClient, err := eventstore.Start(&eventstore.Config{
+		Client:                 dbClient,
+		PersonalData:           config.Eventstore.PersonalData,
+		PersonalDataEncryption: personalDataEncryption,
+	})
 	logging.OnError(err).Fatal("unable to start eventstore")
 	migration.RegisterMappers(eventstoreClient)
 
@@ -94,6 +103,12 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.CorrectCreationDate.dbClient = dbClient
 	steps.AddEventCreatedAt.dbClient = dbClient
 	steps.AddEventCreatedAt.step10 = steps.CorrectCreationDate
+	steps.s12AuthTokensDPoP = &AuthTokensDPoP{dbClient: dbClient.DB}
+	steps.s13PushedAuthRequest = &PushedAuthRequestsTable{dbClient: dbClient.DB}
+	steps.s14RateLimits = &RateLimitsTable{dbClient: dbClient.DB}
+	steps.s15Snapshots = &EventstoreSnapshotsTable{dbClient: dbClient.DB}
+	steps.s16PersonalDataKeys = &PersonalDataKeysTable{dbClient: dbClient.DB}
+	steps.s17EventsArchive = &EventsArchiveTable{dbClient: dbClient.DB}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -109,12 +124,19 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 			es:      eventstoreClient,
 			Version: build.Version(),
 		},
+		&eventNotificationTrigger{
+			dbClient: dbClient,
+			Enabled:  config.Projections.EventNotifications,
+		},
 	}
 
 	err = migration.Migrate(ctx, eventstoreClient, steps.s1ProjectionTable)
 	logging.OnError(err).Fatal("unable to migrate step 1")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s2AssetsTable)
 	logging.OnError(err).Fatal("unable to migrate step 2")
+	// the personal data of the first instance is encrypted with the keys of this table
+	err = migration.Migrate(ctx, eventstoreClient, steps.s16PersonalDataKeys)
+	logging.OnError(err).Fatal("unable to migrate step 16")
 	err = migration.Migrate(ctx, eventstoreClient, steps.FirstInstance)
 	logging.OnError(err).Fatal("unable to migrate step 3")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s4EventstoreIndexes)
@@ -133,6 +155,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 10")
 	err = migration.Migrate(ctx, eventstoreClient, steps.AddEventCreatedAt)
 	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12AuthTokensDPoP)
+	logging.OnError(err).Fatal("unable to migrate step 12")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s13PushedAuthRequest)
+	logging.OnError(err).Fatal("unable to migrate step 13")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s14RateLimits)
+	logging.OnError(err).Fatal("unable to migrate step 14")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s15Snapshots)
+	logging.OnError(err).Fatal("unable to migrate step 15")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s17EventsArchive)
+	logging.OnError(err).Fatal("unable to migrate step 17")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
@@ -140,6 +172,14 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	}
 }
 
+func loadPersonalDataEncryption(dbClient *database.DB, keyConfig *crypto.KeyConfig, enabled bool, masterKey string) (crypto.EncryptionAlgorithm, error) {
+	keyStorage, err := crypto_db.NewKeyStorage(dbClient.DB, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return eventstore.NewPersonalDataEncryption(keyConfig, keyStorage, enabled)
+}
+
 func readStmt(fs embed.FS, folder, typ, filename string) (string, error) {
 	stmt, err := fs.ReadFile(folder + "/" + typ + "/" + filename)
 	return string(stmt), err
@@ -10,12 +10,14 @@ import (
 	"github.com/zitadel/zitadel/internal/actions"
 	admin_es "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
+	cache_config "github.com/zitadel/zitadel/internal/cache/config"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/network"
@@ -27,6 +29,7 @@ import (
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
@@ -41,6 +44,7 @@ type Config struct {
 	TLS               network.TLS
 	HTTP2HostHeader   string
 	HTTP1HostHeader   string
+	TrustedProxies    []string
 	WebAuthNName      string
 	Database          database.Config
 	Tracing           tracing.Config
@@ -66,7 +70,10 @@ type Config struct {
 	Eventstore        *eventstore.Config
 	LogStore          *logstore.Configs
 	Quotas            *QuotasConfig
+	RateLimits        *ratelimit.Config
 	Telemetry         *handlers.TelemetryPusherConfig
+	Webhooks          *handlers.WebhookDeliveryConfig
+	Caches            *cache_config.ConnectorConfig
 }
 
 type QuotasConfig struct {
@@ -98,6 +105,9 @@ func MustNewConfig(v *viper.Viper) *Config {
 	err = config.Metrics.NewMeter()
 	logging.OnError(err).Fatal("unable to set meter")
 
+	err = http_util.SetTrustedProxies(config.TrustedProxies)
+	logging.OnError(err).Fatal("unable to set trusted proxies")
+
 	id.Configure(config.Machine)
 	actions.SetHTTPConfig(&config.Actions.HTTP)
 
@@ -113,6 +123,9 @@ type encryptionKeyConfig struct {
 	SMS                  *crypto.KeyConfig
 	SMTP                 *crypto.KeyConfig
 	User                 *crypto.KeyConfig
+	Webhook              *crypto.KeyConfig
+	PersonalData         *crypto.KeyConfig
+	Action               *crypto.KeyConfig
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
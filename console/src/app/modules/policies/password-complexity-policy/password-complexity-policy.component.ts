@@ -117,6 +117,7 @@ export class PasswordComplexityPolicyComponent implements OnInit {
                 this.complexityData.hasNumber,
                 this.complexityData.hasSymbol,
                 this.complexityData.minLength,
+                this.complexityData.denyBreachedPasswords,
               )
               .then(() => {
                 this.toast.showInfo('POLICY.TOAST.SET', true);
@@ -132,6 +133,7 @@ export class PasswordComplexityPolicyComponent implements OnInit {
                 this.complexityData.hasNumber,
                 this.complexityData.hasSymbol,
                 this.complexityData.minLength,
+                this.complexityData.denyBreachedPasswords,
               )
               .then(() => {
                 this.toast.showInfo('POLICY.TOAST.SET', true);
@@ -149,6 +151,7 @@ export class PasswordComplexityPolicyComponent implements OnInit {
               this.complexityData.hasNumber,
               this.complexityData.hasSymbol,
               this.complexityData.minLength,
+              this.complexityData.denyBreachedPasswords,
             )
             .then(() => {
               this.toast.showInfo('POLICY.TOAST.SET', true);
@@ -1,6 +1,7 @@
 import { Component, Injector, Input, OnInit, Type } from '@angular/core';
 import { UntypedFormGroup } from '@angular/forms';
 import { MatLegacyDialog as MatDialog } from '@angular/material/legacy-dialog';
+import { Duration } from 'google-protobuf/google/protobuf/duration_pb';
 import { GetLockoutPolicyResponse as AdminGetPasswordLockoutPolicyResponse } from 'src/app/proto/generated/zitadel/admin_pb';
 import { GetLockoutPolicyResponse as MgmtGetPasswordLockoutPolicyResponse } from 'src/app/proto/generated/zitadel/management_pb';
 import { LockoutPolicy } from 'src/app/proto/generated/zitadel/policy_pb';
@@ -23,6 +24,8 @@ export class PasswordLockoutPolicyComponent implements OnInit {
 
   public lockoutForm!: UntypedFormGroup;
   public lockoutData?: LockoutPolicy.AsObject;
+  public lockoutDurationMinutes: number = 0;
+  public progressiveDelaySeconds: number = 0;
   public PolicyComponentServiceType: any = PolicyComponentServiceType;
   public InfoSectionType: any = InfoSectionType;
 
@@ -44,6 +47,8 @@ export class PasswordLockoutPolicyComponent implements OnInit {
     this.getData().then((resp) => {
       if (resp.policy) {
         this.lockoutData = resp.policy;
+        this.lockoutDurationMinutes = (resp.policy.lockoutDuration?.seconds ?? 0) / 60;
+        this.progressiveDelaySeconds = resp.policy.progressiveDelay?.seconds ?? 0;
       }
     });
   }
@@ -99,12 +104,31 @@ export class PasswordLockoutPolicyComponent implements OnInit {
     }
   }
 
+  public incrementMaxOTPAttempts(): void {
+    if (this.lockoutData?.maxOtpAttempts !== undefined) {
+      this.lockoutData.maxOtpAttempts++;
+    }
+  }
+
+  public decrementMaxOTPAttempts(): void {
+    if (this.lockoutData?.maxOtpAttempts && this.lockoutData?.maxOtpAttempts > 0) {
+      this.lockoutData.maxOtpAttempts--;
+    }
+  }
+
   public savePolicy(): void {
     let promise: Promise<any>;
     if (this.lockoutData) {
+      const lockoutDuration = new Duration().setSeconds((this.lockoutDurationMinutes ?? 0) * 60);
+      const progressiveDelay = new Duration().setSeconds(this.progressiveDelaySeconds ?? 0);
       if (this.service instanceof AdminService) {
         promise = this.service
-          .updateLockoutPolicy(this.lockoutData.maxPasswordAttempts)
+          .updateLockoutPolicy(
+            this.lockoutData.maxPasswordAttempts,
+            this.lockoutData.maxOtpAttempts,
+            lockoutDuration,
+            progressiveDelay,
+          )
           .then(() => {
             this.toast.showInfo('POLICY.TOAST.SET', true);
             this.fetchData();
@@ -115,7 +139,12 @@ export class PasswordLockoutPolicyComponent implements OnInit {
       } else {
         if ((this.lockoutData as LockoutPolicy.AsObject).isDefault) {
           promise = (this.service as ManagementService)
-            .addCustomLockoutPolicy(this.lockoutData.maxPasswordAttempts)
+            .addCustomLockoutPolicy(
+              this.lockoutData.maxPasswordAttempts,
+              this.lockoutData.maxOtpAttempts,
+              lockoutDuration,
+              progressiveDelay,
+            )
             .then(() => {
               this.toast.showInfo('POLICY.TOAST.SET', true);
               this.fetchData();
@@ -125,7 +154,12 @@ export class PasswordLockoutPolicyComponent implements OnInit {
             });
         } else {
           promise = (this.service as ManagementService)
-            .updateCustomLockoutPolicy(this.lockoutData.maxPasswordAttempts)
+            .updateCustomLockoutPolicy(
+              this.lockoutData.maxPasswordAttempts,
+              this.lockoutData.maxOtpAttempts,
+              lockoutDuration,
+              progressiveDelay,
+            )
             .then(() => {
               this.toast.showInfo('POLICY.TOAST.SET', true);
               this.fetchData();
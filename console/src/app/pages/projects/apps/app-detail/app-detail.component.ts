@@ -101,6 +101,7 @@ export class AppDetailComponent implements OnInit, OnDestroy {
     OIDCGrantType.OIDC_GRANT_TYPE_IMPLICIT,
     OIDCGrantType.OIDC_GRANT_TYPE_DEVICE_CODE,
     OIDCGrantType.OIDC_GRANT_TYPE_REFRESH_TOKEN,
+    OIDCGrantType.OIDC_GRANT_TYPE_TOKEN_EXCHANGE,
   ];
   public oidcAppTypes: OIDCAppType[] = [
     OIDCAppType.OIDC_APP_TYPE_WEB,
@@ -162,6 +163,8 @@ export class AppDetailComponent implements OnInit, OnDestroy {
     this.oidcForm = this.fb.group({
       devMode: [{ value: false, disabled: true }],
       skipNativeAppSuccessPage: [{ value: false, disabled: true }],
+      requirePushedAuthRequest: [{ value: false, disabled: true }],
+      backChannelLogoutUri: [{ value: '', disabled: true }],
       clientId: [{ value: '', disabled: true }],
       responseTypesList: [{ value: [], disabled: true }],
       grantTypesList: [{ value: [], disabled: true }],
@@ -174,6 +177,7 @@ export class AppDetailComponent implements OnInit, OnDestroy {
       accessTokenRoleAssertion: [{ value: false, disabled: true }],
       idTokenRoleAssertion: [{ value: false, disabled: true }],
       idTokenUserinfoAssertion: [{ value: false, disabled: true }],
+      dpopBoundAccessTokens: [{ value: false, disabled: true }],
       clockSkewSeconds: [{ value: 0, disabled: true }],
     });
 
@@ -547,6 +551,7 @@ export class AppDetailComponent implements OnInit, OnDestroy {
         this.app.oidcConfig.accessTokenRoleAssertion = this.accessTokenRoleAssertion?.value;
         this.app.oidcConfig.idTokenRoleAssertion = this.idTokenRoleAssertion?.value;
         this.app.oidcConfig.idTokenUserinfoAssertion = this.idTokenUserinfoAssertion?.value;
+        this.app.oidcConfig.dpopBoundAccessTokens = !!this.dpopBoundAccessTokens?.value;
 
         // redirects
         this.app.oidcConfig.redirectUrisList = this.redirectUrisList;
@@ -554,6 +559,8 @@ export class AppDetailComponent implements OnInit, OnDestroy {
         this.app.oidcConfig.additionalOriginsList = this.additionalOriginsList;
         this.app.oidcConfig.devMode = !!this.devMode?.value;
         this.app.oidcConfig.skipNativeAppSuccessPage = !!this.skipNativeAppSuccessPage?.value;
+        this.app.oidcConfig.requirePushedAuthRequest = !!this.requirePushedAuthRequest?.value;
+        this.app.oidcConfig.backChannelLogoutUri = this.backChannelLogoutUri?.value ?? '';
 
         const req = new UpdateOIDCAppConfigRequest();
         req.setProjectId(this.projectId);
@@ -570,6 +577,7 @@ export class AppDetailComponent implements OnInit, OnDestroy {
         req.setAccessTokenRoleAssertion(this.app.oidcConfig.accessTokenRoleAssertion);
         req.setIdTokenRoleAssertion(this.app.oidcConfig.idTokenRoleAssertion);
         req.setIdTokenUserinfoAssertion(this.app.oidcConfig.idTokenUserinfoAssertion);
+        req.setDpopBoundAccessTokens(this.app.oidcConfig.dpopBoundAccessTokens);
 
         // redirects
         req.setRedirectUrisList(this.app.oidcConfig.redirectUrisList);
@@ -577,6 +585,8 @@ export class AppDetailComponent implements OnInit, OnDestroy {
         req.setPostLogoutRedirectUrisList(this.app.oidcConfig.postLogoutRedirectUrisList);
         req.setDevMode(this.app.oidcConfig.devMode);
         req.setSkipNativeAppSuccessPage(this.app.oidcConfig.skipNativeAppSuccessPage);
+        req.setRequirePushedAuthRequest(this.app.oidcConfig.requirePushedAuthRequest);
+        req.setBackChannelLogoutUri(this.app.oidcConfig.backChannelLogoutUri);
 
         if (this.clockSkewSeconds?.value) {
           const dur = new Duration();
@@ -755,6 +765,14 @@ export class AppDetailComponent implements OnInit, OnDestroy {
     return this.oidcForm.get('skipNativeAppSuccessPage') as FormControl<boolean>;
   }
 
+  public get requirePushedAuthRequest(): FormControl<boolean> | null {
+    return this.oidcForm.get('requirePushedAuthRequest') as FormControl<boolean>;
+  }
+
+  public get backChannelLogoutUri(): FormControl<string> | null {
+    return this.oidcForm.get('backChannelLogoutUri') as FormControl<string>;
+  }
+
   public get accessTokenType(): AbstractControl | null {
     return this.oidcTokenForm.get('accessTokenType');
   }
@@ -771,6 +789,10 @@ export class AppDetailComponent implements OnInit, OnDestroy {
     return this.oidcTokenForm.get('idTokenUserinfoAssertion');
   }
 
+  public get dpopBoundAccessTokens(): AbstractControl | null {
+    return this.oidcTokenForm.get('dpopBoundAccessTokens');
+  }
+
   public get clockSkewSeconds(): AbstractControl | null {
     return this.oidcTokenForm.get('clockSkewSeconds');
   }
@@ -1,4 +1,5 @@
 import { Injectable } from '@angular/core';
+import { Duration } from 'google-protobuf/google/protobuf/duration_pb';
 import { BehaviorSubject, catchError, finalize, from, map, Observable, of, Subject, switchMap, tap } from 'rxjs';
 
 import {
@@ -624,6 +625,7 @@ export class AdminService {
     hasNumber: boolean,
     hasSymbol: boolean,
     minLength: number,
+    denyBreachedPasswords: boolean,
   ): Promise<UpdatePasswordComplexityPolicyResponse.AsObject> {
     const req = new UpdatePasswordComplexityPolicyRequest();
     req.setHasLowercase(hasLowerCase);
@@ -631,6 +633,7 @@ export class AdminService {
     req.setHasNumber(hasNumber);
     req.setHasSymbol(hasSymbol);
     req.setMinLength(minLength);
+    req.setDenyBreachedPasswords(denyBreachedPasswords);
     return this.grpcService.admin.updatePasswordComplexityPolicy(req, null).then((resp) => resp.toObject());
   }
 
@@ -763,9 +766,17 @@ export class AdminService {
     return this.grpcService.admin.getLockoutPolicy(req, null).then((resp) => resp.toObject());
   }
 
-  public updateLockoutPolicy(maxAttempts: number): Promise<UpdateLockoutPolicyResponse.AsObject> {
+  public updateLockoutPolicy(
+    maxAttempts: number,
+    maxOTPAttempts: number,
+    lockoutDuration: Duration,
+    progressiveDelay: Duration,
+  ): Promise<UpdateLockoutPolicyResponse.AsObject> {
     const req = new UpdateLockoutPolicyRequest();
     req.setMaxPasswordAttempts(maxAttempts);
+    req.setMaxOtpAttempts(maxOTPAttempts);
+    req.setLockoutDuration(lockoutDuration);
+    req.setProgressiveDelay(progressiveDelay);
 
     return this.grpcService.admin.updateLockoutPolicy(req, null).then((resp) => resp.toObject());
   }
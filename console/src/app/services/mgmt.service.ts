@@ -1,5 +1,6 @@
 import { Injectable } from '@angular/core';
 import { SortDirection } from '@angular/material/sort';
+import { Duration } from 'google-protobuf/google/protobuf/duration_pb';
 import { Empty } from 'google-protobuf/google/protobuf/empty_pb';
 import { Timestamp } from 'google-protobuf/google/protobuf/timestamp_pb';
 import { BehaviorSubject } from 'rxjs';
@@ -1460,6 +1461,7 @@ export class ManagementService {
     hasNumber: boolean,
     hasSymbol: boolean,
     minLength: number,
+    denyBreachedPasswords: boolean,
   ): Promise<AddCustomPasswordComplexityPolicyResponse.AsObject> {
     const req = new AddCustomPasswordComplexityPolicyRequest();
     req.setHasLowercase(hasLowerCase);
@@ -1467,6 +1469,7 @@ export class ManagementService {
     req.setHasNumber(hasNumber);
     req.setHasSymbol(hasSymbol);
     req.setMinLength(minLength);
+    req.setDenyBreachedPasswords(denyBreachedPasswords);
     return this.grpcService.mgmt.addCustomPasswordComplexityPolicy(req, null).then((resp) => resp.toObject());
   }
 
@@ -1481,6 +1484,7 @@ export class ManagementService {
     hasNumber: boolean,
     hasSymbol: boolean,
     minLength: number,
+    denyBreachedPasswords: boolean,
   ): Promise<UpdateCustomPasswordComplexityPolicyResponse.AsObject> {
     const req = new UpdateCustomPasswordComplexityPolicyRequest();
     req.setHasLowercase(hasLowerCase);
@@ -1488,6 +1492,7 @@ export class ManagementService {
     req.setHasNumber(hasNumber);
     req.setHasSymbol(hasSymbol);
     req.setMinLength(minLength);
+    req.setDenyBreachedPasswords(denyBreachedPasswords);
     return this.grpcService.mgmt.updateCustomPasswordComplexityPolicy(req, null).then((resp) => resp.toObject());
   }
 
@@ -1496,9 +1501,17 @@ export class ManagementService {
     return this.grpcService.mgmt.getLockoutPolicy(req, null).then((resp) => resp.toObject());
   }
 
-  public addCustomLockoutPolicy(maxAttempts: number): Promise<AddCustomLockoutPolicyResponse.AsObject> {
+  public addCustomLockoutPolicy(
+    maxAttempts: number,
+    maxOTPAttempts: number,
+    lockoutDuration: Duration,
+    progressiveDelay: Duration,
+  ): Promise<AddCustomLockoutPolicyResponse.AsObject> {
     const req = new AddCustomLockoutPolicyRequest();
     req.setMaxPasswordAttempts(maxAttempts);
+    req.setMaxOtpAttempts(maxOTPAttempts);
+    req.setLockoutDuration(lockoutDuration);
+    req.setProgressiveDelay(progressiveDelay);
 
     return this.grpcService.mgmt.addCustomLockoutPolicy(req, null).then((resp) => resp.toObject());
   }
@@ -1508,9 +1521,17 @@ export class ManagementService {
     return this.grpcService.mgmt.resetLockoutPolicyToDefault(req, null).then((resp) => resp.toObject());
   }
 
-  public updateCustomLockoutPolicy(maxAttempts: number): Promise<UpdateCustomLockoutPolicyResponse.AsObject> {
+  public updateCustomLockoutPolicy(
+    maxAttempts: number,
+    maxOTPAttempts: number,
+    lockoutDuration: Duration,
+    progressiveDelay: Duration,
+  ): Promise<UpdateCustomLockoutPolicyResponse.AsObject> {
     const req = new UpdateCustomLockoutPolicyRequest();
     req.setMaxPasswordAttempts(maxAttempts);
+    req.setMaxOtpAttempts(maxOTPAttempts);
+    req.setLockoutDuration(lockoutDuration);
+    req.setProgressiveDelay(progressiveDelay);
 
     return this.grpcService.mgmt.updateCustomLockoutPolicy(req, null).then((resp) => resp.toObject());
   }
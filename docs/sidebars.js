@@ -242,6 +242,7 @@ module.exports = {
             "guides/integrate/access-zitadel-apis",
             "guides/integrate/access-zitadel-system-api",
             "guides/integrate/event-api",
+            "guides/integrate/webhooks",
             {
               type: "category",
               label: "Example code",
@@ -542,6 +543,10 @@ module.exports = {
         "apis/actions/internal-authentication",
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
+        "apis/actions/complement-saml-response",
+        "apis/actions/management-flows",
+        "apis/actions/http-target",
+        "apis/actions/testing",
         "apis/actions/objects",
       ]
     },
@@ -592,7 +597,13 @@ module.exports = {
         "self-hosting/manage/tls_modes",
         "self-hosting/manage/database/database",
         "self-hosting/manage/updating_scaling",
-        "self-hosting/manage/quotas"
+        "self-hosting/manage/quotas",
+        "self-hosting/manage/rate-limits",
+        "self-hosting/manage/projections",
+        "self-hosting/manage/personal-data",
+        "self-hosting/manage/instance-export",
+        "self-hosting/manage/event-retention",
+        "self-hosting/manage/cache"
       ],
     },
   ],
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/sirupsen/logrus"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	z_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -18,7 +20,7 @@ type Config struct {
 
 var ErrHalt = errors.New("interrupt")
 
-type jsAction func(fields, fields) error
+type jsAction func(fields, goja.Value) error
 
 const (
 	actionStartedMessage   = "action run started"
@@ -30,7 +32,7 @@ func actionFailedMessage(err error) string {
 }
 
 func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script, name string, opts ...Option) (err error) {
-	config := newRunConfig(ctx, append(opts, withLogger(ctx))...)
+	config := newRunConfig(ctx, append([]Option{withLogger(ctx), withCrypto(), withJWT(ctx), withQuery(ctx)}, opts...)...)
 	if config.functionTimeout == 0 {
 		return z_errs.ThrowInternal(nil, "ACTIO-uCpCx", "Errrors.Internal")
 	}
@@ -49,11 +51,18 @@ func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script
 		} else {
 			config.logger.log(actionSucceededMessage, logrus.InfoLevel, true)
 		}
+		if config.sandbox != nil {
+			config.sandbox.Err = err
+		}
 		if config.allowedToFail {
 			err = nil
 		}
 	}()
 
+	if config.target != nil {
+		return executeTarget(ctx, config, ctxParam, apiParam, name)
+	}
+
 	if err := executeScript(config, ctxParam, apiParam, script); err != nil {
 		return err
 	}
@@ -126,16 +135,22 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 		err = fmt.Errorf("unknown error occurred: %v", r)
 	}()
 
-	if err = fn(config.ctxParam.fields, config.apiParam.fields); err != nil {
+	if err = fn(config.ctxParam.fields, config.api()); err != nil {
 		return err
 	}
 	return nil
 }
 
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 3)
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
+	if a.SigningKey != nil {
+		opts = append(opts, WithSigningKey(a.ID, a.SigningKey))
+	}
+	if a.TargetType == domain.ActionTargetTypeHTTP {
+		opts = append(opts, WithHTTPTarget(a.TargetURL, a.SigningKey))
+	}
 	return opts
 }
@@ -2,11 +2,14 @@ package actions
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
 )
 
 const (
@@ -21,6 +24,14 @@ func WithAllowedToFail() Option {
 	}
 }
 
+// WithSigningKey sets the key of the action to sign the tokens of the jwt module
+func WithSigningKey(keyID string, signingKey *crypto.CryptoValue) Option {
+	return func(c *runConfig) {
+		c.signingKeyID = keyID
+		c.signingKey = signingKey
+	}
+}
+
 type runConfig struct {
 	allowedToFail bool
 	functionTimeout,
@@ -31,6 +42,12 @@ type runConfig struct {
 	vm         *goja.Runtime
 	ctxParam   *ctxConfig
 	apiParam   *apiConfig
+	target     *httpTarget
+	sandbox    *Sandbox
+	// signingKey signs the tokens of the jwt module
+	signingKey    *crypto.CryptoValue
+	signingKeyID  string
+	httpTransport http.RoundTripper
 }
 
 func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
@@ -47,6 +64,7 @@ func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
 		scriptTimeout:   maxPrepareTimeout,
 		modules:         map[string]require.ModuleLoader{},
 		vm:              vm,
+		httpTransport:   new(transport),
 		ctxParam: &ctxConfig{
 			FieldConfig: FieldConfig{
 				Runtime: vm,
@@ -72,6 +90,15 @@ func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
 	return config
 }
 
+// api returns the api parameter of the function,
+// the calls are only recorded if the action runs in a sandbox
+func (c *runConfig) api() goja.Value {
+	if c.sandbox != nil {
+		return c.sandbox.api(c.vm, "")
+	}
+	return c.vm.ToValue(c.apiParam.fields)
+}
+
 func (c *runConfig) StartFunction() *time.Timer {
 	c.vm.ClearInterrupt()
 	return time.AfterFunc(c.functionTimeout, func() {
@@ -0,0 +1,94 @@
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const maxRandomBytes = 1024
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+func withCrypto() Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/crypto"] = requireCrypto
+	}
+}
+
+func requireCrypto(runtime *goja.Runtime, module *goja.Object) {
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("hash", cryptoHash)).Warn("unable to set module")
+	logging.OnError(o.Set("hmac", cryptoHMAC)).Warn("unable to set module")
+	logging.OnError(o.Set("verifyHMAC", cryptoVerifyHMAC)).Warn("unable to set module")
+	logging.OnError(o.Set("randomBytes", cryptoRandomBytes)).Warn("unable to set module")
+	logging.OnError(o.Set("randomUUID", cryptoRandomUUID)).Warn("unable to set module")
+}
+
+// cryptoHash returns the hex encoded hash of the data
+func cryptoHash(algorithm, data string) string {
+	h := hashAlgorithm(algorithm)()
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cryptoHMAC returns the hex encoded HMAC of the data
+func cryptoHMAC(algorithm, key, data string) string {
+	mac := hmac.New(hashAlgorithm(algorithm), []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cryptoVerifyHMAC compares the hex encoded signature with the HMAC of the data in constant time
+func cryptoVerifyHMAC(algorithm, key, data, signature string) bool {
+	expected := cryptoHMAC(algorithm, key, data)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) == 1
+}
+
+// cryptoRandomBytes returns length hex encoded random bytes
+func cryptoRandomBytes(length int) string {
+	if length <= 0 || length > maxRandomBytes {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Iep6b", fmt.Sprintf("length must be between 1 and %d", maxRandomBytes)))
+	}
+	return hex.EncodeToString(randomBytes(length))
+}
+
+// cryptoRandomUUID returns a random (version 4) UUID
+func cryptoRandomUUID() string {
+	b := randomBytes(16)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func hashAlgorithm(algorithm string) func() hash.Hash {
+	h, ok := hashAlgorithms[algorithm]
+	if !ok {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-oo9Ch", "algorithm is invalid"))
+	}
+	return h
+}
+
+func randomBytes(length int) []byte {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
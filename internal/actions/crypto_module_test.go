@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrypto(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    func(t *testing.T, value goja.Value)
+		wantErr bool
+	}{
+		{
+			name:   "hash",
+			script: `crypto.hash('sha256', 'abc')`,
+			want: func(t *testing.T, value goja.Value) {
+				assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", value.String())
+			},
+		},
+		{
+			name:   "hmac",
+			script: `crypto.hmac('sha256', 'key', 'The quick brown fox jumps over the lazy dog')`,
+			want: func(t *testing.T, value goja.Value) {
+				assert.Equal(t, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", value.String())
+			},
+		},
+		{
+			name:   "verify hmac",
+			script: `crypto.verifyHMAC('sha1', 'key', 'The quick brown fox jumps over the lazy dog', 'de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9')`,
+			want: func(t *testing.T, value goja.Value) {
+				assert.True(t, value.ToBoolean())
+			},
+		},
+		{
+			name:   "verify hmac invalid",
+			script: `crypto.verifyHMAC('sha1', 'key', 'The quick brown fox jumps over the lazy dog', 'invalid')`,
+			want: func(t *testing.T, value goja.Value) {
+				assert.False(t, value.ToBoolean())
+			},
+		},
+		{
+			name:   "random bytes",
+			script: `crypto.randomBytes(16)`,
+			want: func(t *testing.T, value goja.Value) {
+				assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), value.String())
+			},
+		},
+		{
+			name:   "random uuid",
+			script: `crypto.randomUUID()`,
+			want: func(t *testing.T, value goja.Value) {
+				assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), value.String())
+			},
+		},
+		{
+			name:    "invalid algorithm",
+			script:  `crypto.hash('md5', 'zitadel')`,
+			wantErr: true,
+		},
+		{
+			name:    "too many random bytes",
+			script:  `crypto.randomBytes(1025)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := goja.New()
+			registry := new(require.Registry)
+			registry.RegisterNativeModule("zitadel/crypto", requireCrypto)
+			registry.Enable(vm)
+
+			var value goja.Value
+			err := func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = r.(error)
+					}
+				}()
+				value, err = vm.RunString("let crypto = require('zitadel/crypto');\n" + tt.script)
+				return err
+			}()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				tt.want(t, value)
+			}
+		})
+	}
+}
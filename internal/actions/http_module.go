@@ -19,7 +19,7 @@ import (
 func WithHTTP(ctx context.Context) Option {
 	return func(c *runConfig) {
 		c.modules["zitadel/http"] = func(runtime *goja.Runtime, module *goja.Object) {
-			requireHTTP(ctx, &http.Client{Transport: new(transport)}, runtime, module)
+			requireHTTP(ctx, &http.Client{Transport: c.httpTransport}, runtime, module)
 		}
 	}
 }
@@ -170,6 +170,12 @@ func parseHeaders(headers *goja.Object) http.Header {
 	return h
 }
 
+// NewDenyListTransport returns a transport, which denies requests to the hosts of the configured deny list.
+// It's used for all calls to URLs configured by users, e.g. webhooks.
+func NewDenyListTransport() http.RoundTripper {
+	return new(transport)
+}
+
 type transport struct{}
 
 func (*transport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,188 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// jwtDefaultLifetime is used if the claims of a token to sign don't contain an expiration
+	jwtDefaultLifetime = 5 * time.Minute
+	// jwksMaxSize limits the size of the key sets to verify tokens
+	jwksMaxSize = 1 << 20
+)
+
+func withJWT(ctx context.Context) Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/jwt"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireJWT(ctx, c, runtime, module)
+		}
+	}
+}
+
+type JWT struct {
+	runtime       *goja.Runtime
+	client        *http.Client
+	signingKey    *crypto.CryptoValue
+	signingKeyID  string
+	verifyContext context.Context
+}
+
+func requireJWT(ctx context.Context, config *runConfig, runtime *goja.Runtime, module *goja.Object) {
+	j := &JWT{
+		runtime:       runtime,
+		client:        &http.Client{Transport: config.httpTransport},
+		signingKey:    config.signingKey,
+		signingKeyID:  config.signingKeyID,
+		verifyContext: ctx,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("sign", j.sign)).Warn("unable to set module")
+	logging.OnError(o.Set("verify", j.verify)).Warn("unable to set module")
+}
+
+// sign returns the claims as token signed with HS256 and the signing key of the action.
+// `iat` and `exp` are set if missing.
+func (j *JWT) sign(claims map[string]interface{}) string {
+	if j.signingKey == nil || signingKeyEncryption == nil {
+		panic(z_errs.ThrowPreconditionFailed(nil, "ACTIO-Oot5i", "action has no signing key"))
+	}
+	key, err := crypto.DecryptString(j.signingKey, signingKeyEncryption)
+	if err != nil {
+		panic(err)
+	}
+	now := time.Now()
+	if claims == nil {
+		claims = make(map[string]interface{}, 2)
+	}
+	if _, ok := claims["iat"]; !ok {
+		claims["iat"] = now.Unix()
+	}
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = now.Add(jwtDefaultLifetime).Unix()
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.HS256, Key: []byte(key)},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", j.signingKeyID),
+	)
+	if err != nil {
+		panic(err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+type verifyConfig struct {
+	JWKSURL  string
+	Issuer   string
+	Audience string
+}
+
+// verify returns the claims of the token, if it's signed by a key of the key set
+// and valid for the issuer and audience (if provided)
+//
+// the first argument is the token
+// the second argument is an object with the following fields:
+// - `jwksUrl`: url of the key set, required
+// - `issuer`: expected issuer
+// - `audience`: expected audience
+func (j *JWT) verify(call goja.FunctionCall) goja.Value {
+	token := call.Argument(0).String()
+	config := j.verifyConfigFromArg(call.Argument(1))
+
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		panic(err)
+	}
+	if len(parsed.Headers) != 1 || strings.HasPrefix(parsed.Headers[0].Algorithm, "HS") {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Aeph4", "signature algorithm is not supported"))
+	}
+	keySet := j.fetchKeySet(config.JWKSURL)
+	keys := keySet.Keys
+	if kid := parsed.Headers[0].KeyID; kid != "" {
+		keys = keySet.Key(kid)
+	}
+	for _, key := range keys {
+		claims := make(map[string]interface{})
+		registered := new(jwt.Claims)
+		if err = parsed.Claims(key, &claims, registered); err != nil {
+			continue
+		}
+		expected := jwt.Expected{
+			Issuer: config.Issuer,
+			Time:   time.Now(),
+		}
+		if config.Audience != "" {
+			expected.Audience = jwt.Audience{config.Audience}
+		}
+		if err = registered.Validate(expected); err != nil {
+			panic(err)
+		}
+		return j.runtime.ToValue(claims)
+	}
+	panic(z_errs.ThrowInvalidArgument(err, "ACTIO-ieK3u", "signature is invalid"))
+}
+
+func (j *JWT) verifyConfigFromArg(arg goja.Value) *verifyConfig {
+	config := new(verifyConfig)
+	if goja.IsUndefined(arg) || goja.IsNull(arg) {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Ie4ai", "jwksUrl is required"))
+	}
+	o := arg.ToObject(j.runtime)
+	for _, key := range o.Keys() {
+		switch key {
+		case "jwksUrl":
+			config.JWKSURL = o.Get(key).String()
+		case "issuer":
+			config.Issuer = o.Get(key).String()
+		case "audience":
+			config.Audience = o.Get(key).String()
+		default:
+			panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Cheo3", "key is invalid"))
+		}
+	}
+	if config.JWKSURL == "" {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Jw5ur", "jwksUrl is required"))
+	}
+	return config
+}
+
+func (j *JWT) fetchKeySet(url string) *jose.JSONWebKeySet {
+	req, err := http.NewRequestWithContext(j.verifyContext, http.MethodGet, url, nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if deadline, ok := j.verifyContext.Deadline(); ok {
+		j.client.Timeout = time.Until(deadline)
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		panic(fmt.Errorf("unexpected response status of key set %s", resp.Status))
+	}
+	keySet := new(jose.JSONWebKeySet)
+	if err = json.NewDecoder(io.LimitReader(resp.Body, jwksMaxSize)).Decode(keySet); err != nil {
+		panic(err)
+	}
+	return keySet
+}
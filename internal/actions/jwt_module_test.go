@@ -0,0 +1,157 @@
+package actions
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestJWT_sign(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetSigningKeyEncryption(crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("signingKey"),
+	}
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			name: "signed",
+			opts: []Option{WithSigningKey("actionID", signingKey)},
+		},
+		{
+			name:    "no signing key, error",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var token string
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx,
+				nil,
+				WithAPIFields(SetFields("setToken", func(t string) { token = t })),
+				`let jwt = require('zitadel/jwt');
+function test(ctx, api) {
+	api.setToken(jwt.sign({sub: 'userID'}));
+}`,
+				"test",
+				tt.opts...,
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			parsed, err := jwt.ParseSigned(token)
+			require.NoError(t, err)
+			assert.Equal(t, "actionID", parsed.Headers[0].KeyID)
+			claims := new(jwt.Claims)
+			require.NoError(t, parsed.Claims([]byte("signingKey"), claims))
+			assert.Equal(t, "userID", claims.Subject)
+			assert.NoError(t, claims.Validate(jwt.Expected{Time: time.Now()}))
+		})
+	}
+}
+
+func TestJWT_verify(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: privateKey.Public(), KeyID: "key1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	}))
+	defer server.Close()
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: privateKey, KeyID: "key1"}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+	hmacSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		signer  jose.Signer
+		claims  jwt.Claims
+		issuer  string
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			signer: signer,
+			claims: jwt.Claims{Subject: "userID", Issuer: "issuer", Expiry: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+			issuer: "issuer",
+		},
+		{
+			name:    "expired, error",
+			signer:  signer,
+			claims:  jwt.Claims{Subject: "userID", Issuer: "issuer", Expiry: jwt.NewNumericDate(time.Now().Add(-time.Hour))},
+			issuer:  "issuer",
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer, error",
+			signer:  signer,
+			claims:  jwt.Claims{Subject: "userID", Issuer: "other"},
+			issuer:  "issuer",
+			wantErr: true,
+		},
+		{
+			name:    "symmetric algorithm, error",
+			signer:  hmacSigner,
+			claims:  jwt.Claims{Subject: "userID", Issuer: "issuer"},
+			issuer:  "issuer",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.Signed(tt.signer).Claims(tt.claims).CompactSerialize()
+			require.NoError(t, err)
+			var subject string
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err = Run(ctx,
+				SetContextFields(SetFields("token", token), SetFields("jwksUrl", server.URL), SetFields("issuer", tt.issuer)),
+				WithAPIFields(SetFields("setSubject", func(s string) { subject = s })),
+				`let jwt = require('zitadel/jwt');
+function test(ctx, api) {
+	let claims = jwt.verify(ctx.token, {jwksUrl: ctx.jwksUrl, issuer: ctx.issuer});
+	api.setSubject(claims.sub);
+}`,
+				"test",
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "userID", subject)
+		})
+	}
+}
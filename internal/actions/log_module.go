@@ -26,6 +26,7 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	sandbox    *Sandbox
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
@@ -66,6 +67,13 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 	if last {
 		record.Took = ts.Sub(l.started)
 	}
+	if l.sandbox != nil {
+		l.sandbox.Logs = append(l.sandbox.Logs, &LogLine{
+			LogDate: ts,
+			Level:   level,
+			Message: msg,
+		})
+	}
 
 	logstoreService.Handle(l.ctx, record)
 }
@@ -0,0 +1,129 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type flowActionsQuerier interface {
+	GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string, withOwnerRemoved bool) ([]*query.Action, error)
+}
+
+// MutationCheck executes the actions of the pre triggers of the user, user grant and membership flows.
+// The mutation is rejected with the message of `api.v1.reject`,
+// or if an action fails which is not allowed to fail
+func MutationCheck(queries flowActionsQuerier) domain.MutationCheck {
+	return func(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, resourceOwner string, mutation interface{}) error {
+		queriedActions, err := queries.GetActiveActionsByFlowAndTriggerType(ctx, flowType, triggerType, resourceOwner, false)
+		if err != nil {
+			return err
+		}
+		fieldName, field := mutationField(mutation)
+		ctxFields := SetContextFields(
+			SetFields("v1",
+				SetFields(fieldName, field),
+			),
+		)
+		for _, action := range queriedActions {
+			var rejection string
+			apiFields := WithAPIFields(
+				SetFields("v1",
+					SetFields("reject", func(message string) {
+						rejection = message
+					}),
+				),
+			)
+			actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+			err = Run(
+				actionCtx,
+				ctxFields,
+				apiFields,
+				action.Script,
+				action.Name,
+				append(ActionToOptions(action), WithHTTP(actionCtx))...,
+			)
+			cancel()
+			if rejection != "" {
+				return z_errs.ThrowPreconditionFailed(nil, "ACTIO-Rj3ct", rejection)
+			}
+			if err != nil {
+				return z_errs.ThrowPreconditionFailed(err, "ACTIO-F41ld", "Errors.Action.Failed")
+			}
+		}
+		return nil
+	}
+}
+
+// mutationField maps the mutation to the field passed to the actions,
+// the fields are named like the fields of the other objects of the actions (e.g. `userId`)
+func mutationField(mutation interface{}) (string, interface{}) {
+	switch m := mutation.(type) {
+	case *domain.UserMutation:
+		return "user", &userMutation{
+			Id:                m.ID,
+			Username:          m.Username,
+			Machine:           m.Machine,
+			FirstName:         m.FirstName,
+			LastName:          m.LastName,
+			NickName:          m.NickName,
+			DisplayName:       m.DisplayName,
+			PreferredLanguage: m.PreferredLanguage,
+			Email:             m.Email,
+			Phone:             m.Phone,
+			Name:              m.Name,
+			Description:       m.Description,
+		}
+	case *domain.UserGrantMutation:
+		return "userGrant", &userGrantMutation{
+			Id:             m.ID,
+			UserId:         m.UserID,
+			ProjectId:      m.ProjectID,
+			ProjectGrantId: m.ProjectGrantID,
+			RoleKeys:       m.RoleKeys,
+		}
+	case *domain.MembershipMutation:
+		return "membership", &membershipMutation{
+			Type:    m.Type,
+			Id:      m.ID,
+			GrantId: m.GrantID,
+			UserId:  m.UserID,
+			Roles:   m.Roles,
+		}
+	default:
+		return "mutation", mutation
+	}
+}
+
+type userMutation struct {
+	Id                string
+	Username          string
+	Machine           bool
+	FirstName         string
+	LastName          string
+	NickName          string
+	DisplayName       string
+	PreferredLanguage string
+	Email             string
+	Phone             string
+	Name              string
+	Description       string
+}
+
+type userGrantMutation struct {
+	Id             string
+	UserId         string
+	ProjectId      string
+	ProjectGrantId string
+	RoleKeys       []string
+}
+
+type membershipMutation struct {
+	Type    string
+	Id      string
+	GrantId string
+	UserId  string
+	Roles   []string
+}
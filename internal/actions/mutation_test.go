@@ -0,0 +1,83 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockFlowActions []*query.Action
+
+func (m mockFlowActions) GetActiveActionsByFlowAndTriggerType(context.Context, domain.FlowType, domain.TriggerType, string, bool) ([]*query.Action, error) {
+	return m, nil
+}
+
+func TestMutationCheck(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name     string
+		actions  []*query.Action
+		mutation interface{}
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "no actions",
+			mutation: &domain.UserMutation{Username: "gigi"},
+			wantErr:  func(err error) bool { return err == nil },
+		},
+		{
+			name: "allowed",
+			actions: []*query.Action{{
+				Name:   "check",
+				Script: `function check(ctx, api) { if (ctx.v1.user.username !== "gigi" || ctx.v1.user.id !== "user1") { throw "unexpected user" } }`,
+			}},
+			mutation: &domain.UserMutation{ID: "user1", Username: "gigi"},
+			wantErr:  func(err error) bool { return err == nil },
+		},
+		{
+			name: "rejected",
+			actions: []*query.Action{{
+				Name:          "check",
+				Script:        `function check(ctx, api) { if (ctx.v1.userGrant.roleKeys.length > 1) { api.v1.reject("only one role allowed") } }`,
+				AllowedToFail: true,
+			}},
+			mutation: &domain.UserGrantMutation{RoleKeys: []string{"a", "b"}},
+			wantErr: func(err error) bool {
+				caosErr := new(z_errs.CaosError)
+				return z_errs.IsPreconditionFailed(err) && errors.As(err, &caosErr) && caosErr.Message == "only one role allowed"
+			},
+		},
+		{
+			name: "failed",
+			actions: []*query.Action{{
+				Name:   "check",
+				Script: `function check(ctx, api) { throw "failed" }`,
+			}},
+			mutation: &domain.MembershipMutation{},
+			wantErr:  z_errs.IsPreconditionFailed,
+		},
+		{
+			name: "failed, allowed to fail",
+			actions: []*query.Action{{
+				Name:          "check",
+				Script:        `function check(ctx, api) { throw "failed" }`,
+				AllowedToFail: true,
+			}},
+			mutation: &domain.MembershipMutation{},
+			wantErr:  func(err error) bool { return err == nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MutationCheck(mockFlowActions(tt.actions))(context.Background(), domain.FlowTypeUser, domain.TriggerTypePreCreation, "org", tt.mutation)
+			if !tt.wantErr(err) {
+				t.Errorf("MutationCheck() unexpected error = (%[1]T) %[1]v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package object
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type event struct {
+	Type          string
+	Sequence      uint64
+	CreationDate  time.Time
+	AggregateId   string
+	AggregateType string
+	ResourceOwner string
+	EditorUser    string
+	Payload       interface{}
+}
+
+// EventFromEventstore passes the event to the post triggers of the flows,
+// the payload is passed as object
+func EventFromEventstore(e eventstore.Event) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		var payload interface{}
+		if data := e.DataAsBytes(); len(data) > 0 {
+			err := json.Unmarshal(data, &payload)
+			logging.WithFields("type", e.Type()).OnError(err).Warn("unable to unmarshal event payload")
+		}
+		return &event{
+			Type:          string(e.Type()),
+			Sequence:      e.Sequence(),
+			CreationDate:  e.CreationDate(),
+			AggregateId:   e.Aggregate().ID,
+			AggregateType: string(e.Aggregate().Type),
+			ResourceOwner: e.Aggregate().ResourceOwner,
+			EditorUser:    e.EditorUser(),
+			Payload:       payload,
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package object
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+var _ actions.Querier = (*Querier)(nil)
+
+// Querier implements the lookups of the `zitadel/query` module
+type Querier struct {
+	queries *query.Queries
+}
+
+func NewQuerier(queries *query.Queries) *Querier {
+	return &Querier{queries: queries}
+}
+
+func (q *Querier) UserMetadata(ctx context.Context, c *actions.FieldConfig, userID string) (goja.Value, error) {
+	metadata, err := q.queries.SearchUserMetadata(ctx, true, userID, &query.UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return UserMetadataListFromQuery(c, metadata), nil
+}
+
+func (q *Querier) OrgMetadata(ctx context.Context, c *actions.FieldConfig, orgID string) (goja.Value, error) {
+	metadata, err := q.queries.SearchOrgMetadata(ctx, true, orgID, &query.OrgMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return OrgMetadataListFromQuery(c, metadata), nil
+}
+
+func (q *Querier) UserGrants(ctx context.Context, c *actions.FieldConfig, userID string) (goja.Value, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := q.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return UserGrantsFromQuery(c, grants), nil
+}
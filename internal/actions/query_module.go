@@ -0,0 +1,72 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// Querier provides the read-only lookups of the query module,
+// the lookups are restricted to the instance of the context
+type Querier interface {
+	UserMetadata(ctx context.Context, c *FieldConfig, userID string) (goja.Value, error)
+	OrgMetadata(ctx context.Context, c *FieldConfig, orgID string) (goja.Value, error)
+	UserGrants(ctx context.Context, c *FieldConfig, userID string) (goja.Value, error)
+}
+
+var querier Querier
+
+func SetQuerier(q Querier) {
+	querier = q
+}
+
+func withQuery(ctx context.Context) Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/query"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireQuery(ctx, runtime, module)
+		}
+	}
+}
+
+type Query struct {
+	ctx    context.Context
+	config *FieldConfig
+}
+
+func requireQuery(ctx context.Context, runtime *goja.Runtime, module *goja.Object) {
+	q := &Query{
+		ctx: ctx,
+		config: &FieldConfig{
+			Runtime: runtime,
+			fields:  fields{},
+		},
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("getUserMetadata", q.lookup(Querier.UserMetadata))).Warn("unable to set module")
+	logging.OnError(o.Set("getOrgMetadata", q.lookup(Querier.OrgMetadata))).Warn("unable to set module")
+	logging.OnError(o.Set("getUserGrants", q.lookup(Querier.UserGrants))).Warn("unable to set module")
+}
+
+// lookup calls the function of the querier with the id passed as first argument
+func (q *Query) lookup(fn func(Querier, context.Context, *FieldConfig, string) (goja.Value, error)) func(id string) goja.Value {
+	return func(id string) goja.Value {
+		if querier == nil {
+			panic(z_errs.ThrowInternal(nil, "ACTIO-Ahgh3", "query module is not available"))
+		}
+		if authz.GetInstance(q.ctx).InstanceID() == "" {
+			panic(z_errs.ThrowPreconditionFailed(nil, "ACTIO-ooR4a", "no instance"))
+		}
+		if id == "" {
+			panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Eo8ie", "id is required"))
+		}
+		value, err := fn(querier, q.ctx, q.config, id)
+		if err != nil {
+			panic(err)
+		}
+		return value
+	}
+}
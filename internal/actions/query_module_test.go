@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+type mockQuerier struct {
+	instanceIDs []string
+}
+
+func (m *mockQuerier) UserMetadata(ctx context.Context, c *FieldConfig, userID string) (goja.Value, error) {
+	m.instanceIDs = append(m.instanceIDs, authz.GetInstance(ctx).InstanceID())
+	return c.Runtime.ToValue(map[string]interface{}{"metadata": []map[string]interface{}{{"key": "userID", "value": userID}}}), nil
+}
+
+func (m *mockQuerier) OrgMetadata(ctx context.Context, c *FieldConfig, orgID string) (goja.Value, error) {
+	m.instanceIDs = append(m.instanceIDs, authz.GetInstance(ctx).InstanceID())
+	return c.Runtime.ToValue(map[string]interface{}{"metadata": []map[string]interface{}{{"key": "orgID", "value": orgID}}}), nil
+}
+
+func (m *mockQuerier) UserGrants(ctx context.Context, c *FieldConfig, userID string) (goja.Value, error) {
+	m.instanceIDs = append(m.instanceIDs, authz.GetInstance(ctx).InstanceID())
+	return c.Runtime.ToValue(map[string]interface{}{"grants": []map[string]interface{}{{"userId": userID}}}), nil
+}
+
+func TestQuery(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		script          string
+		wantValues      []string
+		wantInstanceIDs []string
+		wantErr         bool
+	}{
+		{
+			name: "lookups",
+			ctx:  authz.WithInstanceID(context.Background(), "instanceID"),
+			script: `let query = require('zitadel/query');
+function test(ctx, api) {
+	api.set(query.getUserMetadata('user1').metadata[0].value);
+	api.set(query.getOrgMetadata('org1').metadata[0].value);
+	api.set(query.getUserGrants('user2').grants[0].userId);
+}`,
+			wantValues:      []string{"user1", "org1", "user2"},
+			wantInstanceIDs: []string{"instanceID", "instanceID", "instanceID"},
+		},
+		{
+			name: "no instance, error",
+			ctx:  context.Background(),
+			script: `let query = require('zitadel/query');
+function test(ctx, api) {
+	api.set(query.getUserMetadata('user1').metadata[0].value);
+}`,
+			wantErr: true,
+		},
+		{
+			name: "id missing, error",
+			ctx:  authz.WithInstanceID(context.Background(), "instanceID"),
+			script: `let query = require('zitadel/query');
+function test(ctx, api) {
+	api.set(query.getOrgMetadata('').metadata[0].value);
+}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := new(mockQuerier)
+			SetQuerier(querier)
+			defer SetQuerier(nil)
+
+			var values []string
+			ctx, cancel := context.WithTimeout(tt.ctx, 10*time.Second)
+			defer cancel()
+			err := Run(ctx,
+				nil,
+				WithAPIFields(SetFields("set", func(v string) { values = append(values, v) })),
+				tt.script,
+				"test",
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValues, values)
+			assert.Equal(t, tt.wantInstanceIDs, querier.instanceIDs)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/sirupsen/logrus"
+)
+
+// Sandbox records the effects of an action run instead of applying them.
+// The action is executed by the same runtime as in production.
+type Sandbox struct {
+	mocks []*FetchMock
+
+	Calls   []*APICall
+	Logs    []*LogLine
+	Fetches []*FetchCall
+	Took    time.Duration
+	// Err is the error of the run, even if the action is allowed to fail
+	Err error
+}
+
+// APICall is a call of a function of the `api` parameter, e.g. `v1.claims.setClaim`
+type APICall struct {
+	Function  string
+	Arguments []interface{}
+}
+
+type LogLine struct {
+	LogDate time.Time
+	Level   logrus.Level
+	Message string
+}
+
+// FetchCall is a request of the `zitadel/http` module
+type FetchCall struct {
+	Method string
+	URL    string
+	Status int
+	Mocked bool
+	Took   time.Duration
+	Error  string
+}
+
+// FetchMock responds to the requests with the method and url instead of sending them
+type FetchMock struct {
+	Method string
+	URL    string
+	Status int
+	Body   string
+}
+
+func NewSandbox(mocks ...*FetchMock) *Sandbox {
+	return &Sandbox{
+		mocks: mocks,
+	}
+}
+
+// RunInSandbox runs the action with the recorded context.
+// The context has the same format as the one posted to HTTP targets.
+// Errors of the action are set on the sandbox, the returned error only reports if the action could not be run.
+func RunInSandbox(ctx context.Context, sandbox *Sandbox, recordedContext map[string]interface{}, script, name string, opts ...Option) error {
+	started := time.Now()
+	err := Run(ctx, SetContextFields(recordedFields(recordedContext)...), nil, script, name, append(opts, withSandbox(ctx, sandbox))...)
+	sandbox.Took = time.Since(started)
+	if sandbox.Err != nil {
+		return nil
+	}
+	return err
+}
+
+// recordedFields maps the recorded context to the fields of the context,
+// results of functions are returned by functions again
+func recordedFields(recorded map[string]interface{}) []FieldOption {
+	opts := make([]FieldOption, 0, len(recorded))
+	for key, value := range recorded {
+		if targetContextFunctions[key] {
+			result := value
+			opts = append(opts, SetFields(key, func() interface{} { return result }))
+			continue
+		}
+		if sub, ok := value.(map[string]interface{}); ok && len(sub) > 0 {
+			subOpts := recordedFields(sub)
+			values := make([]interface{}, len(subOpts))
+			for i, opt := range subOpts {
+				values[i] = opt
+			}
+			opts = append(opts, SetFields(key, values...))
+			continue
+		}
+		opts = append(opts, SetFields(key, value))
+	}
+	return opts
+}
+
+func withSandbox(ctx context.Context, sandbox *Sandbox) Option {
+	return func(c *runConfig) {
+		c.sandbox = sandbox
+		c.logger.sandbox = sandbox
+		c.httpTransport = &sandboxTransport{sandbox: sandbox, next: c.httpTransport}
+		WithHTTP(ctx)(c)
+	}
+}
+
+func (s *Sandbox) call(function string, arguments []interface{}) {
+	s.Calls = append(s.Calls, &APICall{
+		Function:  function,
+		Arguments: arguments,
+	})
+}
+
+// api returns an object which records the calls of all its functions,
+// properties are objects of the same kind
+func (s *Sandbox) api(vm *goja.Runtime, path string) goja.Value {
+	target := vm.ToValue(func(goja.FunctionCall) goja.Value { return goja.Undefined() }).ToObject(vm)
+	return vm.ToValue(vm.NewProxy(target, &goja.ProxyTrapConfig{
+		Get: func(_ *goja.Object, property string, _ goja.Value) goja.Value {
+			if path == "" {
+				return s.api(vm, property)
+			}
+			return s.api(vm, path+"."+property)
+		},
+		Apply: func(_ *goja.Object, _ goja.Value, args []goja.Value) goja.Value {
+			arguments := make([]interface{}, len(args))
+			for i, arg := range args {
+				arguments[i] = arg.Export()
+			}
+			s.call(path, arguments)
+			return goja.Undefined()
+		},
+	}))
+}
+
+type sandboxTransport struct {
+	sandbox *Sandbox
+	next    http.RoundTripper
+}
+
+func (t *sandboxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := &FetchCall{
+		Method: req.Method,
+		URL:    req.URL.String(),
+	}
+	t.sandbox.Fetches = append(t.sandbox.Fetches, call)
+	for _, mock := range t.sandbox.mocks {
+		if (mock.Method == "" || strings.EqualFold(mock.Method, req.Method)) && mock.URL == call.URL {
+			call.Mocked = true
+			call.Status = mock.Status
+			return &http.Response{
+				Status:     http.StatusText(mock.Status),
+				StatusCode: mock.Status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(mock.Body)),
+				Request:    req,
+			}, nil
+		}
+	}
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	call.Took = time.Since(started)
+	if err != nil {
+		call.Error = err.Error()
+		return nil, err
+	}
+	call.Status = resp.StatusCode
+	return resp, nil
+}
@@ -0,0 +1,142 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestRunInSandbox(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	type args struct {
+		context map[string]interface{}
+		script  string
+		mocks   []*FetchMock
+		opts    []Option
+	}
+	type res struct {
+		calls   []*APICall
+		logs    []string
+		fetches []*FetchCall
+		err     bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "calls recorded",
+			args: args{
+				context: map[string]interface{}{
+					"v1": map[string]interface{}{
+						"org": map[string]interface{}{
+							"id": "orgID",
+						},
+						"getUser": map[string]interface{}{
+							"username": "gigi",
+						},
+					},
+				},
+				script: `
+function test(ctx, api) {
+	api.v1.claims.setClaim('org', ctx.v1.org.id);
+	api.setFirstName(ctx.v1.getUser().username);
+}`,
+			},
+			res: res{
+				calls: []*APICall{
+					{Function: "v1.claims.setClaim", Arguments: []interface{}{"org", "orgID"}},
+					{Function: "setFirstName", Arguments: []interface{}{"gigi"}},
+				},
+				logs: []string{actionStartedMessage, actionSucceededMessage},
+			},
+		},
+		{
+			name: "logs and mocked fetch recorded",
+			args: args{
+				script: `
+let http = require('zitadel/http');
+let logger = require('zitadel/log');
+
+function test(ctx, api) {
+	let resp = http.fetch('https://example.com/user', {method: 'POST'});
+	logger.log(resp.json().department);
+	api.v1.user.appendMetadata('department', resp.json().department);
+}`,
+				mocks: []*FetchMock{
+					{Method: http.MethodGet, URL: "https://example.com/user", Status: http.StatusNotFound},
+					{Method: http.MethodPost, URL: "https://example.com/user", Status: http.StatusOK, Body: `{"department":"engineering"}`},
+				},
+			},
+			res: res{
+				calls: []*APICall{
+					{Function: "v1.user.appendMetadata", Arguments: []interface{}{"department", "engineering"}},
+				},
+				logs: []string{actionStartedMessage, "engineering", actionSucceededMessage},
+				fetches: []*FetchCall{
+					{Method: http.MethodPost, URL: "https://example.com/user", Status: http.StatusOK, Mocked: true},
+				},
+			},
+		},
+		{
+			name: "error recorded",
+			args: args{
+				script: "function test(ctx, api) {throw 'some error'}",
+			},
+			res: res{
+				logs: []string{actionStartedMessage, "action run failed: some error at test (<eval>:1:26(2))"},
+				err:  true,
+			},
+		},
+		{
+			name: "error recorded if allowed to fail",
+			args: args{
+				script: `
+function test(ctx, api) {
+	api.v1.reject('denied');
+	throw 'some error';
+}`,
+				opts: []Option{WithAllowedToFail()},
+			},
+			res: res{
+				calls: []*APICall{
+					{Function: "v1.reject", Arguments: []interface{}{"denied"}},
+				},
+				logs: []string{actionStartedMessage, "action run failed: some error at test (<eval>:4:2(8))"},
+				err:  true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			sandbox := NewSandbox(tt.args.mocks...)
+			err := RunInSandbox(ctx, sandbox, tt.args.context, tt.args.script, "test", tt.args.opts...)
+			assert.NoError(t, err)
+			if tt.res.err {
+				assert.Error(t, sandbox.Err)
+			} else {
+				assert.NoError(t, sandbox.Err)
+			}
+			assert.Equal(t, tt.res.calls, sandbox.Calls)
+			logs := make([]string, len(sandbox.Logs))
+			for i, log := range sandbox.Logs {
+				logs[i] = log.Message
+			}
+			assert.Equal(t, tt.res.logs, logs)
+			assert.Equal(t, tt.res.fetches, sandbox.Fetches)
+			assert.NotZero(t, sandbox.Took)
+			if len(sandbox.Logs) > 0 {
+				assert.Equal(t, logrus.InfoLevel, sandbox.Logs[0].Level)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	targetActionHeader    = "X-Zitadel-Action"
+	targetSignatureHeader = "X-Zitadel-Signature"
+	// targetMaxResponseSize limits the size of the response body of a target
+	targetMaxResponseSize = 1 << 20
+)
+
+// targetContextFunctions are the functions of the context without parameters,
+// which are called to pass their results to the target
+var targetContextFunctions = map[string]bool{
+	"getUser":     true,
+	"getMetadata": true,
+	"claimsJSON":  true,
+}
+
+var signingKeyEncryption crypto.EncryptionAlgorithm
+
+// SetSigningKeyEncryption sets the algorithm to decrypt the signing keys of the actions
+func SetSigningKeyEncryption(alg crypto.EncryptionAlgorithm) {
+	signingKeyEncryption = alg
+}
+
+// WithHTTPTarget executes the action by posting the context to the URL instead of running the script.
+// The calls in the response are applied to the api of the action, the same way a script calls them.
+func WithHTTPTarget(url string, signingKey *crypto.CryptoValue) Option {
+	return func(c *runConfig) {
+		c.target = &httpTarget{
+			url:        url,
+			signingKey: signingKey,
+		}
+	}
+}
+
+type httpTarget struct {
+	url        string
+	signingKey *crypto.CryptoValue
+}
+
+// targetRequest is the body of the request to the target
+type targetRequest struct {
+	Action  string          `json:"action"`
+	Context json.RawMessage `json:"context"`
+}
+
+// targetResponse is the body of the response of the target
+type targetResponse struct {
+	Calls []*targetCall `json:"calls"`
+}
+
+// targetCall calls the function of the api (e.g. `v1.claims.setClaim`) with the arguments
+type targetCall struct {
+	Function  string        `json:"function"`
+	Arguments []interface{} `json:"arguments"`
+}
+
+func executeTarget(ctx context.Context, config *runConfig, ctxParam contextFields, apiParam apiFields, name string) (err error) {
+	if ctxParam != nil {
+		ctxParam(config.ctxParam)
+	}
+	if apiParam != nil {
+		apiParam(config.apiParam)
+	}
+	t := config.StartFunction()
+	defer func() {
+		t.Stop()
+	}()
+	return executeFn(config, func(ctxFields fields, _ goja.Value) error {
+		body, err := targetPayload(config.vm, name, ctxFields)
+		if err != nil {
+			return err
+		}
+		calls, err := config.target.call(ctx, name, body)
+		if err != nil {
+			return err
+		}
+		if config.sandbox != nil {
+			for _, call := range calls {
+				config.sandbox.call(call.Function, call.Arguments)
+			}
+			return nil
+		}
+		return applyTargetCalls(config.vm, config.apiParam.fields, calls)
+	})
+}
+
+// targetPayload serializes the context the same way a script would read it
+func targetPayload(vm *goja.Runtime, name string, ctxFields fields) ([]byte, error) {
+	payload, err := targetFields(vm, ctxFields)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("stringify"))
+	if !ok {
+		return nil, z_errs.ThrowInternal(nil, "ACTIO-Ahn5o", "Errors.Internal")
+	}
+	serialized, err := stringify(goja.Undefined(), vm.ToValue(payload))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&targetRequest{
+		Action:  name,
+		Context: json.RawMessage(serialized.String()),
+	})
+}
+
+func targetFields(vm *goja.Runtime, f fields) (map[string]interface{}, error) {
+	payload := make(map[string]interface{}, len(f))
+	for key, value := range f {
+		if sub, ok := value.(fields); ok {
+			subPayload, err := targetFields(vm, sub)
+			if err != nil {
+				return nil, err
+			}
+			payload[key] = subPayload
+			continue
+		}
+		fn, isFunction := goja.AssertFunction(vm.ToValue(value))
+		if !isFunction {
+			payload[key] = value
+			continue
+		}
+		if !targetContextFunctions[key] {
+			continue
+		}
+		result, err := fn(goja.Undefined())
+		if err != nil {
+			return nil, err
+		}
+		payload[key] = result
+	}
+	return payload, nil
+}
+
+func (t *httpTarget) call(ctx context.Context, name string, body []byte) ([]*targetCall, error) {
+	if signingKeyEncryption == nil {
+		return nil, z_errs.ThrowInternal(nil, "ACTIO-Ooy7e", "Errors.Internal")
+	}
+	signingKey, err := crypto.DecryptString(t.signingKey, signingKeyEncryption)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(targetActionHeader, name)
+	req.Header.Set(targetSignatureHeader, crypto.SignPayload(signingKey, time.Now(), body))
+
+	client := &http.Client{Transport: new(transport)}
+	if deadline, ok := ctx.Deadline(); ok {
+		client.Timeout = time.Until(deadline)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseTargetResponse(resp)
+}
+
+// parseTargetResponse validates the response of the target,
+// a successful response without body doesn't call any function
+func parseTargetResponse(resp *http.Response) ([]*targetCall, error) {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, targetMaxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > targetMaxResponseSize {
+		return nil, fmt.Errorf("response body exceeds %d bytes", targetMaxResponseSize)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return nil, fmt.Errorf("unexpected response content type %q", resp.Header.Get("Content-Type"))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	response := new(targetResponse)
+	if err = decoder.Decode(response); err != nil {
+		return nil, fmt.Errorf("invalid response body: %w", err)
+	}
+	return response.Calls, nil
+}
+
+// applyTargetCalls calls the functions of the api in the order of the response,
+// all functions are resolved before the first one is called
+func applyTargetCalls(vm *goja.Runtime, apiFields fields, calls []*targetCall) error {
+	functions := make([]goja.Callable, len(calls))
+	for i, call := range calls {
+		fn, err := targetFunction(vm, apiFields, call.Function)
+		if err != nil {
+			return err
+		}
+		functions[i] = fn
+	}
+	for i, call := range calls {
+		args := make([]goja.Value, len(call.Arguments))
+		for j, arg := range call.Arguments {
+			args[j] = vm.ToValue(arg)
+		}
+		if _, err := functions[i](goja.Undefined(), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func targetFunction(vm *goja.Runtime, apiFields fields, path string) (goja.Callable, error) {
+	var value interface{} = apiFields
+	for _, key := range strings.Split(path, ".") {
+		f, ok := value.(fields)
+		if !ok {
+			return nil, fmt.Errorf("function %q not found", path)
+		}
+		if value, ok = f[key]; !ok {
+			return nil, fmt.Errorf("function %q not found", path)
+		}
+	}
+	fn, ok := goja.AssertFunction(vm.ToValue(value))
+	if !ok {
+		return nil, fmt.Errorf("%q is not a function", path)
+	}
+	return fn, nil
+}
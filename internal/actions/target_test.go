@@ -0,0 +1,161 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestRun_HTTPTarget(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetSigningKeyEncryption(crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("signingKey"),
+	}
+	tests := []struct {
+		name       string
+		handler    func(t *testing.T) http.HandlerFunc
+		wantClaims map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			name: "signed request with context",
+			handler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					body, err := io.ReadAll(r.Body)
+					if !assert.NoError(t, err) {
+						return
+					}
+					assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+					assert.Equal(t, "addClaims", r.Header.Get(targetActionHeader))
+					assertTargetSignature(t, r.Header.Get(targetSignatureHeader), body)
+					assert.JSONEq(t, `{"action":"addClaims","context":{"v1":{"org":"orgID","user":{"getUser":{"id":"userID"}}}}}`, string(body))
+					w.WriteHeader(http.StatusNoContent)
+				}
+			},
+			wantClaims: map[string]interface{}{},
+		},
+		{
+			name: "calls applied",
+			handler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json; charset=utf-8")
+					w.Write([]byte(`{"calls":[{"function":"v1.claims.setClaim","arguments":["key","value"]},{"function":"v1.claims.setClaim","arguments":["number",1]}]}`))
+				}
+			},
+			wantClaims: map[string]interface{}{
+				"key":    "value",
+				"number": int64(1),
+			},
+		},
+		{
+			name: "unexpected status, error",
+			handler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			},
+			wantClaims: map[string]interface{}{},
+			wantErr:    true,
+		},
+		{
+			name: "no json response, error",
+			handler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "text/plain")
+					w.Write([]byte(`{"calls":[]}`))
+				}
+			},
+			wantClaims: map[string]interface{}{},
+			wantErr:    true,
+		},
+		{
+			name: "unknown field, error",
+			handler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(`{"claims":{"key":"value"}}`))
+				}
+			},
+			wantClaims: map[string]interface{}{},
+			wantErr:    true,
+		},
+		{
+			name: "unknown function, error",
+			handler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(`{"calls":[{"function":"v1.claims.setClaim","arguments":["key","value"]},{"function":"v1.user.setPassword","arguments":["password"]}]}`))
+				}
+			},
+			wantClaims: map[string]interface{}{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler(t))
+			defer server.Close()
+
+			claims := make(map[string]interface{})
+			ctxFields := SetContextFields(
+				SetFields("v1",
+					SetFields("org", "orgID"),
+					SetFields("user",
+						SetFields("getUser", func() interface{} {
+							return map[string]interface{}{"id": "userID"}
+						}),
+						SetFields("setPassword", func(string) {}),
+					),
+				),
+			)
+			apiFields := WithAPIFields(
+				SetFields("v1",
+					SetFields("claims",
+						SetFields("setClaim", func(key string, value interface{}) {
+							claims[key] = value
+						}),
+					),
+				),
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx, ctxFields, apiFields, "", "addClaims", WithHTTPTarget(server.URL, signingKey))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantClaims, claims)
+		})
+	}
+}
+
+func assertTargetSignature(t *testing.T, header string, body []byte) {
+	t.Helper()
+	parts := strings.Split(header, ",")
+	if !assert.Len(t, parts, 2) {
+		return
+	}
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(parts[0], "t="), 10, 64)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, crypto.SignPayload("signingKey", time.Unix(timestamp, 0), body), header)
+	assert.True(t, json.Valid(body))
+}
@@ -172,6 +172,7 @@ func (a *API) routeGRPCWeb() {
 				http_util.AcceptLanguage,
 				http_util.Authorization,
 				http_util.ZitadelOrgID,
+				http_util.DPoP,
 				http_util.XUserAgent,
 				http_util.XGrpcWeb,
 			},
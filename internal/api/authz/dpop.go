@@ -0,0 +1,210 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	DPoPPrefix = "DPoP "
+
+	dpopProofType = "dpop+jwt"
+	// dpopProofLifetime is the maximum age of a proof,
+	// the jti of used proofs is remembered for this time (plus the clock skew) to prevent replays
+	dpopProofLifetime  = time.Minute
+	dpopProofClockSkew = 5 * time.Second
+)
+
+// usedDPoPProofs remembers the proofs used on this ZITADEL process
+var usedDPoPProofs = newDPoPReplayCache()
+
+type dpopProof struct {
+	proof  string
+	method string
+	path   string
+}
+
+type dpopProofClaims struct {
+	ID              string `json:"jti"`
+	Method          string `json:"htm"`
+	URI             string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath,omitempty"`
+}
+
+// WithDPoPProof sets the DPoP proof (RFC 9449) of the request
+// together with the HTTP method and path the proof has to be issued for
+func WithDPoPProof(ctx context.Context, proof, method, path string) context.Context {
+	if proof == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, dpopKey, &dpopProof{proof: proof, method: method, path: path})
+}
+
+func dpopProofFromCtx(ctx context.Context) *dpopProof {
+	proof, _ := ctx.Value(dpopKey).(*dpopProof)
+	return proof
+}
+
+// VerifyDPoPProof verifies the DPoP proof JWT (RFC 9449, section 4.3) for a request
+// with the provided HTTP method to the host and path.
+// The scheme of the htu claim is not checked, as TLS is mostly terminated in front of ZITADEL.
+// If an access token is passed, the proof must contain its hash in the ath claim.
+// On success the base64url encoded SHA-256 JWK thumbprint of the proof key is returned.
+func VerifyDPoPProof(proof, method, host, path, accessToken string) (jkt string, err error) {
+	jws, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0f1", "invalid DPoP proof")
+	}
+	if len(jws.Signatures) != 1 {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0f2", "invalid DPoP proof")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); !strings.EqualFold(typ, dpopProofType) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0f3", "invalid DPoP proof type")
+	}
+	if !isAsymmetricAlgorithm(header.Algorithm) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0f4", "invalid DPoP proof algorithm")
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.Valid() || !key.IsPublic() {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0f5", "invalid DPoP proof key")
+	}
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0f6", "invalid DPoP proof signature")
+	}
+	claims := new(dpopProofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0f7", "invalid DPoP proof")
+	}
+	if claims.ID == "" {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0f8", "DPoP proof jti missing")
+	}
+	if !strings.EqualFold(claims.Method, method) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0f9", "DPoP proof htm does not match")
+	}
+	if !dpopURIMatches(claims.URI, host, path) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f0", "DPoP proof htu does not match")
+	}
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	now := time.Now()
+	if issuedAt.Before(now.Add(-dpopProofLifetime)) || issuedAt.After(now.Add(dpopProofClockSkew)) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f1", "DPoP proof expired")
+	}
+	if accessToken != "" && claims.AccessTokenHash != AccessTokenHash(accessToken) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f2", "DPoP proof ath does not match")
+	}
+	jkt, err = JWKThumbprint(key)
+	if err != nil {
+		return "", err
+	}
+	if !usedDPoPProofs.use(jkt+":"+claims.ID, issuedAt.Add(dpopProofLifetime+dpopProofClockSkew), now) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f8", "DPoP proof was already used")
+	}
+	return jkt, nil
+}
+
+// dpopReplayCache tracks the jti of used proofs (per key) until the proofs expire.
+// The tracking is done in memory, so a proof could still be replayed once on another ZITADEL process
+// during its short lifetime.
+type dpopReplayCache struct {
+	mu        sync.Mutex
+	proofs    map[string]time.Time
+	nextPrune time.Time
+}
+
+func newDPoPReplayCache() *dpopReplayCache {
+	return &dpopReplayCache{proofs: make(map[string]time.Time)}
+}
+
+// use marks the proof as used and returns false if it was already used before
+func (c *dpopReplayCache) use(id string, expiration, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.After(c.nextPrune) {
+		for proofID, exp := range c.proofs {
+			if now.After(exp) {
+				delete(c.proofs, proofID)
+			}
+		}
+		c.nextPrune = now.Add(dpopProofLifetime)
+	}
+	if exp, ok := c.proofs[id]; ok && !now.After(exp) {
+		return false
+	}
+	c.proofs[id] = expiration
+	return true
+}
+
+// JWKThumbprint returns the base64url encoded SHA-256 thumbprint (RFC 7638) of the key,
+// as used in the jkt confirmation claim
+func JWKThumbprint(key *jose.JSONWebKey) (string, error) {
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp1f3", "invalid DPoP proof key")
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// AccessTokenHash returns the base64url encoded SHA-256 hash of the access token used in the ath claim
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// checkDPoPBinding ensures DPoP bound tokens are only used with the DPoP scheme and a valid proof of the bound key
+func checkDPoPBinding(ctx context.Context, accessToken, jkt string, dpopScheme bool) error {
+	if !dpopScheme {
+		if jkt != "" {
+			return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f4", "DPoP bound token used as bearer token")
+		}
+		return nil
+	}
+	if jkt == "" {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f5", "token is not DPoP bound")
+	}
+	proof := dpopProofFromCtx(ctx)
+	if proof == nil {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f6", "DPoP proof missing")
+	}
+	proofJKT, err := VerifyDPoPProof(proof.proof, proof.method, GetInstance(ctx).RequestedHost(), proof.path, accessToken)
+	if err != nil {
+		return err
+	}
+	if proofJKT != jkt {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1f7", "DPoP proof key does not match token binding")
+	}
+	return nil
+}
+
+func dpopURIMatches(uri, host, path string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || !parsed.IsAbs() {
+		return false
+	}
+	return strings.EqualFold(parsed.Host, host) && parsed.EscapedPath() == path
+}
+
+func isAsymmetricAlgorithm(alg string) bool {
+	switch jose.SignatureAlgorithm(alg) {
+	case jose.RS256, jose.RS384, jose.RS512,
+		jose.PS256, jose.PS384, jose.PS512,
+		jose.ES256, jose.ES384, jose.ES512,
+		jose.EdDSA:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,269 @@
+package authz
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	testDPoPHost = "zitadel.example.com"
+	testDPoPPath = "/zitadel.auth.v1.AuthService/GetMyUser"
+)
+
+func TestVerifyDPoPProof(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jkt, err := JWKThumbprint(&jose.JSONWebKey{Key: key.Public()})
+	require.NoError(t, err)
+
+	type args struct {
+		claims      *dpopProofClaims
+		typ         string
+		method      string
+		path        string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid proof",
+			args: args{
+				claims: testDPoPClaims("valid", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""),
+			},
+		},
+		{
+			name: "valid proof with access token hash",
+			args: args{
+				claims:      testDPoPClaims("ath", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), AccessTokenHash("token")),
+				accessToken: "token",
+			},
+		},
+		{
+			name: "wrong type, error",
+			args: args{
+				claims: testDPoPClaims("type", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""),
+				typ:    "JWT",
+			},
+			wantErr: true,
+		},
+		{
+			name: "jti missing, error",
+			args: args{
+				claims: testDPoPClaims("", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""),
+			},
+			wantErr: true,
+		},
+		{
+			name: "other method, error",
+			args: args{
+				claims: testDPoPClaims("method", "GET", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""),
+			},
+			wantErr: true,
+		},
+		{
+			name: "other path, error",
+			args: args{
+				claims: testDPoPClaims("path", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""),
+				path:   "/zitadel.admin.v1.AdminService/GetMyInstance",
+			},
+			wantErr: true,
+		},
+		{
+			name: "other host, error",
+			args: args{
+				claims: testDPoPClaims("host", "POST", "https://other.example.com"+testDPoPPath, time.Now(), ""),
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired, error",
+			args: args{
+				claims: testDPoPClaims("expired", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now().Add(-2*dpopProofLifetime), ""),
+			},
+			wantErr: true,
+		},
+		{
+			name: "issued in the future, error",
+			args: args{
+				claims: testDPoPClaims("future", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now().Add(time.Minute), ""),
+			},
+			wantErr: true,
+		},
+		{
+			name: "access token hash mismatch, error",
+			args: args{
+				claims:      testDPoPClaims("athMismatch", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), AccessTokenHash("other")),
+				accessToken: "token",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, path := tt.args.method, tt.args.path
+			if method == "" {
+				method = "POST"
+			}
+			if path == "" {
+				path = testDPoPPath
+			}
+			proof := testDPoPProof(t, key, tt.args.typ, tt.args.claims)
+			got, err := VerifyDPoPProof(proof, method, testDPoPHost, path, tt.args.accessToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, jkt, got)
+		})
+	}
+}
+
+func TestVerifyDPoPProof_replay(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	proof := testDPoPProof(t, key, "", testDPoPClaims("replay", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""))
+
+	_, err = VerifyDPoPProof(proof, "POST", testDPoPHost, testDPoPPath, "")
+	require.NoError(t, err)
+	_, err = VerifyDPoPProof(proof, "POST", testDPoPHost, testDPoPPath, "")
+	assert.Error(t, err)
+
+	// the same jti of another key is not a replay
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherProof := testDPoPProof(t, otherKey, "", testDPoPClaims("replay", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), ""))
+	_, err = VerifyDPoPProof(otherProof, "POST", testDPoPHost, testDPoPPath, "")
+	assert.NoError(t, err)
+}
+
+func Test_dpopReplayCache_use(t *testing.T) {
+	cache := newDPoPReplayCache()
+	now := time.Now()
+
+	assert.True(t, cache.use("jti", now.Add(time.Minute), now))
+	assert.False(t, cache.use("jti", now.Add(time.Minute), now.Add(30*time.Second)))
+	// expired entries are pruned and can no longer be replayed, as the proof itself is expired
+	assert.True(t, cache.use("jti", now.Add(3*time.Minute), now.Add(2*time.Minute)))
+	assert.Len(t, cache.proofs, 1)
+}
+
+func Test_checkDPoPBinding(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jkt, err := JWKThumbprint(&jose.JSONWebKey{Key: key.Public()})
+	require.NoError(t, err)
+
+	type args struct {
+		jkt        string
+		dpopScheme bool
+		proof      func() string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "bearer token",
+			args: args{},
+		},
+		{
+			name: "bound token as bearer, error",
+			args: args{
+				jkt: jkt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unbound token with dpop scheme, error",
+			args: args{
+				dpopScheme: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "proof missing, error",
+			args: args{
+				jkt:        jkt,
+				dpopScheme: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "proof of other key, error",
+			args: args{
+				jkt:        "otherJKT",
+				dpopScheme: true,
+				proof: func() string {
+					return testDPoPProof(t, key, "", testDPoPClaims("otherKey", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), AccessTokenHash("token")))
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof",
+			args: args{
+				jkt:        jkt,
+				dpopScheme: true,
+				proof: func() string {
+					return testDPoPProof(t, key, "", testDPoPClaims("bound", "POST", "https://"+testDPoPHost+testDPoPPath, time.Now(), AccessTokenHash("token")))
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithRequestedDomain(context.Background(), testDPoPHost)
+			if tt.args.proof != nil {
+				ctx = WithDPoPProof(ctx, tt.args.proof(), "POST", testDPoPPath)
+			}
+			err := checkDPoPBinding(ctx, "token", tt.args.jkt, tt.args.dpopScheme)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func testDPoPClaims(id, method, uri string, issuedAt time.Time, ath string) *dpopProofClaims {
+	return &dpopProofClaims{
+		ID:              id,
+		Method:          method,
+		URI:             uri,
+		IssuedAt:        issuedAt.Unix(),
+		AccessTokenHash: ath,
+	}
+}
+
+func testDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims *dpopProofClaims) string {
+	if typ == "" {
+		typ = dpopProofType
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ)),
+	)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
@@ -35,7 +35,7 @@ type MembershipsResolver interface {
 }
 
 type authZRepo interface {
-	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner, jkt string, err error)
 	VerifierClientID(ctx context.Context, name string) (clientID, projectID string, err error)
 	SearchMyMemberships(ctx context.Context, orgID string) ([]*Membership, error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
@@ -57,16 +57,16 @@ func Start(authZRepo authZRepo, issuer string, keys map[string]*SystemAPIUser) (
 	}
 }
 
-func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner string, err error) {
+func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner, jkt string, err error) {
 	if strings.HasPrefix(method, "/zitadel.system.v1.SystemService") {
 		userID, err := v.verifySystemToken(ctx, token)
 		if err != nil {
-			return "", "", "", "", "", err
+			return "", "", "", "", "", "", err
 		}
-		return userID, "", "", "", "", nil
+		return userID, "", "", "", "", "", nil
 	}
-	userID, agentID, clientID, prefLang, resourceOwner, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
-	return userID, clientID, agentID, prefLang, resourceOwner, err
+	userID, agentID, clientID, prefLang, resourceOwner, jkt, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
+	return userID, clientID, agentID, prefLang, resourceOwner, jkt, err
 }
 
 func (v *TokenVerifier) verifySystemToken(ctx context.Context, token string) (string, error) {
@@ -162,11 +162,23 @@ func verifyAccessToken(ctx context.Context, token string, t *TokenVerifier, meth
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	parts := strings.Split(token, BearerPrefix)
+	prefix := BearerPrefix
+	dpopScheme := strings.HasPrefix(token, DPoPPrefix)
+	if dpopScheme {
+		prefix = DPoPPrefix
+	}
+	parts := strings.Split(token, prefix)
 	if len(parts) != 2 {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
 	}
-	return t.VerifyAccessToken(ctx, parts[1], method)
+	userID, clientID, agentID, prefLan, resourceOwner, jkt, err := t.VerifyAccessToken(ctx, parts[1], method)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	if err = checkDPoPBinding(ctx, parts[1], jkt, dpopScheme); err != nil {
+		return "", "", "", "", "", err
+	}
+	return userID, clientID, agentID, prefLan, resourceOwner, nil
 }
 
 func SessionTokenVerifier(algorithm crypto.EncryptionAlgorithm) func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
@@ -0,0 +1,241 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	HandlerPrefix = "/events/v1/stream"
+
+	contentTypeEventStream = "text/event-stream"
+	headerLastEventID      = "Last-Event-ID"
+
+	paramAggregateType = "aggregate_type"
+	paramEventType     = "event_type"
+	paramResourceOwner = "resource_owner"
+	paramSequence      = "sequence"
+
+	permissionEventsRead = "events.read"
+
+	// heartbeatInterval keeps idle connections open through proxies
+	heartbeatInterval = 30 * time.Second
+)
+
+type Handler struct {
+	queries           *query.Queries
+	verifier          *authz.TokenVerifier
+	authConfig        authz.Config
+	auditLogRetention time.Duration
+}
+
+// NewHandler creates the server-sent events (SSE) endpoint, which streams the events of the instance
+// the same way as the SubscribeEvents method of the admin API.
+// A subscription is resumed from the sequence of the Last-Event-ID header or the sequence query parameter.
+func NewHandler(
+	queries *query.Queries,
+	verifier *authz.TokenVerifier,
+	authConfig authz.Config,
+	auditLogRetention time.Duration,
+	callDurationInterceptor,
+	instanceInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		queries:           queries,
+		verifier:          verifier,
+		authConfig:        authConfig,
+		auditLogRetention: auditLogRetention,
+	}
+	return callDurationInterceptor(instanceInterceptor(http.HandlerFunc(h.handleStream)))
+}
+
+func (h *Handler) handleStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, err := h.checkAuthorization(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	filter, err := filterFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, caos_errs.ThrowUnimplemented(nil, "STREAM-Oo3ph", "streaming not supported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	writer := &eventWriter{w: w, flusher: flusher}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go writer.heartbeat(ctx, heartbeatInterval)
+
+	err = h.queries.StreamEvents(ctx, filter, h.auditLogRetention, writer.writeEvent)
+	logging.OnError(err).Debug("event stream closed")
+}
+
+func (h *Handler) checkAuthorization(r *http.Request) (context.Context, error) {
+	token := http_utils.GetAuthorization(r)
+	if token == "" {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "STREAM-ahY5u", "auth header missing")
+	}
+	ctx := authz.WithDPoPProof(r.Context(), http_utils.GetDPoPProof(r), r.Method, http_utils.RequestPath(r))
+	ctxSetter, err := authz.CheckUserAuthorization(ctx, r, token, http_utils.GetOrgID(r), "", h.verifier, h.authConfig, authz.Option{Permission: permissionEventsRead}, HandlerPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return ctxSetter(r.Context()), nil
+}
+
+func filterFromRequest(r *http.Request) (*query.EventStreamFilter, error) {
+	params := r.URL.Query()
+	filter := &query.EventStreamFilter{
+		ResourceOwner: params.Get(paramResourceOwner),
+	}
+	for _, aggregateType := range params[paramAggregateType] {
+		filter.AggregateTypes = append(filter.AggregateTypes, eventstore.AggregateType(aggregateType))
+	}
+	for _, eventType := range params[paramEventType] {
+		filter.EventTypes = append(filter.EventTypes, eventstore.EventType(eventType))
+	}
+	sequence := params.Get(paramSequence)
+	if lastEventID := r.Header.Get(headerLastEventID); lastEventID != "" {
+		sequence = lastEventID
+	}
+	if sequence == "" {
+		return filter, nil
+	}
+	var err error
+	filter.Sequence, err = strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "STREAM-Ieng8", "invalid sequence")
+	}
+	return filter, nil
+}
+
+// Event is the data of a sent event
+type Event struct {
+	Sequence     uint64          `json:"sequence"`
+	CreationDate time.Time       `json:"creationDate"`
+	Type         string          `json:"type"`
+	Aggregate    Aggregate       `json:"aggregate"`
+	Editor       Editor          `json:"editor"`
+	Payload      json.RawMessage `json:"payload,omitempty"`
+}
+
+type Aggregate struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	ResourceOwner string `json:"resourceOwner"`
+}
+
+type Editor struct {
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName,omitempty"`
+	Service     string `json:"service,omitempty"`
+}
+
+func eventToSSE(event *query.Event) *Event {
+	return &Event{
+		Sequence:     event.Sequence,
+		CreationDate: event.CreationDate,
+		Type:         event.Type,
+		Aggregate: Aggregate{
+			ID:            event.Aggregate.ID,
+			Type:          string(event.Aggregate.Type),
+			ResourceOwner: event.Aggregate.ResourceOwner,
+		},
+		Editor: Editor{
+			UserID:      event.Editor.ID,
+			DisplayName: event.Editor.DisplayName,
+			Service:     event.Editor.Service,
+		},
+		Payload: event.Payload,
+	}
+}
+
+// eventWriter serializes the writes of events and heartbeats to the response
+type eventWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	flusher http.Flusher
+}
+
+// writeEvent writes the event in the SSE format, the sequence is used as id to resume the stream
+func (e *eventWriter) writeEvent(event *query.Event) error {
+	data, err := json.Marshal(eventToSSE(event))
+	if err != nil {
+		return err
+	}
+	return e.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data))
+}
+
+func (e *eventWriter) heartbeat(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *eventWriter) write(message string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := io.WriteString(e.w, message); err != nil {
+		return err
+	}
+	e.flusher.Flush()
+	return nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case caos_errs.IsUnauthenticated(err):
+		status = http.StatusUnauthorized
+	case caos_errs.IsPermissionDenied(err):
+		status = http.StatusForbidden
+	case caos_errs.IsErrorInvalidArgument(err):
+		status = http.StatusBadRequest
+	case caos_errs.IsUnimplemented(err):
+		status = http.StatusNotImplemented
+	}
+	message := http.StatusText(status)
+	if caosErr := new(caos_errs.CaosError); errors.As(err, &caosErr) {
+		message = caosErr.GetMessage()
+	}
+	http.Error(w, message, status)
+}
@@ -0,0 +1,97 @@
+package eventstream
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_filterFromRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		lastEventID string
+		want        *query.EventStreamFilter
+		wantErr     func(error) bool
+	}{
+		{
+			name:   "no params",
+			target: HandlerPrefix,
+			want:   &query.EventStreamFilter{},
+		},
+		{
+			name:   "all params",
+			target: HandlerPrefix + "?aggregate_type=user&aggregate_type=org&event_type=user.human.added&resource_owner=org1&sequence=10",
+			want: &query.EventStreamFilter{
+				AggregateTypes: []eventstore.AggregateType{"user", "org"},
+				EventTypes:     []eventstore.EventType{"user.human.added"},
+				ResourceOwner:  "org1",
+				Sequence:       10,
+			},
+		},
+		{
+			name:        "last event id overwrites sequence",
+			target:      HandlerPrefix + "?sequence=10",
+			lastEventID: "20",
+			want: &query.EventStreamFilter{
+				Sequence: 20,
+			},
+		},
+		{
+			name:    "invalid sequence",
+			target:  HandlerPrefix + "?sequence=abc",
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.lastEventID != "" {
+				r.Header.Set(headerLastEventID, tt.lastEventID)
+			}
+			got, err := filterFromRequest(r)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_eventWriter_writeEvent(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer := &eventWriter{w: recorder, flusher: recorder}
+
+	err := writer.writeEvent(&query.Event{
+		Editor: &query.EventEditor{
+			ID:      "user1",
+			Service: "Admin-API",
+		},
+		Aggregate: eventstore.Aggregate{
+			ID:            "org1",
+			Type:          "org",
+			ResourceOwner: "org1",
+		},
+		Sequence:     42,
+		CreationDate: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Type:         "org.added",
+		Payload:      []byte(`{"name":"org"}`),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "id: 42\n"+
+		"event: org.added\n"+
+		`data: {"sequence":42,"creationDate":"2023-01-02T03:04:05Z","type":"org.added","aggregate":{"id":"org1","type":"org","resourceOwner":"org1"},"editor":{"userId":"user1","service":"Admin-API"},"payload":{"name":"org"}}`+"\n\n",
+		recorder.Body.String(),
+	)
+	assert.True(t, recorder.Flushed)
+}
@@ -1,8 +1,14 @@
 package action
 
 import (
+	"encoding/json"
+
+	"github.com/zitadel/logging"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
@@ -19,6 +25,14 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeCustomiseToken
 	case domain.FlowTypeInternalAuthentication.ID():
 		return domain.FlowTypeInternalAuthentication
+	case domain.FlowTypeCustomiseSAMLResponse.ID():
+		return domain.FlowTypeCustomiseSAMLResponse
+	case domain.FlowTypeUser.ID():
+		return domain.FlowTypeUser
+	case domain.FlowTypeUserGrant.ID():
+		return domain.FlowTypeUserGrant
+	case domain.FlowTypeMembership.ID():
+		return domain.FlowTypeMembership
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -47,6 +61,16 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreAccessTokenCreation
 	case domain.TriggerTypePreUserinfoCreation.ID():
 		return domain.TriggerTypePreUserinfoCreation
+	case domain.TriggerTypePreSAMLResponseCreation.ID():
+		return domain.TriggerTypePreSAMLResponseCreation
+	case domain.TriggerTypePreChange.ID():
+		return domain.TriggerTypePreChange
+	case domain.TriggerTypePostChange.ID():
+		return domain.TriggerTypePostChange
+	case domain.TriggerTypePreRemoval.ID():
+		return domain.TriggerTypePreRemoval
+	case domain.TriggerTypePostRemoval.ID():
+		return domain.TriggerTypePostRemoval
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -110,6 +134,26 @@ func ActionToPb(action *query.Action) *action_pb.Action {
 		Script:        action.Script,
 		Timeout:       durationpb.New(action.Timeout()),
 		AllowedToFail: action.AllowedToFail,
+		TargetType:    ActionTargetTypeToPb(action.TargetType),
+		TargetUrl:     action.TargetURL,
+	}
+}
+
+func ActionTargetTypeToPb(targetType domain.ActionTargetType) action_pb.ActionTargetType {
+	switch targetType {
+	case domain.ActionTargetTypeHTTP:
+		return action_pb.ActionTargetType_ACTION_TARGET_TYPE_HTTP
+	default:
+		return action_pb.ActionTargetType_ACTION_TARGET_TYPE_SCRIPT
+	}
+}
+
+func ActionTargetTypeToDomain(targetType action_pb.ActionTargetType) domain.ActionTargetType {
+	switch targetType {
+	case action_pb.ActionTargetType_ACTION_TARGET_TYPE_HTTP:
+		return domain.ActionTargetTypeHTTP
+	default:
+		return domain.ActionTargetTypeScript
 	}
 }
 
@@ -145,3 +189,70 @@ func ActionStateToDomain(state action_pb.ActionState) domain.ActionState {
 		return domain.ActionStateUnspecified
 	}
 }
+
+func ActionFetchMocksToDomain(mocks []*action_pb.ActionFetchMock) []*actions.FetchMock {
+	list := make([]*actions.FetchMock, len(mocks))
+	for i, mock := range mocks {
+		list[i] = &actions.FetchMock{
+			Method: mock.Method,
+			URL:    mock.Url,
+			Status: int(mock.Status),
+			Body:   mock.Body,
+		}
+	}
+	return list
+}
+
+func ActionCallsToPb(calls []*actions.APICall) []*action_pb.ActionCall {
+	list := make([]*action_pb.ActionCall, len(calls))
+	for i, call := range calls {
+		list[i] = &action_pb.ActionCall{
+			Function:  call.Function,
+			Arguments: actionCallArgumentsToPb(call.Arguments),
+		}
+	}
+	return list
+}
+
+// actionCallArgumentsToPb converts the exported javascript values to their json representation
+func actionCallArgumentsToPb(arguments []interface{}) *structpb.ListValue {
+	var values []interface{}
+	data, err := json.Marshal(arguments)
+	if err == nil {
+		err = json.Unmarshal(data, &values)
+	}
+	if err != nil {
+		logging.WithError(err).Debug("unable to convert arguments of action call")
+		return nil
+	}
+	list, err := structpb.NewList(values)
+	logging.OnError(err).Debug("unable to convert arguments of action call")
+	return list
+}
+
+func ActionLogsToPb(logs []*actions.LogLine) []*action_pb.ActionLog {
+	list := make([]*action_pb.ActionLog, len(logs))
+	for i, log := range logs {
+		list[i] = &action_pb.ActionLog{
+			LogDate: timestamppb.New(log.LogDate),
+			Level:   log.Level.String(),
+			Message: log.Message,
+		}
+	}
+	return list
+}
+
+func ActionFetchesToPb(fetches []*actions.FetchCall) []*action_pb.ActionFetch {
+	list := make([]*action_pb.ActionFetch, len(fetches))
+	for i, fetch := range fetches {
+		list[i] = &action_pb.ActionFetch{
+			Method: fetch.Method,
+			Url:    fetch.URL,
+			Status: int32(fetch.Status),
+			Mocked: fetch.Mocked,
+			Took:   durationpb.New(fetch.Took),
+			Error:  fetch.Error,
+		}
+	}
+	return list
+}
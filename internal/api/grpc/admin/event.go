@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	event_grpc "github.com/zitadel/zitadel/internal/api/grpc/event"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -25,6 +27,16 @@ func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest)
 	return admin_pb.EventsToPb(ctx, events)
 }
 
+func (s *Server) SubscribeEvents(in *admin_pb.SubscribeEventsRequest, stream admin_pb.AdminService_SubscribeEventsServer) error {
+	return s.query.StreamEvents(stream.Context(), subscribeEventsRequestToFilter(in), s.auditLogRetention, func(event *query.Event) error {
+		pbEvent, err := event_grpc.EventToPb(event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&admin_pb.SubscribeEventsResponse{Event: pbEvent})
+	})
+}
+
 func (s *Server) ListEventTypes(ctx context.Context, in *admin_pb.ListEventTypesRequest) (*admin_pb.ListEventTypesResponse, error) {
 	eventTypes := s.query.SearchEventTypes(ctx)
 	return admin_pb.EventTypesToPb(eventTypes), nil
@@ -73,3 +85,20 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 
 	return builder, nil
 }
+
+func subscribeEventsRequestToFilter(req *admin_pb.SubscribeEventsRequest) *query.EventStreamFilter {
+	eventTypes := make([]eventstore.EventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(req.AggregateTypes))
+	for i, aggregateType := range req.AggregateTypes {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	return &query.EventStreamFilter{
+		AggregateTypes: aggregateTypes,
+		EventTypes:     eventTypes,
+		ResourceOwner:  req.ResourceOwner,
+		Sequence:       req.Sequence,
+	}
+}
@@ -486,6 +486,9 @@ func (s *Server) getLockoutPolicy(ctx context.Context, orgID string) (_ *managem
 	if !queriedLockout.IsDefault {
 		return &management_pb.AddCustomLockoutPolicyRequest{
 			MaxPasswordAttempts: uint32(queriedLockout.MaxPasswordAttempts),
+			MaxOtpAttempts:      uint32(queriedLockout.MaxOTPAttempts),
+			LockoutDuration:     durationpb.New(queriedLockout.LockoutDuration),
+			ProgressiveDelay:    durationpb.New(queriedLockout.ProgressiveDelay),
 		}, nil
 	}
 	return nil, nil
@@ -506,6 +509,8 @@ func (s *Server) getPasswordComplexityPolicy(ctx context.Context, orgID string)
 			HasLowercase: queriedPasswordComplexity.HasLowercase,
 			HasNumber:    queriedPasswordComplexity.HasNumber,
 			HasSymbol:    queriedPasswordComplexity.HasSymbol,
+
+			DenyBreachedPasswords: queriedPasswordComplexity.DenyBreachedPasswords,
 		}, nil
 	}
 	return nil, nil
@@ -801,6 +806,9 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
 						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
 						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						DpopBoundAccessTokens:    app.OIDCConfig.DPoPBoundAccessTokens,
+						RequirePushedAuthRequest: app.OIDCConfig.RequirePushedAuthRequest,
+						BackChannelLogoutUri:     app.OIDCConfig.BackChannelLogoutURI,
 					},
 				})
 			}
@@ -12,5 +12,7 @@ func UpdatePasswordComplexityPolicyToDomain(req *admin_pb.UpdatePasswordComplexi
 		HasUppercase: req.HasUppercase,
 		HasNumber:    req.HasNumber,
 		HasSymbol:    req.HasSymbol,
+
+		DenyBreachedPasswords: req.DenyBreachedPasswords,
 	}
 }
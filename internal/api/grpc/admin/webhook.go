@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	webhook_grpc "github.com/zitadel/zitadel/internal/api/grpc/webhook"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListWebhooks(ctx context.Context, req *admin_pb.ListWebhooksRequest) (*admin_pb.ListWebhooksResponse, error) {
+	queries, err := webhook_grpc.ListWebhooksToQuery(authz.GetInstance(ctx).InstanceID(), req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := s.query.SearchWebhooks(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListWebhooksResponse{
+		Details: obj_grpc.ToListDetails(webhooks.Count, webhooks.Sequence, webhooks.Timestamp),
+		Result:  webhook_grpc.WebhooksToPb(webhooks.Webhooks),
+	}, nil
+}
+
+func (s *Server) GetWebhookByID(ctx context.Context, req *admin_pb.GetWebhookByIDRequest) (*admin_pb.GetWebhookByIDResponse, error) {
+	webhook, err := s.query.GetWebhookByID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetWebhookByIDResponse{
+		Webhook: webhook_grpc.WebhookToPb(webhook),
+	}, nil
+}
+
+func (s *Server) AddWebhook(ctx context.Context, req *admin_pb.AddWebhookRequest) (*admin_pb.AddWebhookResponse, error) {
+	id, signingKey, details, err := s.command.AddWebhook(ctx, webhook_grpc.WebhookToDomain("", req.Name, req.Url, req.EventTypes), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddWebhookResponse{
+		Id:         id,
+		SigningKey: signingKey,
+		Details:    obj_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateWebhook(ctx context.Context, req *admin_pb.UpdateWebhookRequest) (*admin_pb.UpdateWebhookResponse, error) {
+	details, err := s.command.ChangeWebhook(ctx, webhook_grpc.WebhookToDomain(req.Id, req.Name, req.Url, req.EventTypes), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveWebhook(ctx context.Context, req *admin_pb.RemoveWebhookRequest) (*admin_pb.RemoveWebhookResponse, error) {
+	details, err := s.command.RemoveWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListWebhookDeadLetters(ctx context.Context, req *admin_pb.ListWebhookDeadLettersRequest) (*admin_pb.ListWebhookDeadLettersResponse, error) {
+	queries, err := webhook_grpc.ListDeadLettersToQuery(authz.GetInstance(ctx).InstanceID(), req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := s.query.SearchWebhookDeadLetters(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListWebhookDeadLettersResponse{
+		Details: obj_grpc.ToListDetails(deadLetters.Count, deadLetters.Sequence, deadLetters.Timestamp),
+		Result:  webhook_grpc.DeadLettersToPb(deadLetters.DeadLetters),
+	}, nil
+}
+
+func (s *Server) RedeliverWebhookDeadLetter(ctx context.Context, req *admin_pb.RedeliverWebhookDeadLetterRequest) (*admin_pb.RedeliverWebhookDeadLetterResponse, error) {
+	details, err := s.command.RedeliverWebhookEvent(ctx, req.WebhookId, authz.GetInstance(ctx).InstanceID(), req.EventSequence)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RedeliverWebhookDeadLetterResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/peer"
 
 	"github.com/zitadel/zitadel/internal/api/http"
 )
@@ -20,3 +21,13 @@ func GetGatewayHeader(ctx context.Context, headername string) string {
 func GetAuthorizationHeader(ctx context.Context) string {
 	return GetHeader(ctx, http.Authorization)
 }
+
+// GetRemoteIP returns the ip of the client,
+// the X-Forwarded-For metadata (e.g. set by the gateway) is only honoured from trusted proxies
+func GetRemoteIP(ctx context.Context) string {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	return http.ClientIP(remoteAddr, metautils.ExtractIncoming(ctx)[http.ForwardedFor])
+}
@@ -2,9 +2,11 @@ package grpc
 
 import (
 	"context"
+	"net"
 	"testing"
 
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func TestGetHeader(t *testing.T) {
@@ -90,3 +92,56 @@ func TestGetAuthorizationHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRemoteIP(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"empty context",
+			args{
+				ctx: context.Background(),
+			},
+			"",
+		},
+		{
+			"forwarded by untrusted peer",
+			args{
+				ctx: metadata.NewIncomingContext(
+					peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}}),
+					metadata.Pairs("x-forwarded-for", "192.168.1.1, 10.0.0.2"),
+				),
+			},
+			"10.0.0.1",
+		},
+		{
+			"forwarded by gateway",
+			args{
+				ctx: metadata.NewIncomingContext(
+					peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}}),
+					metadata.Pairs("x-forwarded-for", "192.168.1.1, 10.0.0.2"),
+				),
+			},
+			"10.0.0.2",
+		},
+		{
+			"peer",
+			args{
+				ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}}),
+			},
+			"10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRemoteIP(tt.args.ctx); got != tt.want {
+				t.Errorf("GetRemoteIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package management
 
 import (
 	"context"
+	"time"
 
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
@@ -35,12 +41,13 @@ func (s *Server) GetAction(ctx context.Context, req *mgmt_pb.GetActionRequest) (
 }
 
 func (s *Server) CreateAction(ctx context.Context, req *mgmt_pb.CreateActionRequest) (*mgmt_pb.CreateActionResponse, error) {
-	id, details, err := s.command.AddAction(ctx, CreateActionRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	id, signingKey, details, err := s.command.AddAction(ctx, CreateActionRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
 	if err != nil {
 		return nil, err
 	}
 	return &mgmt_pb.CreateActionResponse{
-		Id: id,
+		Id:         id,
+		SigningKey: signingKey,
 		Details: obj_grpc.AddToDetailsPb(
 			details.Sequence,
 			details.EventDate,
@@ -50,7 +57,7 @@ func (s *Server) CreateAction(ctx context.Context, req *mgmt_pb.CreateActionRequ
 }
 
 func (s *Server) UpdateAction(ctx context.Context, req *mgmt_pb.UpdateActionRequest) (*mgmt_pb.UpdateActionResponse, error) {
-	details, err := s.command.ChangeAction(ctx, updateActionRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	signingKey, details, err := s.command.ChangeAction(ctx, updateActionRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +67,73 @@ func (s *Server) UpdateAction(ctx context.Context, req *mgmt_pb.UpdateActionRequ
 			details.EventDate,
 			details.ResourceOwner,
 		),
+		SigningKey: signingKey,
+	}, nil
+}
+
+func (s *Server) RegenerateActionSigningKey(ctx context.Context, req *mgmt_pb.RegenerateActionSigningKeyRequest) (*mgmt_pb.RegenerateActionSigningKeyResponse, error) {
+	signingKey, details, err := s.command.RegenerateActionSigningKey(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RegenerateActionSigningKeyResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+		SigningKey: signingKey,
 	}, nil
 }
 
+func (s *Server) TestAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*mgmt_pb.TestActionResponse, error) {
+	flowType := action_grpc.FlowTypeToDomain(req.FlowType)
+	if !flowType.HasTrigger(action_grpc.TriggerTypeToDomain(req.TriggerType)) {
+		return nil, errors.ThrowInvalidArgument(nil, "MANAG-ieY4a", "Errors.Flow.WrongTriggerType")
+	}
+	action, timeout, err := s.testedAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	actionCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	sandbox := actions.NewSandbox(action_grpc.ActionFetchMocksToDomain(req.FetchMocks)...)
+	err = actions.RunInSandbox(actionCtx, sandbox, req.GetContext().AsMap(), action.Script, action.Name, actions.ActionToOptions(action)...)
+	if err != nil {
+		return nil, err
+	}
+	res := &mgmt_pb.TestActionResponse{
+		Calls:         action_grpc.ActionCallsToPb(sandbox.Calls),
+		Logs:          action_grpc.ActionLogsToPb(sandbox.Logs),
+		Fetches:       action_grpc.ActionFetchesToPb(sandbox.Fetches),
+		ExecutionTime: durationpb.New(sandbox.Took),
+	}
+	if sandbox.Err != nil {
+		res.Error = sandbox.Err.Error()
+	}
+	return res, nil
+}
+
+// testedAction returns the existing action or the action of the script and the timeout of its run
+func (s *Server) testedAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*query.Action, time.Duration, error) {
+	if req.GetActionId() != "" {
+		action, err := s.query.GetActionByID(ctx, req.GetActionId(), authz.GetCtxData(ctx).OrgID, false)
+		if err != nil {
+			return nil, 0, err
+		}
+		return action, action.Timeout(), nil
+	}
+	action := &query.Action{
+		Name:   req.GetScript().GetName(),
+		Script: req.GetScript().GetScript(),
+	}
+	timeout := action.Timeout()
+	if scriptTimeout := req.GetScript().GetTimeout().AsDuration(); scriptTimeout > 0 && scriptTimeout < timeout {
+		timeout = scriptTimeout
+	}
+	return action, timeout, nil
+}
+
 func (s *Server) DeactivateAction(ctx context.Context, req *mgmt_pb.DeactivateActionRequest) (*mgmt_pb.DeactivateActionResponse, error) {
 	details, err := s.command.DeactivateAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
 	return &mgmt_pb.DeactivateActionResponse{
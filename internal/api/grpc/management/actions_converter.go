@@ -16,6 +16,8 @@ func CreateActionRequestToDomain(req *mgmt_pb.CreateActionRequest) *domain.Actio
 		Script:        req.Script,
 		Timeout:       req.Timeout.AsDuration(),
 		AllowedToFail: req.AllowedToFail,
+		TargetType:    action_grpc.ActionTargetTypeToDomain(req.TargetType),
+		TargetURL:     req.TargetUrl,
 	}
 }
 
@@ -28,6 +30,8 @@ func updateActionRequestToDomain(req *mgmt_pb.UpdateActionRequest) *domain.Actio
 		Script:        req.Script,
 		Timeout:       req.Timeout.AsDuration(),
 		AllowedToFail: req.AllowedToFail,
+		TargetType:    action_grpc.ActionTargetTypeToDomain(req.TargetType),
+		TargetURL:     req.TargetUrl,
 	}
 }
 
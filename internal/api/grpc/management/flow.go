@@ -18,6 +18,10 @@ func (s *Server) ListFlowTypes(ctx context.Context, _ *mgmt_pb.ListFlowTypesRequ
 			action_grpc.FlowTypeToPb(domain.FlowTypeExternalAuthentication),
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseToken),
 			action_grpc.FlowTypeToPb(domain.FlowTypeInternalAuthentication),
+			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseSAMLResponse),
+			action_grpc.FlowTypeToPb(domain.FlowTypeUser),
+			action_grpc.FlowTypeToPb(domain.FlowTypeUserGrant),
+			action_grpc.FlowTypeToPb(domain.FlowTypeMembership),
 		},
 	}, nil
 }
@@ -12,6 +12,8 @@ func AddPasswordComplexityPolicyToDomain(req *mgmt_pb.AddCustomPasswordComplexit
 		HasUppercase: req.HasUppercase,
 		HasNumber:    req.HasNumber,
 		HasSymbol:    req.HasSymbol,
+
+		DenyBreachedPasswords: req.DenyBreachedPasswords,
 	}
 }
 
@@ -22,5 +24,7 @@ func UpdatePasswordComplexityPolicyToDomain(req *mgmt_pb.UpdateCustomPasswordCom
 		HasUppercase: req.HasUppercase,
 		HasNumber:    req.HasNumber,
 		HasSymbol:    req.HasSymbol,
+
+		DenyBreachedPasswords: req.DenyBreachedPasswords,
 	}
 }
@@ -57,6 +57,9 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ClockSkew:                req.ClockSkew.AsDuration(),
 		AdditionalOrigins:        req.AdditionalOrigins,
 		SkipNativeAppSuccessPage: req.SkipNativeAppSuccessPage,
+		DPoPBoundAccessTokens:    req.DpopBoundAccessTokens,
+		RequirePushedAuthRequest: req.RequirePushedAuthRequest,
+		BackChannelLogoutURI:     req.BackChannelLogoutUri,
 	}
 }
 
@@ -108,6 +111,9 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ClockSkew:                app.ClockSkew.AsDuration(),
 		AdditionalOrigins:        app.AdditionalOrigins,
 		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+		DPoPBoundAccessTokens:    app.DpopBoundAccessTokens,
+		RequirePushedAuthRequest: app.RequirePushedAuthRequest,
+		BackChannelLogoutURI:     app.BackChannelLogoutUri,
 	}
 }
 
@@ -14,6 +14,8 @@ func ModelPasswordComplexityPolicyToPb(policy *query.PasswordComplexityPolicy) *
 		HasLowercase: policy.HasLowercase,
 		HasNumber:    policy.HasNumber,
 		HasSymbol:    policy.HasSymbol,
+
+		DenyBreachedPasswords: policy.DenyBreachedPasswords,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -21,9 +21,29 @@ func AuthorizationInterceptor(verifier *authz.TokenVerifier, authConfig authz.Co
 }
 
 func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, verifier *authz.TokenVerifier, authConfig authz.Config) (_ interface{}, err error) {
-	authOpt, needsToken := verifier.CheckAuthMethod(info.FullMethod)
+	ctx, err = checkAuthorization(ctx, req, info.FullMethod, verifier, authConfig)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AuthorizationStreamInterceptor authorizes server streaming calls like [AuthorizationInterceptor],
+// as the request is not received yet, permissions cannot be checked against request fields
+func AuthorizationStreamInterceptor(verifier *authz.TokenVerifier, authConfig authz.Config) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := checkAuthorization(stream.Context(), nil, info.FullMethod, verifier, authConfig)
+		if err != nil {
+			return err
+		}
+		return handler(srv, wrapServerStream(ctx, stream))
+	}
+}
+
+func checkAuthorization(ctx context.Context, req interface{}, fullMethod string, verifier *authz.TokenVerifier, authConfig authz.Config) (_ context.Context, err error) {
+	authOpt, needsToken := verifier.CheckAuthMethod(fullMethod)
 	if !needsToken {
-		return handler(ctx, req)
+		return ctx, nil
 	}
 
 	authCtx, span := tracing.NewServerInterceptorSpan(ctx)
@@ -41,12 +61,14 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 		orgDomain = o.OrganisationFromRequest().GetOrgDomain()
 	}
 
-	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, orgDomain, verifier, authConfig, authOpt, info.FullMethod)
+	dpopMethod, dpopPath := dpopTarget(authCtx, fullMethod)
+	authCtx = authz.WithDPoPProof(authCtx, grpc_util.GetHeader(authCtx, http.DPoP), dpopMethod, dpopPath)
+
+	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, orgDomain, verifier, authConfig, authOpt, fullMethod)
 	if err != nil {
 		return nil, err
 	}
-	span.End()
-	return handler(ctxSetter(ctx), req)
+	return ctxSetter(ctx), nil
 }
 
 type OrganisationFromRequest interface {
@@ -14,3 +14,9 @@ func CallDurationHandler() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+func CallDurationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, wrapServerStream(call.WithTimestamp(stream.Context()), stream))
+	}
+}
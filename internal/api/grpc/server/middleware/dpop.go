@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/zitadel/logging"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// gatewayHTTPMethod and gatewayHTTPPath are set by the grpc-gateway
+	// so DPoP proofs of REST calls can be checked against the original request
+	gatewayHTTPMethod = "grpcgateway-http-method"
+	gatewayHTTPPath   = "grpcgateway-http-path"
+	// gatewayVerification proves that the metadata was set by the grpc-gateway of this process
+	// and not by a native gRPC client, which could otherwise replay proofs issued for another target
+	gatewayVerification = "grpcgateway-verification"
+)
+
+// gatewayVerificationValue is a random value generated per process, which is only known to its grpc-gateway
+var gatewayVerificationValue = newGatewayVerificationValue()
+
+func newGatewayVerificationValue() string {
+	value := make([]byte, 32)
+	_, err := rand.Read(value)
+	logging.OnError(err).Fatal("unable to generate grpc-gateway verification value")
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// GatewayMetadata returns the metadata the grpc-gateway has to send along with every call,
+// so the DPoP proof can be checked against the HTTP method and path of the original REST request
+func GatewayMetadata(method, path string) metadata.MD {
+	return metadata.Pairs(
+		gatewayHTTPMethod, method,
+		gatewayHTTPPath, path,
+		gatewayVerification, gatewayVerificationValue,
+	)
+}
+
+// dpopTarget returns the HTTP method and path a DPoP proof must have been issued for.
+// Native gRPC and gRPC-Web calls are always POST requests on the full method name,
+// the target of the REST request is only used if the call was made by the grpc-gateway.
+func dpopTarget(ctx context.Context, fullMethod string) (method, path string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	methods, paths, verifications := md.Get(gatewayHTTPMethod), md.Get(gatewayHTTPPath), md.Get(gatewayVerification)
+	if len(methods) == 0 || len(paths) == 0 || len(verifications) == 0 {
+		return http.MethodPost, fullMethod
+	}
+	// the gateway appends its values after the ones mapped from the request headers
+	if subtle.ConstantTimeCompare([]byte(verifications[len(verifications)-1]), []byte(gatewayVerificationValue)) != 1 {
+		return http.MethodPost, fullMethod
+	}
+	return methods[len(methods)-1], paths[len(paths)-1]
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_dpopTarget(t *testing.T) {
+	const fullMethod = "/zitadel.auth.v1.AuthService/GetMyUser"
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		wantMethod string
+		wantPath   string
+	}{
+		{
+			name:       "native grpc call",
+			wantMethod: http.MethodPost,
+			wantPath:   fullMethod,
+		},
+		{
+			name:       "gateway call",
+			md:         GatewayMetadata(http.MethodGet, "/auth/v1/users/me"),
+			wantMethod: http.MethodGet,
+			wantPath:   "/auth/v1/users/me",
+		},
+		{
+			name: "gateway call with metadata of the client",
+			md: metadata.Join(
+				metadata.Pairs(gatewayHTTPMethod, http.MethodDelete, gatewayHTTPPath, "/other"),
+				GatewayMetadata(http.MethodGet, "/auth/v1/users/me"),
+			),
+			wantMethod: http.MethodGet,
+			wantPath:   "/auth/v1/users/me",
+		},
+		{
+			name:       "native grpc call with gateway metadata, ignored",
+			md:         metadata.Pairs(gatewayHTTPMethod, http.MethodGet, gatewayHTTPPath, "/auth/v1/users/me"),
+			wantMethod: http.MethodPost,
+			wantPath:   fullMethod,
+		},
+		{
+			name:       "native grpc call with wrong verification, ignored",
+			md:         metadata.Pairs(gatewayHTTPMethod, http.MethodGet, gatewayHTTPPath, "/auth/v1/users/me", gatewayVerification, "guess"),
+			wantMethod: http.MethodPost,
+			wantPath:   fullMethod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			method, path := dpopTarget(ctx, fullMethod)
+			assert.Equal(t, tt.wantMethod, method)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
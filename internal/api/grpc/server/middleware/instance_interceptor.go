@@ -56,11 +56,45 @@ func setInstance(ctx context.Context, req interface{}, info *grpc.UnaryServerInf
 		}
 	}
 
-	host, err := hostFromContext(interceptorCtx, headerName)
+	instance, err := instanceByHost(interceptorCtx, verifier, headerName, translator)
+	if err != nil {
+		return nil, err
+	}
+	span.End()
+	return handler(authz.WithInstance(ctx, instance), req)
+}
+
+// InstanceStreamInterceptor sets the instance of server streaming calls based on the requested host
+//
+// calls of the explicitInstanceIdServices are handled without instance, as the request is not received yet
+func InstanceStreamInterceptor(verifier authz.InstanceVerifier, headerName string, explicitInstanceIdServices ...string) grpc.StreamServerInterceptor {
+	translator, err := newZitadelTranslator(language.English)
+	logging.OnError(err).Panic("unable to get translator")
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		for _, service := range explicitInstanceIdServices {
+			if !strings.HasPrefix(service, "/") {
+				service = "/" + service
+			}
+			if strings.HasPrefix(info.FullMethod, service) {
+				return handler(srv, stream)
+			}
+		}
+		interceptorCtx, span := tracing.NewServerInterceptorSpan(stream.Context())
+		instance, err := instanceByHost(interceptorCtx, verifier, headerName, translator)
+		span.EndWithError(err)
+		if err != nil {
+			return err
+		}
+		return handler(srv, wrapServerStream(authz.WithInstance(stream.Context(), instance), stream))
+	}
+}
+
+func instanceByHost(ctx context.Context, verifier authz.InstanceVerifier, headerName string, translator *i18n.Translator) (authz.Instance, error) {
+	host, err := hostFromContext(ctx, headerName)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	instance, err := verifier.InstanceByHost(interceptorCtx, host)
+	instance, err := verifier.InstanceByHost(ctx, host)
 	if err != nil {
 		notFoundErr := new(errors.NotFoundError)
 		if errs.As(err, &notFoundErr) {
@@ -68,8 +102,7 @@ func setInstance(ctx context.Context, req interface{}, info *grpc.UnaryServerInf
 		}
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	span.End()
-	return handler(authz.WithInstance(ctx, instance), req)
+	return instance, nil
 }
 
 func hostFromContext(ctx context.Context, headerName string) (string, error) {
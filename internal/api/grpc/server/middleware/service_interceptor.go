@@ -19,3 +19,13 @@ func ServiceHandler() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+func ServiceStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		namer, ok := srv.(interface{ AppName() string })
+		if !ok {
+			return handler(srv, stream)
+		}
+		return handler(srv, wrapServerStream(service.WithService(stream.Context(), namer.AppName()), stream))
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+)
+
+// wrapServerStream replaces the context of the stream,
+// so the values set by stream interceptors are available in the handler
+func wrapServerStream(ctx context.Context, stream grpc.ServerStream) grpc.ServerStream {
+	wrapped := grpc_middleware.WrapServerStream(stream)
+	wrapped.WrappedContext = ctx
+	return wrapped
+}
+
+// sendMsgStream calls onSend with every message before it is sent to the client
+type sendMsgStream struct {
+	grpc.ServerStream
+	onSend func(m interface{})
+}
+
+func (s *sendMsgStream) SendMsg(m interface{}) error {
+	s.onSend(m)
+	return s.ServerStream.SendMsg(m)
+}
+
+// recvMsgStream calls onRecv with every message received from the client
+type recvMsgStream struct {
+	grpc.ServerStream
+	onRecv func(m interface{}) error
+}
+
+func (s *recvMsgStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.onRecv(m)
+}
@@ -36,3 +36,24 @@ func TranslationHandler() func(ctx context.Context, req interface{}, info *grpc.
 		return resp, err
 	}
 }
+
+// TranslationStreamHandler translates every message sent on a stream and the returned error
+func TranslationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		translator, translatorError := newZitadelTranslator(authz.GetInstance(ctx).DefaultLanguage())
+		if translatorError != nil {
+			logging.New().WithError(translatorError).Error("could not load translator")
+			return handler(srv, stream)
+		}
+		err := handler(srv, &sendMsgStream{
+			ServerStream: stream,
+			onSend: func(m interface{}) {
+				if loc, ok := m.(localizers); ok && m != nil {
+					translateFields(ctx, loc, translator)
+				}
+			},
+		})
+		return translateError(ctx, err, translator)
+	}
+}
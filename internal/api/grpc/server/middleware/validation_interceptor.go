@@ -34,3 +34,22 @@ func validate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	}
 	return handler(ctx, req)
 }
+
+// ValidationStreamHandler validates every message received on a stream
+func ValidationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &recvMsgStream{
+			ServerStream: stream,
+			onRecv: func(m interface{}) error {
+				validate, ok := m.(validator)
+				if !ok {
+					return nil
+				}
+				if err := validate.Validate(); err != nil {
+					return status.Error(codes.InvalidArgument, err.Error())
+				}
+				return nil
+			},
+		})
+	}
+}
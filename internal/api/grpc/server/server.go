@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_trace "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -59,6 +60,18 @@ func CreateServer(
 				middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
 			),
 		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.CallDurationStreamHandler(),
+				grpc_trace.StreamServerInterceptor(),
+				middleware.ErrorStreamHandler(),
+				middleware.InstanceStreamInterceptor(queries, hostHeaderName, system_pb.SystemService_ServiceDesc.ServiceName),
+				middleware.AuthorizationStreamInterceptor(verifier, authConfig),
+				middleware.TranslationStreamHandler(),
+				middleware.ValidationStreamHandler(),
+				middleware.ServiceStreamHandler(),
+			),
+		),
 	}
 	if tlsConfig != nil {
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
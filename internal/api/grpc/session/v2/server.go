@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	session "github.com/zitadel/zitadel/pkg/grpc/session/v2alpha"
 )
 
@@ -18,6 +19,7 @@ type Server struct {
 	command         *command.Commands
 	query           *query.Queries
 	checkPermission domain.PermissionCheck
+	rateLimiter     *ratelimit.Limiter
 }
 
 type Config struct{}
@@ -26,11 +28,13 @@ func CreateServer(
 	command *command.Commands,
 	query *query.Queries,
 	checkPermission domain.PermissionCheck,
+	rateLimiter *ratelimit.Limiter,
 ) *Server {
 	return &Server{
 		command:         command,
 		query:           query,
 		checkPermission: checkPermission,
+		rateLimiter:     rateLimiter,
 	}
 }
 
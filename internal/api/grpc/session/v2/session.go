@@ -7,11 +7,13 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	grpc_util "github.com/zitadel/zitadel/internal/api/grpc"
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	session "github.com/zitadel/zitadel/pkg/grpc/session/v2alpha"
 )
 
@@ -41,6 +43,10 @@ func (s *Server) ListSessions(ctx context.Context, req *session.ListSessionsRequ
 }
 
 func (s *Server) CreateSession(ctx context.Context, req *session.CreateSessionRequest) (*session.CreateSessionResponse, error) {
+	checked, err := s.limitPasswordCheck(ctx, req.GetChecks())
+	if err != nil {
+		return nil, err
+	}
 	checks, metadata, err := s.createSessionRequestToCommand(ctx, req)
 	if err != nil {
 		return nil, err
@@ -48,6 +54,7 @@ func (s *Server) CreateSession(ctx context.Context, req *session.CreateSessionRe
 	challengeResponse, cmds := s.challengesToCommand(req.GetChallenges(), checks)
 
 	set, err := s.command.CreateSession(ctx, cmds, req.GetDomain(), metadata)
+	checked(err)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +67,10 @@ func (s *Server) CreateSession(ctx context.Context, req *session.CreateSessionRe
 }
 
 func (s *Server) SetSession(ctx context.Context, req *session.SetSessionRequest) (*session.SetSessionResponse, error) {
+	checked, err := s.limitPasswordCheck(ctx, req.GetChecks())
+	if err != nil {
+		return nil, err
+	}
 	checks, err := s.setSessionRequestToCommand(ctx, req)
 	if err != nil {
 		return nil, err
@@ -67,6 +78,7 @@ func (s *Server) SetSession(ctx context.Context, req *session.SetSessionRequest)
 	challengeResponse, cmds := s.challengesToCommand(req.GetChallenges(), checks)
 
 	set, err := s.command.UpdateSession(ctx, req.GetSessionId(), req.GetSessionToken(), cmds, req.GetMetadata())
+	checked(err)
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +262,27 @@ func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([
 	return sessionChecks, nil
 }
 
+// limitPasswordCheck returns an error if the password checks of the client or the user are rate limited.
+// The returned function counts the attempt if the check failed.
+func (s *Server) limitPasswordCheck(ctx context.Context, checks *session.Checks) (func(error), error) {
+	if checks.GetPassword() == nil {
+		return func(error) {}, nil
+	}
+	ip := grpc_util.GetRemoteIP(ctx)
+	user := checks.GetUser().GetUserId()
+	if user == "" {
+		user = checks.GetUser().GetLoginName()
+	}
+	if err := s.rateLimiter.Allow(ctx, ratelimit.EndpointSessionCheck, ip, user); err != nil {
+		return nil, err
+	}
+	return func(err error) {
+		if err != nil {
+			s.rateLimiter.Hit(ctx, ratelimit.EndpointSessionCheck, ip, user)
+		}
+	}, nil
+}
+
 func (s *Server) challengesToCommand(challenges []session.ChallengeKind, cmds []command.SessionCommand) (*session.Challenges, []command.SessionCommand) {
 	if len(challenges) == 0 {
 		return nil, cmds
@@ -92,6 +92,8 @@ func passwordSettingsToPb(current *query.PasswordComplexityPolicy) *settings.Pas
 		RequiresNumber:    current.HasNumber,
 		RequiresSymbol:    current.HasSymbol,
 		ResourceOwnerType: isDefaultToResourceOwnerTypePb(current.IsDefault),
+
+		DenyBreachedPasswords: current.DenyBreachedPasswords,
 	}
 }
 
@@ -140,6 +142,9 @@ func lockoutSettingsToPb(current *query.LockoutPolicy) *settings.LockoutSettings
 	return &settings.LockoutSettings{
 		MaxPasswordAttempts: current.MaxPasswordAttempts,
 		ResourceOwnerType:   isDefaultToResourceOwnerTypePb(current.IsDefault),
+		MaxOtpAttempts:      current.MaxOTPAttempts,
+		LockoutDuration:     durationpb.New(current.LockoutDuration),
+		ProgressiveDelay:    durationpb.New(current.ProgressiveDelay),
 	}
 }
 
@@ -183,6 +188,8 @@ func idpTypeToPb(idpType domain.IDPType) settings.IdentityProviderType {
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GITLAB_SELF_HOSTED
 	case domain.IDPTypeGoogle:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GOOGLE
+	case domain.IDPTypeSAML:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SAML
 	default:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED
 	}
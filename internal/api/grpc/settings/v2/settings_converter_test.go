@@ -205,6 +205,8 @@ func Test_passwordSettingsToPb(t *testing.T) {
 		HasNumber:    true,
 		HasSymbol:    true,
 		IsDefault:    true,
+
+		DenyBreachedPasswords: true,
 	}
 	want := &settings.PasswordComplexitySettings{
 		MinLength:         12,
@@ -213,6 +215,8 @@ func Test_passwordSettingsToPb(t *testing.T) {
 		RequiresNumber:    true,
 		RequiresSymbol:    true,
 		ResourceOwnerType: settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+
+		DenyBreachedPasswords: true,
 	}
 
 	got := passwordSettingsToPb(arg)
@@ -321,11 +325,17 @@ func Test_legalSettingsToPb(t *testing.T) {
 func Test_lockoutSettingsToPb(t *testing.T) {
 	arg := &query.LockoutPolicy{
 		MaxPasswordAttempts: 22,
+		MaxOTPAttempts:      5,
+		LockoutDuration:     time.Hour,
+		ProgressiveDelay:    time.Second,
 		IsDefault:           true,
 	}
 	want := &settings.LockoutSettings{
 		MaxPasswordAttempts: 22,
 		ResourceOwnerType:   settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+		MaxOtpAttempts:      5,
+		LockoutDuration:     durationpb.New(time.Hour),
+		ProgressiveDelay:    durationpb.New(time.Second),
 	}
 	got := lockoutSettingsToPb(arg)
 	grpc.AllFieldsSet(t, got.ProtoReflect(), ignoreTypes...)
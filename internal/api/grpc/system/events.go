@@ -0,0 +1,18 @@
+package system
+
+import (
+	"context"
+
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) PruneEvents(ctx context.Context, req *system_pb.PruneEventsRequest) (*system_pb.PruneEventsResponse, error) {
+	result, err := s.command.PruneEvents(ctx, req.InstanceIds...)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.PruneEventsResponse{
+		PrunedAggregates: result.Aggregates,
+		PrunedEvents:     result.Events,
+	}, nil
+}
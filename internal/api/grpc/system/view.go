@@ -3,6 +3,9 @@ package system
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/query"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -35,3 +38,10 @@ func (s *Server) ClearView(ctx context.Context, req *system_pb.ClearViewRequest)
 	}
 	return &system_pb.ClearViewResponse{}, nil
 }
+
+func (s *Server) RebuildProjection(req *system_pb.RebuildProjectionRequest, stream system_pb.SystemService_RebuildProjectionServer) error {
+	return s.query.RebuildProjection(stream.Context(), req.ProjectionName, req.InstanceIds, func(progress *crdb.RebuildProgress) {
+		err := stream.Send(RebuildProgressToPb(progress))
+		logging.WithFields("projection", progress.Projection).OnError(err).Warn("unable to send rebuild progress")
+	})
+}
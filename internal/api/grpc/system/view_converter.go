@@ -3,6 +3,7 @@ package system
 import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/view/model"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
@@ -42,3 +43,26 @@ func CurrentSequenceToPb(database string, currentSequence *query.CurrentSequence
 		LastSuccessfulSpoolerRun: timestamppb.New(currentSequence.Timestamp),
 	}
 }
+
+func RebuildProgressToPb(progress *crdb.RebuildProgress) *system_pb.RebuildProjectionResponse {
+	return &system_pb.RebuildProjectionResponse{
+		ProjectionName:  progress.Projection,
+		InstanceId:      progress.InstanceID,
+		Phase:           rebuildPhaseToPb(progress.Phase),
+		Sequence:        progress.Sequence,
+		ProcessedEvents: progress.ProcessedEvents,
+	}
+}
+
+func rebuildPhaseToPb(phase crdb.RebuildPhase) system_pb.RebuildPhase {
+	switch phase {
+	case crdb.RebuildPhaseReplay:
+		return system_pb.RebuildPhase_REBUILD_PHASE_REPLAY
+	case crdb.RebuildPhaseSwap:
+		return system_pb.RebuildPhase_REBUILD_PHASE_SWAP
+	case crdb.RebuildPhaseDone:
+		return system_pb.RebuildPhase_REBUILD_PHASE_DONE
+	default:
+		return system_pb.RebuildPhase_REBUILD_PHASE_UNSPECIFIED
+	}
+}
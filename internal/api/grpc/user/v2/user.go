@@ -48,7 +48,7 @@ func addUserRequestToAddHuman(req *user.AddHumanUserRequest) (*command.AddHuman,
 			return nil, err
 		}
 	}
-	bcryptedPassword, err := hashedPasswordToCommand(req.GetHashedPassword())
+	encodedPasswordHash, err := hashedPasswordToCommand(req.GetHashedPassword())
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +85,7 @@ func addUserRequestToAddHuman(req *user.AddHumanUserRequest) (*command.AddHuman,
 		Gender:                 genderToDomain(req.GetProfile().GetGender()),
 		Phone:                  command.Phone{}, // TODO: add as soon as possible
 		Password:               req.GetPassword().GetPassword(),
-		BcryptedPassword:       bcryptedPassword,
+		EncodedPasswordHash:    encodedPasswordHash,
 		PasswordChangeRequired: passwordChangeRequired,
 		Passwordless:           false,
 		Register:               false,
@@ -113,11 +113,12 @@ func hashedPasswordToCommand(hashed *user.HashedPassword) (string, error) {
 	if hashed == nil {
 		return "", nil
 	}
-	// we currently only handle bcrypt
-	if hashed.GetAlgorithm() != "bcrypt" {
+	switch hashed.GetAlgorithm() {
+	case "bcrypt", "argon2id", "scrypt", "pbkdf2":
+		return hashed.GetHash(), nil
+	default:
 		return "", errors.ThrowInvalidArgument(nil, "USER-JDk4t", "Errors.InvalidArgument")
 	}
-	return hashed.GetHash(), nil
 }
 
 func (s *Server) AddIDPLink(ctx context.Context, req *user.AddIDPLinkRequest) (_ *user.AddIDPLinkResponse, err error) {
@@ -140,7 +141,7 @@ func (s *Server) StartIdentityProviderFlow(ctx context.Context, req *user.StartI
 	if err != nil {
 		return nil, err
 	}
-	authURL, err := s.command.AuthURLFromProvider(ctx, req.GetIdpId(), id, s.idpCallback(ctx))
+	authURL, err := s.command.AuthURLFromProvider(ctx, req.GetIdpId(), id, s.idpCallback(ctx), s.samlRootURL(ctx, req.GetIdpId()))
 	if err != nil {
 		return nil, err
 	}
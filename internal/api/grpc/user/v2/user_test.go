@@ -49,7 +49,7 @@ func Test_hashedPasswordToCommand(t *testing.T) {
 			},
 		},
 		{
-			"hashed, not bcrypt",
+			"hashed, not supported",
 			args{
 				hashed: &user.HashedPassword{
 					Hash:      "hash",
@@ -76,6 +76,19 @@ func Test_hashedPasswordToCommand(t *testing.T) {
 				nil,
 			},
 		},
+		{
+			"hashed, argon2id",
+			args{
+				hashed: &user.HashedPassword{
+					Hash:      "hash",
+					Algorithm: "argon2id",
+				},
+			},
+			res{
+				"hash",
+				nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
+	webhook_pb "github.com/zitadel/zitadel/pkg/grpc/webhook"
+)
+
+func WebhookToDomain(id, name, url string, eventTypes []string) *domain.Webhook {
+	return &domain.Webhook{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: id,
+		},
+		Name:       name,
+		URL:        url,
+		EventTypes: eventTypes,
+	}
+}
+
+func WebhooksToPb(webhooks []*query.Webhook) []*webhook_pb.Webhook {
+	list := make([]*webhook_pb.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		list[i] = WebhookToPb(webhook)
+	}
+	return list
+}
+
+func WebhookToPb(webhook *query.Webhook) *webhook_pb.Webhook {
+	return &webhook_pb.Webhook{
+		Id:         webhook.ID,
+		Details:    object_grpc.ChangeToDetailsPb(webhook.Sequence, webhook.ChangeDate, webhook.ResourceOwner),
+		State:      WebhookStateToPb(webhook.State),
+		Name:       webhook.Name,
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+	}
+}
+
+func WebhookStateToPb(state domain.WebhookState) webhook_pb.WebhookState {
+	switch state {
+	case domain.WebhookStateActive:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_ACTIVE
+	default:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_UNSPECIFIED
+	}
+}
+
+func DeadLettersToPb(deadLetters []*query.WebhookDeadLetter) []*webhook_pb.DeadLetter {
+	list := make([]*webhook_pb.DeadLetter, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		list[i] = DeadLetterToPb(deadLetter)
+	}
+	return list
+}
+
+func DeadLetterToPb(deadLetter *query.WebhookDeadLetter) *webhook_pb.DeadLetter {
+	return &webhook_pb.DeadLetter{
+		WebhookId:          deadLetter.WebhookID,
+		EventSequence:      deadLetter.EventSequence,
+		EventType:          deadLetter.EventType,
+		AggregateType:      deadLetter.AggregateType,
+		AggregateId:        deadLetter.AggregateID,
+		EventResourceOwner: deadLetter.EventResourceOwner,
+		Attempts:           deadLetter.Attempts,
+		Reason:             deadLetter.Reason,
+		FailedAt:           timestamppb.New(deadLetter.ChangeDate),
+	}
+}
+
+func ListWebhooksToQuery(resourceOwner string, listQuery *object_pb.ListQuery, webhookQueries []*webhook_pb.WebhookQuery) (_ *query.WebhookSearchQueries, err error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(listQuery)
+	queries := make([]query.SearchQuery, len(webhookQueries)+1)
+	queries[0], err = query.NewWebhookResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	for i, webhookQuery := range webhookQueries {
+		queries[i+1], err = WebhookQueryToQuery(webhookQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.WebhookSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func WebhookQueryToQuery(q interface{}) (query.SearchQuery, error) {
+	switch q := q.(type) {
+	case *webhook_pb.WebhookQuery_WebhookIdQuery:
+		return query.NewWebhookIDSearchQuery(q.WebhookIdQuery.Id)
+	case *webhook_pb.WebhookQuery_WebhookNameQuery:
+		return query.NewWebhookNameSearchQuery(object_grpc.TextMethodToQuery(q.WebhookNameQuery.Method), q.WebhookNameQuery.Name)
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "WEBHOOK-Aeb4o", "Errors.Query.InvalidRequest")
+}
+
+func ListDeadLettersToQuery(resourceOwner string, listQuery *object_pb.ListQuery, deadLetterQueries []*webhook_pb.DeadLetterQuery) (_ *query.WebhookDeadLetterSearchQueries, err error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(listQuery)
+	queries := make([]query.SearchQuery, len(deadLetterQueries)+1)
+	queries[0], err = query.NewWebhookDeadLetterResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	for i, deadLetterQuery := range deadLetterQueries {
+		queries[i+1], err = DeadLetterQueryToQuery(deadLetterQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.WebhookDeadLetterSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func DeadLetterQueryToQuery(q interface{}) (query.SearchQuery, error) {
+	switch q := q.(type) {
+	case *webhook_pb.DeadLetterQuery_WebhookIdQuery:
+		return query.NewWebhookDeadLetterWebhookIDSearchQuery(q.WebhookIdQuery.Id)
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "WEBHOOK-ahf8U", "Errors.Query.InvalidRequest")
+}
@@ -27,6 +27,7 @@ const (
 	IfNoneMatch     = "If-None-Match"
 	LastModified    = "Last-Modified"
 	Etag            = "Etag"
+	DPoP            = "dpop"
 
 	ContentSecurityPolicy   = "content-security-policy"
 	XXSSProtection          = "x-xss-protection"
@@ -70,15 +71,8 @@ func OriginFromCtx(ctx context.Context) string {
 }
 
 func RemoteIPFromCtx(ctx context.Context) string {
-	ctxHeaders, ok := HeadersFromCtx(ctx)
-	if !ok {
-		return RemoteAddrFromCtx(ctx)
-	}
-	forwarded, ok := GetForwardedFor(ctxHeaders)
-	if ok {
-		return forwarded
-	}
-	return RemoteAddrFromCtx(ctx)
+	headers, _ := HeadersFromCtx(ctx)
+	return ClientIP(RemoteAddrFromCtx(ctx), headers.Values(ForwardedFor))
 }
 
 func RemoteIPFromRequest(r *http.Request) net.IP {
@@ -86,12 +80,7 @@ func RemoteIPFromRequest(r *http.Request) net.IP {
 }
 
 func RemoteIPStringFromRequest(r *http.Request) string {
-	ip, ok := GetForwardedFor(r.Header)
-	if ok {
-		return ip
-	}
-	host, _, _ := net.SplitHostPort(r.RemoteAddr)
-	return host
+	return ClientIP(r.RemoteAddr, r.Header.Values(ForwardedFor))
 }
 
 func GetAuthorization(r *http.Request) string {
@@ -102,15 +91,15 @@ func GetOrgID(r *http.Request) string {
 	return r.Header.Get(ZitadelOrgID)
 }
 
-func GetForwardedFor(headers http.Header) (string, bool) {
-	forwarded, ok := headers[ForwardedFor]
-	if ok {
-		ip := strings.TrimSpace(strings.Split(forwarded[0], ",")[0])
-		if ip != "" {
-			return ip, true
-		}
-	}
-	return "", false
+func GetDPoPProof(r *http.Request) string {
+	return r.Header.Get(DPoP)
+}
+
+// RequestPath returns the path of the request as sent by the client,
+// regardless of any prefix stripped by the router
+func RequestPath(r *http.Request) string {
+	path, _, _ := strings.Cut(r.RequestURI, "?")
+	return path
 }
 
 func RemoteAddrFromCtx(ctx context.Context) string {
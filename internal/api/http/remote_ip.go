@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// trustedProxies are the networks of the reverse proxies, which are trusted to set the X-Forwarded-For header.
+// Loopback addresses are always trusted, as the grpc-gateway calls the gRPC server over loopback
+// and forwards the address of its peer in the X-Forwarded-For header.
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets the networks (in CIDR notation) of the trusted reverse proxies
+func SetTrustedProxies(cidrs []string) error {
+	proxies := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.ThrowInvalidArgumentf(err, "HTTP-Tp4xy", "trusted proxy %s is not a valid CIDR", cidr)
+		}
+		proxies = append(proxies, network)
+	}
+	trustedProxies = proxies
+	return nil
+}
+
+func isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the ip of the client, which sent the request to the remote address.
+// The X-Forwarded-For header is only honoured if the remote address is a trusted proxy.
+// As every proxy appends the address it received the request from,
+// the right-most address which isn't a trusted proxy is the client,
+// the addresses left of it could be set by the client itself.
+func ClientIP(remoteAddr string, forwardedFor []string) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if !isTrustedProxy(ip) {
+		return ip
+	}
+	forwarded := make([]string, 0, len(forwardedFor))
+	for _, header := range forwardedFor {
+		for _, address := range strings.Split(header, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				forwarded = append(forwarded, address)
+			}
+		}
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip = forwarded[i]
+		if !isTrustedProxy(ip) {
+			return ip
+		}
+	}
+	return ip
+}
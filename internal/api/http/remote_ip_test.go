@@ -0,0 +1,103 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	require.NoError(t, SetTrustedProxies([]string{"10.0.0.0/8", "fd00::/8"}))
+	t.Cleanup(func() { trustedProxies = nil })
+
+	type args struct {
+		remoteAddr   string
+		forwardedFor []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "no remote address",
+			args: args{},
+			want: "",
+		},
+		{
+			name: "direct client",
+			args: args{
+				remoteAddr: "203.0.113.1:1234",
+			},
+			want: "203.0.113.1",
+		},
+		{
+			name: "forwarded by untrusted client, ignored",
+			args: args{
+				remoteAddr:   "203.0.113.1:1234",
+				forwardedFor: []string{"198.51.100.1"},
+			},
+			want: "203.0.113.1",
+		},
+		{
+			name: "forwarded by trusted proxy",
+			args: args{
+				remoteAddr:   "10.0.0.1:1234",
+				forwardedFor: []string{"198.51.100.1"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "spoofed address left of the client, ignored",
+			args: args{
+				remoteAddr:   "10.0.0.1:1234",
+				forwardedFor: []string{"192.0.2.1, 198.51.100.1"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "chain of trusted proxies",
+			args: args{
+				remoteAddr:   "[fd00::1]:1234",
+				forwardedFor: []string{"192.0.2.1", "198.51.100.1, 10.0.0.2"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "loopback (gateway) always trusted",
+			args: args{
+				remoteAddr:   "127.0.0.1:1234",
+				forwardedFor: []string{"198.51.100.1"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "only trusted proxies, left-most",
+			args: args{
+				remoteAddr:   "10.0.0.1:1234",
+				forwardedFor: []string{"10.0.0.3, 10.0.0.2"},
+			},
+			want: "10.0.0.3",
+		},
+		{
+			name: "trusted proxy without header",
+			args: args{
+				remoteAddr: "10.0.0.1:1234",
+			},
+			want: "10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClientIP(tt.args.remoteAddr, tt.args.forwardedFor))
+		})
+	}
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	t.Cleanup(func() { trustedProxies = nil })
+	assert.NoError(t, SetTrustedProxies([]string{"10.0.0.0/8", " 192.168.0.0/16 ", ""}))
+	assert.Len(t, trustedProxies, 2)
+	assert.Error(t, SetTrustedProxies([]string{"10.0.0.1"}))
+}
@@ -24,12 +24,19 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
 const (
-	HandlerPrefix = "/idps"
-	callbackPath  = "/callback"
+	HandlerPrefix    = "/idps"
+	callbackPath     = "/callback"
+	samlPath         = "/{" + varIDPID + "}/saml"
+	samlMetadataPath = samlPath + "/metadata"
+	samlACSPath      = samlPath + "/acs"
+	samlPostPath     = samlPath + "/post"
+
+	varIDPID = "idpid"
 
 	paramIntentID         = "id"
 	paramToken            = "token"
@@ -44,6 +51,7 @@ type Handler struct {
 	parser              *form.Parser
 	encryptionAlgorithm crypto.EncryptionAlgorithm
 	callbackURL         func(ctx context.Context) string
+	samlRootURL         func(ctx context.Context, idpID string) string
 }
 
 type externalIDPCallbackData struct {
@@ -53,6 +61,16 @@ type externalIDPCallbackData struct {
 	ErrorDescription string `schema:"error_description"`
 }
 
+type externalSAMLIDPCallbackData struct {
+	IDPID      string `schema:"-"`
+	Response   string `schema:"SAMLResponse"`
+	RelayState string `schema:"RelayState"`
+}
+
+type samlPostData struct {
+	RelayState string `schema:"RelayState"`
+}
+
 // CallbackURL generates the instance specific URL to the IDP callback handler
 func CallbackURL(externalSecure bool) func(ctx context.Context) string {
 	return func(ctx context.Context) string {
@@ -60,6 +78,13 @@ func CallbackURL(externalSecure bool) func(ctx context.Context) string {
 	}
 }
 
+// SAMLRootURL generates the instance specific base URL of the SAML endpoints (metadata, ACS) of the IDP
+func SAMLRootURL(externalSecure bool) func(ctx context.Context, idpID string) string {
+	return func(ctx context.Context, idpID string) string {
+		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix + "/" + idpID + "/saml"
+	}
+}
+
 func NewHandler(
 	commands *command.Commands,
 	queries *query.Queries,
@@ -73,14 +98,135 @@ func NewHandler(
 		parser:              form.NewParser(),
 		encryptionAlgorithm: encryptionAlgorithm,
 		callbackURL:         CallbackURL(externalSecure),
+		samlRootURL:         SAMLRootURL(externalSecure),
 	}
 
 	router := mux.NewRouter()
 	router.Use(instanceInterceptor)
 	router.HandleFunc(callbackPath, h.handleCallback)
+	router.HandleFunc(samlMetadataPath, h.handleMetadata).Methods(http.MethodGet)
+	router.HandleFunc(samlACSPath, h.handleACS).Methods(http.MethodPost)
+	router.HandleFunc(samlPostPath, h.handleSAMLPost).Methods(http.MethodGet)
 	return router
 }
 
+func (h *Handler) getSAMLProvider(ctx context.Context, idpID string) (*saml.Provider, error) {
+	provider, err := h.commands.GetProvider(ctx, idpID, h.callbackURL(ctx), h.samlRootURL(ctx, idpID))
+	if err != nil {
+		return nil, err
+	}
+	samlProvider, ok := provider.(*saml.Provider)
+	if !ok {
+		return nil, z_errs.ThrowInvalidArgument(nil, "IDP-0n6hdnbr0y", "Errors.ExternalIDP.IDPTypeNotImplemented")
+	}
+	return samlProvider, nil
+}
+
+func (h *Handler) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	provider, err := h.getSAMLProvider(r.Context(), mux.Vars(r)[varIDPID])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	metadata, err := provider.Metadata()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write(metadata)
+	logging.OnError(err).Error("failed to write saml metadata")
+}
+
+// handleSAMLPost renders the form posting the AuthnRequest to the identity provider (HTTP-POST binding).
+// The request is not taken from the caller but created for the started intent passed as RelayState,
+// so the endpoint cannot be used to post arbitrary requests.
+func (h *Handler) handleSAMLPost(w http.ResponseWriter, r *http.Request) {
+	data := new(samlPostData)
+	if err := h.parser.Parse(r, data); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if data.RelayState == "" {
+		http.Error(w, reason("IDP-5pz0mpd7jb", "Errors.Intent.StateMissing"), http.StatusBadRequest)
+		return
+	}
+	intent := h.getActiveIntent(w, r, data.RelayState)
+	if intent == nil {
+		// if we didn't get an active intent the error was already handled (either redirected or display directly)
+		return
+	}
+	ctx := r.Context()
+	if intent.IDPID != mux.Vars(r)[varIDPID] {
+		err := z_errs.ThrowInvalidArgument(nil, "IDP-zb0oqf5nx1", "Errors.Intent.IDPMismatch")
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+	provider, err := h.getSAMLProvider(ctx, intent.IDPID)
+	if err != nil {
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = provider.PostForm(w, intent.AggregateID)
+	logging.OnError(err).Error("failed to render saml post form")
+}
+
+func (h *Handler) handleACS(w http.ResponseWriter, r *http.Request) {
+	data, err := h.parseSAMLCallbackRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	intent := h.getActiveIntent(w, r, data.RelayState)
+	if intent == nil {
+		// if we didn't get an active intent the error was already handled (either redirected or display directly)
+		return
+	}
+
+	ctx := r.Context()
+	if intent.IDPID != data.IDPID {
+		err = z_errs.ThrowInvalidArgument(nil, "IDP-h0j8ss5nym", "Errors.Intent.IDPMismatch")
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+	provider, err := h.getSAMLProvider(ctx, intent.IDPID)
+	if err != nil {
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+
+	session := &saml.Session{
+		Provider:  provider,
+		RequestID: saml.RequestID(intent.AggregateID),
+		Response:  data.Response,
+	}
+	idpUser, err := session.FetchUser(ctx)
+	if err != nil {
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+	userID, err := h.checkExternalUser(ctx, intent.IDPID, idpUser.GetID())
+	logging.WithFields("intent", intent.AggregateID).OnError(err).Error("could not check if idp user already exists")
+
+	token, err := h.commands.SucceedIDPIntent(ctx, intent, idpUser, session, userID)
+	if err != nil {
+		redirectToFailureURLErr(w, r, intent, z_errs.ThrowInternal(err, "IDP-2n2ir6ra5d", "Errors.Intent.TokenCreationFailed"))
+		return
+	}
+	redirectToSuccessURL(w, r, intent, token, userID)
+}
+
 func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 	data, err := h.parseCallbackRequest(r)
 	if err != nil {
@@ -102,7 +248,7 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	provider, err := h.commands.GetProvider(ctx, intent.IDPID, h.callbackURL(ctx))
+	provider, err := h.commands.GetProvider(ctx, intent.IDPID, h.callbackURL(ctx), h.samlRootURL(ctx, intent.IDPID))
 	if err != nil {
 		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
 		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
@@ -140,6 +286,19 @@ func (h *Handler) parseCallbackRequest(r *http.Request) (*externalIDPCallbackDat
 	return data, nil
 }
 
+func (h *Handler) parseSAMLCallbackRequest(r *http.Request) (*externalSAMLIDPCallbackData, error) {
+	data := new(externalSAMLIDPCallbackData)
+	err := h.parser.Parse(r, data)
+	if err != nil {
+		return nil, err
+	}
+	data.IDPID = mux.Vars(r)[varIDPID]
+	if data.RelayState == "" {
+		return nil, z_errs.ThrowInvalidArgument(nil, "IDP-3ylq2gtr2x", "Errors.Intent.StateMissing")
+	}
+	return data, nil
+}
+
 func (h *Handler) getActiveIntent(w http.ResponseWriter, r *http.Request, state string) *command.IDPIntentWriteModel {
 	intent, err := h.commands.GetIntentWriteModel(r.Context(), state, "")
 	if err != nil {
@@ -203,7 +362,7 @@ func (h *Handler) fetchIDPUser(ctx context.Context, identityProvider idp.Provide
 		session = &openid.Session{Provider: provider.Provider, Code: code}
 	case *google.Provider:
 		session = &openid.Session{Provider: provider.Provider, Code: code}
-	case *jwt.Provider, *ldap.Provider:
+	case *jwt.Provider, *ldap.Provider, *saml.Provider:
 		return nil, nil, z_errs.ThrowInvalidArgument(nil, "IDP-52jmn", "Errors.ExternalIDP.IDPTypeNotImplemented")
 	default:
 		return nil, nil, z_errs.ThrowUnimplemented(nil, "IDP-SSDg", "Errors.ExternalIDP.IDPTypeNotImplemented")
@@ -0,0 +1,57 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+const (
+	// ClaimSessionID identifies the session of the user agent at the provider.
+	// The same value is sent as sid in the logout tokens of the back-channel logout.
+	ClaimSessionID = "sid"
+
+	discoveryBackChannelLogoutSupported        = "backchannel_logout_supported"
+	discoveryBackChannelLogoutSessionSupported = "backchannel_logout_session_supported"
+)
+
+// SetUserinfoFromRequest implements the op.CanSetUserinfoFromRequest interface
+// and adds the session id (user agent) to the id_token
+func (o *OPStorage) SetUserinfoFromRequest(_ context.Context, userinfo *oidc.UserInfo, request op.IDTokenRequest, _ []string) error {
+	if sessionID := sessionIDFromRequest(request); sessionID != "" {
+		userinfo.AppendClaims(ClaimSessionID, sessionID)
+	}
+	return nil
+}
+
+func sessionIDFromRequest(request op.IDTokenRequest) string {
+	switch req := request.(type) {
+	case *AuthRequest:
+		return req.AgentID
+	case *RefreshTokenRequest:
+		return req.UserAgentID
+	default:
+		return ""
+	}
+}
+
+// backChannelLogoutDiscovery adds the support of back-channel logout (OpenID Connect Back-Channel Logout 1.0)
+// to the discovery configuration, as it's unknown to the op library.
+// The logout tokens are sent by the notification handlers.
+func backChannelLogoutDiscovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != oidc.DiscoveryEndpoint {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rewriter := newResponseRewriter(w, func(resp map[string]interface{}) bool {
+			resp[discoveryBackChannelLogoutSupported] = true
+			resp[discoveryBackChannelLogoutSessionSupported] = true
+			return true
+		})
+		next.ServeHTTP(rewriter, r)
+		rewriter.flush()
+	})
+}
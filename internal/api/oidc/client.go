@@ -21,6 +21,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
@@ -102,6 +103,15 @@ func (o *OPStorage) ValidateJWTProfileScopes(ctx context.Context, subject string
 func (o *OPStorage) AuthorizeClientIDSecret(ctx context.Context, id string, secret string) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	ip := api_http.RemoteIPFromCtx(ctx)
+	if err = o.rateLimiter.Allow(ctx, ratelimit.EndpointClientSecret, ip, id); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			o.rateLimiter.Hit(ctx, ratelimit.EndpointClientSecret, ip, id)
+		}
+	}()
 	ctx = authz.SetCtxData(ctx, authz.CtxData{
 		UserID: oidcCtx,
 		OrgID:  oidcCtx,
@@ -175,6 +185,9 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			if err != nil {
 				return err
 			}
+			if token.DPoPJKT != "" {
+				userInfo.AppendClaims(ClaimConfirmation, map[string]interface{}{"jkt": token.DPoPJKT})
+			}
 			introspection.SetUserInfo(userInfo)
 			introspection.Scope = token.Scopes
 			introspection.ClientID = token.ApplicationID
@@ -185,6 +198,9 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			introspection.Audience = token.Audience
 			introspection.Issuer = op.IssuerFromContext(ctx)
 			introspection.JWTID = token.ID
+			if token.DPoPJKT != "" {
+				introspection.TokenType = tokenTypeDPoP
+			}
 			return nil
 		}
 	}
@@ -573,7 +589,15 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		}
 	}
 
-	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	claims, err = o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	if err != nil {
+		return nil, err
+	}
+	// the key binding of DPoP bound tokens must not be altered by actions
+	if jkt := dpopJKTFromCtx(ctx); jkt != "" {
+		claims = appendClaim(claims, ClaimConfirmation, map[string]interface{}{"jkt": jkt})
+	}
+	return claims, nil
 }
 
 func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userGrants *query.UserGrants, claims map[string]interface{}) (map[string]interface{}, error) {
@@ -0,0 +1,90 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+const (
+	ClaimConfirmation = "cnf"
+
+	tokenTypeDPoP = "DPoP"
+	// invalidDPoPProof is the error code of RFC 9449, section 5
+	invalidDPoPProof = "invalid_dpop_proof"
+)
+
+type dpopCtxKey struct{}
+
+// dpopValidator verifies the DPoP proof (RFC 9449) sent to the token endpoint.
+// The thumbprint of the proof key is passed to the storage in the context,
+// so the issued tokens are bound to the key.
+type dpopValidator struct {
+	provider *op.Provider
+}
+
+func (d *dpopValidator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost ||
+			d.provider == nil ||
+			r.URL.Path != d.provider.TokenEndpoint().Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		proofs := r.Header.Values(http_utils.DPoP)
+		if len(proofs) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(proofs) > 1 {
+			op.RequestError(w, r, &oidc.Error{ErrorType: invalidDPoPProof, Description: "only one DPoP proof is allowed"})
+			return
+		}
+		ctx := r.Context()
+		jkt, err := authz.VerifyDPoPProof(proofs[0], r.Method, authz.GetInstance(ctx).RequestedHost(), http_utils.RequestPath(r), "")
+		if err != nil {
+			op.RequestError(w, r, &oidc.Error{ErrorType: invalidDPoPProof, Description: "DPoP proof is invalid", Parent: err})
+			return
+		}
+		rewriter := newResponseRewriter(w, dpopTokenResponse)
+		next.ServeHTTP(rewriter, r.WithContext(context.WithValue(ctx, dpopCtxKey{}, jkt)))
+		rewriter.flush()
+	})
+}
+
+// dpopJKTFromCtx returns the thumbprint of the verified DPoP proof key of the token request
+func dpopJKTFromCtx(ctx context.Context) string {
+	jkt, _ := ctx.Value(dpopCtxKey{}).(string)
+	return jkt
+}
+
+// dpopBinding returns the key thumbprint the tokens of the request are bound to.
+// Clients requiring DPoP bound access tokens must send a proof.
+func (o *OPStorage) dpopBinding(ctx context.Context, clientID string) (string, error) {
+	if jkt := dpopJKTFromCtx(ctx); jkt != "" || clientID == "" {
+		return jkt, nil
+	}
+	app, err := o.query.AppByOIDCClientID(ctx, clientID, false)
+	if err != nil {
+		return "", err
+	}
+	if app.OIDCConfig != nil && app.OIDCConfig.DPoPBoundAccessTokens {
+		return "", &oidc.Error{ErrorType: invalidDPoPProof, Description: "DPoP proof is required for this client"}
+	}
+	return "", nil
+}
+
+// dpopTokenResponse sets the token_type of bound tokens to DPoP,
+// as the op library always returns Bearer
+func dpopTokenResponse(resp map[string]interface{}) bool {
+	if tokenType, _ := resp["token_type"].(string); tokenType != oidc.BearerToken {
+		return false
+	}
+	resp["token_type"] = tokenTypeDPoP
+	return true
+}
@@ -0,0 +1,126 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dpopTokenResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		resp        map[string]interface{}
+		want        map[string]interface{}
+		wantRewrite bool
+	}{
+		{
+			name:        "bearer token",
+			resp:        map[string]interface{}{"access_token": "token", "token_type": "Bearer"},
+			want:        map[string]interface{}{"access_token": "token", "token_type": "DPoP"},
+			wantRewrite: true,
+		},
+		{
+			name:        "already dpop",
+			resp:        map[string]interface{}{"access_token": "token", "token_type": "DPoP"},
+			want:        map[string]interface{}{"access_token": "token", "token_type": "DPoP"},
+			wantRewrite: false,
+		},
+		{
+			name:        "no token type",
+			resp:        map[string]interface{}{"error": "invalid_grant"},
+			want:        map[string]interface{}{"error": "invalid_grant"},
+			wantRewrite: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dpopTokenResponse(tt.resp)
+			assert.Equal(t, tt.wantRewrite, got)
+			assert.Equal(t, tt.want, tt.resp)
+		})
+	}
+}
+
+func Test_responseRewriter_dpop(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantBody string
+	}{
+		{
+			name:     "successful token response",
+			status:   http.StatusOK,
+			body:     `{"access_token":"token","token_type":"Bearer"}`,
+			wantBody: `{"access_token":"token","token_type":"DPoP"}`,
+		},
+		{
+			name:     "error response not rewritten",
+			status:   http.StatusBadRequest,
+			body:     `{"error":"invalid_dpop_proof","token_type":"Bearer"}`,
+			wantBody: `{"error":"invalid_dpop_proof","token_type":"Bearer"}`,
+		},
+		{
+			name:     "invalid json not rewritten",
+			status:   http.StatusOK,
+			body:     `token`,
+			wantBody: `token`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			rewriter := newResponseRewriter(recorder, dpopTokenResponse)
+			rewriter.WriteHeader(tt.status)
+			_, err := rewriter.Write([]byte(tt.body))
+			require.NoError(t, err)
+			rewriter.flush()
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.wantBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestOPStorage_dpopBinding(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		clientID string
+		want     string
+	}{
+		{
+			name:     "proof verified",
+			ctx:      context.WithValue(context.Background(), dpopCtxKey{}, "jkt"),
+			clientID: "clientID",
+			want:     "jkt",
+		},
+		{
+			name: "no proof and no client",
+			ctx:  context.Background(),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := new(OPStorage).dpopBinding(tt.ctx, tt.clientID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_dpopValidator_Handler_passThrough(t *testing.T) {
+	var called bool
+	handler := (&dpopValidator{}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Empty(t, dpopJKTFromCtx(r.Context()))
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+	req.Header.Set("DPoP", "proof")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, called)
+}
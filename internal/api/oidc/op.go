@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"golang.org/x/text/language"
@@ -18,11 +19,13 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	"github.com/zitadel/zitadel/internal/telemetry/metrics"
 )
 
@@ -37,6 +40,7 @@ type Config struct {
 	DefaultIdTokenLifetime            time.Duration
 	DefaultRefreshTokenIdleExpiration time.Duration
 	DefaultRefreshTokenExpiration     time.Duration
+	PushedAuthRequestLifetime         time.Duration
 	UserAgentCookieConfig             *middleware.UserAgentCookieConfig
 	Cache                             *middleware.CacheConfig
 	CustomEndpoints                   *EndpointConfig
@@ -52,6 +56,7 @@ type EndpointConfig struct {
 	EndSession    *Endpoint
 	Keys          *Endpoint
 	DeviceAuth    *Endpoint
+	PAR           *Endpoint // pushed authorization requests (RFC 9126)
 }
 
 type Endpoint struct {
@@ -73,15 +78,25 @@ type OPStorage struct {
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
+	checkPermission                   domain.PermissionCheck
+	rateLimiter                       *ratelimit.Limiter
 }
 
-func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *database.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
+func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *database.DB, permissionCheck domain.PermissionCheck, rateLimiter *ratelimit.Limiter, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
-	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
-	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler)
+	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure, permissionCheck, rateLimiter)
+	par := &pushedAuthorizer{
+		storage:  storage,
+		endpoint: pushedAuthRequestEndpoint(config.CustomEndpoints),
+		lifetime: config.PushedAuthRequestLifetime,
+	}
+	dpop := new(dpopValidator)
+	exchanger := &tokenExchanger{storage: storage}
+	tokenLimiter := &tokenRateLimiter{limiter: rateLimiter}
+	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler, tokenLimiter.Handler, par.Handler, dpop.Handler, exchanger.Handler)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
 	}
@@ -94,6 +109,16 @@ func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-DAtg3", "cannot create provider")
 	}
+	par.provider = provider
+	dpop.provider = provider
+	exchanger.provider = provider
+	tokenLimiter.provider = provider
+	router, ok := provider.HttpHandler().(*mux.Router)
+	if !ok {
+		return nil, caos_errs.ThrowInternal(nil, "OIDC-Pa3rR", "cannot register pushed authorization request endpoint")
+	}
+	// the route is added to the router of the op library, so the same interceptors are applied
+	router.HandleFunc(par.endpoint.Relative(), par.push)
 	return provider, nil
 }
 
@@ -119,7 +144,7 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) ([]op.Option, error) {
+func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler, accessHandler, tokenRateLimitHandler, pushedAuthRequestHandler, dpopHandler, tokenExchangeHandler func(http.Handler) http.Handler) ([]op.Option, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	options := []op.Option{
 		op.WithHttpInterceptors(
@@ -130,6 +155,11 @@ func createOptions(config Config, externalSecure bool, userAgentCookie, instance
 			userAgentCookie,
 			http_utils.CopyHeadersToContext,
 			accessHandler,
+			tokenRateLimitHandler,
+			pushedAuthRequestHandler,
+			backChannelLogoutDiscovery,
+			dpopHandler,
+			tokenExchangeHandler,
 		),
 	}
 	if !externalSecure {
@@ -174,7 +204,7 @@ func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	return options
 }
 
-func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool) *OPStorage {
+func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool, permissionCheck domain.PermissionCheck, rateLimiter *ratelimit.Limiter) *OPStorage {
 	return &OPStorage{
 		repo:                              repo,
 		command:                           command,
@@ -189,6 +219,8 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(db.DB, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
+		checkPermission:                   permissionCheck,
+		rateLimiter:                       rateLimiter,
 	}
 }
 
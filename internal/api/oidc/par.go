@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	defaultPushedAuthRequestEndpoint = "oauth/v2/par"
+
+	requestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+	formRequestURI   = "request_uri"
+	// invalidRequestURI is the error code of RFC 9126, section 4
+	invalidRequestURI = "invalid_request_uri"
+
+	discoveryPushedAuthRequestEndpoint = "pushed_authorization_request_endpoint"
+	discoveryRequirePushedAuthRequests = "require_pushed_authorization_requests"
+)
+
+type pushedAuthRequestCtxKey struct{}
+
+type pushedAuthRequestResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  uint64 `json:"expires_in"`
+}
+
+// pushedAuthorizer handles pushed authorization requests (PAR, RFC 9126).
+// The requests are stored on the PAR endpoint and resolved by their request_uri
+// on the authorization endpoint, before the op library handles the authorization request.
+type pushedAuthorizer struct {
+	storage  *OPStorage
+	provider *op.Provider
+	endpoint op.Endpoint
+	lifetime time.Duration
+}
+
+func (p *pushedAuthorizer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p.provider == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch r.URL.Path {
+		case oidc.DiscoveryEndpoint:
+			rewriter := newResponseRewriter(w, p.discovery(r.Context()))
+			next.ServeHTTP(rewriter, r)
+			rewriter.flush()
+		case p.provider.AuthorizationEndpoint().Relative():
+			p.authorize(w, r, next)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// discovery adds the PAR endpoint to the discovery configuration,
+// as it's unknown to the op library
+func (p *pushedAuthorizer) discovery(ctx context.Context) func(resp map[string]interface{}) bool {
+	return func(resp map[string]interface{}) bool {
+		resp[discoveryPushedAuthRequestEndpoint] = p.endpoint.Absolute(op.IssuerFromContext(ctx))
+		// PAR can be required per application only
+		resp[discoveryRequirePushedAuthRequests] = false
+		return true
+	}
+}
+
+// authorize replaces the parameters of an authorization request with a request_uri
+// by the ones of the pushed request
+func (p *pushedAuthorizer) authorize(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	requestURI := r.FormValue(formRequestURI)
+	if !strings.HasPrefix(requestURI, requestURIPrefix) {
+		next.ServeHTTP(w, r)
+		return
+	}
+	request, err := p.pushedRequest(r.Context(), strings.TrimPrefix(requestURI, requestURIPrefix), r.FormValue(formClientID))
+	if err != nil {
+		op.AuthRequestError(w, r, nil, err, p.provider.Encoder())
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), pushedAuthRequestCtxKey{}, true))
+	r.Form = request.Request
+	r.PostForm = make(url.Values)
+	next.ServeHTTP(w, r)
+}
+
+func (p *pushedAuthorizer) pushedRequest(ctx context.Context, id, clientID string) (*domain.PushedAuthRequest, error) {
+	request, err := p.storage.repo.PushedAuthRequestByID(ctx, id)
+	if err != nil {
+		return nil, &oidc.Error{ErrorType: invalidRequestURI, Description: "request_uri is invalid or expired", Parent: err}
+	}
+	if request.ClientID != clientID {
+		return nil, &oidc.Error{ErrorType: invalidRequestURI, Description: "request_uri was not issued to the client"}
+	}
+	return request, nil
+}
+
+// isPushedAuthRequest reports if the authorization request was resolved from a pushed request
+func isPushedAuthRequest(ctx context.Context) bool {
+	pushed, _ := ctx.Value(pushedAuthRequestCtxKey{}).(bool)
+	return pushed
+}
+
+// push handles the PAR endpoint
+func (p *pushedAuthorizer) push(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	resp, err := p.pushRequest(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+func (p *pushedAuthorizer) pushRequest(r *http.Request) (_ *pushedAuthRequestResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	client, err := p.authorizeClient(r)
+	if err != nil {
+		return nil, err
+	}
+	authReq, err := op.ParseAuthorizeRequest(r, p.provider.Decoder())
+	if err != nil {
+		return nil, err
+	}
+	if err = validatePushedAuthRequest(client, authReq, r.Form); err != nil {
+		return nil, err
+	}
+	request, err := p.storage.repo.CreatePushedAuthRequest(ctx, &domain.PushedAuthRequest{
+		ClientID:   client.GetID(),
+		Request:    pushedAuthRequestParams(r.Form, client.GetID()),
+		Expiration: time.Now().Add(p.lifetime),
+	})
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	return &pushedAuthRequestResponse{
+		RequestURI: requestURIPrefix + request.ID,
+		ExpiresIn:  uint64(p.lifetime / time.Second),
+	}, nil
+}
+
+// authorizeClient authenticates the client the same way as on the token endpoint.
+// Public clients only have to provide their client_id.
+func (p *pushedAuthorizer) authorizeClient(r *http.Request) (op.Client, error) {
+	ctx := r.Context()
+	clientID, authenticated, err := op.ClientIDFromRequest(r, p.provider)
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.storage.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	if authenticated {
+		return client, nil
+	}
+	switch client.AuthMethod() {
+	case oidc.AuthMethodNone:
+		return client, nil
+	case oidc.AuthMethodPost:
+		if err = op.AuthorizeClientIDSecret(ctx, clientID, r.Form.Get(formClientSecret), p.storage); err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, oidc.ErrInvalidClient().WithDescription("client authentication missing")
+	}
+}
+
+// validatePushedAuthRequest checks the request as far as possible before it's stored (RFC 9126, section 2.1).
+// Requests passing the parameters as request object are validated on the authorization endpoint.
+func validatePushedAuthRequest(client op.Client, authReq *oidc.AuthRequest, form url.Values) error {
+	if form.Has(formRequestURI) {
+		return oidc.ErrInvalidRequest().WithDescription("request_uri is not allowed")
+	}
+	if authReq.ClientID != "" && authReq.ClientID != client.GetID() {
+		return oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client")
+	}
+	if authReq.RequestParam != "" {
+		return nil
+	}
+	if authReq.RedirectURI == "" {
+		return oidc.ErrInvalidRequest().WithDescription("redirect_uri missing")
+	}
+	if err := op.ValidateAuthReqResponseType(client, authReq.ResponseType); err != nil {
+		return err
+	}
+	return op.ValidateAuthReqRedirectURI(client, authReq.RedirectURI, authReq.ResponseType)
+}
+
+// pushedAuthRequestParams returns the parameters of the authorization request without the client credentials
+func pushedAuthRequestParams(form url.Values, clientID string) url.Values {
+	params := make(url.Values, len(form))
+	for key, values := range form {
+		switch key {
+		case formClientSecret, formClientAssertion, formClientAssertionType:
+			continue
+		}
+		params[key] = values
+	}
+	params.Set(formClientID, clientID)
+	return params
+}
+
+// checkPushedAuthRequest returns an error if the client requires pushed authorization requests,
+// but the authorization request was not pushed
+func (o *OPStorage) checkPushedAuthRequest(ctx context.Context, clientID string) error {
+	if isPushedAuthRequest(ctx) {
+		return nil
+	}
+	app, err := o.query.AppByOIDCClientID(ctx, clientID, false)
+	if err != nil {
+		return err
+	}
+	if app.OIDCConfig != nil && app.OIDCConfig.RequirePushedAuthRequest {
+		return oidc.ErrInvalidRequest().WithDescription("pushed authorization request required")
+	}
+	return nil
+}
+
+func pushedAuthRequestEndpoint(endpointConfig *EndpointConfig) op.Endpoint {
+	if endpointConfig == nil || endpointConfig.PAR == nil {
+		return op.NewEndpoint(defaultPushedAuthRequestEndpoint)
+	}
+	return op.NewEndpointWithURL(endpointConfig.PAR.Path, endpointConfig.PAR.URL)
+}
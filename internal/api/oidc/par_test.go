@@ -0,0 +1,169 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/eventstore"
+	"github.com/zitadel/zitadel/internal/auth_request/repository/cache"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var consumePushedAuthRequestStmt = regexp.QuoteMeta("DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 AND id = $2 RETURNING client_id, request, creation_date, expiration")
+
+// testParRepo uses the auth request repository for the pushed requests, all other calls panic
+type testParRepo struct {
+	repository.Repository
+	authRequests *eventstore.AuthRequestRepo
+}
+
+func (r *testParRepo) PushedAuthRequestByID(ctx context.Context, id string) (*domain.PushedAuthRequest, error) {
+	return r.authRequests.PushedAuthRequestByID(ctx, id)
+}
+
+type expectedConsume struct {
+	id         string
+	clientID   string
+	expiration time.Time
+	err        error
+}
+
+func newTestPushedAuthorizer(t *testing.T, consumes ...expectedConsume) (*pushedAuthorizer, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	for _, consume := range consumes {
+		expect := mock.ExpectQuery(consumePushedAuthRequestStmt).
+			WithArgs("instanceID", consume.id)
+		if consume.err != nil {
+			expect.WillReturnError(consume.err)
+			continue
+		}
+		expect.WillReturnRows(
+			sqlmock.NewRows([]string{"client_id", "request", "creation_date", "expiration"}).
+				AddRow(consume.clientID, []byte(`{"client_id":["`+consume.clientID+`"],"redirect_uri":["https://example.com/cb"]}`), time.Now(), consume.expiration),
+		)
+	}
+	return &pushedAuthorizer{
+		storage: &OPStorage{
+			repo: &testParRepo{
+				authRequests: &eventstore.AuthRequestRepo{
+					AuthRequests: cache.Start(&database.DB{DB: db}),
+				},
+			},
+		},
+	}, mock
+}
+
+func Test_pushedAuthorizer_pushedRequest(t *testing.T) {
+	type args struct {
+		id       string
+		clientID string
+	}
+	tests := []struct {
+		name     string
+		consumes []expectedConsume
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "valid request",
+			consumes: []expectedConsume{
+				{id: "requestID", clientID: "clientID", expiration: time.Now().Add(time.Minute)},
+			},
+			args: args{
+				id:       "requestID",
+				clientID: "clientID",
+			},
+		},
+		{
+			name: "expired request, error",
+			consumes: []expectedConsume{
+				{id: "requestID", clientID: "clientID", expiration: time.Now().Add(-time.Second)},
+			},
+			args: args{
+				id:       "requestID",
+				clientID: "clientID",
+			},
+			wantErr: true,
+		},
+		{
+			name: "request of other client, error",
+			consumes: []expectedConsume{
+				{id: "requestID", clientID: "otherClientID", expiration: time.Now().Add(time.Minute)},
+			},
+			args: args{
+				id:       "requestID",
+				clientID: "clientID",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown request, error",
+			consumes: []expectedConsume{
+				{id: "unknown", err: sql.ErrNoRows},
+			},
+			args: args{
+				id:       "unknown",
+				clientID: "clientID",
+			},
+			wantErr: true,
+		},
+		{
+			name: "database error, error",
+			consumes: []expectedConsume{
+				{id: "requestID", err: driver.ErrBadConn},
+			},
+			args: args{
+				id:       "requestID",
+				clientID: "clientID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, mock := newTestPushedAuthorizer(t, tt.consumes...)
+			got, err := p.pushedRequest(authz.WithInstanceID(context.Background(), "instanceID"), tt.args.id, tt.args.clientID)
+			require.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr {
+				var oidcErr *oidc.Error
+				require.True(t, errors.As(err, &oidcErr))
+				assert.EqualValues(t, invalidRequestURI, oidcErr.ErrorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.args.clientID, got.ClientID)
+			assert.Equal(t, "https://example.com/cb", url.Values(got.Request).Get("redirect_uri"))
+		})
+	}
+}
+
+func Test_pushedAuthorizer_pushedRequest_singleUse(t *testing.T) {
+	// the request is deleted when it's read, the second read does not find it anymore
+	p, mock := newTestPushedAuthorizer(t,
+		expectedConsume{id: "requestID", clientID: "clientID", expiration: time.Now().Add(time.Minute)},
+		expectedConsume{id: "requestID", err: sql.ErrNoRows},
+	)
+	ctx := authz.WithInstanceID(context.Background(), "instanceID")
+
+	_, err := p.pushedRequest(ctx, "requestID", "clientID")
+	require.NoError(t, err)
+	_, err = p.pushedRequest(ctx, "requestID", "clientID")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
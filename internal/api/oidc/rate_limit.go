@@ -0,0 +1,43 @@
+package oidc
+
+import (
+	"net/http"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/ratelimit"
+)
+
+// tokenRateLimiter limits the requests to the token endpoint per client ip, client and instance
+type tokenRateLimiter struct {
+	provider op.OpenIDProvider
+	limiter  *ratelimit.Limiter
+}
+
+func (t *tokenRateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if t.limiter == nil ||
+			r.Method != http.MethodPost ||
+			t.provider == nil ||
+			r.URL.Path != t.provider.TokenEndpoint().Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := t.limiter.Take(r.Context(), ratelimit.EndpointOIDCToken, http_utils.RemoteIPStringFromRequest(r), requestClientID(r)); err != nil {
+			httphelper.MarshalJSONWithStatus(w, oidc.ErrInvalidRequest().WithDescription("too many requests"), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestClientID returns the client_id of the basic auth header or the form
+func requestClientID(r *http.Request) string {
+	if clientID, _, ok := r.BasicAuth(); ok {
+		return clientID
+	}
+	return r.FormValue("client_id")
+}
@@ -0,0 +1,63 @@
+package oidc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// responseRewriter buffers the JSON response of the op library,
+// so a successful response can be modified before it is sent to the client
+type responseRewriter struct {
+	http.ResponseWriter
+	status  int
+	body    bytes.Buffer
+	rewrite func(resp map[string]interface{}) bool
+}
+
+// newResponseRewriter returns a [responseRewriter] calling rewrite on the decoded response.
+// The response is only encoded again if rewrite reports a modification.
+func newResponseRewriter(w http.ResponseWriter, rewrite func(resp map[string]interface{}) bool) *responseRewriter {
+	return &responseRewriter{
+		ResponseWriter: w,
+		rewrite:        rewrite,
+	}
+}
+
+func (w *responseRewriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *responseRewriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// flush writes the (rewritten) response to the underlying [http.ResponseWriter]
+func (w *responseRewriter) flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	body := w.body.Bytes()
+	if w.status == http.StatusOK {
+		body = w.rewriteBody(body)
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(body)
+}
+
+func (w *responseRewriter) rewriteBody(body []byte) []byte {
+	resp := make(map[string]interface{})
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return body
+	}
+	if !w.rewrite(resp) {
+		return body
+	}
+	rewritten, err := json.Marshal(resp)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
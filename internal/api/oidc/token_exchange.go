@@ -0,0 +1,593 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	ClaimActor = "act"
+
+	formClientID            = "client_id"
+	formClientSecret        = "client_secret"
+	formClientAssertion     = "client_assertion"
+	formClientAssertionType = "client_assertion_type"
+)
+
+// tokenExchanger handles the token exchange grant (RFC 8693) on the token endpoint.
+// The handling is done in an interceptor instead of the op library,
+// because the library is neither able to verify opaque access tokens nor private_key_jwt authenticated clients.
+type tokenExchanger struct {
+	storage  *OPStorage
+	provider *op.Provider
+}
+
+func (t *tokenExchanger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost ||
+			t.provider == nil ||
+			r.URL.Path != t.provider.TokenEndpoint().Relative() ||
+			r.FormValue("grant_type") != string(oidc.GrantTypeTokenExchange) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		resp, err := t.exchange(r)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		httphelper.MarshalJSON(w, resp)
+	})
+}
+
+func (t *tokenExchanger) exchange(r *http.Request) (_ *oidc.TokenExchangeResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	exchangeReq, clientID, clientSecret, err := op.ParseTokenExchangeRequest(r, t.provider.Decoder())
+	if err != nil {
+		return nil, err
+	}
+	client, err := t.authorizeClient(ctx, r, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !op.ValidateGrantType(client, oidc.GrantTypeTokenExchange) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("token exchange is not allowed for this client")
+	}
+	if exchangeReq.SubjectToken == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token missing")
+	}
+	if exchangeReq.SubjectTokenType == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token_type missing")
+	}
+	if exchangeReq.ActorToken != "" && exchangeReq.ActorTokenType == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("actor_token_type missing")
+	}
+	projectID, err := t.storage.query.ProjectIDFromClientID(ctx, client.GetID(), false)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	subjectToken, err := t.verifyToken(ctx, exchangeReq.SubjectToken, exchangeReq.SubjectTokenType)
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token is invalid").WithParent(err)
+	}
+	if !isTokenForClient(subjectToken, client.GetID(), projectID) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token is not valid for this client")
+	}
+	if err = checkDPoPBinding(ctx, subjectToken); err != nil {
+		return nil, err
+	}
+	req := &tokenExchangeRequest{
+		subjectToken:       subjectToken,
+		subjectTokenType:   exchangeReq.SubjectTokenType,
+		subject:            subjectToken.userID,
+		resource:           exchangeReq.Resource,
+		audience:           exchangeReq.Audience,
+		scopes:             exchangeReq.Scopes,
+		requestedTokenType: exchangeReq.RequestedTokenType,
+		clientID:           client.GetID(),
+		projectID:          projectID,
+		authTime:           subjectToken.authTime,
+	}
+	if exchangeReq.ActorToken != "" {
+		req.actorToken, err = t.verifyToken(ctx, exchangeReq.ActorToken, exchangeReq.ActorTokenType)
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("actor_token is invalid").WithParent(err)
+		}
+		if !isTokenForClient(req.actorToken, client.GetID(), projectID) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("actor_token is not valid for this client")
+		}
+		if err = checkDPoPBinding(ctx, req.actorToken); err != nil {
+			return nil, err
+		}
+		req.actorTokenType = exchangeReq.ActorTokenType
+	}
+	if req.authTime.IsZero() {
+		req.authTime = time.Now().UTC()
+	}
+	if err = t.storage.ValidateTokenExchangeRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	if err = t.storage.CreateTokenExchangeRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	// the act claim can only be transported in a JWT
+	if req.GetExchangeActor() != "" {
+		client = &tokenExchangeClient{Client: client, accessTokenType: op.AccessTokenTypeJWT}
+	}
+	return op.CreateTokenExchangeResponse(ctx, req, client, t.provider)
+}
+
+// authorizeClient authenticates the client either by its client_secret (Basic Auth and POST)
+// or a client_assertion (JWT Profile)
+func (t *tokenExchanger) authorizeClient(ctx context.Context, r *http.Request, clientID, clientSecret string) (op.Client, error) {
+	if assertion := r.Form.Get(formClientAssertion); assertion != "" {
+		if r.Form.Get(formClientAssertionType) != oidc.ClientAssertionTypeJWTAssertion {
+			return nil, oidc.ErrInvalidClient().WithDescription("client_assertion_type is not supported")
+		}
+		client, err := op.AuthorizePrivateJWTKey(ctx, assertion, t.provider)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+		return client, nil
+	}
+	if clientID == "" {
+		clientID, clientSecret = r.Form.Get(formClientID), r.Form.Get(formClientSecret)
+	}
+	if clientID == "" {
+		return nil, oidc.ErrInvalidClient().WithDescription("client authentication missing")
+	}
+	if err := op.AuthorizeClientIDSecret(ctx, clientID, clientSecret, t.storage); err != nil {
+		return nil, err
+	}
+	client, err := t.storage.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	return client, nil
+}
+
+// exchangeToken is the verified subject or actor token of a token exchange request
+type exchangeToken struct {
+	tokenIDOrToken string
+	userID         string
+	audience       []string
+	// scopes are only known for access tokens and will be nil otherwise
+	scopes   []string
+	claims   map[string]interface{}
+	authTime time.Time
+	amr      []string
+	// dpopJKT is the thumbprint of the key a DPoP bound access token is bound to
+	dpopJKT string
+}
+
+func (t *tokenExchanger) verifyToken(ctx context.Context, token string, tokenType oidc.TokenType) (*exchangeToken, error) {
+	switch tokenType {
+	case oidc.AccessTokenType:
+		return t.verifyAccessToken(ctx, token)
+	case oidc.IDTokenType:
+		claims, err := op.VerifyIDTokenHint[*oidc.IDTokenClaims](ctx, token, t.provider.IDTokenHintVerifier(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return &exchangeToken{
+			tokenIDOrToken: token,
+			userID:         claims.Subject,
+			audience:       claims.Audience,
+			claims:         claims.Claims,
+			authTime:       claims.GetAuthTime(),
+			amr:            claims.AuthenticationMethodsReferences,
+		}, nil
+	case oidc.JWTTokenType:
+		// a JWT signed by the user itself with one of its keys (JWT Profile)
+		jwtReq, err := op.VerifyJWTAssertion(ctx, token, t.provider.JWTProfileVerifier(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return &exchangeToken{
+			tokenIDOrToken: token,
+			userID:         jwtReq.Subject,
+			audience:       jwtReq.Audience,
+		}, nil
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("token type %s is not supported", tokenType)
+	}
+}
+
+// verifyAccessToken verifies opaque as well as JWT access tokens and ensures they are still active
+func (t *tokenExchanger) verifyAccessToken(ctx context.Context, token string) (*exchangeToken, error) {
+	var tokenID, subject string
+	var claims map[string]interface{}
+	if tokenIDSubject, err := t.provider.Crypto().Decrypt(token); err == nil {
+		splitToken := strings.Split(tokenIDSubject, ":")
+		if len(splitToken) != 2 {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid access token")
+		}
+		tokenID, subject = splitToken[0], splitToken[1]
+	} else {
+		accessTokenClaims, err := op.VerifyAccessToken[*oidc.AccessTokenClaims](ctx, token, t.provider.AccessTokenVerifier(ctx))
+		if err != nil {
+			return nil, err
+		}
+		tokenID, subject, claims = accessTokenClaims.JWTID, accessTokenClaims.Subject, accessTokenClaims.Claims
+	}
+	tokenView, err := t.storage.repo.TokenByIDs(ctx, subject, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return &exchangeToken{
+		tokenIDOrToken: tokenID,
+		userID:         tokenView.UserID,
+		audience:       tokenView.Audience,
+		scopes:         tokenView.Scopes,
+		claims:         claims,
+		dpopJKT:        tokenView.DPoPJKT,
+	}, nil
+}
+
+// checkDPoPBinding ensures that a DPoP bound token is only exchanged with a proof of the same key,
+// so the sender constraint is kept: the issued token is bound to the key of the proof as well
+func checkDPoPBinding(ctx context.Context, token *exchangeToken) error {
+	if token.dpopJKT == "" {
+		return nil
+	}
+	jkt := dpopJKTFromCtx(ctx)
+	if jkt == "" {
+		return &oidc.Error{ErrorType: invalidDPoPProof, Description: "DPoP proof is required for bound tokens"}
+	}
+	if jkt != token.dpopJKT {
+		return &oidc.Error{ErrorType: invalidDPoPProof, Description: "DPoP proof does not match the key of the bound token"}
+	}
+	return nil
+}
+
+// isTokenForClient checks that the subject or actor token was issued for the requesting client or its project.
+// This applies to all token types, so self signed JWTs must also name the client or project besides the issuer.
+func isTokenForClient(token *exchangeToken, clientID, projectID string) bool {
+	return containsScope(token.audience, clientID) || containsScope(token.audience, projectID)
+}
+
+// ValidateTokenExchangeRequest implements the [op.TokenExchangeStorage] interface.
+// It checks the requested token type, reduces the scopes to the ones of the subject token,
+// and checks if the actor is allowed to impersonate the subject.
+func (o *OPStorage) ValidateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	req, ok := request.(*tokenExchangeRequest)
+	if !ok {
+		return oidc.ErrServerError().WithDescription("unexpected token exchange request")
+	}
+	switch req.requestedTokenType {
+	case "":
+		req.requestedTokenType = oidc.AccessTokenType
+	case oidc.AccessTokenType, oidc.IDTokenType:
+	default:
+		return oidc.ErrInvalidRequest().WithDescription("requested_token_type is not supported")
+	}
+	subject, err := o.query.GetUserByID(ctx, false, req.subject, false)
+	if err != nil || subject.State != domain.UserStateActive {
+		return oidc.ErrInvalidGrant().WithDescription("subject is not active").WithParent(err)
+	}
+	req.subjectOrgID = subject.ResourceOwner
+	scopes, err := downscope(req.subjectToken.scopes, req.scopes)
+	if err != nil {
+		return err
+	}
+	scopes, err = o.checkOrgScopes(ctx, subject, scopes)
+	if err != nil {
+		return err
+	}
+	for _, aud := range req.audience {
+		if err = o.checkRequestedAudience(ctx, req, aud); err != nil {
+			return err
+		}
+		scopes = append(scopes, domain.ProjectIDScope+aud+domain.AudSuffix)
+	}
+	req.scopes = scopes
+	req.audience, err = o.tokenExchangeAudience(ctx, req.projectID, scopes)
+	if err != nil {
+		return err
+	}
+	actor := req.GetExchangeActor()
+	if actor == "" || actor == req.subject {
+		return nil
+	}
+	return o.checkImpersonation(ctx, subject, actor)
+}
+
+// downscope returns the requested scopes if they were all granted to the subject token.
+// If none are requested, the scopes of the subject token are returned.
+// Tokens without scopes (id_token, jwt) have nothing granted, so no scopes can be requested.
+func downscope(granted, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	for _, scope := range requested {
+		if !containsScope(granted, scope) {
+			return nil, oidc.ErrInvalidScope().WithDescription("scope %s was not granted to the subject_token", scope)
+		}
+	}
+	return requested, nil
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRequestedAudience ensures the subject is allowed to use the requested project (audience parameter).
+// This is the case for the project of the client, projects the subject token is already valid for
+// and projects the subject is granted to. The ZITADEL API can only be requested by tokens already valid for it.
+func (o *OPStorage) checkRequestedAudience(ctx context.Context, req *tokenExchangeRequest, projectID string) error {
+	instanceProjectID := authz.GetInstance(ctx).ProjectID()
+	if isAudienceOfToken(projectID, req.projectID, instanceProjectID, req.subjectToken.audience) {
+		return nil
+	}
+	if projectID != domain.ProjectIDScopeZITADEL && projectID != instanceProjectID {
+		granted, err := o.isProjectGranted(ctx, req.subject, projectID)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return nil
+		}
+	}
+	return oidc.ErrInvalidRequest().WithDescription("audience %s is not allowed for the subject", projectID)
+}
+
+// isAudienceOfToken checks if the project is the one of the client or is already part of the token audience
+func isAudienceOfToken(projectID, clientProjectID, instanceProjectID string, tokenAudience []string) bool {
+	if projectID == domain.ProjectIDScopeZITADEL {
+		projectID = instanceProjectID
+	}
+	return projectID == clientProjectID || containsScope(tokenAudience, projectID)
+}
+
+func (o *OPStorage) isProjectGranted(ctx context.Context, userID, projectID string) (bool, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return false, err
+	}
+	projectIDQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return false, err
+	}
+	grants, err := o.query.UserGrants(ctx, &query.UserGrantsQueries{
+		SearchRequest: query.SearchRequest{Limit: 1},
+		Queries:       []query.SearchQuery{userIDQuery, projectIDQuery},
+	}, false, false)
+	if err != nil {
+		return false, err
+	}
+	return len(grants.UserGrants) > 0, nil
+}
+
+// tokenExchangeAudience returns the client ids of the project of the client and the project itself,
+// as well as the projects requested by the audience scopes
+func (o *OPStorage) tokenExchangeAudience(ctx context.Context, projectID string, scopes []string) ([]string, error) {
+	projectIDQuery, err := query.NewAppProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	audience, err := o.query.SearchClientIDs(ctx, &query.AppSearchQueries{Queries: []query.SearchQuery{projectIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	if !containsScope(audience, projectID) {
+		audience = append(audience, projectID)
+	}
+	return domain.AddAudScopeToAudience(ctx, audience, scopes), nil
+}
+
+// checkImpersonation checks if the actor is granted the permission to impersonate the subject.
+// Subjects with a manager role require the admin impersonation permission.
+func (o *OPStorage) checkImpersonation(ctx context.Context, subject *query.User, actorID string) error {
+	actor, err := o.query.GetUserByID(ctx, false, actorID, false)
+	if err != nil || actor.State != domain.UserStateActive {
+		return oidc.ErrInvalidGrant().WithDescription("actor is not active").WithParent(err)
+	}
+	userIDQuery, err := query.NewMembershipUserIDQuery(subject.ID)
+	if err != nil {
+		return err
+	}
+	memberships, err := o.query.Memberships(ctx, &query.MembershipSearchQuery{
+		SearchRequest: query.SearchRequest{Limit: 1},
+		Queries:       []query.SearchQuery{userIDQuery},
+	}, false)
+	if err != nil {
+		return err
+	}
+	permission := domain.PermissionImpersonation
+	if len(memberships.Memberships) > 0 {
+		permission = domain.PermissionAdminImpersonation
+	}
+	ctx = authz.SetCtxData(ctx, authz.CtxData{
+		UserID: actor.ID,
+		OrgID:  actor.ResourceOwner,
+	})
+	if err = o.checkPermission(ctx, permission, subject.ResourceOwner, subject.ID); err != nil {
+		return oidc.ErrInvalidGrant().WithDescription("actor is not allowed to impersonate the subject").WithParent(err)
+	}
+	return nil
+}
+
+// CreateTokenExchangeRequest implements the [op.TokenExchangeStorage] interface.
+// It records the exchange on the subject user for auditing.
+func (o *OPStorage) CreateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	var orgID string
+	if req, ok := request.(*tokenExchangeRequest); ok {
+		orgID = req.subjectOrgID
+	}
+	return o.command.UserTokenExchanged(setContextUserSystem(ctx), orgID, request.GetSubject(), request.GetClientID(),
+		request.GetExchangeActor(),
+		string(request.GetExchangeSubjectTokenType()),
+		string(request.GetExchangeActorTokenType()),
+		string(request.GetRequestedTokenType()),
+		request.GetAudience(),
+		request.GetScopes(),
+	)
+}
+
+// GetPrivateClaimsFromTokenExchangeRequest implements the [op.TokenExchangeStorage] interface.
+// It returns the claims of the scopes and the actor (act claim) if the token is issued on behalf of the subject.
+func (o *OPStorage) GetPrivateClaimsFromTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) (claims map[string]interface{}, err error) {
+	claims, err = o.GetPrivateClaimsFromScopes(ctx, request.GetSubject(), request.GetClientID(), request.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+	if actor := request.GetExchangeActor(); actor != "" {
+		claims = appendClaim(claims, ClaimActor, map[string]interface{}{"sub": actor})
+	}
+	return claims, nil
+}
+
+// SetUserinfoFromTokenExchangeRequest implements the [op.TokenExchangeStorage] interface.
+// It sets the userinfo of the scopes and the actor (act claim) if the token is issued on behalf of the subject.
+func (o *OPStorage) SetUserinfoFromTokenExchangeRequest(ctx context.Context, userInfo *oidc.UserInfo, request op.TokenExchangeRequest) error {
+	err := o.SetUserinfoFromScopes(ctx, userInfo, request.GetSubject(), request.GetClientID(), request.GetScopes())
+	if err != nil {
+		return err
+	}
+	if actor := request.GetExchangeActor(); actor != "" {
+		userInfo.AppendClaims(ClaimActor, map[string]interface{}{"sub": actor})
+	}
+	return nil
+}
+
+type tokenExchangeRequest struct {
+	subjectToken       *exchangeToken
+	subjectTokenType   oidc.TokenType
+	subjectOrgID       string
+	actorToken         *exchangeToken
+	actorTokenType     oidc.TokenType
+	subject            string
+	resource           []string
+	audience           []string
+	scopes             []string
+	requestedTokenType oidc.TokenType
+	clientID           string
+	projectID          string
+	authTime           time.Time
+}
+
+// GetAMR returns the authentication methods of the subject token (only available for id_tokens)
+func (r *tokenExchangeRequest) GetAMR() []string {
+	return r.subjectToken.amr
+}
+
+// GetAudience returns the audience for the token to be created because of the token exchange request
+func (r *tokenExchangeRequest) GetAudience() []string {
+	return r.audience
+}
+
+func (r *tokenExchangeRequest) GetResourses() []string {
+	return r.resource
+}
+
+// GetAuthTime returns the auth_time of the subject token if available, else the time of the exchange
+func (r *tokenExchangeRequest) GetAuthTime() time.Time {
+	return r.authTime
+}
+
+// GetClientID returns the client_id of the client requesting the token exchange
+func (r *tokenExchangeRequest) GetClientID() string {
+	return r.clientID
+}
+
+func (r *tokenExchangeRequest) GetScopes() []string {
+	return r.scopes
+}
+
+// GetSubject returns the subject for the token to be created, which is the subject of the subject token
+func (r *tokenExchangeRequest) GetSubject() string {
+	return r.subject
+}
+
+func (r *tokenExchangeRequest) GetRequestedTokenType() oidc.TokenType {
+	return r.requestedTokenType
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubject() string {
+	return r.subjectToken.userID
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubjectTokenType() oidc.TokenType {
+	return r.subjectTokenType
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubjectTokenIDOrToken() string {
+	return r.subjectToken.tokenIDOrToken
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubjectTokenClaims() map[string]interface{} {
+	return r.subjectToken.claims
+}
+
+// GetExchangeActor returns the user id of the actor or an empty string if no actor token was provided
+func (r *tokenExchangeRequest) GetExchangeActor() string {
+	if r.actorToken == nil {
+		return ""
+	}
+	return r.actorToken.userID
+}
+
+func (r *tokenExchangeRequest) GetExchangeActorTokenType() oidc.TokenType {
+	return r.actorTokenType
+}
+
+func (r *tokenExchangeRequest) GetExchangeActorTokenIDOrToken() string {
+	if r.actorToken == nil {
+		return ""
+	}
+	return r.actorToken.tokenIDOrToken
+}
+
+func (r *tokenExchangeRequest) GetExchangeActorTokenClaims() map[string]interface{} {
+	if r.actorToken == nil {
+		return nil
+	}
+	return r.actorToken.claims
+}
+
+func (r *tokenExchangeRequest) SetCurrentScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+func (r *tokenExchangeRequest) SetRequestedTokenType(tt oidc.TokenType) {
+	r.requestedTokenType = tt
+}
+
+func (r *tokenExchangeRequest) SetSubject(subject string) {
+	r.subject = subject
+}
+
+// tokenExchangeClient overwrites the access token type of the client,
+// e.g. to issue a JWT containing the act claim for clients with opaque access tokens
+type tokenExchangeClient struct {
+	op.Client
+	accessTokenType op.AccessTokenType
+}
+
+func (c *tokenExchangeClient) AccessTokenType() op.AccessTokenType {
+	return c.accessTokenType
+}
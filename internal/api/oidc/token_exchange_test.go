@@ -0,0 +1,301 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+func Test_downscope(t *testing.T) {
+	type args struct {
+		granted   []string
+		requested []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "nothing requested, granted scopes",
+			args: args{
+				granted: []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+			},
+			want: []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+		},
+		{
+			name: "subset requested",
+			args: args{
+				granted:   []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+				requested: []string{oidc.ScopeOpenID},
+			},
+			want: []string{oidc.ScopeOpenID},
+		},
+		{
+			name: "not granted scope requested, error",
+			args: args{
+				granted:   []string{oidc.ScopeOpenID},
+				requested: []string{oidc.ScopeOpenID, "urn:zitadel:iam:org:project:id:zitadel:aud"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing granted (id_token, jwt), error",
+			args: args{
+				granted:   nil,
+				requested: []string{"urn:zitadel:iam:org:project:id:zitadel:aud"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing granted and requested",
+			args: args{
+				granted: nil,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := downscope(tt.args.granted, tt.args.requested)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_isTokenForClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		audience []string
+		want     bool
+	}{
+		{
+			name:     "client",
+			audience: []string{"issuer", "clientID"},
+			want:     true,
+		},
+		{
+			name:     "project",
+			audience: []string{"projectID"},
+			want:     true,
+		},
+		{
+			name:     "issuer only (jwt), not allowed",
+			audience: []string{"issuer"},
+			want:     false,
+		},
+		{
+			name:     "other client, not allowed",
+			audience: []string{"otherClientID", "otherProjectID"},
+			want:     false,
+		},
+		{
+			name:     "no audience, not allowed",
+			audience: nil,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isTokenForClient(&exchangeToken{audience: tt.audience}, "clientID", "projectID")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_isAudienceOfToken(t *testing.T) {
+	type args struct {
+		projectID     string
+		tokenAudience []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "project of client",
+			args: args{
+				projectID: "clientProjectID",
+			},
+			want: true,
+		},
+		{
+			name: "project of token audience",
+			args: args{
+				projectID:     "otherProjectID",
+				tokenAudience: []string{"clientID", "otherProjectID"},
+			},
+			want: true,
+		},
+		{
+			name: "other project",
+			args: args{
+				projectID:     "otherProjectID",
+				tokenAudience: []string{"clientID", "clientProjectID"},
+			},
+			want: false,
+		},
+		{
+			name: "zitadel not in token audience",
+			args: args{
+				projectID:     "zitadel",
+				tokenAudience: []string{"clientID", "clientProjectID"},
+			},
+			want: false,
+		},
+		{
+			name: "zitadel in token audience",
+			args: args{
+				projectID:     "zitadel",
+				tokenAudience: []string{"clientID", "instanceProjectID"},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isAudienceOfToken(tt.args.projectID, "clientProjectID", "instanceProjectID", tt.args.tokenAudience)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOPStorage_checkRequestedAudience(t *testing.T) {
+	tests := []struct {
+		name          string
+		projectID     string
+		tokenAudience []string
+		wantErr       bool
+	}{
+		{
+			name:          "project of client",
+			projectID:     "clientProjectID",
+			tokenAudience: []string{"clientID"},
+		},
+		{
+			name:          "project of token",
+			projectID:     "otherProjectID",
+			tokenAudience: []string{"clientID", "otherProjectID"},
+		},
+		{
+			name:          "zitadel without token audience, error",
+			projectID:     "zitadel",
+			tokenAudience: []string{"clientID"},
+			wantErr:       true,
+		},
+		{
+			name:          "instance project without token audience, error",
+			projectID:     "instanceProjectID",
+			tokenAudience: []string{"clientID"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithConsole(context.Background(), "instanceProjectID", "consoleAppID")
+			req := &tokenExchangeRequest{
+				subject:      "userID",
+				clientID:     "clientID",
+				projectID:    "clientProjectID",
+				subjectToken: &exchangeToken{audience: tt.tokenAudience},
+			}
+			err := new(OPStorage).checkRequestedAudience(ctx, req, tt.projectID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_tokenExchangeRequest_GetExchangeActor(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorToken *exchangeToken
+		want       string
+	}{
+		{
+			name: "no actor",
+			want: "",
+		},
+		{
+			name:       "actor",
+			actorToken: &exchangeToken{userID: "actorID", tokenIDOrToken: "tokenID"},
+			want:       "actorID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &tokenExchangeRequest{
+				subjectToken: &exchangeToken{userID: "subjectID"},
+				actorToken:   tt.actorToken,
+			}
+			assert.Equal(t, tt.want, req.GetExchangeActor())
+			assert.Equal(t, "subjectID", req.GetExchangeSubject())
+		})
+	}
+}
+
+func Test_checkDPoPBinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		jkt     string
+		token   *exchangeToken
+		wantErr bool
+	}{
+		{
+			name:  "unbound token without proof",
+			token: &exchangeToken{},
+		},
+		{
+			name:  "unbound token with proof",
+			jkt:   "jkt",
+			token: &exchangeToken{},
+		},
+		{
+			name:    "bound token without proof",
+			token:   &exchangeToken{dpopJKT: "jkt"},
+			wantErr: true,
+		},
+		{
+			name:    "bound token with proof of other key",
+			jkt:     "other",
+			token:   &exchangeToken{dpopJKT: "jkt"},
+			wantErr: true,
+		},
+		{
+			name:  "bound token with proof of same key",
+			jkt:   "jkt",
+			token: &exchangeToken{dpopJKT: "jkt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.jkt != "" {
+				ctx = context.WithValue(ctx, dpopCtxKey{}, tt.jkt)
+			}
+			err := checkDPoPBinding(ctx, tt.token)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var oidcErr *oidc.Error
+			if assert.ErrorAs(t, err, &oidcErr) {
+				assert.EqualValues(t, invalidDPoPProof, oidcErr.ErrorType)
+			}
+		})
+	}
+}
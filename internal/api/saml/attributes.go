@@ -0,0 +1,187 @@
+package saml
+
+import (
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider/models"
+)
+
+const (
+	attributeNameFormatBasic = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+	nameIDFormatEmail        = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+	// names of the attributes as they are set by the provider
+	attributeEmail     = "Email"
+	attributeSurname   = "SurName"
+	attributeGivenName = "FirstName"
+	attributeFullName  = "FullName"
+	attributeUsername  = "UserName"
+	attributeUserID    = "UserID"
+)
+
+// customAttributeSetter is implemented by providers which support attributes
+// other than the ones of the [models.AttributeSetter]
+type customAttributeSetter interface {
+	SetCustomAttribute(name, friendlyName, nameFormat string, attributeValue []string)
+}
+
+// nameIDSetter is implemented by providers which support setting the NameID,
+// otherwise the username is used as NameID in the emailAddress format
+type nameIDSetter interface {
+	SetNameID(format, value string)
+}
+
+type attribute struct {
+	name       string
+	nameFormat string
+	values     []string
+}
+
+// responseAttributes collects the attributes and the NameID of the SAMLResponse,
+// so they can be customised by the actions before they are passed to the provider
+type responseAttributes struct {
+	nameIDFormat  string
+	nameID        string
+	nameIDChanged bool
+	list          []*attribute
+}
+
+var _ models.AttributeSetter = (*responseAttributes)(nil)
+
+func (a *responseAttributes) SetEmail(value string) {
+	a.set(attributeEmail, attributeNameFormatBasic, value)
+}
+
+func (a *responseAttributes) SetFullName(value string) {
+	a.set(attributeFullName, attributeNameFormatBasic, value)
+}
+
+func (a *responseAttributes) SetGivenName(value string) {
+	a.set(attributeGivenName, attributeNameFormatBasic, value)
+}
+
+func (a *responseAttributes) SetSurname(value string) {
+	a.set(attributeSurname, attributeNameFormatBasic, value)
+}
+
+func (a *responseAttributes) SetUserID(value string) {
+	a.set(attributeUserID, attributeNameFormatBasic, value)
+}
+
+func (a *responseAttributes) SetUsername(value string) {
+	a.set(attributeUsername, attributeNameFormatBasic, value)
+	a.nameIDFormat = nameIDFormatEmail
+	a.nameID = value
+}
+
+// set adds the attribute or replaces the values of an existing one, empty values are not set
+func (a *responseAttributes) set(name, nameFormat string, values ...string) {
+	if len(values) == 0 || len(values) == 1 && values[0] == "" {
+		return
+	}
+	if attr := a.get(name); attr != nil {
+		attr.nameFormat = nameFormat
+		attr.values = values
+		return
+	}
+	a.list = append(a.list, &attribute{name: name, nameFormat: nameFormat, values: values})
+}
+
+func (a *responseAttributes) get(name string) *attribute {
+	for _, attr := range a.list {
+		if attr.name == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+// setNameID overwrites the non empty format and value of the NameID
+func (a *responseAttributes) setNameID(format, value string) {
+	if format != "" {
+		a.nameIDFormat = format
+	}
+	if value != "" {
+		a.nameID = value
+	}
+	a.nameIDChanged = true
+}
+
+func (a *responseAttributes) rename(name, newName string) bool {
+	attr := a.get(name)
+	if attr == nil || a.get(newName) != nil {
+		return false
+	}
+	attr.name = newName
+	return true
+}
+
+func (a *responseAttributes) remove(name string) {
+	for i, attr := range a.list {
+		if attr.name == name {
+			a.list = append(a.list[:i], a.list[i+1:]...)
+			return
+		}
+	}
+}
+
+// values returns the attribute values by name, as they are passed to the actions
+func (a *responseAttributes) values() map[string][]string {
+	values := make(map[string][]string, len(a.list))
+	for _, attr := range a.list {
+		values[attr.name] = attr.values
+	}
+	return values
+}
+
+// apply passes the attributes and the NameID to the provider.
+// The attributes and the NameID format the provider does not support are logged and skipped
+func (a *responseAttributes) apply(userinfo models.AttributeSetter) {
+	custom, customSupported := userinfo.(customAttributeSetter)
+	nameIDs, nameIDSupported := userinfo.(nameIDSetter)
+	for _, attr := range a.list {
+		if setStandard := standardAttributeSetter(userinfo, attr); setStandard != nil {
+			setStandard(attr.values[0])
+			continue
+		}
+		if !customSupported {
+			logging.WithFields("attribute", attr.name).Warn("custom SAML attributes are not supported by the provider")
+			continue
+		}
+		custom.SetCustomAttribute(attr.name, "", attr.nameFormat, attr.values)
+	}
+	if nameIDSupported {
+		nameIDs.SetNameID(a.nameIDFormat, a.nameID)
+		return
+	}
+	// the provider uses the username as NameID
+	if a.nameIDFormat != nameIDFormatEmail {
+		logging.WithFields("format", a.nameIDFormat).Warn("NameID format is not supported by the provider")
+	}
+	if username := a.get(attributeUsername); a.nameIDChanged || username == nil || standardAttributeSetter(userinfo, username) == nil {
+		userinfo.SetUsername(a.nameID)
+	}
+}
+
+// standardAttributeSetter returns the setter of the attribute,
+// if the provider sets the attribute the same way by itself
+func standardAttributeSetter(userinfo models.AttributeSetter, attr *attribute) func(string) {
+	if attr.nameFormat != attributeNameFormatBasic || len(attr.values) != 1 {
+		return nil
+	}
+	switch attr.name {
+	case attributeEmail:
+		return userinfo.SetEmail
+	case attributeSurname:
+		return userinfo.SetSurname
+	case attributeGivenName:
+		return userinfo.SetGivenName
+	case attributeFullName:
+		return userinfo.SetFullName
+	case attributeUsername:
+		return userinfo.SetUsername
+	case attributeUserID:
+		return userinfo.SetUserID
+	default:
+		return nil
+	}
+}
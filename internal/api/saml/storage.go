@@ -2,14 +2,20 @@ package saml
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
 	"github.com/zitadel/saml/pkg/provider"
 	"github.com/zitadel/saml/pkg/provider/key"
 	"github.com/zitadel/saml/pkg/provider/models"
 	"github.com/zitadel/saml/pkg/provider/serviceprovider"
 	"github.com/zitadel/saml/pkg/provider/xml/samlp"
 
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
@@ -132,8 +138,7 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.Att
 		return err
 	}
 
-	setUserinfo(user, userinfo, attributes)
-	return nil
+	return p.setUserinfo(ctx, user, userinfo, attributes)
 }
 
 func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.AttributeSetter, loginName string, attributes []int) (err error) {
@@ -149,7 +154,153 @@ func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.
 		return err
 	}
 
-	setUserinfo(user, userinfo, attributes)
+	return p.setUserinfo(ctx, user, userinfo, attributes)
+}
+
+// setUserinfo sets the attributes of the user, customised by the actions of the [domain.FlowTypeCustomiseSAMLResponse]
+func (p *Storage) setUserinfo(ctx context.Context, user *query.User, userinfo models.AttributeSetter, attributes []int) error {
+	attrs := new(responseAttributes)
+	setUserinfo(user, attrs, attributes)
+	if err := p.samlResponseFlows(ctx, user, attrs); err != nil {
+		return err
+	}
+	attrs.apply(userinfo)
+	return nil
+}
+
+func (p *Storage) samlResponseFlows(ctx context.Context, user *query.User, attrs *responseAttributes) error {
+	queriedActions, err := p.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, user.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+	if len(queriedActions) == 0 {
+		return nil
+	}
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("attributes", func(c *actions.FieldConfig) interface{} {
+				return c.Runtime.ToValue(attrs.values())
+			}),
+			actions.SetFields("nameID", func(c *actions.FieldConfig) interface{} {
+				return c.Runtime.ToValue(map[string]string{
+					"format": attrs.nameIDFormat,
+					"value":  attrs.nameID,
+				})
+			}),
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
+					return func(goja.FunctionCall) goja.Value {
+						resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+						if err != nil {
+							logging.WithError(err).Debug("unable to create search query")
+							panic(err)
+						}
+						metadata, err := p.query.SearchUserMetadata(
+							ctx,
+							true,
+							user.ID,
+							&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
+							false,
+						)
+						if err != nil {
+							logging.WithError(err).Info("unable to get md in action")
+							panic(err)
+						}
+						return object.UserMetadataListFromQuery(c, metadata)
+					}
+				}),
+				actions.SetFields("grants", func(c *actions.FieldConfig) interface{} {
+					userIDQuery, err := query.NewUserGrantUserIDSearchQuery(user.ID)
+					if err != nil {
+						panic(err)
+					}
+					grants, err := p.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, true, false)
+					if err != nil {
+						logging.WithError(err).Info("unable to get grants in action")
+						panic(err)
+					}
+					return object.UserGrantsFromQuery(c, grants)
+				}),
+			),
+		),
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+
+		apiFields := actions.WithAPIFields(
+			actions.SetFields("v1",
+				actions.SetFields("attributes",
+					actions.SetFields("setCustomAttribute", func(name, nameFormat string, values ...string) {
+						if nameFormat == "" {
+							nameFormat = attributeNameFormatBasic
+						}
+						attrs.set(name, nameFormat, values...)
+					}),
+					actions.SetFields("renameAttribute", func(name, newName string) {
+						if !attrs.rename(name, newName) {
+							panic(fmt.Sprintf("unable to rename attribute %q to %q", name, newName))
+						}
+					}),
+					actions.SetFields("removeAttribute", func(name string) {
+						attrs.remove(name)
+					}),
+				),
+				actions.SetFields("nameID",
+					actions.SetFields("setFormat", func(format string) {
+						attrs.setNameID(format, "")
+					}),
+					actions.SetFields("setValue", func(value string) {
+						attrs.setNameID("", value)
+					}),
+				),
+				actions.SetFields("user",
+					actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
+						if len(call.Arguments) != 2 {
+							panic("exactly 2 (key, value) arguments expected")
+						}
+						key := call.Arguments[0].Export().(string)
+						val := call.Arguments[1].Export()
+
+						value, err := json.Marshal(val)
+						if err != nil {
+							logging.WithError(err).Debug("unable to marshal")
+							panic(err)
+						}
+
+						metadata := &domain.Metadata{
+							Key:   key,
+							Value: value,
+						}
+						if _, err = p.command.SetUserMetadata(ctx, metadata, user.ID, user.ResourceOwner); err != nil {
+							logging.WithError(err).Info("unable to set md in action")
+							panic(err)
+						}
+						return nil
+					}),
+				),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,84 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const schemaError = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+// scimType values defined in RFC 7644 section 3.12
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeUniqueness    = "uniqueness"
+	scimTypeMutability    = "mutability"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeInvalidValue  = "invalidValue"
+)
+
+// Error is the SCIM representation of an error response (RFC 7644 section 3.12)
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status"`
+
+	status int
+}
+
+func newError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{schemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+		status:   status,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Status + " " + e.ScimType + ": " + e.Detail
+}
+
+// toError maps any error to the SCIM error representation
+func toError(err error) *Error {
+	scimErr := new(Error)
+	if errors.As(err, &scimErr) {
+		return scimErr
+	}
+	status, scimType := statusFromError(err)
+	detail := http.StatusText(status)
+	if caosErr := new(caos_errs.CaosError); errors.As(err, &caosErr) {
+		detail = caosErr.GetMessage() + " (" + caosErr.GetID() + ")"
+	}
+	return newError(status, scimType, detail)
+}
+
+func statusFromError(err error) (int, string) {
+	switch {
+	case caos_errs.IsNotFound(err):
+		return http.StatusNotFound, ""
+	case caos_errs.IsErrorAlreadyExists(err):
+		return http.StatusConflict, scimTypeUniqueness
+	case caos_errs.IsErrorInvalidArgument(err),
+		caos_errs.IsPreconditionFailed(err):
+		return http.StatusBadRequest, scimTypeInvalidValue
+	case caos_errs.IsUnauthenticated(err):
+		return http.StatusUnauthorized, ""
+	case caos_errs.IsPermissionDenied(err):
+		return http.StatusForbidden, ""
+	case caos_errs.IsUnimplemented(err):
+		return http.StatusNotImplemented, ""
+	case caos_errs.IsResourceExhausted(err):
+		return http.StatusTooManyRequests, ""
+	case caos_errs.IsUnavailable(err):
+		return http.StatusServiceUnavailable, ""
+	default:
+		return http.StatusInternalServerError, ""
+	}
+}
@@ -0,0 +1,62 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_toError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *Error
+	}{
+		{
+			name: "scim error",
+			err:  newError(http.StatusPreconditionFailed, "", "modified"),
+			want: &Error{Schemas: []string{schemaError}, Detail: "modified", Status: "412", status: http.StatusPreconditionFailed},
+		},
+		{
+			name: "not found",
+			err:  caos_errs.ThrowNotFound(nil, "ID", "Errors.User.NotFound"),
+			want: &Error{Schemas: []string{schemaError}, Detail: "Errors.User.NotFound (ID)", Status: "404", status: http.StatusNotFound},
+		},
+		{
+			name: "already exists",
+			err:  caos_errs.ThrowAlreadyExists(nil, "ID", "Errors.User.AlreadyExists"),
+			want: &Error{Schemas: []string{schemaError}, ScimType: scimTypeUniqueness, Detail: "Errors.User.AlreadyExists (ID)", Status: "409", status: http.StatusConflict},
+		},
+		{
+			name: "invalid argument",
+			err:  caos_errs.ThrowInvalidArgument(nil, "ID", "Errors.User.Email.Invalid"),
+			want: &Error{Schemas: []string{schemaError}, ScimType: scimTypeInvalidValue, Detail: "Errors.User.Email.Invalid (ID)", Status: "400", status: http.StatusBadRequest},
+		},
+		{
+			name: "permission denied",
+			err:  caos_errs.ThrowPermissionDenied(nil, "ID", "Errors.PermissionDenied"),
+			want: &Error{Schemas: []string{schemaError}, Detail: "Errors.PermissionDenied (ID)", Status: "403", status: http.StatusForbidden},
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("boom"),
+			want: &Error{Schemas: []string{schemaError}, Detail: "Internal Server Error", Status: "500", status: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toError(tt.err))
+		})
+	}
+}
+
+func TestError_json(t *testing.T) {
+	got, err := json.Marshal(newError(http.StatusBadRequest, scimTypeInvalidFilter, "unknown operator"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"scimType":"invalidFilter","detail":"unknown operator","status":"400"}`, string(got))
+}
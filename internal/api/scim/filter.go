@@ -0,0 +1,467 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// filter is a node of a parsed SCIM filter expression (RFC 7644 section 3.4.2.2)
+type filter interface {
+	isFilter()
+}
+
+// attributeFilter compares an attribute with a value, e.g. `userName eq "gigi"`
+type attributeFilter struct {
+	// path is the lower cased attribute path without schema, e.g. `name.givenname`
+	path     string
+	operator string
+	value    interface{}
+}
+
+// logicalFilter combines two filters with `and` / `or`
+type logicalFilter struct {
+	operator string
+	left     filter
+	right    filter
+}
+
+// notFilter negates the contained filter
+type notFilter struct {
+	filter filter
+}
+
+func (*attributeFilter) isFilter() {}
+func (*logicalFilter) isFilter()   {}
+func (*notFilter) isFilter()       {}
+
+const (
+	operatorEqual      = "eq"
+	operatorNotEqual   = "ne"
+	operatorContains   = "co"
+	operatorStartsWith = "sw"
+	operatorEndsWith   = "ew"
+	operatorPresent    = "pr"
+	operatorGreater    = "gt"
+	operatorGreaterEq  = "ge"
+	operatorLess       = "lt"
+	operatorLessEq     = "le"
+
+	operatorAnd = "and"
+	operatorOr  = "or"
+	operatorNot = "not"
+)
+
+func isCompareOperator(op string) bool {
+	switch op {
+	case operatorEqual, operatorNotEqual,
+		operatorContains, operatorStartsWith, operatorEndsWith,
+		operatorGreater, operatorGreaterEq, operatorLess, operatorLessEq:
+		return true
+	}
+	return false
+}
+
+func invalidFilter(detail string) error {
+	return newError(http.StatusBadRequest, scimTypeInvalidFilter, detail)
+}
+
+// parseFilter parses a SCIM filter expression
+func parseFilter(expression string) (filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, invalidFilter("unexpected token " + p.peek().value)
+	}
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func tokenizeFilter(expression string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, value: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, value: ")"})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, value: "["})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, value: "]"})
+			i++
+		case r == '"':
+			end := i + 1
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(runes) {
+				return nil, invalidFilter("unterminated string")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(string(runes[i:end+1])), &value); err != nil {
+				return nil, invalidFilter("invalid string " + string(runes[i:end+1]))
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()[]"`, runes[end]); end++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens   []token
+	position int
+}
+
+func (p *filterParser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() (token, error) {
+	if p.done() {
+		return token{}, invalidFilter("unexpected end of filter")
+	}
+	t := p.tokens[p.position]
+	p.position++
+	return t, nil
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return !p.done() && p.peek().kind == tokenWord && strings.EqualFold(p.peek().value, keyword)
+}
+
+func (p *filterParser) expect(kind tokenKind, value string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return invalidFilter("expected " + value + " but got " + t.value)
+	}
+	return nil
+}
+
+// parseOr parses filters combined by `or`, the prefix is set inside a value path, e.g. `emails[...]`
+func (p *filterParser) parseOr(prefix string) (filter, error) {
+	left, err := p.parseAnd(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword(operatorOr) {
+		p.position++
+		right, err := p.parseAnd(prefix)
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{operator: operatorOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(prefix string) (filter, error) {
+	left, err := p.parseUnary(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword(operatorAnd) {
+		p.position++
+		right, err := p.parseUnary(prefix)
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{operator: operatorAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary(prefix string) (filter, error) {
+	if p.peekKeyword(operatorNot) {
+		p.position++
+		if err := p.expect(tokenOpenParen, "("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseParen, ")"); err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case tokenOpenParen:
+		f, err := p.parseOr(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseParen, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case tokenWord:
+		return p.parseAttribute(prefix, t.value)
+	default:
+		return nil, invalidFilter("unexpected token " + t.value)
+	}
+}
+
+func (p *filterParser) parseAttribute(prefix, attribute string) (filter, error) {
+	path := attributePath(prefix, attribute)
+	if !p.done() && p.peek().kind == tokenOpenBracket {
+		if prefix != "" {
+			return nil, invalidFilter("nested value paths are not allowed")
+		}
+		p.position++
+		f, err := p.parseOr(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	operator := strings.ToLower(t.value)
+	if t.kind != tokenWord {
+		return nil, invalidFilter("expected operator but got " + t.value)
+	}
+	if operator == operatorPresent {
+		return &attributeFilter{path: path, operator: operator}, nil
+	}
+	if !isCompareOperator(operator) {
+		return nil, invalidFilter("unknown operator " + t.value)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &attributeFilter{path: path, operator: operator, value: value}, nil
+}
+
+func (p *filterParser) parseValue() (interface{}, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if t.kind == tokenString {
+		return t.value, nil
+	}
+	if t.kind != tokenWord {
+		return nil, invalidFilter("expected value but got " + t.value)
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(strings.ToLower(t.value)), &value); err != nil {
+		return nil, invalidFilter("invalid value " + t.value)
+	}
+	return value, nil
+}
+
+// attributePath returns the lower cased path of the attribute without the user schema urn
+func attributePath(prefix, attribute string) string {
+	path := strings.ToLower(attribute)
+	path = strings.TrimPrefix(path, strings.ToLower(schemaUser)+":")
+	if prefix != "" {
+		path = prefix + "." + path
+	}
+	return path
+}
+
+type textQueryFunc func(value string, comparison query.TextComparison) (query.SearchQuery, error)
+
+func userIDQuery(value string, comparison query.TextComparison) (query.SearchQuery, error) {
+	return query.NewTextQuery(query.UserIDCol, value, comparison)
+}
+
+// textAttributes maps the filterable string attributes of the user resource to their query,
+// the case exact flag defines if the comparison is case sensitive
+var textAttributes = map[string]struct {
+	query     textQueryFunc
+	caseExact bool
+}{
+	"id":                 {query: userIDQuery, caseExact: true},
+	"username":           {query: query.NewUserUsernameSearchQuery},
+	"name.givenname":     {query: query.NewUserFirstNameSearchQuery},
+	"name.familyname":    {query: query.NewUserLastNameSearchQuery},
+	"displayname":        {query: query.NewUserDisplayNameSearchQuery},
+	"nickname":           {query: query.NewUserNickNameSearchQuery},
+	"emails":             {query: query.NewUserEmailSearchQuery},
+	"emails.value":       {query: query.NewUserEmailSearchQuery},
+	"phonenumbers":       {query: query.NewUserPhoneSearchQuery},
+	"phonenumbers.value": {query: query.NewUserPhoneSearchQuery},
+}
+
+// filterToQuery converts the parsed filter to a query on the users
+func filterToQuery(f filter) (query.SearchQuery, error) {
+	switch f := f.(type) {
+	case *logicalFilter:
+		left, err := filterToQuery(f.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := filterToQuery(f.right)
+		if err != nil {
+			return nil, err
+		}
+		if f.operator == operatorOr {
+			return query.NewOrQuery(left, right)
+		}
+		return query.NewAndQuery(left, right)
+	case *notFilter:
+		q, err := filterToQuery(f.filter)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewNotQuery(q)
+	case *attributeFilter:
+		if f.path == "active" {
+			return activeQuery(f)
+		}
+		return textQuery(f)
+	}
+	return nil, invalidFilter("unsupported filter")
+}
+
+func textQuery(f *attributeFilter) (query.SearchQuery, error) {
+	attribute, ok := textAttributes[f.path]
+	if !ok {
+		return nil, invalidFilter("filtering on " + f.path + " is not supported")
+	}
+	if f.operator == operatorPresent {
+		q, err := attribute.query("", query.TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewNotQuery(q)
+	}
+	value, ok := f.value.(string)
+	if !ok {
+		return nil, invalidFilter(f.path + " must be compared with a string")
+	}
+	comparison, ok := textComparison(f.operator, attribute.caseExact)
+	if !ok {
+		return nil, invalidFilter("operator " + f.operator + " is not supported on " + f.path)
+	}
+	q, err := attribute.query(value, comparison)
+	if err != nil {
+		return nil, err
+	}
+	if f.operator == operatorNotEqual {
+		return query.NewNotQuery(q)
+	}
+	return q, nil
+}
+
+func textComparison(operator string, caseExact bool) (query.TextComparison, bool) {
+	switch operator {
+	case operatorEqual, operatorNotEqual:
+		if caseExact {
+			return query.TextEquals, true
+		}
+		return query.TextEqualsIgnoreCase, true
+	case operatorContains:
+		if caseExact {
+			return query.TextContains, true
+		}
+		return query.TextContainsIgnoreCase, true
+	case operatorStartsWith:
+		if caseExact {
+			return query.TextStartsWith, true
+		}
+		return query.TextStartsWithIgnoreCase, true
+	case operatorEndsWith:
+		if caseExact {
+			return query.TextEndsWith, true
+		}
+		return query.TextEndsWithIgnoreCase, true
+	}
+	return 0, false
+}
+
+func activeQuery(f *attributeFilter) (query.SearchQuery, error) {
+	if f.operator == operatorPresent {
+		// every user has a state
+		return query.NewNotNullQuery(query.UserStateCol)
+	}
+	activeState, err := query.NewUserStateSearchQuery(int32(domain.UserStateActive))
+	if err != nil {
+		return nil, err
+	}
+	initialState, err := query.NewUserStateSearchQuery(int32(domain.UserStateInitial))
+	if err != nil {
+		return nil, err
+	}
+	active, err := query.NewOrQuery(activeState, initialState)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := f.value.(bool)
+	if !ok {
+		return nil, invalidFilter("active must be compared with a boolean")
+	}
+	switch f.operator {
+	case operatorEqual:
+	case operatorNotEqual:
+		value = !value
+	default:
+		return nil, invalidFilter("operator " + f.operator + " is not supported on active")
+	}
+	if value {
+		return active, nil
+	}
+	return query.NewNotQuery(active)
+}
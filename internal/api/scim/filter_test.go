@@ -0,0 +1,214 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       filter
+		wantErr    bool
+	}{
+		{
+			name:       "equals",
+			expression: `userName eq "gigi"`,
+			want:       &attributeFilter{path: "username", operator: operatorEqual, value: "gigi"},
+		},
+		{
+			name:       "schema prefix and case insensitive operator",
+			expression: `urn:ietf:params:scim:schemas:core:2.0:User:name.givenName SW "Gi"`,
+			want:       &attributeFilter{path: "name.givenname", operator: operatorStartsWith, value: "Gi"},
+		},
+		{
+			name:       "escaped string",
+			expression: `displayName co "\"quoted\""`,
+			want:       &attributeFilter{path: "displayname", operator: operatorContains, value: `"quoted"`},
+		},
+		{
+			name:       "boolean",
+			expression: `active eq True`,
+			want:       &attributeFilter{path: "active", operator: operatorEqual, value: true},
+		},
+		{
+			name:       "present",
+			expression: `phoneNumbers pr`,
+			want:       &attributeFilter{path: "phonenumbers", operator: operatorPresent},
+		},
+		{
+			name:       "and binds stronger than or",
+			expression: `userName eq "a" or userName eq "b" and active eq false`,
+			want: &logicalFilter{
+				operator: operatorOr,
+				left:     &attributeFilter{path: "username", operator: operatorEqual, value: "a"},
+				right: &logicalFilter{
+					operator: operatorAnd,
+					left:     &attributeFilter{path: "username", operator: operatorEqual, value: "b"},
+					right:    &attributeFilter{path: "active", operator: operatorEqual, value: false},
+				},
+			},
+		},
+		{
+			name:       "parentheses and not",
+			expression: `not (userName eq "a" or userName eq "b") and (active eq true)`,
+			want: &logicalFilter{
+				operator: operatorAnd,
+				left: &notFilter{
+					filter: &logicalFilter{
+						operator: operatorOr,
+						left:     &attributeFilter{path: "username", operator: operatorEqual, value: "a"},
+						right:    &attributeFilter{path: "username", operator: operatorEqual, value: "b"},
+					},
+				},
+				right: &attributeFilter{path: "active", operator: operatorEqual, value: true},
+			},
+		},
+		{
+			name:       "value path",
+			expression: `emails[value ew "@zitadel.com"]`,
+			want:       &attributeFilter{path: "emails.value", operator: operatorEndsWith, value: "@zitadel.com"},
+		},
+		{
+			name:       "unknown operator",
+			expression: `userName like "gigi"`,
+			wantErr:    true,
+		},
+		{
+			name:       "missing value",
+			expression: `userName eq`,
+			wantErr:    true,
+		},
+		{
+			name:       "unterminated string",
+			expression: `userName eq "gigi`,
+			wantErr:    true,
+		},
+		{
+			name:       "unbalanced parentheses",
+			expression: `(userName eq "gigi"`,
+			wantErr:    true,
+		},
+		{
+			name:       "trailing token",
+			expression: `userName eq "gigi" active`,
+			wantErr:    true,
+		},
+		{
+			name:       "nested value path",
+			expression: `emails[value[type eq "work"]]`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.expression)
+			if tt.wantErr {
+				assertSCIMError(t, err, http.StatusBadRequest, scimTypeInvalidFilter)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_filterToQuery(t *testing.T) {
+	mustQuery := func(q query.SearchQuery, err error) query.SearchQuery {
+		require.NoError(t, err)
+		return q
+	}
+	activeQuery := mustQuery(query.NewOrQuery(
+		mustQuery(query.NewUserStateSearchQuery(int32(domain.UserStateActive))),
+		mustQuery(query.NewUserStateSearchQuery(int32(domain.UserStateInitial))),
+	))
+	tests := []struct {
+		name       string
+		expression string
+		want       query.SearchQuery
+		wantErr    bool
+	}{
+		{
+			name:       "username equals ignores case",
+			expression: `userName eq "Gigi"`,
+			want:       mustQuery(query.NewUserUsernameSearchQuery("Gigi", query.TextEqualsIgnoreCase)),
+		},
+		{
+			name:       "id is case exact",
+			expression: `id sw "123"`,
+			want:       mustQuery(query.NewTextQuery(query.UserIDCol, "123", query.TextStartsWith)),
+		},
+		{
+			name:       "not equals",
+			expression: `emails.value ne "gigi@zitadel.com"`,
+			want:       mustQuery(query.NewNotQuery(mustQuery(query.NewUserEmailSearchQuery("gigi@zitadel.com", query.TextEqualsIgnoreCase)))),
+		},
+		{
+			name:       "present",
+			expression: `phoneNumbers pr`,
+			want:       mustQuery(query.NewNotQuery(mustQuery(query.NewUserPhoneSearchQuery("", query.TextEquals)))),
+		},
+		{
+			name:       "active",
+			expression: `active eq true`,
+			want:       activeQuery,
+		},
+		{
+			name:       "inactive",
+			expression: `active ne true`,
+			want:       mustQuery(query.NewNotQuery(activeQuery)),
+		},
+		{
+			name:       "logical",
+			expression: `name.givenName co "gi" and not (name.familyName ew "x")`,
+			want: mustQuery(query.NewAndQuery(
+				mustQuery(query.NewUserFirstNameSearchQuery("gi", query.TextContainsIgnoreCase)),
+				mustQuery(query.NewNotQuery(mustQuery(query.NewUserLastNameSearchQuery("x", query.TextEndsWithIgnoreCase)))),
+			)),
+		},
+		{
+			name:       "unsupported attribute",
+			expression: `title eq "boss"`,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported operator",
+			expression: `userName gt "a"`,
+			wantErr:    true,
+		},
+		{
+			name:       "wrong value type",
+			expression: `active eq "yes"`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseFilter(tt.expression)
+			require.NoError(t, err)
+			got, err := filterToQuery(f)
+			if tt.wantErr {
+				assertSCIMError(t, err, http.StatusBadRequest, scimTypeInvalidFilter)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func assertSCIMError(t *testing.T, err error, status int, scimType string) {
+	t.Helper()
+	scimErr := new(Error)
+	require.True(t, errors.As(err, &scimErr), "expected scim error, got %v", err)
+	assert.Equal(t, status, scimErr.status)
+	assert.Equal(t, scimType, scimErr.ScimType)
+}
@@ -0,0 +1,188 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+// PatchRequest is the body of a PATCH request (RFC 7644 section 3.5.2)
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// valueFilterRegexp matches the value filter of a multi-valued attribute path, e.g. `[type eq "work"]`
+var valueFilterRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+// patchPath returns the normalized path of an operation,
+// as the user resource holds only one email and phone number the value filter is ignored
+func patchPath(path string) string {
+	return attributePath("", valueFilterRegexp.ReplaceAllString(path, ""))
+}
+
+// applyPatch applies the operations in order onto the user resource
+func applyPatch(user *User, operations []*PatchOperation) error {
+	if len(operations) == 0 {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "no operations provided")
+	}
+	for _, operation := range operations {
+		if err := applyPatchOperation(user, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyPatchOperation(user *User, operation *PatchOperation) error {
+	switch strings.ToLower(operation.Op) {
+	case patchOpRemove:
+		if operation.Path == "" {
+			return newError(http.StatusBadRequest, scimTypeNoTarget, "path is required for remove operations")
+		}
+		return removeAttribute(user, patchPath(operation.Path))
+	case patchOpAdd, patchOpReplace:
+		if operation.Path != "" {
+			return setAttribute(user, patchPath(operation.Path), operation.Value)
+		}
+		attributes := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "value must be an object if no path is provided")
+		}
+		for path, value := range attributes {
+			if err := setAttribute(user, patchPath(path), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "unknown operation "+operation.Op)
+	}
+}
+
+func setAttribute(user *User, path string, value json.RawMessage) (err error) {
+	switch path {
+	case "username":
+		user.UserName, err = stringValue(path, value)
+	case "name":
+		user.Name = ensureName(user.Name)
+		if json.Unmarshal(value, user.Name) != nil {
+			return invalidValue(path)
+		}
+	case "name.givenname":
+		user.Name = ensureName(user.Name)
+		user.Name.GivenName, err = stringValue(path, value)
+	case "name.familyname":
+		user.Name = ensureName(user.Name)
+		user.Name.FamilyName, err = stringValue(path, value)
+	case "name.formatted":
+		// the formatted name is derived from the given and family name
+	case "displayname":
+		user.DisplayName, err = stringValue(path, value)
+	case "nickname":
+		user.NickName, err = stringValue(path, value)
+	case "preferredlanguage":
+		user.PreferredLanguage, err = stringValue(path, value)
+	case "password":
+		user.Password, err = stringValue(path, value)
+	case "active":
+		var active bool
+		active, err = boolValue(path, value)
+		user.Active = &active
+	case "emails":
+		user.Emails, err = attributesValue(path, value)
+	case "emails.value":
+		var email string
+		email, err = stringValue(path, value)
+		user.Emails = []*Attribute{{Value: email, Primary: true}}
+	case "phonenumbers":
+		user.PhoneNumbers, err = attributesValue(path, value)
+	case "phonenumbers.value":
+		var phone string
+		phone, err = stringValue(path, value)
+		user.PhoneNumbers = []*Attribute{{Value: phone, Primary: true}}
+	case "externalid":
+		// the external id is not stored
+	default:
+		return newError(http.StatusBadRequest, scimTypeInvalidPath, "unsupported path "+path)
+	}
+	return err
+}
+
+func removeAttribute(user *User, path string) error {
+	switch path {
+	case "displayname":
+		user.DisplayName = ""
+	case "nickname":
+		user.NickName = ""
+	case "preferredlanguage":
+		user.PreferredLanguage = ""
+	case "phonenumbers", "phonenumbers.value":
+		user.PhoneNumbers = nil
+	case "name.formatted", "externalid":
+		// not stored
+	case "username", "name", "name.givenname", "name.familyname", "emails", "emails.value", "active", "password":
+		return newError(http.StatusBadRequest, scimTypeMutability, path+" is required and cannot be removed")
+	default:
+		return newError(http.StatusBadRequest, scimTypeInvalidPath, "unsupported path "+path)
+	}
+	return nil
+}
+
+func ensureName(name *Name) *Name {
+	if name == nil {
+		return new(Name)
+	}
+	return name
+}
+
+func invalidValue(path string) error {
+	return newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid value for "+path)
+}
+
+func stringValue(path string, value json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return "", invalidValue(path)
+	}
+	return s, nil
+}
+
+// boolValue also accepts booleans sent as string, as some clients do
+func boolValue(path string, value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	s, err := stringValue(path, value)
+	if err != nil {
+		return false, err
+	}
+	b, err = strconv.ParseBool(s)
+	if err != nil {
+		return false, invalidValue(path)
+	}
+	return b, nil
+}
+
+func attributesValue(path string, value json.RawMessage) ([]*Attribute, error) {
+	attributes := make([]*Attribute, 0)
+	if err := json.Unmarshal(value, &attributes); err != nil {
+		return nil, invalidValue(path)
+	}
+	return attributes, nil
+}
@@ -0,0 +1,140 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUser() *User {
+	active := true
+	return &User{
+		Schemas:      []string{schemaUser},
+		ID:           "user1",
+		UserName:     "gigi",
+		Name:         &Name{GivenName: "Gigi", FamilyName: "Giraffe"},
+		DisplayName:  "Gigi Giraffe",
+		NickName:     "gg",
+		Active:       &active,
+		Emails:       []*Attribute{{Value: "gigi@zitadel.com", Primary: true}},
+		PhoneNumbers: []*Attribute{{Value: "+41791234567", Primary: true}},
+	}
+}
+
+func Test_applyPatch(t *testing.T) {
+	inactive := false
+	tests := []struct {
+		name       string
+		operations string
+		want       func(*User)
+		wantStatus int
+		wantType   string
+	}{
+		{
+			name:       "replace with path",
+			operations: `[{"op":"replace","path":"name.givenName","value":"Gaga"}]`,
+			want: func(u *User) {
+				u.Name.GivenName = "Gaga"
+			},
+		},
+		{
+			name:       "replace without path and capitalized op",
+			operations: `[{"op":"Replace","value":{"active":"False","displayName":"GG","name":{"familyName":"Zebra"}}}]`,
+			want: func(u *User) {
+				u.Active = &inactive
+				u.DisplayName = "GG"
+				u.Name.FamilyName = "Zebra"
+			},
+		},
+		{
+			name:       "add email with value filter",
+			operations: `[{"op":"add","path":"emails[type eq \"work\"].value","value":"gaga@zitadel.com"}]`,
+			want: func(u *User) {
+				u.Emails = []*Attribute{{Value: "gaga@zitadel.com", Primary: true}}
+			},
+		},
+		{
+			name:       "operations in order",
+			operations: `[{"op":"remove","path":"nickName"},{"op":"add","path":"nickName","value":"gaga"},{"op":"remove","path":"phoneNumbers"}]`,
+			want: func(u *User) {
+				u.NickName = "gaga"
+				u.PhoneNumbers = nil
+			},
+		},
+		{
+			name:       "no operations",
+			operations: `[]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   scimTypeInvalidValue,
+		},
+		{
+			name:       "unknown op",
+			operations: `[{"op":"move","path":"nickName"}]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   scimTypeInvalidSyntax,
+		},
+		{
+			name:       "remove without path",
+			operations: `[{"op":"remove"}]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   scimTypeNoTarget,
+		},
+		{
+			name:       "remove required attribute",
+			operations: `[{"op":"remove","path":"userName"}]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   scimTypeMutability,
+		},
+		{
+			name:       "unknown path",
+			operations: `[{"op":"replace","path":"title","value":"boss"}]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   scimTypeInvalidPath,
+		},
+		{
+			name:       "invalid value",
+			operations: `[{"op":"replace","path":"userName","value":1}]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   scimTypeInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var operations []*PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.operations), &operations))
+			got := testUser()
+			err := applyPatch(got, operations)
+			if tt.wantStatus != 0 {
+				assertSCIMError(t, err, tt.wantStatus, tt.wantType)
+				return
+			}
+			require.NoError(t, err)
+			want := testUser()
+			tt.want(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_matchesETag(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"empty", "", false},
+		{"weak", `W/"42"`, true},
+		{"strong", `"42"`, true},
+		{"wildcard", "*", true},
+		{"list", `W/"41", W/"42"`, true},
+		{"mismatch", `W/"41"`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesETag(tt.header, 42))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	resourceTypeUser = "User"
+)
+
+// User is the SCIM representation of a human user (RFC 7643 section 4.1)
+type User struct {
+	Schemas           []string     `json:"schemas"`
+	ID                string       `json:"id,omitempty"`
+	UserName          string       `json:"userName"`
+	Name              *Name        `json:"name,omitempty"`
+	DisplayName       string       `json:"displayName,omitempty"`
+	NickName          string       `json:"nickName,omitempty"`
+	PreferredLanguage string       `json:"preferredLanguage,omitempty"`
+	Active            *bool        `json:"active,omitempty"`
+	Password          string       `json:"password,omitempty"`
+	Emails            []*Attribute `json:"emails,omitempty"`
+	PhoneNumbers      []*Attribute `json:"phoneNumbers,omitempty"`
+	Meta              *Meta        `json:"meta,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Attribute is a multi-valued attribute like an email or a phone number.
+// Verified is not part of the core schema, it's used by clients to state that the value was verified by them.
+type Attribute struct {
+	Value    string `json:"value"`
+	Type     string `json:"type,omitempty"`
+	Primary  bool   `json:"primary,omitempty"`
+	Verified bool   `json:"verified,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+	Version      string    `json:"version,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults uint64        `json:"totalResults"`
+	StartIndex   uint64        `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// etag returns the weak entity tag of a resource based on its aggregate sequence
+func etag(sequence uint64) string {
+	return `W/"` + strconv.FormatUint(sequence, 10) + `"`
+}
+
+func userToResource(user *query.User, location string) *User {
+	active := user.State == domain.UserStateActive || user.State == domain.UserStateInitial
+	resource := &User{
+		Schemas:  []string{schemaUser},
+		ID:       user.ID,
+		UserName: user.Username,
+		Active:   &active,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      user.CreationDate,
+			LastModified: user.ChangeDate,
+			Location:     location,
+			Version:      etag(user.Sequence),
+		},
+	}
+	if user.Human == nil {
+		return resource
+	}
+	resource.Name = &Name{
+		Formatted:  strings.TrimSpace(user.Human.FirstName + " " + user.Human.LastName),
+		GivenName:  user.Human.FirstName,
+		FamilyName: user.Human.LastName,
+	}
+	resource.DisplayName = user.Human.DisplayName
+	resource.NickName = user.Human.NickName
+	if !user.Human.PreferredLanguage.IsRoot() {
+		resource.PreferredLanguage = user.Human.PreferredLanguage.String()
+	}
+	if user.Human.Email != "" {
+		resource.Emails = []*Attribute{{Value: string(user.Human.Email), Primary: true, Verified: user.Human.IsEmailVerified}}
+	}
+	if user.Human.Phone != "" {
+		resource.PhoneNumbers = []*Attribute{{Value: string(user.Human.Phone), Primary: true, Verified: user.Human.IsPhoneVerified}}
+	}
+	return resource
+}
+
+// primaryAttribute returns the primary attribute or the first one if none is marked as primary
+func primaryAttribute(attributes []*Attribute) *Attribute {
+	for _, attribute := range attributes {
+		if attribute.Primary {
+			return attribute
+		}
+	}
+	if len(attributes) > 0 {
+		return attributes[0]
+	}
+	return new(Attribute)
+}
+
+// primaryValue returns the value of the primary attribute or the first one if none is marked as primary
+func primaryValue(attributes []*Attribute) string {
+	return primaryAttribute(attributes).Value
+}
+
+func (u *User) givenName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return u.Name.GivenName
+}
+
+func (u *User) familyName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return u.Name.FamilyName
+}
+
+func (u *User) isActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+func (u *User) preferredLanguage() language.Tag {
+	if u.PreferredLanguage == "" {
+		return language.Und
+	}
+	tag, err := language.Parse(u.PreferredLanguage)
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// validate checks the attributes required by ZITADEL to create or replace a user
+func (u *User) validate() error {
+	if strings.TrimSpace(u.UserName) == "" {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "userName is required")
+	}
+	if strings.TrimSpace(u.givenName()) == "" || strings.TrimSpace(u.familyName()) == "" {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "name.givenName and name.familyName are required")
+	}
+	if primaryValue(u.Emails) == "" {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "an email is required")
+	}
+	return nil
+}
+
+// toAddHuman maps the resource to the command to create a human user.
+// The email and phone are only set verified if the client marks them as verified,
+// otherwise ZITADEL sends a verification code.
+func (u *User) toAddHuman() *command.AddHuman {
+	email, phone := primaryAttribute(u.Emails), primaryAttribute(u.PhoneNumbers)
+	return &command.AddHuman{
+		Username:          u.UserName,
+		FirstName:         u.givenName(),
+		LastName:          u.familyName(),
+		NickName:          u.NickName,
+		DisplayName:       u.DisplayName,
+		PreferredLanguage: u.preferredLanguage(),
+		Email: command.Email{
+			Address:  domain.EmailAddress(email.Value),
+			Verified: email.Verified,
+		},
+		Phone: command.Phone{
+			Number:   domain.PhoneNumber(phone.Value),
+			Verified: phone.Verified,
+		},
+		Password:    u.Password,
+		Deactivated: !u.isActive(),
+	}
+}
@@ -0,0 +1,171 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	HandlerPrefix = "/scim/v2"
+
+	contentTypeSCIM = "application/scim+json"
+
+	varOrgID  = "orgID"
+	varUserID = "userID"
+
+	orgPath                   = "/{" + varOrgID + "}"
+	usersPath                 = orgPath + "/Users"
+	userPath                  = usersPath + "/{" + varUserID + "}"
+	serviceProviderConfigPath = orgPath + "/ServiceProviderConfig"
+	resourceTypesPath         = orgPath + "/ResourceTypes"
+
+	permissionUserRead   = "user.read"
+	permissionUserWrite  = "user.write"
+	permissionUserDelete = "user.delete"
+)
+
+type Handler struct {
+	commands    *command.Commands
+	queries     *query.Queries
+	verifier    *authz.TokenVerifier
+	authConfig  authz.Config
+	userCodeAlg crypto.EncryptionAlgorithm
+	rootURL     func(ctx context.Context, orgID string) string
+	maxResults  uint64
+}
+
+// RootURL generates the instance and organisation specific base URL of the SCIM endpoints
+func RootURL(externalSecure bool) func(ctx context.Context, orgID string) string {
+	return func(ctx context.Context, orgID string) string {
+		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix + "/" + orgID
+	}
+}
+
+// NewHandler creates the SCIM 2.0 (RFC 7643 / 7644) endpoints for the provisioning of users into an organisation.
+// Every request is authorized by the bearer token against the organisation of the path.
+func NewHandler(
+	commands *command.Commands,
+	queries *query.Queries,
+	verifier *authz.TokenVerifier,
+	authConfig authz.Config,
+	userCodeAlg crypto.EncryptionAlgorithm,
+	externalSecure bool,
+	instanceInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		commands:    commands,
+		queries:     queries,
+		verifier:    verifier,
+		authConfig:  authConfig,
+		userCodeAlg: userCodeAlg,
+		rootURL:     RootURL(externalSecure),
+		maxResults:  100,
+	}
+
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor)
+	router.HandleFunc(serviceProviderConfigPath, h.authorized(permissionUserRead, h.handleServiceProviderConfig)).Methods(http.MethodGet)
+	router.HandleFunc(resourceTypesPath, h.authorized(permissionUserRead, h.handleResourceTypes)).Methods(http.MethodGet)
+	router.HandleFunc(usersPath, h.authorized(permissionUserRead, h.handleListUsers)).Methods(http.MethodGet)
+	router.HandleFunc(usersPath, h.authorized(permissionUserWrite, h.handleCreateUser)).Methods(http.MethodPost)
+	router.HandleFunc(userPath, h.authorized(permissionUserRead, h.handleGetUser)).Methods(http.MethodGet)
+	router.HandleFunc(userPath, h.authorized(permissionUserWrite, h.handleReplaceUser)).Methods(http.MethodPut)
+	router.HandleFunc(userPath, h.authorized(permissionUserWrite, h.handlePatchUser)).Methods(http.MethodPatch)
+	router.HandleFunc(userPath, h.authorized(permissionUserDelete, h.handleDeleteUser)).Methods(http.MethodDelete)
+	return router
+}
+
+// authorized checks the bearer token of the request for the required permission on the organisation of the path
+func (h *Handler) authorized(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := h.checkAuthorization(r, permission)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func (h *Handler) checkAuthorization(r *http.Request, permission string) (context.Context, error) {
+	token := http_utils.GetAuthorization(r)
+	if token == "" {
+		return nil, newError(http.StatusUnauthorized, "", "auth header missing")
+	}
+	ctx := authz.WithDPoPProof(r.Context(), http_utils.GetDPoPProof(r), r.Method, http_utils.RequestPath(r))
+	ctxSetter, err := authz.CheckUserAuthorization(ctx, r, token, mux.Vars(r)[varOrgID], "", h.verifier, h.authConfig, authz.Option{Permission: permission}, r.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	return ctxSetter(r.Context()), nil
+}
+
+func (h *Handler) handleServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{schemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": h.maxResults},
+		"changePassword": map[string]bool{"supported": true},
+		"sort":           map[string]bool{"supported": true},
+		"etag":           map[string]bool{"supported": true},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication scheme using the OAuth Bearer Token Standard",
+			"specUri":     "https://www.rfc-editor.org/info/rfc6750",
+			"primary":     true,
+		}},
+	})
+}
+
+func (h *Handler) handleResourceTypes(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: 1,
+		StartIndex:   1,
+		ItemsPerPage: 1,
+		Resources: []interface{}{
+			map[string]interface{}{
+				"schemas":  []string{schemaResourceType},
+				"id":       resourceTypeUser,
+				"name":     resourceTypeUser,
+				"endpoint": "/Users",
+				"schema":   schemaUser,
+			},
+		},
+	})
+}
+
+func writeResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", contentTypeSCIM)
+	w.WriteHeader(status)
+	if body == nil {
+		return
+	}
+	err := json.NewEncoder(w).Encode(body)
+	logging.OnError(err).Warn("unable to write scim response")
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	scimErr := toError(err)
+	logging.WithError(err).WithField("status", scimErr.Status).Debug("scim request failed")
+	writeResponse(w, scimErr.status, scimErr)
+}
+
+func readBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "unable to parse body: "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// sortColumns maps the sortable attributes of the user resource to their column
+var sortColumns = map[string]query.Column{
+	"id":                query.UserIDCol,
+	"username":          query.UserUsernameCol,
+	"name.givenname":    query.HumanFirstNameCol,
+	"name.familyname":   query.HumanLastNameCol,
+	"displayname":       query.HumanDisplayNameCol,
+	"nickname":          query.HumanNickNameCol,
+	"emails":            query.HumanEmailCol,
+	"emails.value":      query.HumanEmailCol,
+	"meta.created":      query.UserCreationDateCol,
+	"meta.lastmodified": query.UserChangeDateCol,
+}
+
+func (h *Handler) userLocation(ctx context.Context, orgID, userID string) string {
+	return h.rootURL(ctx, orgID) + "/Users/" + userID
+}
+
+func (h *Handler) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)[varOrgID]
+	queries, err := h.listUsersQuery(r, orgID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	users, err := h.queries.SearchUsers(r.Context(), queries, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resources := make([]interface{}, 0, len(users.Users))
+	// a count of 0 only requests the total amount of results
+	if r.URL.Query().Get("count") != "0" {
+		for _, user := range users.Users {
+			resources = append(resources, userToResource(user, h.userLocation(r.Context(), orgID, user.ID)))
+		}
+	}
+	writeResponse(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: users.Count,
+		StartIndex:   queries.Offset + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) listUsersQuery(r *http.Request, orgID string) (*query.UserSearchQueries, error) {
+	params := r.URL.Query()
+	startIndex, err := positiveParam(params.Get("startIndex"), 1)
+	if err != nil {
+		return nil, err
+	}
+	count, err := positiveParam(params.Get("count"), h.maxResults)
+	if err != nil {
+		return nil, err
+	}
+	if count > h.maxResults || count == 0 {
+		count = h.maxResults
+	}
+	queries := &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: startIndex - 1,
+			Limit:  count,
+			Asc:    !strings.EqualFold(params.Get("sortOrder"), "descending"),
+		},
+	}
+	if sortBy := params.Get("sortBy"); sortBy != "" {
+		column, ok := sortColumns[attributePath("", sortBy)]
+		if !ok {
+			return nil, invalidFilter("sorting by " + sortBy + " is not supported")
+		}
+		queries.SortingColumn = column
+	}
+	if err = queries.AppendMyResourceOwnerQuery(orgID); err != nil {
+		return nil, err
+	}
+	humanQuery, err := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	queries.Queries = append(queries.Queries, humanQuery)
+	if expression := params.Get("filter"); expression != "" {
+		f, err := parseFilter(expression)
+		if err != nil {
+			return nil, err
+		}
+		filterQuery, err := filterToQuery(f)
+		if err != nil {
+			return nil, err
+		}
+		queries.Queries = append(queries.Queries, filterQuery)
+	}
+	return queries, nil
+}
+
+// positiveParam parses the query parameter, values lower than 1 are treated as 1 (RFC 7644 section 3.4.2.4)
+func positiveParam(value string, defaultValue uint64) (uint64, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid number "+value)
+	}
+	if i < 1 {
+		return 1, nil
+	}
+	return uint64(i), nil
+}
+
+func (h *Handler) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	orgID, userID := mux.Vars(r)[varOrgID], mux.Vars(r)[varUserID]
+	user, err := h.getUser(r.Context(), orgID, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if matchesETag(r.Header.Get("If-None-Match"), user.Sequence) {
+		w.Header().Set("ETag", etag(user.Sequence))
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.writeUser(w, r.Context(), http.StatusOK, orgID, user)
+}
+
+func (h *Handler) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)[varOrgID]
+	resource := new(User)
+	if err := readBody(r, resource); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := resource.validate(); err != nil {
+		writeError(w, err)
+		return
+	}
+	human := resource.toAddHuman()
+	if err := h.commands.AddHuman(r.Context(), orgID, human, false); err != nil {
+		writeError(w, err)
+		return
+	}
+	user, err := h.getUser(r.Context(), orgID, human.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Location", h.userLocation(r.Context(), orgID, user.ID))
+	h.writeUser(w, r.Context(), http.StatusCreated, orgID, user)
+}
+
+func (h *Handler) handleReplaceUser(w http.ResponseWriter, r *http.Request) {
+	resource := new(User)
+	if err := readBody(r, resource); err != nil {
+		writeError(w, err)
+		return
+	}
+	h.updateUser(w, r, func(*User) (*User, error) {
+		return resource, nil
+	})
+}
+
+func (h *Handler) handlePatchUser(w http.ResponseWriter, r *http.Request) {
+	patch := new(PatchRequest)
+	if err := readBody(r, patch); err != nil {
+		writeError(w, err)
+		return
+	}
+	h.updateUser(w, r, func(current *User) (*User, error) {
+		return current, applyPatch(current, patch.Operations)
+	})
+}
+
+// updateUser checks the precondition of the request and changes the user to the resource returned by desired
+func (h *Handler) updateUser(w http.ResponseWriter, r *http.Request, desired func(current *User) (*User, error)) {
+	ctx := r.Context()
+	orgID, userID := mux.Vars(r)[varOrgID], mux.Vars(r)[varUserID]
+	user, err := h.getUser(ctx, orgID, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = checkPrecondition(r, user.Sequence); err != nil {
+		writeError(w, err)
+		return
+	}
+	resource, err := desired(userToResource(user, ""))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = resource.validate(); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = h.changeUser(ctx, orgID, user, resource); err != nil {
+		writeError(w, err)
+		return
+	}
+	user, err = h.getUser(ctx, orgID, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.writeUser(w, ctx, http.StatusOK, orgID, user)
+}
+
+// changeUser executes the commands needed to change the existing user to the desired resource
+func (h *Handler) changeUser(ctx context.Context, orgID string, existing *query.User, desired *User) (err error) {
+	objectRoot := es_models.ObjectRoot{AggregateID: existing.ID, ResourceOwner: orgID}
+	if desired.UserName != existing.Username {
+		if _, err = h.commands.ChangeUsername(ctx, orgID, existing.ID, desired.UserName); err != nil {
+			return err
+		}
+	}
+	profile := &domain.Profile{
+		ObjectRoot:        objectRoot,
+		FirstName:         desired.givenName(),
+		LastName:          desired.familyName(),
+		NickName:          desired.NickName,
+		DisplayName:       desired.DisplayName,
+		PreferredLanguage: desired.preferredLanguage(),
+		Gender:            existing.Human.Gender,
+	}
+	if profile.DisplayName == "" {
+		profile.DisplayName = profile.FirstName + " " + profile.LastName
+	}
+	if profile.PreferredLanguage.IsRoot() {
+		profile.PreferredLanguage = existing.Human.PreferredLanguage
+	}
+	if profileChanged(existing.Human, profile) {
+		if _, err = h.commands.ChangeHumanProfile(ctx, profile); err != nil {
+			return err
+		}
+	}
+	if err = h.changeEmail(ctx, objectRoot, existing, primaryAttribute(desired.Emails)); err != nil {
+		return err
+	}
+	if err = h.changePhone(ctx, orgID, existing, primaryAttribute(desired.PhoneNumbers)); err != nil {
+		return err
+	}
+	if desired.Password != "" {
+		if _, err = h.commands.SetPassword(ctx, orgID, existing.ID, desired.Password, false); err != nil {
+			return err
+		}
+	}
+	return h.changeState(ctx, orgID, existing, desired.isActive())
+}
+
+func profileChanged(existing *query.Human, profile *domain.Profile) bool {
+	return existing.FirstName != profile.FirstName ||
+		existing.LastName != profile.LastName ||
+		existing.NickName != profile.NickName ||
+		existing.DisplayName != profile.DisplayName ||
+		existing.PreferredLanguage != profile.PreferredLanguage
+}
+
+// changeEmail changes the email if the address changed or the client marked it as verified,
+// a changed email which is not marked as verified will be sent a verification code
+func (h *Handler) changeEmail(ctx context.Context, objectRoot es_models.ObjectRoot, existing *query.User, attribute *Attribute) error {
+	email := domain.EmailAddress(attribute.Value)
+	if email == existing.Human.Email && (!attribute.Verified || existing.Human.IsEmailVerified) {
+		return nil
+	}
+	emailCodeGenerator, err := h.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, h.userCodeAlg)
+	if err != nil {
+		return err
+	}
+	_, err = h.commands.ChangeHumanEmail(ctx, &domain.Email{ObjectRoot: objectRoot, EmailAddress: email, IsEmailVerified: attribute.Verified}, emailCodeGenerator)
+	return err
+}
+
+// changePhone changes the phone analog to [Handler.changeEmail] and removes it if no number is provided
+func (h *Handler) changePhone(ctx context.Context, orgID string, existing *query.User, attribute *Attribute) (err error) {
+	phone := domain.PhoneNumber(attribute.Value)
+	if phone == "" {
+		if existing.Human.Phone == "" {
+			return nil
+		}
+		_, err = h.commands.RemoveHumanPhone(ctx, existing.ID, orgID)
+		return err
+	}
+	if phone, err = phone.Normalize(); err != nil {
+		return err
+	}
+	if phone == existing.Human.Phone && (!attribute.Verified || existing.Human.IsPhoneVerified) {
+		return nil
+	}
+	phoneCodeGenerator, err := h.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyPhoneCode, h.userCodeAlg)
+	if err != nil {
+		return err
+	}
+	_, err = h.commands.ChangeHumanPhone(ctx, &domain.Phone{
+		ObjectRoot:      es_models.ObjectRoot{AggregateID: existing.ID},
+		PhoneNumber:     phone,
+		IsPhoneVerified: attribute.Verified,
+	}, orgID, phoneCodeGenerator)
+	return err
+}
+
+func (h *Handler) changeState(ctx context.Context, orgID string, existing *query.User, active bool) (err error) {
+	switch {
+	case active && existing.State == domain.UserStateInactive:
+		_, err = h.commands.ReactivateUser(ctx, existing.ID, orgID)
+	case !active && (existing.State == domain.UserStateActive || existing.State == domain.UserStateInitial):
+		_, err = h.commands.DeactivateUser(ctx, existing.ID, orgID)
+	}
+	return err
+}
+
+func (h *Handler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, userID := mux.Vars(r)[varOrgID], mux.Vars(r)[varUserID]
+	user, err := h.getUser(ctx, orgID, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = checkPrecondition(r, user.Sequence); err != nil {
+		writeError(w, err)
+		return
+	}
+	memberships, grants, err := h.removeUserDependencies(ctx, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, err = h.commands.RemoveUser(ctx, userID, orgID, memberships, grants...); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getUser returns the human user of the organisation
+func (h *Handler) getUser(ctx context.Context, orgID, userID string) (*query.User, error) {
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.queries.GetUserByID(ctx, true, userID, false, ownerQuery)
+	if err != nil {
+		return nil, err
+	}
+	if user.Human == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "SCIM-4k2Vd", "Errors.User.NotHuman")
+	}
+	return user, nil
+}
+
+func (h *Handler) writeUser(w http.ResponseWriter, ctx context.Context, status int, orgID string, user *query.User) {
+	w.Header().Set("ETag", etag(user.Sequence))
+	writeResponse(w, status, userToResource(user, h.userLocation(ctx, orgID, user.ID)))
+}
+
+// checkPrecondition verifies the If-Match header of the request against the current version of the resource
+func checkPrecondition(r *http.Request, sequence uint64) error {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || matchesETag(ifMatch, sequence) {
+		return nil
+	}
+	return newError(http.StatusPreconditionFailed, "", "the resource has been modified, current version is "+etag(sequence))
+}
+
+// matchesETag checks if one of the comma separated entity tags of the header matches the version of the resource.
+// As only weak entity tags are issued, the weak prefix is optional.
+func matchesETag(header string, sequence uint64) bool {
+	if header == "" {
+		return false
+	}
+	current := strings.TrimPrefix(etag(sequence), "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := h.queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := h.queries.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cascadingMemberships(memberships.Memberships), userGrantsToIDs(grants.UserGrants), nil
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascades[i] = &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+		}
+		if membership.IAM != nil {
+			cascades[i].IAM = &command.CascadingIAMMembership{IAMID: membership.IAM.IAMID}
+		}
+		if membership.Org != nil {
+			cascades[i].Org = &command.CascadingOrgMembership{OrgID: membership.Org.OrgID}
+		}
+		if membership.Project != nil {
+			cascades[i].Project = &command.CascadingProjectMembership{ProjectID: membership.Project.ProjectID}
+		}
+		if membership.ProjectGrant != nil {
+			cascades[i].ProjectGrant = &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectGrant.ProjectID, GrantID: membership.ProjectGrant.GrantID}
+		}
+	}
+	return cascades
+}
+
+func userGrantsToIDs(userGrants []*query.UserGrant) []string {
+	ids := make([]string, len(userGrants))
+	for i, grant := range userGrants {
+		ids[i] = grant.ID
+	}
+	return ids
+}
@@ -32,15 +32,16 @@ type initUserFormData struct {
 type initUserData struct {
 	baseData
 	profileData
-	Code         string
-	LoginName    string
-	UserID       string
-	PasswordSet  bool
-	MinLength    uint64
-	HasUppercase string
-	HasLowercase string
-	HasNumber    string
-	HasSymbol    string
+	Code                  string
+	LoginName             string
+	UserID                string
+	PasswordSet           bool
+	MinLength             uint64
+	HasUppercase          string
+	HasLowercase          string
+	HasNumber             string
+	HasSymbol             string
+	DenyBreachedPasswords bool
 }
 
 func InitUserLink(origin, userID, loginName, code, orgID string, passwordSet bool) string {
@@ -140,6 +141,7 @@ func (l *Login) renderInitUser(w http.ResponseWriter, r *http.Request, authReq *
 		if policy.HasNumber {
 			data.HasNumber = NumberRegex
 		}
+		data.DenyBreachedPasswords = policy.DenyBreachedPasswords
 	}
 	if authReq == nil {
 		user, err := l.query.GetUserByID(r.Context(), false, userID, false)
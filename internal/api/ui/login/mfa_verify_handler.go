@@ -3,8 +3,10 @@ package login
 import (
 	"net/http"
 
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 )
 
 const (
@@ -34,8 +36,16 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if data.MFAType == domain.MFATypeOTP {
+		ip := http_utils.RemoteIPStringFromRequest(r)
+		if err = l.rateLimiter.Allow(r.Context(), ratelimit.EndpointLoginOTP, ip, authReq.UserID); err != nil {
+			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
+			return
+		}
 		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+		if err != nil {
+			l.rateLimiter.Hit(r.Context(), ratelimit.EndpointLoginOTP, ip, authReq.UserID)
+		}
 
 		metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodOTP, err)
 		if err == nil && actionErr == nil && len(metadata) > 0 {
@@ -3,7 +3,9 @@ package login
 import (
 	"net/http"
 
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 )
 
 const (
@@ -38,7 +40,15 @@ func (l *Login) handlePasswordCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	ip := http_utils.RemoteIPStringFromRequest(r)
+	if err = l.rateLimiter.Allow(r.Context(), ratelimit.EndpointLoginPassword, ip, authReq.UserID); err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
 	err = l.authRepo.VerifyPassword(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Password, authReq.AgentID, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.rateLimiter.Hit(r.Context(), ratelimit.EndpointLoginPassword, ip, authReq.UserID)
+	}
 
 	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodPassword, err)
 	if err == nil && actionErr == nil && len(metadata) > 0 {
@@ -29,14 +29,15 @@ type registerFormData struct {
 type registerData struct {
 	baseData
 	registerFormData
-	MinLength          uint64
-	HasUppercase       string
-	HasLowercase       string
-	HasNumber          string
-	HasSymbol          string
-	ShowUsername       bool
-	ShowUsernameSuffix bool
-	OrgRegister        bool
+	MinLength             uint64
+	HasUppercase          string
+	HasLowercase          string
+	HasNumber             string
+	HasSymbol             string
+	DenyBreachedPasswords bool
+	ShowUsername          bool
+	ShowUsernameSuffix    bool
+	OrgRegister           bool
 }
 
 func (l *Login) handleRegister(w http.ResponseWriter, r *http.Request) {
@@ -179,6 +180,7 @@ func (l *Login) renderRegister(w http.ResponseWriter, r *http.Request, authReque
 		if pwPolicy.HasNumber {
 			data.HasNumber = NumberRegex
 		}
+		data.DenyBreachedPasswords = pwPolicy.DenyBreachedPasswords
 	}
 
 	orgIAMPolicy, err := l.getOrgDomainPolicy(r, resourceOwner)
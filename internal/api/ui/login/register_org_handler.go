@@ -33,6 +33,7 @@ type registerOrgData struct {
 	HasLowercase              string
 	HasNumber                 string
 	HasSymbol                 string
+	DenyBreachedPasswords     bool
 	UserLoginMustBeDomain     bool
 	IamDomain                 string
 }
@@ -106,6 +107,7 @@ func (l *Login) renderRegisterOrg(w http.ResponseWriter, r *http.Request, authRe
 		if pwPolicy.HasNumber {
 			data.HasNumber = NumberRegex
 		}
+		data.DenyBreachedPasswords = pwPolicy.DenyBreachedPasswords
 	}
 	orgPolicy, _ := l.getDefaultDomainPolicy(r)
 	if orgPolicy != nil {
@@ -654,6 +654,8 @@ type passwordData struct {
 	HasLowercase string
 	HasNumber    string
 	HasSymbol    string
+
+	DenyBreachedPasswords bool
 }
 
 type userSelectionData struct {
@@ -0,0 +1,162 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const defaultBulkLimit = 1000
+
+// RuntimeAggregateTypes are not exported by default,
+// their aggregates are created by ZITADEL while the instance is used (e.g. sessions and signing keys)
+var RuntimeAggregateTypes = []string{
+	"session",
+	"device_auth",
+	"idpintent",
+	"key_pair",
+	"milestone",
+	"pseudo",
+}
+
+type ExportConfig struct {
+	InstanceID string
+	// IncludeRuntimeAggregates exports the [RuntimeAggregateTypes] as well
+	IncludeRuntimeAggregates bool
+	// BulkLimit is the amount of events filtered at once
+	BulkLimit uint64
+}
+
+// ExportResult contains the amount of exported records
+type ExportResult struct {
+	// Events is the amount of events per aggregate type
+	Events            map[string]int
+	UniqueConstraints int
+}
+
+// Export writes the events and unique constraints of the instance into the archive.
+// The events are written in the order they were pushed, their personal data is decrypted.
+// The secrets stay encrypted, the fingerprints of the keys of the system are written into the header.
+func Export(ctx context.Context, es *eventstore.Eventstore, keyStorage crypto.KeyStorage, w io.Writer, config *ExportConfig) (*ExportResult, error) {
+	ctx = authz.WithInstanceID(ctx, config.InstanceID)
+	bulkLimit := config.BulkLimit
+	if bulkLimit == 0 {
+		bulkLimit = defaultBulkLimit
+	}
+	excluded := make(map[string]bool, len(RuntimeAggregateTypes))
+	if !config.IncludeRuntimeAggregates {
+		for _, typ := range RuntimeAggregateTypes {
+			excluded[typ] = true
+		}
+	}
+
+	fingerprints, err := keyFingerprints(keyStorage)
+	if err != nil {
+		return nil, err
+	}
+	writer := NewWriter(w)
+	err = writer.Write(&Record{Header: &Header{
+		Version:        Version,
+		InstanceID:     config.InstanceID,
+		ExportedAt:     time.Now().UTC(),
+		EncryptionKeys: fingerprints,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExportResult{Events: make(map[string]int)}
+	constraints, err := es.UniqueConstraints(ctx, config.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, constraint := range constraints {
+		err = writer.Write(&Record{UniqueConstraint: &UniqueConstraint{
+			Type:  constraint.UniqueType,
+			Field: constraint.UniqueField,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		result.UniqueConstraints++
+	}
+
+	// the global unique constraints are not related to an instance,
+	// the ones of the instance domains are computed from the events instead
+	domains := make(map[string]bool)
+	var sequence uint64
+	for {
+		events, err := es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(config.InstanceID).
+			OrderAsc().
+			Limit(bulkLimit).
+			AddQuery().
+			SequenceGreater(sequence).
+			Builder(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			sequence = event.Sequence()
+			switch e := event.(type) {
+			case *instance.DomainAddedEvent:
+				domains[strings.ToLower(e.Domain)] = true
+			case *instance.DomainRemovedEvent:
+				delete(domains, strings.ToLower(e.Domain))
+			}
+			if excluded[string(event.Aggregate().Type)] {
+				continue
+			}
+			if err = writer.Write(&Record{Event: eventToRecord(event)}); err != nil {
+				return nil, err
+			}
+			result.Events[string(event.Aggregate().Type)]++
+		}
+		if uint64(len(events)) < bulkLimit {
+			break
+		}
+	}
+
+	globalConstraints := make([]string, 0, len(domains))
+	for domain := range domains {
+		globalConstraints = append(globalConstraints, domain)
+	}
+	sort.Strings(globalConstraints)
+	for _, domain := range globalConstraints {
+		constraint := instance.NewAddInstanceDomainUniqueConstraint(domain)
+		err = writer.Write(&Record{UniqueConstraint: &UniqueConstraint{
+			Type:   constraint.UniqueType,
+			Field:  constraint.UniqueField,
+			Global: true,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		result.UniqueConstraints++
+	}
+	return result, nil
+}
+
+func eventToRecord(event eventstore.Event) *Event {
+	aggregate := event.Aggregate()
+	return &Event{
+		AggregateType:    string(aggregate.Type),
+		AggregateID:      aggregate.ID,
+		AggregateVersion: string(aggregate.Version),
+		ResourceOwner:    aggregate.ResourceOwner,
+		Type:             string(event.Type()),
+		Sequence:         event.Sequence(),
+		CreationDate:     event.CreationDate(),
+		EditorService:    event.EditorService(),
+		EditorUser:       event.EditorUser(),
+		Payload:          event.DataAsBytes(),
+	}
+}
@@ -0,0 +1,148 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// Version is the version of the archive format written by [Export]
+const Version = "v1"
+
+// Record is a single line of an archive.
+// Exactly one of the fields is set.
+// The first record of an archive is always the header.
+type Record struct {
+	Header           *Header           `json:"header,omitempty"`
+	UniqueConstraint *UniqueConstraint `json:"uniqueConstraint,omitempty"`
+	Event            *Event            `json:"event,omitempty"`
+}
+
+// Header describes the exported instance
+type Header struct {
+	Version    string    `json:"version"`
+	InstanceID string    `json:"instanceId"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// EncryptionKeys are the fingerprints of the encryption keys of the exported system by their id,
+	// they ensure the secrets of the archive are imported into a system which is able to decrypt them
+	EncryptionKeys map[string]string `json:"encryptionKeys,omitempty"`
+}
+
+// UniqueConstraint is a unique constraint of the exported instance
+type UniqueConstraint struct {
+	Type  string `json:"type"`
+	Field string `json:"field"`
+	// Global constraints are unique over all instances (e.g. the instance domains)
+	Global bool `json:"global,omitempty"`
+}
+
+// Event is an event of the exported instance.
+// The payload contains the personal data unencrypted.
+type Event struct {
+	// InstanceID is only set in archives of pruned events, which contain the events of all instances
+	InstanceID       string          `json:"instanceId,omitempty"`
+	AggregateType    string          `json:"aggregateType"`
+	AggregateID      string          `json:"aggregateId"`
+	AggregateVersion string          `json:"aggregateVersion"`
+	ResourceOwner    string          `json:"resourceOwner"`
+	Type             string          `json:"type"`
+	Sequence         uint64          `json:"sequence"`
+	CreationDate     time.Time       `json:"creationDate"`
+	EditorService    string          `json:"editorService,omitempty"`
+	EditorUser       string          `json:"editorUser,omitempty"`
+	Payload          json.RawMessage `json:"payload,omitempty"`
+}
+
+// Writer writes the records of an archive as newline delimited json
+type Writer struct {
+	encoder *json.Encoder
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{encoder: json.NewEncoder(w)}
+}
+
+func (w *Writer) Write(record *Record) error {
+	if err := record.validate(); err != nil {
+		return err
+	}
+	if err := w.encoder.Encode(record); err != nil {
+		return caos_errs.ThrowInternal(err, "ARCHI-Wr1te", "unable to write record")
+	}
+	return nil
+}
+
+// Reader reads the records of an archive written by a [Writer]
+type Reader struct {
+	decoder *json.Decoder
+	header  *Header
+}
+
+// NewReader reads the header of the archive and verifies its version
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{decoder: json.NewDecoder(r)}
+	record, err := reader.next()
+	if errors.Is(err, io.EOF) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ARCHI-Hd1em", "archive is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if record.Header == nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ARCHI-Hd2ms", "archive must start with the header")
+	}
+	if record.Header.Version != Version {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "ARCHI-Hd3vr", "archive version %q is not supported", record.Header.Version)
+	}
+	reader.header = record.Header
+	return reader, nil
+}
+
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// Next returns the next record after the header or [io.EOF] at the end of the archive
+func (r *Reader) Next() (*Record, error) {
+	record, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if record.Header != nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ARCHI-Hd4dp", "archive contains multiple headers")
+	}
+	return record, nil
+}
+
+func (r *Reader) next() (*Record, error) {
+	record := new(Record)
+	if err := r.decoder.Decode(record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, caos_errs.ThrowInvalidArgument(err, "ARCHI-Rd1ec", "unable to read record")
+	}
+	if err := record.validate(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (r *Record) validate() error {
+	set := 0
+	for _, isSet := range []bool{r.Header != nil, r.UniqueConstraint != nil, r.Event != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return caos_errs.ThrowInvalidArgument(nil, "ARCHI-Rc1vl", "record must contain exactly one of header, unique constraint or event")
+	}
+	if r.Event != nil && (r.Event.AggregateType == "" || r.Event.AggregateID == "" || r.Event.Type == "" || r.Event.AggregateVersion == "") {
+		return caos_errs.ThrowInvalidArgument(nil, "ARCHI-Ev1vl", "event must contain aggregate type, id, version and event type")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestWriterReader(t *testing.T) {
+	records := []*Record{
+		{UniqueConstraint: &UniqueConstraint{Type: "usernames", Field: "gigi"}},
+		{Event: &Event{
+			AggregateType:    "user",
+			AggregateID:      "user1",
+			AggregateVersion: "v2",
+			ResourceOwner:    "org1",
+			Type:             "user.human.added",
+			Sequence:         2,
+			CreationDate:     time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+			EditorUser:       "admin1",
+			Payload:          json.RawMessage(`{"userName":"gigi"}`),
+		}},
+		{UniqueConstraint: &UniqueConstraint{Type: "instance_domain", Field: "acme.localhost", Global: true}},
+	}
+	header := &Header{Version: Version, InstanceID: "instance1", ExportedAt: time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC)}
+
+	buf := new(bytes.Buffer)
+	writer := NewWriter(buf)
+	if err := writer.Write(&Record{Header: header}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(records)+1 {
+		t.Errorf("expected one line per record, got %d lines", lines)
+	}
+
+	reader, err := NewReader(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(reader.Header(), header) {
+		t.Errorf("unexpected header: %+v", reader.Header())
+	}
+	for i, want := range records {
+		got, err := reader.Next()
+		if err != nil {
+			t.Fatalf("record %d: unexpected error: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("record %d: got %+v, want %+v", i, got, want)
+		}
+	}
+	if _, err = reader.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive string
+	}{
+		{
+			name:    "empty",
+			archive: "",
+		},
+		{
+			name:    "no header",
+			archive: `{"uniqueConstraint":{"type":"usernames","field":"gigi"}}`,
+		},
+		{
+			name:    "unsupported version",
+			archive: `{"header":{"version":"v0","instanceId":"instance1"}}`,
+		},
+		{
+			name:    "multiple fields",
+			archive: `{"header":{"version":"v1","instanceId":"instance1"},"uniqueConstraint":{"type":"usernames","field":"gigi"}}`,
+		},
+		{
+			name:    "no json",
+			archive: `header`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(strings.NewReader(tt.archive))
+			if !caos_errs.IsErrorInvalidArgument(err) {
+				t.Errorf("expected invalid argument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReader_Next(t *testing.T) {
+	tests := []struct {
+		name    string
+		records string
+	}{
+		{
+			name:    "second header",
+			records: `{"header":{"version":"v1","instanceId":"instance2"}}`,
+		},
+		{
+			name:    "empty record",
+			records: `{}`,
+		},
+		{
+			name:    "event without type",
+			records: `{"event":{"aggregateType":"user","aggregateId":"user1","aggregateVersion":"v2"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(`{"header":{"version":"v1","instanceId":"instance1"}}` + "\n" + tt.records))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err = reader.Next(); !caos_errs.IsErrorInvalidArgument(err) {
+				t.Errorf("expected invalid argument, got %v", err)
+			}
+		})
+	}
+}
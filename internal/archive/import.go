@@ -0,0 +1,354 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+)
+
+const defaultPushBulkLimit = 100
+
+type ImportConfig struct {
+	// InstanceID of the imported instance, a new id is generated if empty
+	InstanceID string
+	// RemapIDs generates new ids for all aggregates of the archive and the entities within them (e.g. applications),
+	// the ids of the archive are kept otherwise
+	RemapIDs bool
+	// Domains replaces the domains of the archive (e.g. the instance domains) and their subdomains,
+	// the domains are matched case insensitive
+	Domains map[string]string
+	// DryRun only validates the archive against the eventstore, no events are pushed
+	DryRun bool
+	// BulkLimit is the amount of events pushed in a single transaction
+	BulkLimit int
+}
+
+// ImportResult describes the imported or, in case of a dry run, the validated archive
+type ImportResult struct {
+	SourceInstanceID string
+	InstanceID       string
+	// Events is the amount of events per aggregate type
+	Events            map[string]int
+	UniqueConstraints int
+	// Conflicts are the global unique constraints already used by other instances
+	Conflicts []*UniqueConstraint
+	// IDs maps the ids of the archive to the imported ids
+	IDs map[string]string
+}
+
+// Import validates the archive and pushes its events into a new instance.
+// The archive is read twice, the first pass validates the events and computes the new ids,
+// the second pass pushes the remapped events in bulks.
+// A failed import leaves the events pushed so far, the instance can be removed afterwards.
+// The secrets of the archive are imported as they are, the keys of the system must match the ones of the exported system.
+func Import(ctx context.Context, es *eventstore.Eventstore, keyStorage crypto.KeyStorage, archive io.ReadSeeker, config *ImportConfig) (*ImportResult, error) {
+	result, constraints, err := validate(ctx, es, keyStorage, archive, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Conflicts) > 0 && !config.DryRun {
+		return result, caos_errs.ThrowAlreadyExists(nil, "ARCHI-Im2cf", "Errors.Instance.Domain.AlreadyExists")
+	}
+	if config.DryRun {
+		return result, nil
+	}
+
+	ctx = authz.WithInstanceID(ctx, result.InstanceID)
+	if err = es.NewInstance(ctx, result.InstanceID); err != nil {
+		return nil, err
+	}
+	if _, err = archive.Seek(0, io.SeekStart); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Im3sk", "unable to read archive")
+	}
+	reader, err := NewReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	remap := newRemapper(result.IDs, config.Domains)
+	bulkLimit := config.BulkLimit
+	if bulkLimit <= 0 {
+		bulkLimit = defaultPushBulkLimit
+	}
+
+	cmds := make([]eventstore.Command, 0, bulkLimit)
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record.Event == nil {
+			continue
+		}
+		cmd, err := newImportCommand(es, result.InstanceID, record.Event, remap)
+		if err != nil {
+			return nil, err
+		}
+		// the unique constraints are added with the first event of the instance
+		if constraints != nil {
+			cmd.constraints = constraints
+			constraints = nil
+		}
+		cmds = append(cmds, cmd)
+		if len(cmds) < bulkLimit {
+			continue
+		}
+		if _, err = es.Push(ctx, cmds...); err != nil {
+			return nil, err
+		}
+		cmds = cmds[:0]
+	}
+	if len(cmds) > 0 {
+		if _, err = es.Push(ctx, cmds...); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// validate reads the whole archive, checks the events against the registered event mappers,
+// the encryption keys of the secrets against the ones of the system
+// and the global unique constraints against the ones of other instances.
+// It returns the unique constraints of the archive with the new ids.
+func validate(ctx context.Context, es *eventstore.Eventstore, keyStorage crypto.KeyStorage, archive io.Reader, config *ImportConfig) (*ImportResult, []*eventstore.EventUniqueConstraint, error) {
+	reader, err := NewReader(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &ImportResult{
+		SourceInstanceID: reader.Header().InstanceID,
+		InstanceID:       config.InstanceID,
+		Events:           make(map[string]int),
+		IDs:              make(map[string]string),
+	}
+	if result.InstanceID == "" {
+		if result.InstanceID, err = id.SonyFlakeGenerator().Next(); err != nil {
+			return nil, nil, err
+		}
+	}
+	result.IDs[result.SourceInstanceID] = result.InstanceID
+	if err = instanceMustNotExist(authz.WithInstanceID(ctx, result.InstanceID), es); err != nil {
+		return nil, nil, err
+	}
+
+	var idErr error
+	addID := func(oldID string) {
+		if _, ok := result.IDs[oldID]; ok || oldID == "" || idErr != nil {
+			return
+		}
+		newID := oldID
+		if config.RemapIDs {
+			newID, idErr = id.SonyFlakeGenerator().Next()
+		}
+		result.IDs[oldID] = newID
+	}
+	collectIDs := func(kind fieldKind, value string) string {
+		if kind == fieldID {
+			addID(value)
+		}
+		return value
+	}
+
+	knownEventTypes := es.EventTypes()
+	keyIDs := make(map[string]bool)
+	var archived []*UniqueConstraint
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if record.UniqueConstraint != nil {
+			archived = append(archived, record.UniqueConstraint)
+			continue
+		}
+		event := record.Event
+		fields, ok := fieldsOf(event.Type)
+		if i := sort.SearchStrings(knownEventTypes, event.Type); !ok || i == len(knownEventTypes) || knownEventTypes[i] != event.Type {
+			return nil, nil, caos_errs.ThrowInvalidArgumentf(nil, "ARCHI-Im6et", "event %d of type %s is unknown", event.Sequence, event.Type)
+		}
+		if _, err = es.MapEvent(recordToRepository(result.SourceInstanceID, event, event.Payload)); err != nil {
+			return nil, nil, caos_errs.ThrowInvalidArgumentf(err, "ARCHI-Im4mp", "event %d of type %s is invalid", event.Sequence, event.Type)
+		}
+		addID(event.AggregateID)
+		addID(event.ResourceOwner)
+		if len(event.Payload) > 0 {
+			payload, err := decodePayload(event.Payload)
+			if err != nil {
+				return nil, nil, err
+			}
+			walkFields(payload, fields, collectIDs)
+			encryptionKeyIDs(payload, keyIDs)
+		}
+		if idErr != nil {
+			return nil, nil, idErr
+		}
+		result.Events[event.AggregateType]++
+	}
+	if err = checkEncryptionKeys(keyIDs, reader.Header().EncryptionKeys, keyStorage); err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := es.UniqueConstraints(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	global := make(map[string]bool, len(existing))
+	for _, constraint := range existing {
+		global[constraint.UniqueType+":"+constraint.UniqueField] = true
+	}
+	remap := newRemapper(result.IDs, config.Domains)
+	constraints := make([]*eventstore.EventUniqueConstraint, len(archived))
+	for i, constraint := range archived {
+		field, err := remap.constraint(constraint)
+		if err != nil {
+			return nil, nil, err
+		}
+		field = strings.ToLower(field)
+		if constraint.Global && global[constraint.Type+":"+field] {
+			result.Conflicts = append(result.Conflicts, &UniqueConstraint{Type: constraint.Type, Field: field, Global: true})
+		}
+		if constraint.Global {
+			constraints[i] = eventstore.NewAddGlobalEventUniqueConstraint(constraint.Type, field, "Errors.Instance.Domain.AlreadyExists")
+			continue
+		}
+		constraints[i] = eventstore.NewAddEventUniqueConstraint(constraint.Type, field, "Errors.Instance.AlreadyExists")
+	}
+	result.UniqueConstraints = len(constraints)
+	return result, constraints, nil
+}
+
+func instanceMustNotExist(ctx context.Context, es *eventstore.Eventstore) error {
+	sequence, err := es.LatestSequence(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsMaxSequence).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		Builder(),
+	)
+	if err != nil {
+		return err
+	}
+	if sequence > 0 {
+		return caos_errs.ThrowAlreadyExists(nil, "ARCHI-Im1ex", "Errors.Instance.AlreadyExists")
+	}
+	return nil
+}
+
+func recordToRepository(instanceID string, event *Event, payload []byte) *repository.Event {
+	return &repository.Event{
+		AggregateID:   event.AggregateID,
+		AggregateType: repository.AggregateType(event.AggregateType),
+		ResourceOwner: sql.NullString{String: event.ResourceOwner, Valid: event.ResourceOwner != ""},
+		InstanceID:    instanceID,
+		EditorService: event.EditorService,
+		EditorUser:    event.EditorUser,
+		Type:          repository.EventType(event.Type),
+		Version:       repository.Version(event.AggregateVersion),
+		Sequence:      event.Sequence,
+		CreationDate:  event.CreationDate,
+		Data:          payload,
+	}
+}
+
+// importCommand pushes an archived event with its remapped payload
+type importCommand struct {
+	aggregate     eventstore.Aggregate
+	typ           eventstore.EventType
+	editorService string
+	editorUser    string
+	payload       []byte
+	constraints   []*eventstore.EventUniqueConstraint
+	// mapped is the event struct of the archived event,
+	// it defines the personal data of the payload
+	mapped eventstore.Event
+}
+
+func newImportCommand(es *eventstore.Eventstore, instanceID string, event *Event, remap *remapper) (*importCommand, error) {
+	payload, err := remap.payload(event.Type, event.Payload)
+	if err != nil {
+		return nil, err
+	}
+	remapped := &Event{
+		AggregateType:    event.AggregateType,
+		AggregateID:      remap.id(event.AggregateID),
+		AggregateVersion: event.AggregateVersion,
+		ResourceOwner:    remap.id(event.ResourceOwner),
+		Type:             event.Type,
+		EditorService:    event.EditorService,
+		EditorUser:       remap.id(event.EditorUser),
+	}
+	mapped, err := es.MapEvent(recordToRepository(instanceID, remapped, payload))
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgumentf(err, "ARCHI-Im5mp", "event %d of type %s is invalid", event.Sequence, event.Type)
+	}
+	return &importCommand{
+		aggregate: eventstore.Aggregate{
+			ID:            remapped.AggregateID,
+			Type:          eventstore.AggregateType(remapped.AggregateType),
+			ResourceOwner: remapped.ResourceOwner,
+			InstanceID:    instanceID,
+			Version:       eventstore.Version(remapped.AggregateVersion),
+		},
+		typ:           eventstore.EventType(remapped.Type),
+		editorService: remapped.EditorService,
+		editorUser:    remapped.EditorUser,
+		payload:       payload,
+		mapped:        mapped,
+	}, nil
+}
+
+func (c *importCommand) Aggregate() eventstore.Aggregate {
+	return c.aggregate
+}
+
+func (c *importCommand) EditorService() string {
+	return c.editorService
+}
+
+func (c *importCommand) EditorUser() string {
+	return c.editorUser
+}
+
+func (c *importCommand) Type() eventstore.EventType {
+	return c.typ
+}
+
+func (c *importCommand) Data() interface{} {
+	if len(c.payload) == 0 {
+		return nil
+	}
+	return c.payload
+}
+
+func (c *importCommand) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return c.constraints
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (c *importCommand) PersonalDataFields() []string {
+	if personalData, ok := c.mapped.(interface{ PersonalDataFields() []string }); ok {
+		return personalData.PersonalDataFields()
+	}
+	return nil
+}
+
+// ForgetsPersonalData implements [eventstore.PersonalDataForgetter]
+func (c *importCommand) ForgetsPersonalData() bool {
+	if forgetter, ok := c.mapped.(interface{ ForgetsPersonalData() bool }); ok {
+		return forgetter.ForgetsPersonalData()
+	}
+	return false
+}
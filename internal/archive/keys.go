@@ -0,0 +1,95 @@
+package archive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// keyFingerprint identifies the value of an encryption key without revealing it
+func keyFingerprint(value string) string {
+	mac := hmac.New(sha256.New, []byte(value))
+	mac.Write([]byte("zitadel archive"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// keyFingerprints returns the fingerprints of all encryption keys of the system by their id
+func keyFingerprints(keyStorage crypto.KeyStorage) (map[string]string, error) {
+	keys, err := keyStorage.ReadKeys()
+	if err != nil {
+		return nil, err
+	}
+	fingerprints := make(map[string]string, len(keys))
+	for id, value := range keys {
+		fingerprints[id] = keyFingerprint(value)
+	}
+	return fingerprints, nil
+}
+
+// encryptionKeyIDs adds the key ids of the encrypted secrets (e.g. client secrets) of the payload,
+// hashed secrets don't depend on the keys of the system
+func encryptionKeyIDs(value interface{}, keyIDs map[string]bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			encryptionKeyIDs(item, keyIDs)
+		}
+	case map[string]interface{}:
+		if keyID, ok := encryptionKeyID(v); ok {
+			keyIDs[keyID] = true
+			return
+		}
+		for _, item := range v {
+			encryptionKeyIDs(item, keyIDs)
+		}
+	}
+}
+
+// encryptionKeyID returns the key id if the object is an encrypted [crypto.CryptoValue]
+func encryptionKeyID(object map[string]interface{}) (string, bool) {
+	if _, ok := object["Crypted"]; !ok {
+		return "", false
+	}
+	keyID, ok := object["KeyID"].(string)
+	if !ok || keyID == "" {
+		return "", false
+	}
+	cryptoType, ok := object["CryptoType"].(json.Number)
+	if !ok || cryptoType.String() != "0" {
+		return "", false
+	}
+	return keyID, true
+}
+
+// checkEncryptionKeys ensures the secrets of the archive can be decrypted by the system.
+// The secrets are imported as they are,
+// so the keys used to encrypt them must have the same value as the keys of the exported system.
+func checkEncryptionKeys(keyIDs map[string]bool, archived map[string]string, keyStorage crypto.KeyStorage) error {
+	if len(keyIDs) == 0 {
+		return nil
+	}
+	fingerprints, err := keyFingerprints(keyStorage)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(keyIDs))
+	for id := range keyIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fingerprint, ok := fingerprints[id]
+		if !ok {
+			return caos_errs.ThrowPreconditionFailedf(nil, "ARCHI-Ky1nf", "secrets of the archive are encrypted with the key %s, which doesn't exist in this system: import the archive into a system with the encryption keys of the exported system", id)
+		}
+		if archived[id] != fingerprint {
+			return caos_errs.ThrowPreconditionFailedf(nil, "ARCHI-Ky2df", "secrets of the archive are encrypted with the key %s, which differs from the key of this system: import the archive into a system with the encryption keys of the exported system", id)
+		}
+	}
+	return nil
+}
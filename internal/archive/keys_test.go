@@ -0,0 +1,86 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type testKeyStorage crypto.Keys
+
+func (s testKeyStorage) ReadKeys() (crypto.Keys, error) {
+	return crypto.Keys(s), nil
+}
+
+func (s testKeyStorage) ReadKey(id string) (*crypto.Key, error) {
+	return &crypto.Key{ID: id, Value: s[id]}, nil
+}
+
+func (s testKeyStorage) CreateKeys(...*crypto.Key) error {
+	return nil
+}
+
+func Test_encryptionKeyIDs(t *testing.T) {
+	payload, err := decodePayload([]byte(`{
+		"clientSecret": {"CryptoType": 0, "Algorithm": "aes", "KeyID": "clientSecretKey", "Crypted": "c2VjcmV0"},
+		"secret": {"CryptoType": 1, "Algorithm": "bcrypt", "KeyID": "", "Crypted": "aGFzaA=="},
+		"nested": [{"code": {"CryptoType": 0, "Algorithm": "aes", "KeyID": "userKey", "Crypted": "Y29kZQ=="}}],
+		"name": "KeyID"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyIDs := make(map[string]bool)
+	encryptionKeyIDs(payload, keyIDs)
+	if len(keyIDs) != 2 || !keyIDs["clientSecretKey"] || !keyIDs["userKey"] {
+		t.Errorf("unexpected key ids: %v", keyIDs)
+	}
+}
+
+func Test_checkEncryptionKeys(t *testing.T) {
+	keyStorage := testKeyStorage{"userKey": "key of this system"}
+	tests := []struct {
+		name     string
+		keyIDs   map[string]bool
+		archived map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "no secrets",
+		},
+		{
+			name:     "same key",
+			keyIDs:   map[string]bool{"userKey": true},
+			archived: map[string]string{"userKey": keyFingerprint("key of this system")},
+		},
+		{
+			name:     "different key",
+			keyIDs:   map[string]bool{"userKey": true},
+			archived: map[string]string{"userKey": keyFingerprint("key of the exported system")},
+			wantErr:  true,
+		},
+		{
+			name:     "key missing in the system",
+			keyIDs:   map[string]bool{"otpKey": true},
+			archived: map[string]string{"otpKey": keyFingerprint("key of this system")},
+			wantErr:  true,
+		},
+		{
+			name:    "fingerprint missing in the archive",
+			keyIDs:  map[string]bool{"userKey": true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEncryptionKeys(tt.keyIDs, tt.archived, keyStorage)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkEncryptionKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !caos_errs.IsPreconditionFailed(err) {
+				t.Errorf("expected precondition failed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// NewPruneArchive writes the header of an archive for the events removed by [eventstore.Eventstore.Prune].
+// The returned function writes the pruned events of all instances into the archive,
+// the header doesn't contain an instance id.
+func NewPruneArchive(w io.Writer) (eventstore.ArchiveFunc, error) {
+	writer := NewWriter(w)
+	err := writer.Write(&Record{Header: &Header{
+		Version:    Version,
+		ExportedAt: time.Now().UTC(),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return func(_ context.Context, events []*repository.Event) error {
+		for _, event := range events {
+			if err := writer.Write(&Record{Event: prunedToRecord(event)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+func prunedToRecord(event *repository.Event) *Event {
+	return &Event{
+		InstanceID:       event.InstanceID,
+		AggregateType:    string(event.AggregateType),
+		AggregateID:      event.AggregateID,
+		AggregateVersion: string(event.Version),
+		ResourceOwner:    event.ResourceOwner.String,
+		Type:             string(event.Type),
+		Sequence:         event.Sequence,
+		CreationDate:     event.CreationDate,
+		EditorService:    event.EditorService,
+		EditorUser:       event.EditorUser,
+		Payload:          json.RawMessage(event.Data),
+	}
+}
@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestNewPruneArchive(t *testing.T) {
+	buf := new(bytes.Buffer)
+	archive, err := NewPruneArchive(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = archive(context.Background(), []*repository.Event{
+		{
+			InstanceID:    "instance1",
+			AggregateType: "session",
+			AggregateID:   "session1",
+			Version:       "v1",
+			ResourceOwner: sql.NullString{String: "org1", Valid: true},
+			Type:          "session.added",
+			Sequence:      3,
+			Data:          []byte(`{"userAgent":"test"}`),
+		},
+		{
+			InstanceID:    "instance2",
+			AggregateType: "session",
+			AggregateID:   "session2",
+			Version:       "v1",
+			Type:          "session.terminated",
+			Sequence:      4,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reader, err := NewReader(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reader.Header().InstanceID != "" {
+		t.Errorf("expected header without instance, got %q", reader.Header().InstanceID)
+	}
+	for _, want := range []string{"instance1", "instance2"} {
+		record, err := reader.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if record.Event.InstanceID != want {
+			t.Errorf("expected event of %s, got %s", want, record.Event.InstanceID)
+		}
+	}
+}
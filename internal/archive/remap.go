@@ -0,0 +1,426 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// fieldKind defines how the value of a payload field or unique constraint is remapped
+type fieldKind int
+
+const (
+	// fieldID is the id of an aggregate or of an entity within an aggregate (e.g. an application),
+	// a new id is generated for each id of the archive if the ids are remapped
+	fieldID fieldKind = iota + 1
+	// fieldReference is replaced only if it's an id of the archive,
+	// because it might contain other values as well (e.g. the client ids of an audience)
+	fieldReference
+	// fieldDomain is a domain, the subdomains of a replaced domain are replaced as well
+	fieldDomain
+	// fieldLogin is a login name, the domain after the @ is replaced
+	fieldLogin
+	// fieldURL is an url, its host is replaced
+	fieldURL
+)
+
+// eventFields are the json names of the payload fields containing ids or domains,
+// nested fields are separated by a dot.
+// The values of the fields are either strings or lists of strings.
+type eventFields map[string]fieldKind
+
+// payloadFields are the fields of the payloads by the prefix of the event types,
+// the fields of an event type are the ones of its longest prefix.
+// The other fields of the payloads are imported as they are (e.g. metadata, hashes and e-mail addresses).
+var payloadFields = map[string]eventFields{
+	"action.": {
+		"targetUrl": fieldURL,
+	},
+	"device.authorization.": {},
+	"iam.idp.": {
+		"idpConfigId": fieldID,
+	},
+	"idpintent.": {
+		"idpId":      fieldID,
+		"userId":     fieldID,
+		"successURL": fieldURL,
+		"failureURL": fieldURL,
+	},
+	"instance.": {
+		"id":                 fieldID,
+		"iamProjectId":       fieldID,
+		"orgId":              fieldID,
+		"appId":              fieldID,
+		"idpConfigId":        fieldID,
+		"userId":             fieldID,
+		"domain":             fieldDomain,
+		"allowedOrigins":     fieldURL,
+		"defaultRedirectURI": fieldURL,
+		"tosLink":            fieldURL,
+		"privacyLink":        fieldURL,
+		"helpLink":           fieldURL,
+	},
+	"key_pair.": {},
+	"milestone.": {
+		"primaryDomain":  fieldDomain,
+		"externalDomain": fieldDomain,
+	},
+	"org.": {
+		"id":                 fieldID,
+		"idpConfigId":        fieldID,
+		"userId":             fieldID,
+		"actionID":           fieldID,
+		"actionIDs":          fieldID,
+		"domain":             fieldDomain,
+		"defaultRedirectURI": fieldURL,
+		"tosLink":            fieldURL,
+		"privacyLink":        fieldURL,
+		"helpLink":           fieldURL,
+	},
+	"project.": {
+		"appId":                  fieldID,
+		"applicationId":          fieldID,
+		"grantId":                fieldID,
+		"grantedOrgId":           fieldID,
+		"userId":                 fieldID,
+		"keyId":                  fieldID,
+		"redirectUris":           fieldURL,
+		"postLogoutRedirectUris": fieldURL,
+		"additionalOrigins":      fieldURL,
+		"backChannelLogoutURI":   fieldURL,
+	},
+	"quota.": {
+		"id":      fieldID,
+		"callURL": fieldURL,
+	},
+	"session.": {
+		"userID":  fieldID,
+		"tokenID": fieldID,
+		"domain":  fieldDomain,
+	},
+	"user.": {
+		"id":                                  fieldID,
+		"userId":                              fieldID,
+		"actorUserId":                         fieldID,
+		"webAuthNTokenId":                     fieldID,
+		"idpConfigId":                         fieldID,
+		"keyId":                               fieldID,
+		"tokenId":                             fieldID,
+		"refreshTokenID":                      fieldID,
+		"applicationId":                       fieldID,
+		"grantId":                             fieldID,
+		"projectId":                           fieldID,
+		"authRequestInfo.selectedIDPConfigID": fieldID,
+		"sessionId":                           fieldReference,
+		"audience":                            fieldReference,
+		"userName":                            fieldLogin,
+		"rpID":                                fieldDomain,
+	},
+	"webhook.": {
+		"aggregateId":        fieldReference,
+		"eventResourceOwner": fieldReference,
+	},
+}
+
+// fieldsOf returns the payload fields of the event type
+// and false if the event type isn't known by the archive
+func fieldsOf(eventType string) (eventFields, bool) {
+	var (
+		longest string
+		found   eventFields
+		ok      bool
+	)
+	for prefix, fields := range payloadFields {
+		if strings.HasPrefix(eventType, prefix) && len(prefix) > len(longest) {
+			longest, found, ok = prefix, fields, true
+		}
+	}
+	return found, ok
+}
+
+// constraintFields define how the fields of the unique constraints are remapped by their type
+var constraintFields = map[string]constraintKind{
+	"member":               constraintIDs,
+	"user_grant":           constraintIDs,
+	"project_grant":        constraintIDs,
+	"project_grant_member": constraintIDs,
+	"mail_text":            constraintIDs,
+	"appname":              constraintNameID,
+	"project_role":         constraintNameID,
+	"action_names":         constraintNameID,
+	"project_names":        constraintNameOwner,
+	"idp_config_names":     constraintNameOwner,
+	"usernames":            constraintLoginOwner,
+	"external_idps":        constraintIDName,
+	"org_domain":           constraintDomain,
+	"instance_domain":      constraintDomain,
+	"org_name":             constraintPlain,
+	"entity_ids":           constraintPlain,
+	"secret_generator":     constraintPlain,
+	"quota_units":          constraintPlain,
+	"quota_notification":   constraintPlain,
+	"user_code":            constraintPlain,
+	"device_code":          constraintPlain,
+}
+
+type constraintKind int
+
+const (
+	// constraintPlain doesn't contain ids or domains
+	constraintPlain constraintKind = iota + 1
+	// constraintIDs are ids separated by colons (e.g. the aggregate id and user id of a member)
+	constraintIDs
+	// constraintNameID is a name followed by a colon and an id (e.g. the name and project id of an app)
+	constraintNameID
+	// constraintNameOwner is a name directly followed by the id of the resource owner
+	constraintNameOwner
+	// constraintLoginOwner is a login name, optionally followed by the id of the resource owner
+	constraintLoginOwner
+	// constraintIDName is an id directly followed by a name (e.g. the idp id and the external user id of a link)
+	constraintIDName
+	// constraintDomain is a domain
+	constraintDomain
+)
+
+// remapper replaces the ids and domains of an archive
+// in the known fields of its events and unique constraints
+type remapper struct {
+	ids map[string]string
+	// idLengths are the distinct lengths of the ids, longest first,
+	// to find the ids which are directly followed or preceded by a name
+	idLengths []int
+	domains   []domainMapping
+}
+
+type domainMapping struct {
+	// old is lower case, the domains are matched case insensitive
+	old string
+	new string
+}
+
+// newRemapper replaces the keys of ids and domains with their values.
+// Longer domains take precedence, so a domain is replaced before one of its parent domains.
+func newRemapper(ids, domains map[string]string) *remapper {
+	r := &remapper{
+		ids:     make(map[string]string, len(ids)),
+		domains: make([]domainMapping, 0, len(domains)),
+	}
+	lengths := make(map[int]bool)
+	for oldID, newID := range ids {
+		if oldID == "" {
+			continue
+		}
+		r.ids[oldID] = newID
+		lengths[len(oldID)] = true
+	}
+	for length := range lengths {
+		r.idLengths = append(r.idLengths, length)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(r.idLengths)))
+	for oldDomain, newDomain := range domains {
+		if oldDomain == "" || oldDomain == newDomain {
+			continue
+		}
+		r.domains = append(r.domains, domainMapping{old: strings.ToLower(oldDomain), new: newDomain})
+	}
+	sort.Slice(r.domains, func(i, j int) bool {
+		if len(r.domains[i].old) != len(r.domains[j].old) {
+			return len(r.domains[i].old) > len(r.domains[j].old)
+		}
+		return r.domains[i].old < r.domains[j].old
+	})
+	return r
+}
+
+// id replaces the id if it's an id of the archive
+func (r *remapper) id(id string) string {
+	if newID, ok := r.ids[id]; ok {
+		return newID
+	}
+	return id
+}
+
+// domain replaces the domain or its parent domain
+func (r *remapper) domain(domain string) string {
+	lower := strings.ToLower(domain)
+	for _, mapping := range r.domains {
+		if lower == mapping.old {
+			return mapping.new
+		}
+		if strings.HasSuffix(lower, "."+mapping.old) {
+			return domain[:len(domain)-len(mapping.old)] + mapping.new
+		}
+	}
+	return domain
+}
+
+// login replaces the domain of a login name
+func (r *remapper) login(login string) string {
+	i := strings.LastIndex(login, "@")
+	if i < 0 {
+		return login
+	}
+	return login[:i+1] + r.domain(login[i+1:])
+}
+
+// url replaces the host of the url, other parts of the url are kept
+func (r *remapper) url(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	host := r.domain(parsed.Hostname())
+	if host == parsed.Hostname() {
+		return rawURL
+	}
+	if port := parsed.Port(); port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	parsed.Host = host
+	return parsed.String()
+}
+
+func (r *remapper) field(kind fieldKind, value string) string {
+	switch kind {
+	case fieldID, fieldReference:
+		return r.id(value)
+	case fieldDomain:
+		return r.domain(value)
+	case fieldLogin:
+		return r.login(value)
+	case fieldURL:
+		return r.url(value)
+	default:
+		return value
+	}
+}
+
+// constraint replaces the ids and domains of the field of a unique constraint
+func (r *remapper) constraint(constraint *UniqueConstraint) (string, error) {
+	kind, ok := constraintFields[constraint.Type]
+	if !ok {
+		return "", caos_errs.ThrowInvalidArgumentf(nil, "ARCHI-Rm3uc", "unique constraint type %s is unknown", constraint.Type)
+	}
+	field := constraint.Field
+	switch kind {
+	case constraintIDs:
+		parts := strings.Split(field, ":")
+		for i, part := range parts {
+			parts[i] = r.id(part)
+		}
+		return strings.Join(parts, ":"), nil
+	case constraintNameID:
+		i := strings.LastIndex(field, ":")
+		if i < 0 {
+			return field, nil
+		}
+		return field[:i+1] + r.id(field[i+1:]), nil
+	case constraintNameOwner:
+		name, owner := r.splitID(field, false)
+		return name + owner, nil
+	case constraintLoginOwner:
+		login, owner := r.splitID(field, false)
+		return r.login(login) + owner, nil
+	case constraintIDName:
+		name, id := r.splitID(field, true)
+		return id + name, nil
+	case constraintDomain:
+		return r.domain(field), nil
+	default:
+		return field, nil
+	}
+}
+
+// splitID splits the id of the archive at the end (or the start) of the field from the rest of the field.
+// The remapped id is returned, it's empty if the field doesn't end (or start) with an id.
+func (r *remapper) splitID(field string, atStart bool) (rest, id string) {
+	for _, length := range r.idLengths {
+		if length >= len(field) {
+			continue
+		}
+		if atStart {
+			if newID, ok := r.ids[field[:length]]; ok {
+				return field[length:], newID
+			}
+			continue
+		}
+		if newID, ok := r.ids[field[len(field)-length:]]; ok {
+			return field[:len(field)-length], newID
+		}
+	}
+	return field, ""
+}
+
+// payload replaces the ids and domains in the fields of the event type,
+// the other fields, numbers and the structure of the payload are kept
+func (r *remapper) payload(eventType string, data []byte) ([]byte, error) {
+	fields, ok := fieldsOf(eventType)
+	if !ok {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "ARCHI-Rm4et", "event type %s is unknown", eventType)
+	}
+	if len(data) == 0 || len(fields) == 0 {
+		return data, nil
+	}
+	payload, err := decodePayload(data)
+	if err != nil {
+		return nil, err
+	}
+	walkFields(payload, fields, r.field)
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(payload); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Rm2pe", "unable to marshal payload")
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func decodePayload(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "ARCHI-Rm1pd", "unable to unmarshal payload")
+	}
+	return payload, nil
+}
+
+// walkFields replaces the string values of the fields of the payload by the result of replace
+func walkFields(payload interface{}, fields eventFields, replace func(kind fieldKind, value string) string) {
+	object, ok := payload.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for name, kind := range fields {
+		walkField(object, strings.Split(name, "."), kind, replace)
+	}
+}
+
+func walkField(object map[string]interface{}, path []string, kind fieldKind, replace func(kind fieldKind, value string) string) {
+	value, ok := object[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) > 1 {
+		if nested, ok := value.(map[string]interface{}); ok {
+			walkField(nested, path[1:], kind, replace)
+		}
+		return
+	}
+	switch v := value.(type) {
+	case string:
+		object[path[0]] = replace(kind, v)
+	case []interface{}:
+		for i, item := range v {
+			if s, ok := item.(string); ok {
+				v[i] = replace(kind, s)
+			}
+		}
+	}
+}
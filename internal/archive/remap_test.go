@@ -0,0 +1,223 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+func Test_payloadFields_eventTypes(t *testing.T) {
+	es := eventstore.NewEventstore(eventstore.TestConfig(nil))
+	query.RegisterEventMappers(es)
+	quota.RegisterEventMappers(es)
+	for _, eventType := range es.EventTypes() {
+		if _, ok := fieldsOf(eventType); !ok {
+			t.Errorf("event type %s has no payload fields", eventType)
+		}
+	}
+}
+
+func Test_remapper_domain(t *testing.T) {
+	remap := newRemapper(nil, map[string]string{
+		"Acme.com":          "acme.test",
+		"login.acme.com":    "auth.acme.test",
+		"unchanged.example": "unchanged.example",
+	})
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "acme.com", want: "acme.test"},
+		{in: "ACME.com", want: "acme.test"},
+		{in: "Org.Acme.COM", want: "Org.acme.test"},
+		{in: "login.acme.com", want: "auth.acme.test"},
+		{in: "notacme.com", want: "notacme.com"},
+		{in: "acme.com.evil", want: "acme.com.evil"},
+		{in: "unchanged.example", want: "unchanged.example"},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := remap.domain(tt.in); got != tt.want {
+				t.Errorf("domain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_remapper_payload(t *testing.T) {
+	remap := newRemapper(
+		map[string]string{
+			"211111111111111111": "311111111111111111",
+			"222222222222222222": "322222222222222222",
+		},
+		map[string]string{"acme.com": "acme.test"},
+	)
+	tests := []struct {
+		name      string
+		eventType string
+		payload   string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "empty",
+			eventType: "user.human.added",
+			payload:   "",
+			want:      "",
+		},
+		{
+			name:      "null",
+			eventType: "user.human.added",
+			payload:   "null",
+			want:      "null",
+		},
+		{
+			name:      "unknown event type",
+			eventType: "unknown.added",
+			payload:   `{"userId":"211111111111111111"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "no fields, payload kept",
+			eventType: "key_pair.added",
+			payload:   `{"usage": 0, "privateKey": {"Crypted": "211111111111111111"}}`,
+			want:      `{"usage": 0, "privateKey": {"Crypted": "211111111111111111"}}`,
+		},
+		{
+			name:      "login name, e-mail kept",
+			eventType: "user.human.added",
+			payload:   `{"userName":"211111111111111111@ACME.com","email":"gigi.acme.com@acme.com","firstName":"211111111111111111"}`,
+			want:      `{"email":"gigi.acme.com@acme.com","firstName":"211111111111111111","userName":"211111111111111111@acme.test"}`,
+		},
+		{
+			name:      "hash kept",
+			eventType: "user.human.password.changed",
+			payload:   `{"encodedHash":"$2a$14$211111111111111111acme.com","userAgentID":"211111111111111111"}`,
+			want:      `{"encodedHash":"$2a$14$211111111111111111acme.com","userAgentID":"211111111111111111"}`,
+		},
+		{
+			name:      "metadata kept",
+			eventType: "org.metadata.set",
+			payload:   `{"key":"211111111111111111","value":"aHR0cHM6Ly9hY21lLmNvbQ=="}`,
+			want:      `{"key":"211111111111111111","value":"aHR0cHM6Ly9hY21lLmNvbQ=="}`,
+		},
+		{
+			name:      "mixed case domain",
+			eventType: "org.domain.added",
+			payload:   `{"domain":"Shop.ACME.com"}`,
+			want:      `{"domain":"Shop.acme.test"}`,
+		},
+		{
+			name:      "sub entity ids and urls",
+			eventType: "project.application.config.oidc.added",
+			payload:   `{"appId":"222222222222222222","clientId":"211111111111111111@acme","redirectUris":["https://acme.com:8080/cb?next=https://acme.com","https://other.example/acme.com"],"expiry":123456789012345678}`,
+			want:      `{"appId":"322222222222222222","clientId":"211111111111111111@acme","expiry":123456789012345678,"redirectUris":["https://acme.test:8080/cb?next=https://acme.com","https://other.example/acme.com"]}`,
+		},
+		{
+			name:      "nested fields and lists",
+			eventType: "user.human.password.check.succeeded",
+			payload:   `{"authRequestInfo":{"id":"211111111111111111","selectedIDPConfigID":"222222222222222222"}}`,
+			want:      `{"authRequestInfo":{"id":"211111111111111111","selectedIDPConfigID":"322222222222222222"}}`,
+		},
+		{
+			name:      "references",
+			eventType: "user.token.added",
+			payload:   `{"tokenId":"211111111111111111","audience":["222222222222222222","client@acme"]}`,
+			want:      `{"audience":["322222222222222222","client@acme"],"tokenId":"311111111111111111"}`,
+		},
+		{
+			name:      "no json",
+			eventType: "user.human.added",
+			payload:   `{"userId"`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := remap.payload(tt.eventType, []byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("payload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("payload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_remapper_constraint(t *testing.T) {
+	remap := newRemapper(
+		map[string]string{
+			"211111111111111111": "311111111111111111",
+			"222222222222222222": "322222222222222222",
+			"custom":             "custom",
+		},
+		map[string]string{"acme.com": "acme.test"},
+	)
+	tests := []struct {
+		name       string
+		constraint *UniqueConstraint
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "unknown type",
+			constraint: &UniqueConstraint{Type: "unknown", Field: "211111111111111111"},
+			wantErr:    true,
+		},
+		{
+			name:       "plain",
+			constraint: &UniqueConstraint{Type: "org_name", Field: "211111111111111111 acme.com"},
+			want:       "211111111111111111 acme.com",
+		},
+		{
+			name:       "ids",
+			constraint: &UniqueConstraint{Type: "user_grant", Field: "211111111111111111:222222222222222222:custom:"},
+			want:       "311111111111111111:322222222222222222:custom:",
+		},
+		{
+			name:       "name and id",
+			constraint: &UniqueConstraint{Type: "appname", Field: "211111111111111111:app:222222222222222222"},
+			want:       "211111111111111111:app:322222222222222222",
+		},
+		{
+			name:       "name and owner",
+			constraint: &UniqueConstraint{Type: "project_names", Field: "acme.com211111111111111111"},
+			want:       "acme.com311111111111111111",
+		},
+		{
+			name:       "login and owner",
+			constraint: &UniqueConstraint{Type: "usernames", Field: "gigi@acme.com211111111111111111"},
+			want:       "gigi@acme.test311111111111111111",
+		},
+		{
+			name:       "login without owner",
+			constraint: &UniqueConstraint{Type: "usernames", Field: "gigi@acme.com"},
+			want:       "gigi@acme.test",
+		},
+		{
+			name:       "id and name",
+			constraint: &UniqueConstraint{Type: "external_idps", Field: "222222222222222222211111111111111111"},
+			want:       "322222222222222222211111111111111111",
+		},
+		{
+			name:       "domain",
+			constraint: &UniqueConstraint{Type: "instance_domain", Field: "login.acme.com"},
+			want:       "login.acme.test",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := remap.constraint(tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("constraint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("constraint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ type AuthRequestRepository interface {
 	AuthRequestByCode(ctx context.Context, code string) (*domain.AuthRequest, error)
 	SaveAuthCode(ctx context.Context, id, code, userAgentID string) error
 	DeleteAuthRequest(ctx context.Context, id string) error
+	CreatePushedAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) (*domain.PushedAuthRequest, error)
+	PushedAuthRequestByID(ctx context.Context, id string) (*domain.PushedAuthRequest, error)
 
 	CheckLoginName(ctx context.Context, id, loginName, userAgentID string) error
 	CheckExternalUserLogin(ctx context.Context, authReqID, userAgentID string, user *domain.ExternalUser, info *domain.BrowserInfo) error
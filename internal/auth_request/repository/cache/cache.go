@@ -55,6 +55,45 @@ func (c *AuthRequestCache) DeleteAuthRequest(ctx context.Context, id string) err
 	return nil
 }
 
+// SavePushedAuthRequest stores the pushed request and removes the expired ones of the instance
+func (c *AuthRequestCache) SavePushedAuthRequest(_ context.Context, request *domain.PushedAuthRequest) error {
+	b, err := json.Marshal(request.Request)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Pq2fA", "Errors.Internal")
+	}
+	_, err = c.client.Exec("DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 AND expiration < now()", request.InstanceID)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Pq2fB", "Errors.Internal")
+	}
+	_, err = c.client.Exec("INSERT INTO auth.pushed_auth_requests (id, instance_id, client_id, request, creation_date, expiration) VALUES($1, $2, $3, $4, $5, $6)",
+		request.ID, request.InstanceID, request.ClientID, b, request.CreationDate, request.Expiration)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Pq2fC", "Errors.Internal")
+	}
+	return nil
+}
+
+// ConsumePushedAuthRequest returns the pushed request and deletes it, so it can only be used once
+func (c *AuthRequestCache) ConsumePushedAuthRequest(ctx context.Context, id string) (*domain.PushedAuthRequest, error) {
+	request := &domain.PushedAuthRequest{
+		ID:         id,
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+	}
+	var b []byte
+	err := c.client.QueryRow("DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 AND id = $2 RETURNING client_id, request, creation_date, expiration", request.InstanceID, id).
+		Scan(&request.ClientID, &b, &request.CreationDate, &request.Expiration)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, caos_errs.ThrowNotFound(err, "CACHE-Pq2fD", "Errors.AuthRequest.NotFound")
+		}
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Pq2fE", "Errors.Internal")
+	}
+	if err = json.Unmarshal(b, &request.Request); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Pq2fF", "Errors.Internal")
+	}
+	return request, nil
+}
+
 func (c *AuthRequestCache) getAuthRequest(key, value, instanceID string) (*domain.AuthRequest, error) {
 	var b []byte
 	var requestType domain.AuthRequestType
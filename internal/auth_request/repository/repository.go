@@ -14,4 +14,7 @@ type AuthRequestCache interface {
 	SaveAuthRequest(ctx context.Context, request *domain.AuthRequest) error
 	UpdateAuthRequest(ctx context.Context, request *domain.AuthRequest) error
 	DeleteAuthRequest(ctx context.Context, id string) error
+
+	SavePushedAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) error
+	ConsumePushedAuthRequest(ctx context.Context, id string) (*domain.PushedAuthRequest, error)
 }
@@ -15,11 +15,14 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/view"
+	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/crypto"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
+	user_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	usr_model "github.com/zitadel/zitadel/internal/user/model"
 	usr_view "github.com/zitadel/zitadel/internal/user/repository/view"
@@ -33,6 +36,8 @@ type TokenVerifierRepo struct {
 	View                 *view.View
 	Query                *query.Queries
 	ExternalSecure       bool
+	// Cache stores the tokens of the view if set
+	Cache cache.Cache
 }
 
 func (repo *TokenVerifierRepo) Health() error {
@@ -44,7 +49,7 @@ func (repo *TokenVerifierRepo) tokenByID(ctx context.Context, tokenID, userID st
 	defer func() { span.EndWithError(err) }()
 
 	instanceID := authz.GetInstance(ctx).InstanceID()
-	token, viewErr := repo.View.TokenByIDs(tokenID, userID, instanceID)
+	token, viewErr := repo.tokenView(tokenID, userID, instanceID)
 	if viewErr != nil && !caos_errs.IsNotFound(viewErr) {
 		return nil, viewErr
 	}
@@ -84,32 +89,74 @@ func (repo *TokenVerifierRepo) tokenByID(ctx context.Context, tokenID, userID st
 	return model.TokenViewToModel(token), nil
 }
 
-func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error) {
+// tokenView returns the token of the view, which is cached if a cache is configured.
+// Outdated tokens don't have to be invalidated,
+// because the events of the user created after the sequence of the token are applied in any case
+func (repo *TokenVerifierRepo) tokenView(tokenID, userID, instanceID string) (*model.TokenView, error) {
+	if repo.Cache == nil {
+		return repo.View.TokenByIDs(tokenID, userID, instanceID)
+	}
+	key := tokenCacheKey(instanceID, userID, tokenID)
+	token := new(model.TokenView)
+	if err := repo.Cache.Get(key, token); err == nil {
+		return token, nil
+	}
+	token, err := repo.View.TokenByIDs(tokenID, userID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	err = repo.Cache.Set(key, token)
+	logging.WithFields("instanceID", instanceID, "tokenID", tokenID).OnError(err).Warn("unable to cache token")
+	return token, nil
+}
+
+// InvalidateTokens removes the removed tokens from the cache
+func (repo *TokenVerifierRepo) InvalidateTokens(_ context.Context, events []eventstore.Event) {
+	for _, event := range events {
+		var tokenID string
+		switch e := event.(type) {
+		case *user_repo.UserTokenRemovedEvent:
+			tokenID = e.TokenID
+		case *user_repo.PersonalAccessTokenRemovedEvent:
+			tokenID = e.TokenID
+		default:
+			continue
+		}
+		err := repo.Cache.Delete(tokenCacheKey(event.Aggregate().InstanceID, event.Aggregate().ID, tokenID))
+		logging.WithFields("tokenID", tokenID).OnError(err).Warn("unable to invalidate cached token")
+	}
+}
+
+func tokenCacheKey(instanceID, userID, tokenID string) string {
+	return "token:" + instanceID + ":" + userID + ":" + tokenID
+}
+
+func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner, jkt string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	tokenID, subject, ok := repo.getTokenIDAndSubject(ctx, tokenString)
 	if !ok {
-		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "APP-Reb32", "invalid token")
+		return "", "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "APP-Reb32", "invalid token")
 	}
 	_, tokenSpan := tracing.NewNamedSpan(ctx, "token")
 	token, err := repo.tokenByID(ctx, tokenID, subject)
 	tokenSpan.EndWithError(err)
 	if err != nil {
-		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-BxUSiL", "invalid token")
+		return "", "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-BxUSiL", "invalid token")
 	}
 	if !token.Expiration.After(time.Now().UTC()) {
-		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-k9KS0", "invalid token")
+		return "", "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-k9KS0", "invalid token")
 	}
 	if token.IsPAT {
-		return token.UserID, "", "", "", token.ResourceOwner, nil
+		return token.UserID, "", "", "", token.ResourceOwner, "", nil
 	}
 	for _, aud := range token.Audience {
 		if verifierClientID == aud || projectID == aud {
-			return token.UserID, token.UserAgentID, token.ApplicationID, token.PreferredLanguage, token.ResourceOwner, nil
+			return token.UserID, token.UserAgentID, token.ApplicationID, token.PreferredLanguage, token.ResourceOwner, token.DPoPJKT, nil
 		}
 	}
-	return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "APP-Zxfako", "invalid audience")
+	return "", "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "APP-Zxfako", "invalid audience")
 }
 
 func (repo *TokenVerifierRepo) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error) {
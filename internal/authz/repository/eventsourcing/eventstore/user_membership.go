@@ -25,26 +25,7 @@ func (repo *UserMembershipRepo) SearchMyMemberships(ctx context.Context, orgID s
 func (repo *UserMembershipRepo) searchUserMemberships(ctx context.Context, orgID string) (_ []*query.Membership, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	ctxData := authz.GetCtxData(ctx)
-	userIDQuery, err := query.NewMembershipUserIDQuery(ctxData.UserID)
-	if err != nil {
-		return nil, err
-	}
-	orgIDsQuery, err := query.NewMembershipResourceOwnersSearchQuery(orgID, authz.GetInstance(ctx).InstanceID())
-	if err != nil {
-		return nil, err
-	}
-	grantedIDQuery, err := query.NewMembershipGrantedOrgIDSearchQuery(orgID)
-	if err != nil {
-		return nil, err
-	}
-	memberships, err := repo.Queries.Memberships(ctx, &query.MembershipSearchQuery{
-		Queries: []query.SearchQuery{userIDQuery, query.Or(orgIDsQuery, grantedIDQuery)},
-	}, false)
-	if err != nil {
-		return nil, err
-	}
-	return memberships.Memberships, nil
+	return repo.Queries.PermissionMemberships(ctx, authz.GetCtxData(ctx).UserID, orgID)
 }
 
 func userMembershipToMembership(membership *query.Membership) *authz.Membership {
@@ -6,11 +6,14 @@ import (
 	"github.com/zitadel/zitadel/internal/authz/repository"
 	"github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
 	authz_view "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/view"
+	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	eventstore2 "github.com/zitadel/zitadel/internal/eventstore"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
 type EsRepository struct {
@@ -18,8 +21,8 @@ type EsRepository struct {
 	eventstore.TokenVerifierRepo
 }
 
-func Start(queries *query.Queries, dbClient *database.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure, allowOrderByCreationDate bool) (repository.Repository, error) {
-	es, err := v1.Start(dbClient, allowOrderByCreationDate)
+func Start(ctx context.Context, queries *query.Queries, dbClient *database.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure, allowOrderByCreationDate bool, tokenCache cache.Cache) (repository.Repository, error) {
+	es, err := v1.Start(dbClient, allowOrderByCreationDate, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +33,7 @@ func Start(queries *query.Queries, dbClient *database.DB, keyEncryptionAlgorithm
 		return nil, err
 	}
 
-	return &EsRepository{
+	repo := &EsRepository{
 		eventstore.UserMembershipRepo{
 			Queries: queries,
 		},
@@ -40,8 +43,18 @@ func Start(queries *query.Queries, dbClient *database.DB, keyEncryptionAlgorithm
 			View:                 view,
 			Query:                queries,
 			ExternalSecure:       externalSecure,
+			Cache:                tokenCache,
 		},
-	}, nil
+	}
+	if tokenCache != nil {
+		cache.Invalidate(ctx, map[eventstore2.AggregateType][]eventstore2.EventType{
+			user.AggregateType: {
+				user.UserTokenRemovedType,
+				user.PersonalAccessTokenRemovedType,
+			},
+		}, repo.TokenVerifierRepo.InvalidateTokens)
+	}
+	return repo, nil
 }
 
 func (repo *EsRepository) Health(ctx context.Context) error {
@@ -0,0 +1,151 @@
+// Package breach checks passwords against lists of breached passwords
+// using the k-anonymity model of Have I Been Pwned:
+// only the first 5 characters of the SHA-1 hash of the password leave the checker.
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	ModeDisabled = ""
+	ModeAPI      = "api"
+	ModeFile     = "file"
+
+	prefixLength = 5
+)
+
+type Config struct {
+	// Mode is either api, file or empty to disable the check
+	Mode string
+	// FailOpen accepts the passwords if the check fails (e.g. the API is not reachable),
+	// they are rejected otherwise
+	FailOpen bool
+	API      APIConfig
+	File     FileConfig
+}
+
+type APIConfig struct {
+	// URL of the range endpoint, the hash prefix is appended
+	URL     string
+	Timeout time.Duration
+	// AddPadding requests padded responses, so the size of the response doesn't reveal the prefix
+	AddPadding bool
+}
+
+type FileConfig struct {
+	// Path to the directory of the range files,
+	// which are named by the hash prefix (e.g. 21BD1.txt) and contain the lines <hash suffix>:<count>
+	Path string
+}
+
+// Checker returns true if the password is part of a breach
+type Checker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// NewChecker returns the checker of the configured mode,
+// nil is returned if the check is disabled
+func NewChecker(config Config, client *http.Client) (Checker, error) {
+	switch strings.ToLower(config.Mode) {
+	case ModeDisabled:
+		return nil, nil
+	case ModeAPI:
+		if config.API.URL == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "BREACH-Ap1ur", "breached passwords api url is missing")
+		}
+		if client == nil {
+			client = http.DefaultClient
+		}
+		return &apiChecker{config: config.API, client: client}, nil
+	case ModeFile:
+		if info, err := os.Stat(config.File.Path); err != nil || !info.IsDir() {
+			return nil, errors.ThrowInvalidArgument(err, "BREACH-Fi1di", "breached passwords path must be a directory")
+		}
+		return &fileChecker{path: config.File.Path}, nil
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "BREACH-Mo1de", "breached passwords mode %s not supported", config.Mode)
+	}
+}
+
+type apiChecker struct {
+	config APIConfig
+	client *http.Client
+}
+
+func (c *apiChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	prefix, suffix := hashPassword(password)
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.config.URL, "/")+"/"+prefix, nil)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "BREACH-Ap2rq", "unable to create request")
+	}
+	if c.config.AddPadding {
+		req.Header.Set("Add-Padding", "true")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, errors.ThrowUnavailable(err, "BREACH-Ap3do", "breached passwords api not reachable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.ThrowUnavailablef(nil, "BREACH-Ap4st", "breached passwords api returned status %d", resp.StatusCode)
+	}
+	return rangeContains(resp.Body, suffix)
+}
+
+type fileChecker struct {
+	path string
+}
+
+func (c *fileChecker) IsBreached(_ context.Context, password string) (bool, error) {
+	prefix, suffix := hashPassword(password)
+	file, err := os.Open(filepath.Join(c.path, prefix+".txt"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.ThrowInternal(err, "BREACH-Fi2op", "unable to open range file")
+	}
+	defer file.Close()
+	return rangeContains(file, suffix)
+}
+
+// hashPassword returns the prefix and suffix of the upper case hex encoded SHA-1 hash
+func hashPassword(password string) (prefix, suffix string) {
+	hash := sha1.Sum([]byte(password))
+	encoded := strings.ToUpper(hex.EncodeToString(hash[:]))
+	return encoded[:prefixLength], encoded[prefixLength:]
+}
+
+// rangeContains searches the suffix in a range of lines <hash suffix>:<count>,
+// padding entries with a count of 0 are ignored
+func rangeContains(r io.Reader, suffix string) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		hash, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !strings.EqualFold(hash, suffix) {
+			continue
+		}
+		return count != "0", nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.ThrowInternal(err, "BREACH-Ra1sc", "unable to read range")
+	}
+	return false, nil
+}
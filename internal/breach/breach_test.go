@@ -0,0 +1,142 @@
+package breach
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const passwordRange = "003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD9:0\r\n"
+
+func Test_apiChecker_IsBreached(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		status   int
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name:     "breached",
+			password: "password",
+			status:   http.StatusOK,
+			want:     true,
+		},
+		{
+			name:     "not breached",
+			password: "not breached password",
+			status:   http.StatusOK,
+			want:     false,
+		},
+		{
+			name:     "api error",
+			password: "password",
+			status:   http.StatusServiceUnavailable,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedPath, padding string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestedPath = r.URL.Path
+				padding = r.Header.Get("Add-Padding")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(passwordRange))
+			}))
+			defer server.Close()
+
+			checker, err := NewChecker(Config{Mode: ModeAPI, API: APIConfig{URL: server.URL + "/range/", AddPadding: true}}, server.Client())
+			require.NoError(t, err)
+			got, err := checker.IsBreached(context.Background(), tt.password)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			prefix, _ := hashPassword(tt.password)
+			assert.Equal(t, "/range/"+prefix, requestedPath)
+			assert.Equal(t, "true", padding)
+		})
+	}
+}
+
+func Test_fileChecker_IsBreached(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(passwordRange), 0o600))
+	checker, err := NewChecker(Config{Mode: ModeFile, File: FileConfig{Path: dir}}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{
+			name:     "breached",
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "range file missing",
+			password: "not breached password",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.IsBreached(context.Background(), tt.password)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			config:  Config{},
+			wantNil: true,
+		},
+		{
+			name:    "api without url",
+			config:  Config{Mode: ModeAPI},
+			wantErr: true,
+		},
+		{
+			name:    "file without directory",
+			config:  Config{Mode: ModeFile, File: FileConfig{Path: filepath.Join(t.TempDir(), "missing")}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			config:  Config{Mode: "unknown"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewChecker(tt.config, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+		})
+	}
+}
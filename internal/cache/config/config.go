@@ -6,6 +6,7 @@ import (
 	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/cache/bigcache"
 	"github.com/zitadel/zitadel/internal/cache/fastcache"
+	"github.com/zitadel/zitadel/internal/cache/redis"
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
@@ -17,6 +18,7 @@ type CacheConfig struct {
 var caches = map[string]func() cache.Config{
 	"bigcache":  func() cache.Config { return &bigcache.Config{} },
 	"fastcache": func() cache.Config { return &fastcache.Config{} },
+	"redis":     func() cache.Config { return &redis.Config{} },
 }
 
 func (c *CacheConfig) UnmarshalJSON(data []byte) error {
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+	"github.com/zitadel/zitadel/internal/cache/fastcache"
+	"github.com/zitadel/zitadel/internal/cache/redis"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// ConnectorConfig configures the connector of a cache,
+// in contrast to [CacheConfig] it can be decoded from the runtime configuration
+type ConnectorConfig struct {
+	// Connector is the type of the connector used,
+	// possible values are bigcache, fastcache and redis.
+	// If empty no cache is used
+	Connector string
+	Bigcache  bigcache.Config
+	Fastcache fastcache.Config
+	Redis     redis.Config
+}
+
+// NewCache returns the cache of the configured connector,
+// it returns nil if no connector is configured
+func (c *ConnectorConfig) NewCache() (cache.Cache, error) {
+	if c == nil {
+		return nil, nil
+	}
+	switch c.Connector {
+	case "":
+		return nil, nil
+	case "bigcache":
+		return c.Bigcache.NewCache()
+	case "fastcache":
+		return c.Fastcache.NewCache()
+	case "redis":
+		return c.Redis.NewCache()
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "CONFI-Cn3ct", "unknown cache connector %s", c.Connector)
+}
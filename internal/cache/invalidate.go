@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const invalidationQueueSize = 100
+
+// Invalidate calls invalidate with the subscribed events pushed by this ZITADEL process until the context is done.
+// The events received in the meantime are passed at once, so the entries can be invalidated in batches.
+// Events pushed by other processes are not received,
+// so the entries of in-process caches (bigcache, fastcache) on other processes only expire,
+// whereas the entries of a network cache (redis) are invalidated for all processes
+func Invalidate(ctx context.Context, types map[eventstore.AggregateType][]eventstore.EventType, invalidate func(ctx context.Context, events []eventstore.Event)) {
+	queue := make(chan eventstore.Event, invalidationQueueSize)
+	sub := eventstore.SubscribeEventTypes(queue, types)
+
+	var (
+		mu      sync.Mutex
+		pending []eventstore.Event
+	)
+	received := make(chan struct{}, 1)
+	// the events are sent while the subscriptions are locked,
+	// so they are collected without waiting for the invalidation
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-queue:
+				mu.Lock()
+				pending = append(pending, event)
+				mu.Unlock()
+				select {
+				case received <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-received:
+				mu.Lock()
+				events := pending
+				pending = nil
+				mu.Unlock()
+				invalidate(ctx, events)
+			}
+		}
+	}()
+}
@@ -0,0 +1,235 @@
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultPoolSize = 10
+	defaultTimeout  = 5 * time.Second
+)
+
+// errNil is returned if the server replies with a nil bulk string
+var errNil = errors.New("redis: nil")
+
+// serverError is an error reply of the server
+type serverError string
+
+func (e serverError) Error() string {
+	return "redis: " + string(e)
+}
+
+// client sends commands using the RESP2 protocol over a pool of connections
+type client struct {
+	config *Config
+	pool   chan *conn
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+func newClient(config *Config) *client {
+	poolSize := config.PoolSize
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+	return &client{
+		config: config,
+		pool:   make(chan *conn, poolSize),
+	}
+}
+
+func (c *client) timeout() time.Duration {
+	if c.config.Timeout > 0 {
+		return c.config.Timeout
+	}
+	return defaultTimeout
+}
+
+// do sends the command and returns the reply,
+// the connection is only reused if the reply was read completely
+func (c *client) do(args ...string) (interface{}, error) {
+	cn, err := c.conn()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cn.do(c.timeout(), args...)
+	var replyErr serverError
+	if err != nil && !errors.Is(err, errNil) && !errors.As(err, &replyErr) {
+		cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+func (c *client) conn() (*conn, error) {
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	default:
+		return c.dial()
+	}
+}
+
+func (c *client) put(cn *conn) {
+	select {
+	case c.pool <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+func (c *client) dial() (*conn, error) {
+	dialer := &net.Dialer{Timeout: c.timeout()}
+	var (
+		netConn net.Conn
+		err     error
+	)
+	if c.config.TLS {
+		host, _, _ := net.SplitHostPort(c.config.Addr)
+		netConn, err = tls.DialWithDialer(dialer, "tcp", c.config.Addr, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	} else {
+		netConn, err = dialer.Dial("tcp", c.config.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cn := &conn{
+		Conn: netConn,
+		r:    bufio.NewReader(netConn),
+		w:    bufio.NewWriter(netConn),
+	}
+	if err = c.init(cn); err != nil {
+		cn.Close()
+		return nil, err
+	}
+	return cn, nil
+}
+
+// init authenticates the connection and selects the database
+func (c *client) init(cn *conn) error {
+	if c.config.Password != "" {
+		args := []string{"AUTH", c.config.Password}
+		if c.config.Username != "" {
+			args = []string{"AUTH", c.config.Username, c.config.Password}
+		}
+		if _, err := cn.do(c.timeout(), args...); err != nil {
+			return err
+		}
+	}
+	if c.config.DB != 0 {
+		if _, err := cn.do(c.timeout(), "SELECT", strconv.Itoa(c.config.DB)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *client) close() {
+	for {
+		select {
+		case cn := <-c.pool:
+			cn.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (cn *conn) do(timeout time.Duration, args ...string) (interface{}, error) {
+	if err := cn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if err := writeCommand(cn.w, args...); err != nil {
+		return nil, err
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, err
+	}
+	return readReply(cn.r)
+}
+
+// writeCommand writes the arguments as array of bulk strings
+func writeCommand(w *bufio.Writer, args ...string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readReply reads a reply,
+// simple and bulk strings are returned as string, integers as int64 and arrays as []interface{}
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, serverError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, errNil
+		}
+		data := make([]byte, length+2)
+		if _, err = io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:length]), nil
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, errNil
+		}
+		values := make([]interface{}, length)
+		for i := range values {
+			values[i], err = readReply(r)
+			if err != nil && !errors.Is(err, errNil) {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed reply %q", line)
+	}
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type Config struct {
+	// Addr is the host:port of the redis compatible server
+	Addr     string
+	Username string
+	Password string
+	// DB is the index of the database selected after connecting
+	DB int
+	// TLS connects to the server using TLS
+	TLS bool
+	// PoolSize is the maximum amount of idle connections kept open
+	PoolSize int
+	// Timeout of dialing and of each command
+	Timeout time.Duration
+	// TTL if set, entries expire after the duration
+	TTL time.Duration
+}
+
+func (c *Config) NewCache() (cache.Cache, error) {
+	return NewRedis(c)
+}
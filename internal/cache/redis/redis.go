@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	goerrors "errors"
+	"reflect"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Redis stores the entries on a redis compatible server,
+// so the entries are shared between all ZITADEL processes
+type Redis struct {
+	client *client
+	ttl    string
+}
+
+func NewRedis(c *Config) (*Redis, error) {
+	if c.Addr == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "REDIS-Ad9sk", "address of the redis server is missing")
+	}
+	cache := &Redis{client: newClient(c)}
+	if c.TTL > 0 {
+		cache.ttl = strconv.FormatInt(c.TTL.Milliseconds(), 10)
+	}
+	if _, err := cache.client.do("PING"); err != nil {
+		cache.client.close()
+		return nil, errors.ThrowUnavailable(err, "REDIS-Pi3ng", "unable to connect to redis")
+	}
+	return cache, nil
+}
+
+func (c *Redis) Set(key string, object interface{}) error {
+	if key == "" || reflect.ValueOf(object).IsNil() {
+		return errors.ThrowInvalidArgument(nil, "REDIS-s7eKy", "key or value should not be empty")
+	}
+	var b bytes.Buffer
+	enc := gob.NewEncoder(&b)
+	if err := enc.Encode(object); err != nil {
+		return errors.ThrowInvalidArgument(err, "REDIS-E2nco", "unable to encode object")
+	}
+	args := []string{"SET", key, b.String()}
+	if c.ttl != "" {
+		args = append(args, "PX", c.ttl)
+	}
+	if _, err := c.client.do(args...); err != nil {
+		return errors.ThrowInternal(err, "REDIS-wR1te", "unable to write to cache")
+	}
+	return nil
+}
+
+func (c *Redis) Get(key string, ptrToObject interface{}) error {
+	if key == "" || reflect.ValueOf(ptrToObject).IsNil() {
+		return errors.ThrowInvalidArgument(nil, "REDIS-g3Tky", "key or value should not be empty")
+	}
+	reply, err := c.client.do("GET", key)
+	if goerrors.Is(err, errNil) {
+		return errors.ThrowNotFound(nil, "REDIS-n0tFd", "not in cache")
+	}
+	if err != nil {
+		logging.WithError(err).Info("read from cache failed")
+		return errors.ThrowInternal(err, "REDIS-r3aDd", "error in reading from cache")
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return errors.ThrowInternal(nil, "REDIS-tYp3e", "unexpected reply from cache")
+	}
+	dec := gob.NewDecoder(bytes.NewBufferString(value))
+	return dec.Decode(ptrToObject)
+}
+
+func (c *Redis) Delete(key string) error {
+	if key == "" {
+		return errors.ThrowInvalidArgument(nil, "REDIS-d3lKy", "key should not be empty")
+	}
+	if _, err := c.client.do("DEL", key); err != nil {
+		return errors.ThrowInternal(err, "REDIS-dEl3e", "unable to delete from cache")
+	}
+	return nil
+}
+
+// Close closes the idle connections
+func (c *Redis) Close() {
+	c.client.close()
+}
@@ -0,0 +1,347 @@
+package redis
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type TestStruct struct {
+	Test string
+}
+
+// testServer is a minimal stand-in of a redis server
+// supporting the commands used by the cache
+type testServer struct {
+	listener net.Listener
+	password string
+
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]string
+	conns  int
+}
+
+func newTestServer(t *testing.T, password string) *testServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	s := &testServer{
+		listener: listener,
+		password: password,
+		values:   make(map[string]string),
+		ttls:     make(map[string]string),
+	}
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		values := reply.([]interface{})
+		args := make([]string, len(values))
+		for i, value := range values {
+			args[i] = value.(string)
+		}
+		s.mu.Lock()
+		var response string
+		switch {
+		case strings.EqualFold(args[0], "AUTH"):
+			authenticated = args[len(args)-1] == s.password
+			response = "+OK\r\n"
+			if !authenticated {
+				response = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			response = "-NOAUTH Authentication required.\r\n"
+		case strings.EqualFold(args[0], "PING"):
+			response = "+PONG\r\n"
+		case strings.EqualFold(args[0], "SET"):
+			s.values[args[1]] = args[2]
+			if len(args) == 5 {
+				s.ttls[args[1]] = args[4]
+			}
+			response = "+OK\r\n"
+		case strings.EqualFold(args[0], "GET"):
+			value, ok := s.values[args[1]]
+			response = "$-1\r\n"
+			if ok {
+				response = "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+			}
+		case strings.EqualFold(args[0], "DEL"):
+			_, ok := s.values[args[1]]
+			delete(s.values, args[1])
+			response = ":0\r\n"
+			if ok {
+				response = ":1\r\n"
+			}
+		default:
+			response = "-ERR unknown command\r\n"
+		}
+		s.mu.Unlock()
+		if _, err = conn.Write([]byte(response)); err != nil {
+			return
+		}
+	}
+}
+
+func getRedisMock(t *testing.T) (*Redis, *testServer) {
+	server := newTestServer(t, "")
+	cache, err := NewRedis(&Config{Addr: server.listener.Addr().String(), TTL: time.Minute})
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	t.Cleanup(cache.Close)
+	return cache, server
+}
+
+func TestSet(t *testing.T) {
+	type args struct {
+		key   string
+		value *TestStruct
+	}
+	type res struct {
+		result  *TestStruct
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "set cache no err",
+			args: args{
+				key:   "KEY",
+				value: &TestStruct{Test: "Test"},
+			},
+			res: res{
+				result: &TestStruct{},
+			},
+		},
+		{
+			name: "key empty",
+			args: args{
+				key:   "",
+				value: &TestStruct{Test: "Test"},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set cache nil value",
+			args: args{
+				key: "KEY",
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, server := getRedisMock(t)
+			err := cache.Set(tt.args.key, tt.args.value)
+
+			if tt.res.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc != nil && !tt.res.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.errFunc != nil {
+				return
+			}
+			if err = cache.Get(tt.args.key, tt.res.result); err != nil {
+				t.Errorf("unable to read value: %v", err)
+			}
+			if tt.res.result.Test != tt.args.value.Test {
+				t.Errorf("got wrong result: %v, want %v", tt.res.result, tt.args.value)
+			}
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if ttl := server.ttls[tt.args.key]; ttl != "60000" {
+				t.Errorf("got wrong ttl: %q", ttl)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	type args struct {
+		setKey string
+		getKey string
+		value  *TestStruct
+		result *TestStruct
+	}
+	tests := []struct {
+		name    string
+		args    args
+		errFunc func(err error) bool
+	}{
+		{
+			name: "get cache no err",
+			args: args{
+				setKey: "KEY",
+				getKey: "KEY",
+				value:  &TestStruct{Test: "Test"},
+				result: &TestStruct{},
+			},
+		},
+		{
+			name: "get cache not found",
+			args: args{
+				setKey: "KEY",
+				getKey: "KEY2",
+				value:  &TestStruct{Test: "Test"},
+				result: &TestStruct{},
+			},
+			errFunc: errors.IsNotFound,
+		},
+		{
+			name: "get cache nil result",
+			args: args{
+				setKey: "KEY",
+				getKey: "KEY",
+				value:  &TestStruct{Test: "Test"},
+			},
+			errFunc: errors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, _ := getRedisMock(t)
+			if err := cache.Set(tt.args.setKey, tt.args.value); err != nil {
+				t.Fatalf("unable to set value: %v", err)
+			}
+			err := cache.Get(tt.args.getKey, tt.args.result)
+			if tt.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if tt.errFunc != nil && !tt.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.errFunc == nil && tt.args.result.Test != tt.args.value.Test {
+				t.Errorf("got wrong result: %v, want %v", tt.args.result, tt.args.value)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cache, _ := getRedisMock(t)
+	if err := cache.Set("KEY", &TestStruct{Test: "Test"}); err != nil {
+		t.Fatalf("unable to set value: %v", err)
+	}
+	if err := cache.Delete("KEY"); err != nil {
+		t.Errorf("got wrong result should not get err: %v ", err)
+	}
+	if err := cache.Get("KEY", new(TestStruct)); !errors.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	if err := cache.Delete("KEY"); err != nil {
+		t.Errorf("deleting a missing key should not fail: %v", err)
+	}
+	if err := cache.Delete(""); !errors.IsErrorInvalidArgument(err) {
+		t.Errorf("got wrong err: %v ", err)
+	}
+}
+
+func TestNewRedis(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		config   func(addr string) *Config
+		wantErr  bool
+	}{
+		{
+			name:     "authenticated",
+			password: "secret",
+			config: func(addr string) *Config {
+				return &Config{Addr: addr, Username: "zitadel", Password: "secret"}
+			},
+		},
+		{
+			name:     "wrong password",
+			password: "secret",
+			config: func(addr string) *Config {
+				return &Config{Addr: addr, Password: "wrong"}
+			},
+			wantErr: true,
+		},
+		{
+			name:     "missing password",
+			password: "secret",
+			config: func(addr string) *Config {
+				return &Config{Addr: addr}
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing address",
+			config: func(string) *Config {
+				return &Config{}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.password)
+			cache, err := NewRedis(tt.config(server.listener.Addr().String()))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRedis() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cache != nil {
+				cache.Close()
+			}
+		})
+	}
+}
+
+func TestRedis_reusesConnections(t *testing.T) {
+	cache, server := getRedisMock(t)
+	for i := 0; i < 10; i++ {
+		if err := cache.Set("KEY", &TestStruct{Test: "Test"}); err != nil {
+			t.Fatalf("unable to set value: %v", err)
+		}
+		if err := cache.Get("KEY2", new(TestStruct)); !errors.IsNotFound(err) {
+			t.Fatalf("expected not found, got %v", err)
+		}
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.conns != 1 {
+		t.Errorf("expected a single connection, got %d", server.conns)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -25,6 +26,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
 	"github.com/zitadel/zitadel/internal/static"
 	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 )
@@ -33,6 +35,7 @@ type Commands struct {
 	httpClient *http.Client
 
 	checkPermission domain.PermissionCheck
+	checkMutation   domain.MutationCheck
 	newCode         cryptoCodeFunc
 
 	eventstore     *eventstore.Eventstore
@@ -43,18 +46,23 @@ type Commands struct {
 	externalSecure bool
 	externalPort   uint16
 
-	idpConfigEncryption         crypto.EncryptionAlgorithm
-	smtpEncryption              crypto.EncryptionAlgorithm
-	smsEncryption               crypto.EncryptionAlgorithm
-	userEncryption              crypto.EncryptionAlgorithm
-	userPasswordAlg             crypto.HashAlgorithm
-	machineKeySize              int
-	applicationKeySize          int
-	domainVerificationAlg       crypto.EncryptionAlgorithm
-	domainVerificationGenerator crypto.Generator
-	domainVerificationValidator func(domain, token, verifier string, checkType api_http.CheckType) error
-	sessionTokenCreator         func(sessionID string) (id string, token string, err error)
-	sessionTokenVerifier        func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+	idpConfigEncryption            crypto.EncryptionAlgorithm
+	smtpEncryption                 crypto.EncryptionAlgorithm
+	smsEncryption                  crypto.EncryptionAlgorithm
+	userEncryption                 crypto.EncryptionAlgorithm
+	webhookEncryption              crypto.EncryptionAlgorithm
+	actionEncryption               crypto.EncryptionAlgorithm
+	userPasswordAlg                crypto.HashAlgorithm
+	breachedPasswords              breach.Checker
+	breachedPasswordsFailOpen      bool
+	machineKeySize                 int
+	applicationKeySize             int
+	domainVerificationAlg          crypto.EncryptionAlgorithm
+	domainVerificationGenerator    crypto.Generator
+	domainVerificationValidator    func(domain, token, verifier string, checkType api_http.CheckType) error
+	sessionTokenCreator            func(sessionID string) (id string, token string, err error)
+	sessionTokenVerifier           func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
 
 	multifactors         domain.MultifactorConfigs
 	webauthnConfig       *webauthn_helper.Config
@@ -76,9 +84,10 @@ func StartCommands(
 	externalDomain string,
 	externalSecure bool,
 	externalPort uint16,
-	idpConfigEncryption, otpEncryption, smtpEncryption, smsEncryption, userEncryption, domainVerificationEncryption, oidcEncryption, samlEncryption crypto.EncryptionAlgorithm,
+	idpConfigEncryption, otpEncryption, smtpEncryption, smsEncryption, userEncryption, domainVerificationEncryption, oidcEncryption, samlEncryption, webhookEncryption, actionEncryption crypto.EncryptionAlgorithm,
 	httpClient *http.Client,
 	permissionCheck domain.PermissionCheck,
+	mutationCheck domain.MutationCheck,
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error),
 ) (repo *Commands, err error) {
 	if externalDomain == "" {
@@ -104,16 +113,20 @@ func StartCommands(
 		smtpEncryption:        smtpEncryption,
 		smsEncryption:         smsEncryption,
 		userEncryption:        userEncryption,
+		webhookEncryption:     webhookEncryption,
+		actionEncryption:      actionEncryption,
 		domainVerificationAlg: domainVerificationEncryption,
 		keyAlgorithm:          oidcEncryption,
 		certificateAlgorithm:  samlEncryption,
 		webauthnConfig:        webAuthN,
 		httpClient:            httpClient,
 		checkPermission:       permissionCheck,
+		checkMutation:         mutationCheck,
 		newCode:               newCryptoCodeWithExpiry,
 		sessionTokenCreator:   sessionTokenCreator(idGenerator, sessionAlg),
 		sessionTokenVerifier:  sessionTokenVerifier,
 	}
+	repo.samlCertificateAndKeyGenerator = samlCertificateAndKeyGenerator(defaults.KeyConfig.Size)
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
 	org.RegisterEventMappers(repo.eventstore)
@@ -126,8 +139,17 @@ func StartCommands(
 	session.RegisterEventMappers(repo.eventstore)
 	idpintent.RegisterEventMappers(repo.eventstore)
 	milestone.RegisterEventMappers(repo.eventstore)
+	webhook.RegisterEventMappers(repo.eventstore)
 
-	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
+	repo.userPasswordAlg, err = crypto.NewPasswordHasher(defaults.PasswordHasher)
+	if err != nil {
+		return nil, err
+	}
+	repo.breachedPasswords, err = breach.NewChecker(defaults.BreachedPasswords, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	repo.breachedPasswordsFailOpen = defaults.BreachedPasswords.FailOpen
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
 	repo.applicationKeySize = int(defaults.SecretGenerators.ApplicationKeySize)
 
@@ -0,0 +1,13 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// PruneEvents removes the expired aggregates and events of the instances from the eventstore,
+// all instances are pruned if no instance ids are passed
+func (c *Commands) PruneEvents(ctx context.Context, instanceIDs ...string) (*eventstore.PruneResult, error) {
+	return c.eventstore.Prune(ctx, nil, instanceIDs...)
+}
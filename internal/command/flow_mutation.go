@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// checkPreMutation executes the actions of the pre trigger of the flow,
+// the mutation is allowed if no check is configured
+func (c *Commands) checkPreMutation(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, resourceOwner string, mutation interface{}) error {
+	if c.checkMutation == nil {
+		return nil
+	}
+	return c.checkMutation(ctx, flowType, triggerType, resourceOwner, mutation)
+}
+
+// checkPreInstanceMemberMutation executes the actions of the membership flow of the default organisation,
+// as instances have no flows of their own
+func (c *Commands) checkPreInstanceMemberMutation(ctx context.Context, triggerType domain.TriggerType, userID string, roles []string) error {
+	instance := authz.GetInstance(ctx)
+	return c.checkPreMutation(ctx, domain.FlowTypeMembership, triggerType, instance.DefaultOrganisationID(), &domain.MembershipMutation{
+		Type:   domain.MembershipTypeInstance,
+		ID:     instance.InstanceID(),
+		UserID: userID,
+		Roles:  roles,
+	})
+}
+
+func (c *Commands) checkPreOrgMemberMutation(ctx context.Context, triggerType domain.TriggerType, orgID, userID string, roles []string) error {
+	return c.checkPreMutation(ctx, domain.FlowTypeMembership, triggerType, orgID, &domain.MembershipMutation{
+		Type:   domain.MembershipTypeOrg,
+		ID:     orgID,
+		UserID: userID,
+		Roles:  roles,
+	})
+}
+
+// checkPreProjectGrantMemberMutation executes the actions of the membership flow of the organisation owning the project
+func (c *Commands) checkPreProjectGrantMemberMutation(ctx context.Context, triggerType domain.TriggerType, projectID, grantID, userID string, roles []string) error {
+	if c.checkMutation == nil {
+		return nil
+	}
+	project, err := c.getProjectWriteModelByID(ctx, projectID, "")
+	if err != nil {
+		return err
+	}
+	return c.checkPreMutation(ctx, domain.FlowTypeMembership, triggerType, project.ResourceOwner, &domain.MembershipMutation{
+		Type:    domain.MembershipTypeProjectGrant,
+		ID:      projectID,
+		GrantID: grantID,
+		UserID:  userID,
+		Roles:   roles,
+	})
+}
+
+func userMutationFromAddHuman(human *AddHuman) *domain.UserMutation {
+	return &domain.UserMutation{
+		ID:                human.ID,
+		Username:          human.Username,
+		FirstName:         human.FirstName,
+		LastName:          human.LastName,
+		NickName:          human.NickName,
+		DisplayName:       human.DisplayName,
+		PreferredLanguage: human.PreferredLanguage.String(),
+		Email:             string(human.Email.Address),
+		Phone:             string(human.Phone.Number),
+	}
+}
+
+func userMutationFromHuman(human *domain.Human) *domain.UserMutation {
+	mutation := &domain.UserMutation{
+		ID:       human.AggregateID,
+		Username: human.Username,
+	}
+	if human.Profile != nil {
+		mutation.FirstName = human.FirstName
+		mutation.LastName = human.LastName
+		mutation.NickName = human.NickName
+		mutation.DisplayName = human.DisplayName
+		mutation.PreferredLanguage = human.PreferredLanguage.String()
+	}
+	if human.Email != nil {
+		mutation.Email = string(human.EmailAddress)
+	}
+	if human.Phone != nil {
+		mutation.Phone = string(human.PhoneNumber)
+	}
+	return mutation
+}
+
+func userMutationFromMachine(machine *Machine) *domain.UserMutation {
+	return &domain.UserMutation{
+		ID:          machine.AggregateID,
+		Username:    machine.Username,
+		Machine:     true,
+		Name:        machine.Name,
+		Description: machine.Description,
+	}
+}
+
+func userGrantMutationFromDomain(userGrant *domain.UserGrant) *domain.UserGrantMutation {
+	return &domain.UserGrantMutation{
+		ID:             userGrant.AggregateID,
+		UserID:         userGrant.UserID,
+		ProjectID:      userGrant.ProjectID,
+		ProjectGrantID: userGrant.ProjectGrantID,
+		RoleKeys:       userGrant.RoleKeys,
+	}
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/member"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_RemoveProjectMember_mutationCheck(t *testing.T) {
+	type fields struct {
+		eventstore    *eventstore.Eventstore
+		checkMutation func(t *testing.T) domain.MutationCheck
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	memberAdded := expectFilter(
+		eventFromEventPusher(
+			project.NewProjectMemberAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"user1",
+				[]string{"PROJECT_OWNER"}...,
+			),
+		),
+	)
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "rejected, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t, memberAdded),
+				checkMutation: func(t *testing.T) domain.MutationCheck {
+					return func(_ context.Context, flowType domain.FlowType, triggerType domain.TriggerType, resourceOwner string, mutation interface{}) error {
+						assert.Equal(t, domain.FlowTypeMembership, flowType)
+						assert.Equal(t, domain.TriggerTypePreRemoval, triggerType)
+						assert.Equal(t, "org1", resourceOwner)
+						assert.Equal(t, &domain.MembershipMutation{
+							Type:   domain.MembershipTypeProject,
+							ID:     "project1",
+							UserID: "user1",
+							Roles:  []string{"PROJECT_OWNER"},
+						}, mutation)
+						return errors.ThrowPreconditionFailed(nil, "ACTIO-Rj3ct", "last owner")
+					}
+				},
+			},
+			res: res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "allowed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					memberAdded,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(project.NewProjectMemberRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"user1",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(member.NewRemoveMemberUniqueConstraint("project1", "user1")),
+					),
+				),
+				checkMutation: func(*testing.T) domain.MutationCheck {
+					return func(context.Context, domain.FlowType, domain.TriggerType, string, interface{}) error {
+						return nil
+					}
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				checkMutation: tt.fields.checkMutation(t),
+			}
+			got, err := r.RemoveProjectMember(context.Background(), "project1", "user1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
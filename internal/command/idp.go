@@ -2,11 +2,18 @@ package command
 
 import (
 	"context"
+	"crypto/x509"
+	"math/big"
+	"strings"
 	"time"
 
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 )
 
@@ -95,6 +102,15 @@ type GoogleProvider struct {
 	IDPOptions   idp.Options
 }
 
+type SAMLProvider struct {
+	Name              string
+	Metadata          []byte
+	MetadataURL       string
+	Binding           string
+	WithSignedRequest bool
+	IDPOptions        idp.Options
+}
+
 type LDAPProvider struct {
 	Name              string
 	Servers           []string
@@ -174,3 +190,50 @@ func IDPProviderWriteModel(ctx context.Context, filter preparation.FilterToQuery
 
 	return allWriteModel, err
 }
+
+// samlCertificateAndKeyGenerator creates a self-signed certificate and the corresponding key,
+// which is used by ZITADEL as service provider to sign the requests sent to the SAML identity provider
+func samlCertificateAndKeyGenerator(keySize int) func(id string) ([]byte, []byte, error) {
+	return func(id string) ([]byte, []byte, error) {
+		now := time.Now()
+		privateKey, _, certificate, err := crypto.GenerateCACertificate(keySize, &crypto.CertificateInformations{
+			SerialNumber: big.NewInt(now.UnixNano()),
+			Organisation: []string{"ZITADEL"},
+			CommonName:   id,
+			NotBefore:    now,
+			NotAfter:     now.AddDate(10, 0, 0),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return certificate, crypto.PrivateKeyToBytes(privateKey), nil
+	}
+}
+
+// samlMetadata returns the provided metadata of the SAML identity provider or reads it from the metadata URL
+// and checks that it can be used for the authentication
+func (c *Commands) samlMetadata(provider SAMLProvider) ([]byte, error) {
+	metadata := provider.Metadata
+	if len(metadata) == 0 {
+		var err error
+		metadata, err = saml_xml.ReadMetadataFromURL(c.httpClient, strings.TrimSpace(provider.MetadataURL))
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMAND-9lw1zxtuva", "Errors.Project.App.SAMLMetadataMissing")
+		}
+	}
+	entityDescriptor, err := saml_xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil || entityDescriptor.IDPSSODescriptor == nil {
+		return nil, errors.ThrowInvalidArgument(err, "COMMAND-1z6e7pfsk3", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	return metadata, nil
+}
+
+func validSAMLBinding(binding string) bool {
+	switch binding {
+	case "", saml.BindingRedirect, saml.BindingPost:
+		return true
+	default:
+		return false
+	}
+}
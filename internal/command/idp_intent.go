@@ -74,16 +74,21 @@ func (c *Commands) CreateIntent(ctx context.Context, idpID, successURL, failureU
 	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
 }
 
-func (c *Commands) GetProvider(ctx context.Context, idpID, callbackURL string) (idp.Provider, error) {
+// GetProvider returns the [idp.Provider] of the IDP.
+// SAML providers are created with the samlRootURL as base for their endpoints instead of the callbackURL.
+func (c *Commands) GetProvider(ctx context.Context, idpID, callbackURL, samlRootURL string) (idp.Provider, error) {
 	writeModel, err := IDPProviderWriteModel(ctx, c.eventstore.Filter, idpID)
 	if err != nil {
 		return nil, err
 	}
+	if writeModel.IDPType == domain.IDPTypeSAML {
+		return writeModel.ToProvider(samlRootURL, c.idpConfigEncryption)
+	}
 	return writeModel.ToProvider(callbackURL, c.idpConfigEncryption)
 }
 
-func (c *Commands) AuthURLFromProvider(ctx context.Context, idpID, state, callbackURL string) (string, error) {
-	provider, err := c.GetProvider(ctx, idpID, callbackURL)
+func (c *Commands) AuthURLFromProvider(ctx context.Context, idpID, state, callbackURL, samlRootURL string) (string, error) {
+	provider, err := c.GetProvider(ctx, idpID, callbackURL, samlRootURL)
 	if err != nil {
 		return "", err
 	}
@@ -55,6 +55,8 @@ type InstanceSetup struct {
 		HasUppercase bool
 		HasNumber    bool
 		HasSymbol    bool
+
+		DenyBreachedPasswords bool
 	}
 	PasswordAgePolicy struct {
 		ExpireWarnDays uint64
@@ -107,7 +109,10 @@ type InstanceSetup struct {
 	}
 	LockoutPolicy struct {
 		MaxAttempts              uint64
+		MaxOTPAttempts           uint64
 		ShouldShowLockoutFailure bool
+		LockoutDuration          time.Duration
+		ProgressiveDelay         time.Duration
 	}
 	EmailTemplate     []byte
 	MessageTexts      []*domain.CustomMessageText
@@ -208,6 +213,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.PasswordComplexityPolicy.HasUppercase,
 			setup.PasswordComplexityPolicy.HasNumber,
 			setup.PasswordComplexityPolicy.HasSymbol,
+			setup.PasswordComplexityPolicy.DenyBreachedPasswords,
 		),
 		prepareAddDefaultPasswordAgePolicy(
 			instanceAgg,
@@ -245,7 +251,14 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
 		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
-		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
+		prepareAddDefaultLockoutPolicy(
+			instanceAgg,
+			setup.LockoutPolicy.MaxAttempts,
+			setup.LockoutPolicy.MaxOTPAttempts,
+			setup.LockoutPolicy.ShouldShowLockoutFailure,
+			setup.LockoutPolicy.LockoutDuration,
+			setup.LockoutPolicy.ProgressiveDelay,
+		),
 
 		prepareAddDefaultLabelPolicy(
 			instanceAgg,
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"golang.org/x/text/language"
@@ -55,3 +56,15 @@ func (wm *InstanceCustomLoginTextReadModel) Query() *eventstore.SearchQueryBuild
 			instance.CustomTextTemplateRemovedEventType).
 		Builder()
 }
+
+func (wm *InstanceCustomLoginTextReadModel) SnapshotType() string {
+	return "instance.login_text.v1." + wm.Language.String()
+}
+
+func (wm *InstanceCustomLoginTextReadModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(wm)
+}
+
+func (wm *InstanceCustomLoginTextReadModel) UnmarshalSnapshot(data []byte) error {
+	return json.Unmarshal(data, wm)
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"golang.org/x/text/language"
@@ -53,3 +54,15 @@ func (wm *InstanceCustomMessageTextWriteModel) Query() *eventstore.SearchQueryBu
 		EventTypes(instance.CustomTextSetEventType, instance.CustomTextRemovedEventType, instance.CustomTextTemplateRemovedEventType).
 		Builder()
 }
+
+func (wm *InstanceCustomMessageTextWriteModel) SnapshotType() string {
+	return "instance.message_text.v1." + wm.MessageTextType + "." + wm.Language.String()
+}
+
+func (wm *InstanceCustomMessageTextWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(wm)
+}
+
+func (wm *InstanceCustomMessageTextWriteModel) UnmarshalSnapshot(data []byte) error {
+	return json.Unmarshal(data, wm)
+}
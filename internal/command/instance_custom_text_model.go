@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"golang.org/x/text/language"
@@ -50,3 +51,15 @@ func (wm *InstanceCustomTextWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.CustomTextSetEventType).
 		Builder()
 }
+
+func (wm *InstanceCustomTextWriteModel) SnapshotType() string {
+	return "instance.custom_text.v1." + wm.Key + "." + wm.Language.String()
+}
+
+func (wm *InstanceCustomTextWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(wm)
+}
+
+func (wm *InstanceCustomTextWriteModel) UnmarshalSnapshot(data []byte) error {
+	return json.Unmarshal(data, wm)
+}
@@ -71,6 +71,9 @@ func IsInstanceMember(ctx context.Context, filter preparation.FilterToQueryReduc
 
 func (c *Commands) AddInstanceMember(ctx context.Context, userID string, roles ...string) (*domain.Member, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	if err := c.checkPreInstanceMemberMutation(ctx, domain.TriggerTypePreCreation, userID, roles); err != nil {
+		return nil, err
+	}
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.AddInstanceMemberCommand(instanceAgg, userID, roles...))
 	if err != nil {
 		return nil, err
@@ -104,6 +107,9 @@ func (c *Commands) ChangeInstanceMember(ctx context.Context, member *domain.Memb
 	if reflect.DeepEqual(existingMember.Roles, member.Roles) {
 		return nil, errors.ThrowPreconditionFailed(nil, "INSTANCE-LiaZi", "Errors.IAM.Member.RolesNotChanged")
 	}
+	if err = c.checkPreInstanceMemberMutation(ctx, domain.TriggerTypePreChange, member.UserID, member.Roles); err != nil {
+		return nil, err
+	}
 	instanceAgg := InstanceAggregateFromWriteModel(&existingMember.MemberWriteModel.WriteModel)
 	pushedEvents, err := c.eventstore.Push(ctx, instance.NewMemberChangedEvent(ctx, instanceAgg, member.UserID, member.Roles...))
 	if err != nil {
@@ -130,6 +136,10 @@ func (c *Commands) RemoveInstanceMember(ctx context.Context, userID string) (*do
 		return &domain.ObjectDetails{}, nil
 	}
 
+	if err = c.checkPreInstanceMemberMutation(ctx, domain.TriggerTypePreRemoval, userID, memberWriteModel.Roles); err != nil {
+		return nil, err
+	}
+
 	instanceAgg := InstanceAggregateFromWriteModel(&memberWriteModel.MemberWriteModel.WriteModel)
 	removeEvent := c.removeInstanceMember(ctx, instanceAgg, userID, false)
 	pushedEvents, err := c.eventstore.Push(ctx, removeEvent)
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -84,3 +86,15 @@ func (wm *InstanceWriteModel) Query() *eventstore.SearchQueryBuilder {
 func InstanceAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, instance.AggregateType, instance.AggregateVersion)
 }
+
+func (wm *InstanceWriteModel) SnapshotType() string {
+	return "instance.v1"
+}
+
+func (wm *InstanceWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(wm)
+}
+
+func (wm *InstanceWriteModel) UnmarshalSnapshot(data []byte) error {
+	return json.Unmarshal(data, wm)
+}
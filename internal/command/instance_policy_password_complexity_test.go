@@ -27,6 +27,8 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 		hasUppercase bool
 		hasNumber    bool
 		hasSymbol    bool
+
+		denyBreachedPasswords bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -68,6 +70,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
 								true, true, true, true,
+								false,
 							),
 						),
 					),
@@ -99,6 +102,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									8,
 									true, true, true, true,
+									true,
 								),
 							),
 						},
@@ -112,6 +116,8 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 				hasLowercase: true,
 				hasNumber:    true,
 				hasSymbol:    true,
+
+				denyBreachedPasswords: true,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -125,7 +131,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultPasswordComplexityPolicy(tt.args.ctx, tt.args.minLength, tt.args.hasLowercase, tt.args.hasUppercase, tt.args.hasNumber, tt.args.hasSymbol)
+			got, err := r.AddDefaultPasswordComplexityPolicy(tt.args.ctx, tt.args.minLength, tt.args.hasLowercase, tt.args.hasUppercase, tt.args.hasNumber, tt.args.hasSymbol, tt.args.denyBreachedPasswords)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -211,6 +217,7 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
 								true, true, true, true,
+								false,
 							),
 						),
 					),
@@ -241,6 +248,7 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
 								true, true, true, true,
+								false,
 							),
 						),
 					),
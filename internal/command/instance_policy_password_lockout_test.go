@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -23,7 +24,10 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 	type args struct {
 		ctx                 context.Context
 		maxPasswordAttempts uint64
+		maxOTPAttempts      uint64
 		showLockOutFailures bool
+		lockoutDuration     time.Duration
+		progressiveDelay    time.Duration
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -45,7 +49,10 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
@@ -73,7 +80,10 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 								instance.NewLockoutPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									10,
+									5,
 									true,
+									time.Hour,
+									time.Second,
 								),
 							),
 						},
@@ -83,7 +93,10 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 			args: args{
 				ctx:                 authz.WithInstanceID(context.Background(), "INSTANCE"),
 				maxPasswordAttempts: 10,
+				maxOTPAttempts:      5,
 				showLockOutFailures: true,
+				lockoutDuration:     time.Hour,
+				progressiveDelay:    time.Second,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -97,7 +110,7 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.showLockOutFailures)
+			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.maxOTPAttempts, tt.args.showLockOutFailures, tt.args.lockoutDuration, tt.args.progressiveDelay)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -158,7 +171,10 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
@@ -185,14 +201,17 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newDefaultLockoutPolicyChangedEvent(context.Background(), 20, false),
+								newDefaultLockoutPolicyChangedEvent(context.Background(), 20, 5, false, time.Hour, time.Second),
 							),
 						},
 					),
@@ -202,7 +221,10 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 				ctx: context.Background(),
 				policy: &domain.LockoutPolicy{
 					MaxPasswordAttempts: 20,
+					MaxOTPAttempts:      5,
 					ShowLockOutFailures: false,
+					LockoutDuration:     time.Hour,
+					ProgressiveDelay:    time.Second,
 				},
 			},
 			res: res{
@@ -212,7 +234,10 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 						ResourceOwner: "INSTANCE",
 					},
 					MaxPasswordAttempts: 20,
+					MaxOTPAttempts:      5,
 					ShowLockOutFailures: false,
+					LockoutDuration:     time.Hour,
+					ProgressiveDelay:    time.Second,
 				},
 			},
 		},
@@ -236,12 +261,15 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 	}
 }
 
-func newDefaultLockoutPolicyChangedEvent(ctx context.Context, maxAttempts uint64, showLockoutFailure bool) *instance.LockoutPolicyChangedEvent {
+func newDefaultLockoutPolicyChangedEvent(ctx context.Context, maxAttempts, maxOTPAttempts uint64, showLockoutFailure bool, lockoutDuration, progressiveDelay time.Duration) *instance.LockoutPolicyChangedEvent {
 	event, _ := instance.NewLockoutPolicyChangedEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
 		[]policy.LockoutPolicyChanges{
 			policy.ChangeMaxAttempts(maxAttempts),
+			policy.ChangeMaxOTPAttempts(maxOTPAttempts),
 			policy.ChangeShowLockOutFailures(showLockoutFailure),
+			policy.ChangeLockoutDuration(lockoutDuration),
+			policy.ChangeProgressiveDelay(progressiveDelay),
 		},
 	)
 	return event
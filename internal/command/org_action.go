@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -11,6 +12,13 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
+var actionSigningKeyConfig = crypto.GeneratorConfig{
+	Length:              32,
+	IncludeLowerLetters: true,
+	IncludeUpperLetters: true,
+	IncludeDigits:       true,
+}
+
 func (c *Commands) AddActionWithID(ctx context.Context, addAction *domain.Action, resourceOwner, actionID string) (_ string, _ *domain.ObjectDetails, err error) {
 	existingAction, err := c.getActionWriteModelByID(ctx, actionID, resourceOwner)
 	if err != nil {
@@ -20,27 +28,38 @@ func (c *Commands) AddActionWithID(ctx context.Context, addAction *domain.Action
 		return "", nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-nau2k", "Errors.Action.AlreadyExisting")
 	}
 
-	return c.addActionWithID(ctx, addAction, resourceOwner, actionID)
+	id, _, details, err := c.addActionWithID(ctx, addAction, resourceOwner, actionID)
+	return id, details, err
 }
 
-func (c *Commands) AddAction(ctx context.Context, addAction *domain.Action, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+// AddAction adds an action to the organization (resourceOwner).
+// The returned signing key is used to sign the requests to the HTTP target and is only returned once.
+func (c *Commands) AddAction(ctx context.Context, addAction *domain.Action, resourceOwner string) (_ string, _ string, _ *domain.ObjectDetails, err error) {
 	if !addAction.IsValid() {
-		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-eg2gf", "Errors.Action.Invalid")
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-eg2gf", "Errors.Action.Invalid")
 	}
 
 	actionID, err := c.idGenerator.Next()
 	if err != nil {
-		return "", nil, err
+		return "", "", nil, err
 	}
 
 	return c.addActionWithID(ctx, addAction, resourceOwner, actionID)
 }
 
-func (c *Commands) addActionWithID(ctx context.Context, addAction *domain.Action, resourceOwner, actionID string) (_ string, _ *domain.ObjectDetails, err error) {
+func (c *Commands) addActionWithID(ctx context.Context, addAction *domain.Action, resourceOwner, actionID string) (_ string, _ string, _ *domain.ObjectDetails, err error) {
 	addAction.AggregateID = actionID
 	actionModel := NewActionWriteModel(addAction.AggregateID, resourceOwner)
 	actionAgg := ActionAggregateFromWriteModel(&actionModel.WriteModel)
 
+	var signingKey *crypto.CryptoValue
+	var plainSigningKey string
+	if addAction.TargetType == domain.ActionTargetTypeHTTP {
+		signingKey, plainSigningKey, err = c.newActionSigningKey()
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
 	pushedEvents, err := c.eventstore.Push(ctx, action.NewAddedEvent(
 		ctx,
 		actionAgg,
@@ -48,30 +67,43 @@ func (c *Commands) addActionWithID(ctx context.Context, addAction *domain.Action
 		addAction.Script,
 		addAction.Timeout,
 		addAction.AllowedToFail,
+		addAction.TargetType,
+		addAction.TargetURL,
+		signingKey,
 	))
 	if err != nil {
-		return "", nil, err
+		return "", "", nil, err
 	}
 	err = AppendAndReduce(actionModel, pushedEvents...)
 	if err != nil {
-		return "", nil, err
+		return "", "", nil, err
 	}
-	return actionModel.AggregateID, writeModelToObjectDetails(&actionModel.WriteModel), nil
+	return actionModel.AggregateID, plainSigningKey, writeModelToObjectDetails(&actionModel.WriteModel), nil
 }
 
-func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action, resourceOwner string) (*domain.ObjectDetails, error) {
+// ChangeAction changes the action of the organization (resourceOwner).
+// If the action is changed to an HTTP target without signing key, the generated signing key is returned once.
+func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
 	if !actionChange.IsValid() || actionChange.AggregateID == "" {
-		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Df2f3", "Errors.Action.Invalid")
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Df2f3", "Errors.Action.Invalid")
 	}
 
 	existingAction, err := c.getActionWriteModelByID(ctx, actionChange.AggregateID, resourceOwner)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	if !existingAction.State.Exists() {
-		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sfg2t", "Errors.Action.NotFound")
+		return "", nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sfg2t", "Errors.Action.NotFound")
 	}
 
+	var signingKey *crypto.CryptoValue
+	var plainSigningKey string
+	if actionChange.TargetType == domain.ActionTargetTypeHTTP && existingAction.SigningKey == nil {
+		signingKey, plainSigningKey, err = c.newActionSigningKey()
+		if err != nil {
+			return "", nil, err
+		}
+	}
 	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
 	changedEvent, err := existingAction.NewChangedEvent(
 		ctx,
@@ -79,19 +111,65 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 		actionChange.Name,
 		actionChange.Script,
 		actionChange.Timeout,
-		actionChange.AllowedToFail)
+		actionChange.AllowedToFail,
+		actionChange.TargetType,
+		actionChange.TargetURL,
+		signingKey,
+	)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	err = AppendAndReduce(existingAction, pushedEvents...)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	return writeModelToObjectDetails(&existingAction.WriteModel), nil
+	return plainSigningKey, writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
+// RegenerateActionSigningKey replaces the signing key of the action,
+// which signs the requests to the HTTP target and the tokens of the `zitadel/jwt` module.
+// The new signing key is only returned once.
+func (c *Commands) RegenerateActionSigningKey(ctx context.Context, actionID string, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+	if actionID == "" || resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vai3e", "Errors.IDMissing")
+	}
+
+	existingAction, err := c.getActionWriteModelByID(ctx, actionID, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	if !existingAction.State.Exists() {
+		return "", nil, caos_errs.ThrowNotFound(nil, "COMMAND-ieR4o", "Errors.Action.NotFound")
+	}
+	signingKey, plainSigningKey, err := c.newActionSigningKey()
+	if err != nil {
+		return "", nil, err
+	}
+	changedEvent, err := action.NewChangedEvent(
+		ctx,
+		ActionAggregateFromWriteModel(&existingAction.WriteModel),
+		[]action.ActionChanges{action.ChangeSigningKey(signingKey)},
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(existingAction, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return plainSigningKey, writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
+func (c *Commands) newActionSigningKey() (*crypto.CryptoValue, string, error) {
+	return crypto.NewCode(crypto.NewEncryptionGenerator(actionSigningKeyConfig, c.actionEncryption))
 }
 
 func (c *Commands) DeactivateAction(ctx context.Context, actionID string, resourceOwner string) (*domain.ObjectDetails, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/action"
@@ -17,6 +18,9 @@ type ActionWriteModel struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         domain.ActionState
+	TargetType    domain.ActionTargetType
+	TargetURL     string
+	SigningKey    *crypto.CryptoValue
 }
 
 func NewActionWriteModel(actionID string, resourceOwner string) *ActionWriteModel {
@@ -36,6 +40,9 @@ func (wm *ActionWriteModel) Reduce() error {
 			wm.Script = e.Script
 			wm.Timeout = e.Timeout
 			wm.AllowedToFail = e.AllowedToFail
+			wm.TargetType = e.TargetType
+			wm.TargetURL = e.TargetURL
+			wm.SigningKey = e.SigningKey
 			wm.State = domain.ActionStateActive
 		case *action.ChangedEvent:
 			if e.Name != nil {
@@ -50,6 +57,15 @@ func (wm *ActionWriteModel) Reduce() error {
 			if e.AllowedToFail != nil {
 				wm.AllowedToFail = *e.AllowedToFail
 			}
+			if e.TargetType != nil {
+				wm.TargetType = *e.TargetType
+			}
+			if e.TargetURL != nil {
+				wm.TargetURL = *e.TargetURL
+			}
+			if e.SigningKey != nil {
+				wm.SigningKey = e.SigningKey
+			}
 		case *action.DeactivatedEvent:
 			wm.State = domain.ActionStateInactive
 		case *action.ReactivatedEvent:
@@ -82,6 +98,9 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	script string,
 	timeout time.Duration,
 	allowedToFail bool,
+	targetType domain.ActionTargetType,
+	targetURL string,
+	signingKey *crypto.CryptoValue,
 ) (*action.ChangedEvent, error) {
 	changes := make([]action.ActionChanges, 0)
 	if wm.Name != name {
@@ -96,6 +115,15 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	if wm.AllowedToFail != allowedToFail {
 		changes = append(changes, action.ChangeAllowedToFail(allowedToFail))
 	}
+	if wm.TargetType != targetType {
+		changes = append(changes, action.ChangeTargetType(targetType))
+	}
+	if wm.TargetURL != targetURL {
+		changes = append(changes, action.ChangeTargetURL(targetURL))
+	}
+	if signingKey != nil {
+		changes = append(changes, action.ChangeSigningKey(signingKey))
+	}
 	return action.NewChangedEvent(ctx, agg, changes)
 }
 
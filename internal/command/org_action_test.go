@@ -54,6 +54,24 @@ func TestCommands_AddAction(t *testing.T) {
 				err: errors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			"invalid target url, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addAction: &domain.Action{
+					Name:       "name",
+					TargetType: domain.ActionTargetTypeHTTP,
+					TargetURL:  "ftp://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"unique constraint failed, error",
 			fields{
@@ -68,6 +86,9 @@ func TestCommands_AddAction(t *testing.T) {
 									"name() {};",
 									0,
 									false,
+									domain.ActionTargetTypeScript,
+									"",
+									nil,
 								),
 							),
 						},
@@ -101,6 +122,9 @@ func TestCommands_AddAction(t *testing.T) {
 									"name2() {};",
 									0,
 									false,
+									domain.ActionTargetTypeScript,
+									"",
+									nil,
 								),
 							),
 						},
@@ -131,7 +155,7 @@ func TestCommands_AddAction(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			id, details, err := c.AddAction(tt.args.ctx, tt.args.addAction, tt.args.resourceOwner)
+			id, _, details, err := c.AddAction(tt.args.ctx, tt.args.addAction, tt.args.resourceOwner)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -216,6 +240,9 @@ func TestCommands_ChangeAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -248,6 +275,9 @@ func TestCommands_ChangeAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -299,6 +329,9 @@ func TestCommands_ChangeAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -345,7 +378,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 			c := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			details, err := c.ChangeAction(tt.args.ctx, tt.args.changeAction, tt.args.resourceOwner)
+			_, details, err := c.ChangeAction(tt.args.ctx, tt.args.changeAction, tt.args.resourceOwner)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -359,6 +392,103 @@ func TestCommands_ChangeAction(t *testing.T) {
 	}
 }
 
+func TestCommands_RegenerateActionSigningKey(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		actionID      string
+		resourceOwner string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"removed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							action.NewRemovedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			_, _, err := c.RegenerateActionSigningKey(tt.args.ctx, tt.args.actionID, tt.args.resourceOwner)
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
 func TestCommands_DeactivateAction(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -420,6 +550,9 @@ func TestCommands_DeactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -451,6 +584,9 @@ func TestCommands_DeactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -557,6 +693,9 @@ func TestCommands_ReactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -583,6 +722,9 @@ func TestCommands_ReactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -695,6 +837,9 @@ func TestCommands_DeleteAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -734,6 +879,9 @@ func TestCommands_DeleteAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
@@ -30,6 +30,8 @@ func orgWriteModelToPasswordComplexityPolicy(wm *OrgPasswordComplexityPolicyWrit
 		HasUppercase: wm.HasUppercase,
 		HasNumber:    wm.HasNumber,
 		HasSymbol:    wm.HasSymbol,
+
+		DenyBreachedPasswords: wm.DenyBreachedPasswords,
 	}
 }
 
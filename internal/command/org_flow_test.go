@@ -237,6 +237,9 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 								"function(ctx, api) action {};",
 								0,
 								false,
+								domain.ActionTargetTypeScript,
+								"",
+								nil,
 							),
 						),
 					),
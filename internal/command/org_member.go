@@ -75,6 +75,9 @@ func IsOrgMember(ctx context.Context, filter preparation.FilterToQueryReducer, o
 
 func (c *Commands) AddOrgMember(ctx context.Context, orgID, userID string, roles ...string) (*domain.Member, error) {
 	orgAgg := org.NewAggregate(orgID)
+	if err := c.checkPreOrgMemberMutation(ctx, domain.TriggerTypePreCreation, orgID, userID, roles); err != nil {
+		return nil, err
+	}
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.AddOrgMemberCommand(orgAgg, userID, roles...))
 	if err != nil {
 		return nil, err
@@ -126,6 +129,9 @@ func (c *Commands) ChangeOrgMember(ctx context.Context, member *domain.Member) (
 	if reflect.DeepEqual(existingMember.Roles, member.Roles) {
 		return nil, errors.ThrowPreconditionFailed(nil, "Org-LiaZi", "Errors.Org.Member.RolesNotChanged")
 	}
+	if err = c.checkPreOrgMemberMutation(ctx, domain.TriggerTypePreChange, existingMember.AggregateID, member.UserID, member.Roles); err != nil {
+		return nil, err
+	}
 	orgAgg := OrgAggregateFromWriteModel(&existingMember.MemberWriteModel.WriteModel)
 	pushedEvents, err := c.eventstore.Push(ctx, org.NewMemberChangedEvent(ctx, orgAgg, member.UserID, member.Roles...))
 	if err != nil {
@@ -149,6 +155,10 @@ func (c *Commands) RemoveOrgMember(ctx context.Context, orgID, userID string) (*
 		return &domain.ObjectDetails{}, nil
 	}
 
+	if err = c.checkPreOrgMemberMutation(ctx, domain.TriggerTypePreRemoval, orgID, userID, m.Roles); err != nil {
+		return nil, err
+	}
+
 	orgAgg := OrgAggregateFromWriteModel(&m.MemberWriteModel.WriteModel)
 	removeEvent := c.removeOrgMember(ctx, orgAgg, userID, false)
 	pushedEvents, err := c.eventstore.Push(ctx, removeEvent)
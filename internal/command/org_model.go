@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/org"
@@ -63,3 +65,15 @@ func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 func OrgAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, org.AggregateType, org.AggregateVersion)
 }
+
+func (wm *OrgWriteModel) SnapshotType() string {
+	return "org.v1"
+}
+
+func (wm *OrgWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(wm)
+}
+
+func (wm *OrgWriteModel) UnmarshalSnapshot(data []byte) error {
+	return json.Unmarshal(data, wm)
+}
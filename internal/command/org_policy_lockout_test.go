@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -62,7 +63,10 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
@@ -92,7 +96,10 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 								org.NewLockoutPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									10,
+									0,
 									true,
+									0,
+									0,
 								),
 							),
 						},
@@ -205,7 +212,10 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
@@ -233,14 +243,17 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newPasswordLockoutPolicyChangedEvent(context.Background(), "org1", 5, false),
+								newPasswordLockoutPolicyChangedEvent(context.Background(), "org1", 5, 3, false, time.Hour, 0),
 							),
 						},
 					),
@@ -251,7 +264,9 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 				orgID: "org1",
 				policy: &domain.LockoutPolicy{
 					MaxPasswordAttempts: 5,
+					MaxOTPAttempts:      3,
 					ShowLockOutFailures: false,
+					LockoutDuration:     time.Hour,
 				},
 			},
 			res: res{
@@ -261,7 +276,9 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 						ResourceOwner: "org1",
 					},
 					MaxPasswordAttempts: 5,
+					MaxOTPAttempts:      3,
 					ShowLockOutFailures: false,
+					LockoutDuration:     time.Hour,
 				},
 			},
 		},
@@ -343,7 +360,10 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
 							),
 						),
 					),
@@ -384,13 +404,19 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 	}
 }
 
-func newPasswordLockoutPolicyChangedEvent(ctx context.Context, orgID string, maxAttempts uint64, showLockoutFailure bool) *org.LockoutPolicyChangedEvent {
+func newPasswordLockoutPolicyChangedEvent(ctx context.Context, orgID string, maxAttempts, maxOTPAttempts uint64, showLockoutFailure bool, lockoutDuration, progressiveDelay time.Duration) *org.LockoutPolicyChangedEvent {
+	changes := []policy.LockoutPolicyChanges{
+		policy.ChangeMaxAttempts(maxAttempts),
+		policy.ChangeMaxOTPAttempts(maxOTPAttempts),
+		policy.ChangeShowLockOutFailures(showLockoutFailure),
+		policy.ChangeLockoutDuration(lockoutDuration),
+	}
+	if progressiveDelay != 0 {
+		changes = append(changes, policy.ChangeProgressiveDelay(progressiveDelay))
+	}
 	event, _ := org.NewLockoutPolicyChangedEvent(ctx,
 		&org.NewAggregate(orgID).Aggregate,
-		[]policy.LockoutPolicyChanges{
-			policy.ChangeMaxAttempts(maxAttempts),
-			policy.ChangeShowLockOutFailures(showLockoutFailure),
-		},
+		changes,
 	)
 	return event
 }
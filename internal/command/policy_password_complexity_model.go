@@ -25,6 +25,8 @@ type PasswordComplexityPolicyWriteModel struct {
 	HasNumber    bool
 	HasSymbol    bool
 	State        domain.PolicyState
+
+	DenyBreachedPasswords bool
 }
 
 func (wm *PasswordComplexityPolicyWriteModel) Reduce() error {
@@ -36,6 +38,7 @@ func (wm *PasswordComplexityPolicyWriteModel) Reduce() error {
 			wm.HasUppercase = e.HasUppercase
 			wm.HasNumber = e.HasNumber
 			wm.HasSymbol = e.HasSymbol
+			wm.DenyBreachedPasswords = e.DenyBreachedPasswords
 			wm.State = domain.PolicyStateActive
 		case *policy.PasswordComplexityPolicyChangedEvent:
 			if e.MinLength != nil {
@@ -53,6 +56,9 @@ func (wm *PasswordComplexityPolicyWriteModel) Reduce() error {
 			if e.HasSymbol != nil {
 				wm.HasSymbol = *e.HasSymbol
 			}
+			if e.DenyBreachedPasswords != nil {
+				wm.DenyBreachedPasswords = *e.DenyBreachedPasswords
+			}
 		case *policy.PasswordComplexityPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
@@ -34,6 +34,9 @@ type addOIDCApp struct {
 	ClockSkew                   time.Duration
 	AdditionalOrigins           []string
 	SkipSuccessPageForNativeApp bool
+	DPoPBoundAccessTokens       bool
+	RequirePushedAuthRequest    bool
+	BackChannelLogoutURI        string
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -111,6 +114,9 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					app.AdditionalOrigins,
 					app.SkipSuccessPageForNativeApp,
+					app.DPoPBoundAccessTokens,
+					app.RequirePushedAuthRequest,
+					app.BackChannelLogoutURI,
 				),
 			}, nil
 		}, nil
@@ -195,6 +201,9 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		oidcApp.AdditionalOrigins,
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.DPoPBoundAccessTokens,
+		oidcApp.RequirePushedAuthRequest,
+		oidcApp.BackChannelLogoutURI,
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -247,6 +256,9 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		oidc.AdditionalOrigins,
 		oidc.SkipNativeAppSuccessPage,
+		oidc.DPoPBoundAccessTokens,
+		oidc.RequirePushedAuthRequest,
+		oidc.BackChannelLogoutURI,
 	)
 	if err != nil {
 		return nil, err
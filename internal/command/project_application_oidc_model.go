@@ -36,6 +36,9 @@ type OIDCApplicationWriteModel struct {
 	State                    domain.AppState
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	DPoPBoundAccessTokens    bool
+	RequirePushedAuthRequest bool
+	BackChannelLogoutURI     string
 	oidc                     bool
 }
 
@@ -158,6 +161,9 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.DPoPBoundAccessTokens = e.DPoPBoundAccessTokens
+	wm.RequirePushedAuthRequest = e.RequirePushedAuthRequest
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +212,15 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.DPoPBoundAccessTokens != nil {
+		wm.DPoPBoundAccessTokens = *e.DPoPBoundAccessTokens
+	}
+	if e.RequirePushedAuthRequest != nil {
+		wm.RequirePushedAuthRequest = *e.RequirePushedAuthRequest
+	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -245,7 +260,10 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
-	skipNativeAppSuccessPage bool,
+	skipNativeAppSuccessPage,
+	dpopBoundAccessTokens,
+	requirePushedAuthRequest bool,
+	backChannelLogoutURI string,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +313,15 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.DPoPBoundAccessTokens != dpopBoundAccessTokens {
+		changes = append(changes, project.ChangeDPoPBoundAccessTokens(dpopBoundAccessTokens))
+	}
+	if wm.RequirePushedAuthRequest != requirePushedAuthRequest {
+		changes = append(changes, project.ChangeRequirePushedAuthRequest(requirePushedAuthRequest))
+	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
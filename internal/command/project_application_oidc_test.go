@@ -170,6 +170,9 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -328,6 +331,9 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									time.Second*1,
 									[]string{"https://sub.test.ch"},
 									true,
+									false,
+									false,
+									"",
 								),
 							),
 						},
@@ -565,6 +571,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -639,6 +648,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -840,6 +852,9 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
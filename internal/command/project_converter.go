@@ -47,6 +47,9 @@ func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.O
 		ClockSkew:                writeModel.ClockSkew,
 		AdditionalOrigins:        writeModel.AdditionalOrigins,
 		SkipNativeAppSuccessPage: writeModel.SkipNativeAppSuccessPage,
+		DPoPBoundAccessTokens:    writeModel.DPoPBoundAccessTokens,
+		RequirePushedAuthRequest: writeModel.RequirePushedAuthRequest,
+		BackChannelLogoutURI:     writeModel.BackChannelLogoutURI,
 	}
 }
 
@@ -30,6 +30,10 @@ func (c *Commands) AddProjectGrantMember(ctx context.Context, member *domain.Pro
 	if addedMember.State == domain.MemberStateActive {
 		return nil, errors.ThrowAlreadyExists(nil, "PROJECT-16dVN", "Errors.Project.Member.AlreadyExists")
 	}
+	err = c.checkPreProjectGrantMemberMutation(ctx, domain.TriggerTypePreCreation, member.AggregateID, member.GrantID, member.UserID, member.Roles)
+	if err != nil {
+		return nil, err
+	}
 	projectAgg := ProjectAggregateFromWriteModel(&addedMember.WriteModel)
 	pushedEvents, err := c.eventstore.Push(
 		ctx,
@@ -62,6 +66,10 @@ func (c *Commands) ChangeProjectGrantMember(ctx context.Context, member *domain.
 	if reflect.DeepEqual(existingMember.Roles, member.Roles) {
 		return nil, errors.ThrowPreconditionFailed(nil, "PROJECT-2n8vx", "Errors.Project.Member.RolesNotChanged")
 	}
+	err = c.checkPreProjectGrantMemberMutation(ctx, domain.TriggerTypePreChange, member.AggregateID, member.GrantID, member.UserID, member.Roles)
+	if err != nil {
+		return nil, err
+	}
 	projectAgg := ProjectAggregateFromWriteModel(&existingMember.WriteModel)
 	pushedEvents, err := c.eventstore.Push(
 		ctx,
@@ -86,6 +94,11 @@ func (c *Commands) RemoveProjectGrantMember(ctx context.Context, projectID, user
 		return nil, err
 	}
 
+	err = c.checkPreProjectGrantMemberMutation(ctx, domain.TriggerTypePreRemoval, projectID, grantID, userID, m.Roles)
+	if err != nil {
+		return nil, err
+	}
+
 	projectAgg := ProjectAggregateFromWriteModel(&m.WriteModel)
 	removeEvent := c.removeProjectGrantMember(ctx, projectAgg, userID, grantID, false)
 	pushedEvents, err := c.eventstore.Push(ctx, removeEvent)
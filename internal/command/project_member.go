@@ -50,6 +50,15 @@ func (c *Commands) addProjectMember(ctx context.Context, projectAgg *eventstore.
 	if addedMember.State == domain.MemberStateActive {
 		return nil, errors.ThrowAlreadyExists(nil, "PROJECT-PtXi1", "Errors.Project.Member.AlreadyExists")
 	}
+	err = c.checkPreMutation(ctx, domain.FlowTypeMembership, domain.TriggerTypePreCreation, addedMember.ResourceOwner, &domain.MembershipMutation{
+		Type:   domain.MembershipTypeProject,
+		ID:     projectAgg.ID,
+		UserID: member.UserID,
+		Roles:  member.Roles,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return project.NewProjectMemberAddedEvent(ctx, projectAgg, member.UserID, member.Roles...), nil
 }
@@ -71,6 +80,15 @@ func (c *Commands) ChangeProjectMember(ctx context.Context, member *domain.Membe
 	if reflect.DeepEqual(existingMember.Roles, member.Roles) {
 		return nil, errors.ThrowPreconditionFailed(nil, "PROJECT-LiaZi", "Errors.Project.Member.RolesNotChanged")
 	}
+	err = c.checkPreMutation(ctx, domain.FlowTypeMembership, domain.TriggerTypePreChange, existingMember.ResourceOwner, &domain.MembershipMutation{
+		Type:   domain.MembershipTypeProject,
+		ID:     existingMember.AggregateID,
+		UserID: member.UserID,
+		Roles:  member.Roles,
+	})
+	if err != nil {
+		return nil, err
+	}
 	projectAgg := ProjectAggregateFromWriteModel(&existingMember.MemberWriteModel.WriteModel)
 	pushedEvents, err := c.eventstore.Push(ctx, project.NewProjectMemberChangedEvent(ctx, projectAgg, member.UserID, member.Roles...))
 	if err != nil {
@@ -98,6 +116,16 @@ func (c *Commands) RemoveProjectMember(ctx context.Context, projectID, userID, r
 		return &domain.ObjectDetails{}, nil
 	}
 
+	err = c.checkPreMutation(ctx, domain.FlowTypeMembership, domain.TriggerTypePreRemoval, m.ResourceOwner, &domain.MembershipMutation{
+		Type:   domain.MembershipTypeProject,
+		ID:     projectID,
+		UserID: userID,
+		Roles:  m.Roles,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	projectAgg := ProjectAggregateFromWriteModel(&m.MemberWriteModel.WriteModel)
 	removeEvent := c.removeProjectMember(ctx, projectAgg, userID, false)
 	pushedEvents, err := c.eventstore.Push(ctx, removeEvent)
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -13,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
@@ -28,6 +31,7 @@ type SessionCommands struct {
 	userPasswordAlg    crypto.HashAlgorithm
 	intentAlg          crypto.EncryptionAlgorithm
 	createToken        func(sessionID string) (id string, token string, err error)
+	getLockoutPolicy   func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error)
 	now                func() time.Time
 }
 
@@ -39,6 +43,7 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		userPasswordAlg:   c.userPasswordAlg,
 		intentAlg:         c.idpConfigEncryption,
 		createToken:       c.sessionTokenCreator,
+		getLockoutPolicy:  c.getOrgLockoutPolicy,
 		now:               time.Now,
 	}
 }
@@ -71,18 +76,75 @@ func CheckPassword(password string) SessionCommand {
 		if cmd.passwordWriteModel.Secret == nil {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-WEf3t", "Errors.User.Password.NotSet")
 		}
+		lockout, lockoutPolicy, unlocked, err := cmd.checkLockout(ctx, cmd.passwordWriteModel.ResourceOwner, lockoutCheckPassword)
+		if err != nil {
+			return err
+		}
+		userAgg := UserAggregateFromWriteModel(&cmd.passwordWriteModel.WriteModel)
 		ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
 		err = crypto.CompareHash(cmd.passwordWriteModel.Secret, []byte(password), cmd.userPasswordAlg)
 		spanPasswordComparison.EndWithError(err)
 		if err != nil {
+			cmd.passwordCheckFailed(ctx, userAgg, lockout, lockoutPolicy, unlocked)
 			//TODO: maybe we want to reset the session in the future https://github.com/zitadel/zitadel/issues/5807
 			return caos_errs.ThrowInvalidArgument(err, "COMMAND-SAF3g", "Errors.User.Password.Invalid")
 		}
 		cmd.sessionWriteModel.PasswordChecked(ctx, cmd.now())
+		if unlocked != nil {
+			cmd.sessionWriteModel.commands = append(cmd.sessionWriteModel.commands, unlocked)
+		}
+		if lockout.PasswordCheckFailedCount > 0 {
+			cmd.sessionWriteModel.commands = append(cmd.sessionWriteModel.commands, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, nil))
+		}
+		if rehashed := rehashPassword(ctx, userAgg, cmd.passwordWriteModel.Secret, password, cmd.userPasswordAlg); rehashed != nil {
+			cmd.sessionWriteModel.commands = append(cmd.sessionWriteModel.commands, rehashed)
+		}
 		return nil
 	}
 }
 
+// checkLockout returns an error if the user is locked or has to wait for the progressive delay of the lockout policy,
+// an expired lockout is removed by the returned unlock event.
+// The policy is only queried if the user is locked or has failed checks.
+func (s *SessionCommands) checkLockout(ctx context.Context, resourceOwner string, check lockoutCheck) (_ *HumanLockoutWriteModel, _ *domain.LockoutPolicy, unlocked eventstore.Command, err error) {
+	lockout := NewHumanLockoutWriteModel(s.sessionWriteModel.UserID, "")
+	if err = s.eventstore.FilterToQueryReducer(ctx, lockout); err != nil {
+		return nil, nil, nil, err
+	}
+	if failedChecks, _ := lockout.failedChecks(check); !lockout.Locked && failedChecks == 0 {
+		return lockout, nil, nil, nil
+	}
+	lockoutPolicy, err := s.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	unlocked, err = lockout.checkLockout(ctx, lockoutPolicy, check, s.now())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return lockout, lockoutPolicy, unlocked, nil
+}
+
+// passwordCheckFailed pushes the failed check directly, because the session will not be updated.
+// The user is locked if the max attempts of the lockout policy are reached.
+func (s *SessionCommands) passwordCheckFailed(ctx context.Context, userAgg *eventstore.Aggregate, lockout *HumanLockoutWriteModel, lockoutPolicy *domain.LockoutPolicy, unlocked eventstore.Command) {
+	var err error
+	if lockoutPolicy == nil {
+		lockoutPolicy, err = s.getLockoutPolicy(ctx, userAgg.ResourceOwner)
+		logging.WithFields("userID", userAgg.ID).OnError(err).Warn("unable to get lockout policy")
+	}
+	events := make([]eventstore.Command, 0, 3)
+	if unlocked != nil {
+		events = append(events, unlocked)
+	}
+	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, nil))
+	if locked := lockout.lockOnFailedCheck(ctx, lockoutPolicy, lockoutCheckPassword); locked != nil {
+		events = append(events, locked)
+	}
+	_, err = s.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userAgg.ID).OnError(err).Error("unable to push password check failed event")
+}
+
 // CheckIntent defines a check for a succeeded intent to be executed for a session update
 func CheckIntent(intentID, token string) SessionCommand {
 	return func(ctx context.Context, cmd *SessionCommands) error {
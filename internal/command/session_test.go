@@ -507,6 +507,7 @@ func TestCommands_updateSession(t *testing.T) {
 									}, false, ""),
 							),
 						),
+						expectFilter(),
 					),
 					createToken: func(sessionID string) (string, string, error) {
 						return "tokenID",
@@ -187,6 +187,9 @@ func (c *Commands) RemoveUser(ctx context.Context, userID, resourceOwner string,
 	if !isUserStateExists(existingUser.UserState) {
 		return nil, errors.ThrowNotFound(nil, "COMMAND-m9od", "Errors.User.NotFound")
 	}
+	if err = c.checkPreMutation(ctx, domain.FlowTypeUser, domain.TriggerTypePreRemoval, existingUser.ResourceOwner, &domain.UserMutation{ID: userID, Username: existingUser.UserName}); err != nil {
+		return nil, err
+	}
 
 	domainPolicy, err := c.getOrgDomainPolicy(ctx, existingUser.ResourceOwner)
 	if err != nil {
@@ -224,12 +227,12 @@ func (c *Commands) RemoveUser(ctx context.Context, userID, resourceOwner string,
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
-func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration) (*domain.Token, error) {
+func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration, dpopJKT string) (*domain.Token, error) {
 	if userID == "" { //do not check for empty orgID (JWT Profile requests won't provide it, so service user requests fail)
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Dbge4", "Errors.IDMissing")
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	event, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", audience, scopes, lifetime)
+	event, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", audience, scopes, lifetime, dpopJKT)
 	if err != nil {
 		return nil, err
 	}
@@ -256,7 +259,7 @@ func (c *Commands) RevokeAccessToken(ctx context.Context, userID, orgID, tokenID
 	return writeModelToObjectDetails(&accessTokenWriteModel.WriteModel), nil
 }
 
-func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteModel, agentID, clientID, refreshTokenID string, audience, scopes []string, lifetime time.Duration) (*user.UserTokenAddedEvent, *domain.Token, error) {
+func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteModel, agentID, clientID, refreshTokenID string, audience, scopes []string, lifetime time.Duration, dpopJKT string) (*user.UserTokenAddedEvent, *domain.Token, error) {
 	err := c.eventstore.FilterToQueryReducer(ctx, userWriteModel)
 	if err != nil {
 		return nil, nil, err
@@ -279,7 +282,7 @@ func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteMo
 	}
 
 	userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
-	return user.NewUserTokenAddedEvent(ctx, userAgg, tokenID, clientID, agentID, preferredLanguage, refreshTokenID, audience, scopes, expiration),
+	return user.NewUserTokenAddedEvent(ctx, userAgg, tokenID, clientID, agentID, preferredLanguage, refreshTokenID, audience, scopes, expiration, dpopJKT),
 		&domain.Token{
 			ObjectRoot: models.ObjectRoot{
 				AggregateID: userWriteModel.AggregateID,
@@ -292,6 +295,7 @@ func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteMo
 			Scopes:            scopes,
 			Expiration:        expiration,
 			PreferredLanguage: preferredLanguage,
+			DPoPJKT:           dpopJKT,
 		}, nil
 }
 
@@ -385,6 +389,45 @@ func (c *Commands) UserDomainClaimedSent(ctx context.Context, orgID, userID stri
 	return err
 }
 
+// UserTokenExchanged records on the subject user that a token was issued to the client by a token exchange.
+// If the actorUserID is set, the token was issued on behalf of the subject (impersonation).
+func (c *Commands) UserTokenExchanged(ctx context.Context, orgID, userID, clientID, actorUserID, subjectTokenType, actorTokenType, requestedTokenType string, audience, scopes []string) (err error) {
+	if userID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Wr3fq", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Kd9ww", "Errors.User.NotFound")
+	}
+
+	_, err = c.eventstore.Push(ctx,
+		user.NewUserTokenExchangedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel),
+			clientID, actorUserID, subjectTokenType, actorTokenType, requestedTokenType, audience, scopes))
+	return err
+}
+
+// BackChannelLogoutSent records that the client was notified about the end of the session (sessionID) of the user.
+// The logoutSequence is the sequence of the event, which ended the session.
+func (c *Commands) BackChannelLogoutSent(ctx context.Context, orgID, userID, clientID, sessionID string, logoutSequence uint64) (err error) {
+	if userID == "" || clientID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Bcl2s", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Bcl3n", "Errors.User.NotFound")
+	}
+
+	_, err = c.eventstore.Push(ctx,
+		user.NewBackChannelLogoutSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), clientID, sessionID, logoutSequence))
+	return err
+}
+
 func (c *Commands) checkUserExists(ctx context.Context, userID, resourceOwner string) error {
 	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
 	if err != nil {
@@ -41,6 +41,10 @@ func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant
 	if err != nil {
 		return nil, nil, err
 	}
+	err = c.checkPreMutation(ctx, domain.FlowTypeUserGrant, domain.TriggerTypePreCreation, resourceOwner, userGrantMutationFromDomain(userGrant))
+	if err != nil {
+		return nil, nil, err
+	}
 
 	addedUserGrant := NewUserGrantWriteModel(userGrant.AggregateID, resourceOwner)
 	userGrantAgg := UserGrantAggregateFromWriteModel(&addedUserGrant.WriteModel)
@@ -95,6 +99,14 @@ func (c *Commands) changeUserGrant(ctx context.Context, userGrant *domain.UserGr
 	if err != nil {
 		return nil, nil, err
 	}
+	if !cascade {
+		mutation := userGrantMutationFromDomain(userGrant)
+		mutation.UserID = existingUserGrant.UserID
+		err = c.checkPreMutation(ctx, domain.FlowTypeUserGrant, domain.TriggerTypePreChange, existingUserGrant.ResourceOwner, mutation)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
 	changedUserGrant := NewUserGrantWriteModel(userGrant.AggregateID, resourceOwner)
 	userGrantAgg := UserGrantAggregateFromWriteModel(&changedUserGrant.WriteModel)
@@ -253,6 +265,16 @@ func (c *Commands) removeUserGrant(ctx context.Context, grantID, resourceOwner s
 		if err != nil {
 			return nil, nil, err
 		}
+		err = c.checkPreMutation(ctx, domain.FlowTypeUserGrant, domain.TriggerTypePreRemoval, existingUserGrant.ResourceOwner, &domain.UserGrantMutation{
+			ID:             grantID,
+			UserID:         existingUserGrant.UserID,
+			ProjectID:      existingUserGrant.ProjectID,
+			ProjectGrantID: existingUserGrant.ProjectGrantID,
+			RoleKeys:       existingUserGrant.RoleKeys,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	removeUserGrant := NewUserGrantWriteModel(grantID, existingUserGrant.ResourceOwner)
@@ -49,14 +49,17 @@ type AddHuman struct {
 	Phone Phone
 	// Password is optional
 	Password string
-	// BcryptedPassword is optional
-	BcryptedPassword string
+	// EncodedPasswordHash is optional,
+	// it must be a hash of one of the supported algorithms (bcrypt, argon2id, scrypt, pbkdf2)
+	EncodedPasswordHash string
 	// PasswordChangeRequired is used if the `Password`-field is set
 	PasswordChangeRequired bool
 	Passwordless           bool
 	ExternalIDP            bool
 	Register               bool
 	Metadata               []*AddMetadataEntry
+	// Deactivated is optional, the user will be created in the inactive state
+	Deactivated bool
 
 	// Links are optional
 	Links []*AddLink
@@ -123,6 +126,9 @@ func (c *Commands) AddHuman(ctx context.Context, resourceOwner string, human *Ad
 	if resourceOwner == "" {
 		return errors.ThrowInvalidArgument(nil, "COMMA-5Ky74", "Errors.Internal")
 	}
+	if err = c.checkPreMutation(ctx, domain.FlowTypeUser, domain.TriggerTypePreCreation, resourceOwner, userMutationFromAddHuman(human)); err != nil {
+		return err
+	}
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter,
 		c.AddHumanCommand(
 			human,
@@ -210,7 +216,7 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, passwordAlg cr
 				createCmd.AddPhoneData(human.Phone.Number)
 			}
 
-			if err := addHumanCommandPassword(ctx, filter, createCmd, human, passwordAlg); err != nil {
+			if err := c.addHumanCommandPassword(ctx, filter, createCmd, human, passwordAlg); err != nil {
 				return nil, err
 			}
 
@@ -242,6 +248,9 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, passwordAlg cr
 				}
 				cmds = append(cmds, cmd)
 			}
+			if human.Deactivated {
+				cmds = append(cmds, user.NewUserDeactivatedEvent(ctx, &a.Aggregate))
+			}
 
 			return cmds, nil
 		}, nil
@@ -316,9 +325,9 @@ func (c *Commands) addHumanCommandCheckID(ctx context.Context, filter preparatio
 	return nil
 }
 
-func addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQueryReducer, createCmd humanCreationCommand, human *AddHuman, passwordAlg crypto.HashAlgorithm) (err error) {
+func (c *Commands) addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQueryReducer, createCmd humanCreationCommand, human *AddHuman, passwordAlg crypto.HashAlgorithm) (err error) {
 	if human.Password != "" {
-		if err = humanValidatePassword(ctx, filter, human.Password); err != nil {
+		if err = c.humanValidatePassword(ctx, filter, human.Password); err != nil {
 			return err
 		}
 
@@ -330,8 +339,12 @@ func addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQue
 		return nil
 	}
 
-	if human.BcryptedPassword != "" {
-		createCmd.AddPasswordData(crypto.FillHash([]byte(human.BcryptedPassword), passwordAlg), human.PasswordChangeRequired)
+	if human.EncodedPasswordHash != "" {
+		secret, err := crypto.FillPasswordHash([]byte(human.EncodedPasswordHash))
+		if err != nil {
+			return err
+		}
+		createCmd.AddPasswordData(secret, human.PasswordChangeRequired)
 	}
 	return nil
 }
@@ -363,13 +376,15 @@ func userValidateDomain(ctx context.Context, a *user.Aggregate, username string,
 	return nil
 }
 
-func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
+func (c *Commands) humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
 	passwordComplexity, err := passwordComplexityPolicyWriteModel(ctx, filter)
 	if err != nil {
 		return err
 	}
-
-	return passwordComplexity.Validate(password)
+	if err = passwordComplexity.Validate(password); err != nil {
+		return err
+	}
+	return c.checkPasswordBreached(ctx, passwordComplexity.DenyBreachedPasswords, password)
 }
 
 func (h *AddHuman) ensureDisplayName() {
@@ -423,6 +438,9 @@ func (c *Commands) ImportHuman(ctx context.Context, orgID string, human *domain.
 			return nil, nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ziuna", "Errors.User.AlreadyExisting")
 		}
 	}
+	if err = c.checkPreMutation(ctx, domain.FlowTypeUser, domain.TriggerTypePreCreation, orgID, userMutationFromHuman(human)); err != nil {
+		return nil, nil, err
+	}
 
 	events, addedHuman, addedCode, code, err := c.importHuman(ctx, orgID, human, passwordless, links, domainPolicy, pwPolicy, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator, passwordlessCodeGenerator)
 	if err != nil {
@@ -581,6 +599,16 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 		if err := human.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg, human.Password.ChangeRequired); err != nil {
 			return nil, nil, err
 		}
+		if pwPolicy != nil {
+			if err := c.checkPasswordBreached(ctx, pwPolicy.DenyBreachedPasswords, human.Password.SecretString); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if human.HashedPassword != nil {
+		if human.HashedPassword.SecretCrypto, err = crypto.FillPasswordHash([]byte(human.HashedPassword.SecretString)); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	addedHuman = NewHumanWriteModel(human.AggregateID, orgID)
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// lockoutCheck defines which failed checks and max attempts of the lockout policy are relevant
+type lockoutCheck int
+
+const (
+	lockoutCheckPassword lockoutCheck = iota
+	// lockoutCheckOTP is used for OTP, U2F and passwordless checks
+	lockoutCheckOTP
+)
+
+func (c *Commands) humanLockoutWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanLockoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanLockoutWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// checkLockout returns an error if the user is locked or the progressive delay since the last failed check didn't pass yet.
+// If the lockout duration of the policy expired, the user is unlocked by the returned event.
+func (wm *HumanLockoutWriteModel) checkLockout(ctx context.Context, policy *domain.LockoutPolicy, check lockoutCheck, now time.Time) (eventstore.Command, error) {
+	if wm.Locked {
+		if !policy.LockoutExpired(wm.LockedAt, now) {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jei5o", "Errors.User.Locked")
+		}
+		wm.unlock()
+		return user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel)), nil
+	}
+	failedChecks, lastFailed := wm.failedChecks(check)
+	if delay := policy.CheckDelay(failedChecks); delay > 0 && now.Before(lastFailed.Add(delay)) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ohW4a", "Errors.User.CheckDelayed")
+	}
+	return nil, nil
+}
+
+// lockOnFailedCheck returns the locked event if the failed check reaches the max attempts of the policy
+func (wm *HumanLockoutWriteModel) lockOnFailedCheck(ctx context.Context, policy *domain.LockoutPolicy, check lockoutCheck) eventstore.Command {
+	if policy == nil {
+		return nil
+	}
+	maxAttempts := policy.MaxPasswordAttempts
+	if check == lockoutCheckOTP {
+		maxAttempts = policy.MaxOTPAttempts
+	}
+	failedChecks, _ := wm.failedChecks(check)
+	if maxAttempts == 0 || failedChecks+1 < maxAttempts {
+		return nil
+	}
+	return user.NewUserLockedEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel))
+}
+
+func (wm *HumanLockoutWriteModel) failedChecks(check lockoutCheck) (uint64, time.Time) {
+	if check == lockoutCheckOTP {
+		return wm.OTPCheckFailedCount, wm.LastOTPCheckFailed
+	}
+	return wm.PasswordCheckFailedCount, wm.LastPasswordCheckFailed
+}
+
+// UnlockHumanIfLockoutExpired unlocks a locked user if the lockout duration of the policy expired,
+// it returns true if the user was unlocked
+func (c *Commands) UnlockHumanIfLockoutExpired(ctx context.Context, userID, resourceOwner string, policy *domain.LockoutPolicy) (bool, error) {
+	if userID == "" {
+		return false, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ai4Ae", "Errors.User.UserIDMissing")
+	}
+	if policy == nil || policy.LockoutDuration <= 0 {
+		return false, nil
+	}
+	lockout, err := c.humanLockoutWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if !lockout.Locked || !policy.LockoutExpired(lockout.LockedAt, time.Now()) {
+		return false, nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&lockout.WriteModel)))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanLockoutWriteModel counts the consecutive failed checks of a user,
+// which are relevant for the lockout policy
+type HumanLockoutWriteModel struct {
+	eventstore.WriteModel
+
+	Locked   bool
+	LockedAt time.Time
+
+	PasswordCheckFailedCount uint64
+	LastPasswordCheckFailed  time.Time
+	// OTPCheckFailedCount counts the failed OTP, U2F and passwordless checks
+	OTPCheckFailedCount uint64
+	LastOTPCheckFailed  time.Time
+}
+
+func NewHumanLockoutWriteModel(userID, resourceOwner string) *HumanLockoutWriteModel {
+	return &HumanLockoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanLockoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserLockedEvent:
+			wm.Locked = true
+			wm.LockedAt = e.CreationDate()
+		case *user.UserUnlockedEvent:
+			wm.unlock()
+		case *user.HumanPasswordCheckFailedEvent:
+			wm.PasswordCheckFailedCount++
+			wm.LastPasswordCheckFailed = e.CreationDate()
+		case *user.HumanPasswordCheckSucceededEvent,
+			*user.HumanPasswordChangedEvent:
+			wm.PasswordCheckFailedCount = 0
+		case *user.HumanOTPCheckFailedEvent:
+			wm.otpCheckFailed(e.CreationDate())
+		case *user.HumanU2FCheckFailedEvent:
+			wm.otpCheckFailed(e.CreationDate())
+		case *user.HumanPasswordlessCheckFailedEvent:
+			wm.otpCheckFailed(e.CreationDate())
+		case *user.HumanOTPCheckSucceededEvent,
+			*user.HumanU2FCheckSucceededEvent,
+			*user.HumanPasswordlessCheckSucceededEvent:
+			wm.OTPCheckFailedCount = 0
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLockoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserLockedType,
+			user.UserUnlockedType,
+			user.HumanPasswordCheckFailedType,
+			user.HumanPasswordCheckSucceededType,
+			user.HumanPasswordChangedType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckFailedType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.UserV1PasswordCheckFailedType,
+			user.UserV1PasswordCheckSucceededType,
+			user.UserV1PasswordChangedType,
+			user.UserV1MFAOTPCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *HumanLockoutWriteModel) otpCheckFailed(at time.Time) {
+	wm.OTPCheckFailedCount++
+	wm.LastOTPCheckFailed = at
+}
+
+func (wm *HumanLockoutWriteModel) unlock() {
+	wm.Locked = false
+	wm.LockedAt = time.Time{}
+	wm.PasswordCheckFailedCount = 0
+	wm.OTPCheckFailedCount = 0
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -151,6 +152,9 @@ func (c *Commands) changePassword(ctx context.Context, userAgentID string, passw
 	if err := password.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg); err != nil {
 		return nil, err
 	}
+	if err := c.checkPasswordBreached(ctx, pwPolicy.DenyBreachedPasswords, password.SecretString); err != nil {
+		return nil, err
+	}
 	return user.NewHumanPasswordChangedEvent(ctx, userAgg, password.SecretCrypto, password.ChangeRequired, userAgentID), nil
 }
 
@@ -250,27 +254,55 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3n77z", "Errors.User.Password.NotSet")
 	}
 
+	lockout, err := c.humanLockoutWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	events := make([]eventstore.Command, 0, 3)
+	unlocked, err := lockout.checkLockout(ctx, lockoutPolicy, lockoutCheckPassword, time.Now())
+	if err != nil {
+		return err
+	}
+	if unlocked != nil {
+		events = append(events, unlocked)
+	}
+
 	userAgg := UserAggregateFromWriteModel(&existingPassword.WriteModel)
 	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
 	err = crypto.CompareHash(existingPassword.Secret, []byte(password), c.userPasswordAlg)
 	spanPasswordComparison.EndWithError(err)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		events = append(events, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		if rehashed := rehashPassword(ctx, userAgg, existingPassword.Secret, password, c.userPasswordAlg); rehashed != nil {
+			events = append(events, rehashed)
+		}
+		_, err = c.eventstore.Push(ctx, events...)
 		return err
 	}
-	events := make([]eventstore.Command, 0)
 	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
-	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
-		if existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			events = append(events, user.NewUserLockedEvent(ctx, userAgg))
-		}
-
+	if locked := lockout.lockOnFailedCheck(ctx, lockoutPolicy, lockoutCheckPassword); locked != nil {
+		events = append(events, locked)
 	}
 	_, err = c.eventstore.Push(ctx, events...)
 	logging.Log("COMMAND-9fj7s").OnError(err).Error("error create password check failed event")
 	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
 }
 
+// rehashPassword returns the event to update the hash of a successfully checked password,
+// if the hash was created by another algorithm or with other parameters than configured
+func rehashPassword(ctx context.Context, userAgg *eventstore.Aggregate, secret *crypto.CryptoValue, password string, alg crypto.HashAlgorithm) eventstore.Command {
+	rehasher, ok := alg.(crypto.Rehasher)
+	if !ok || !rehasher.NeedsRehash(secret) {
+		return nil
+	}
+	rehashed, err := crypto.Hash([]byte(password), rehasher)
+	if err != nil {
+		logging.WithFields("userid", userAgg.ID).WithError(err).Warn("unable to rehash password")
+		return nil
+	}
+	return user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, rehashed)
+}
+
 func (c *Commands) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
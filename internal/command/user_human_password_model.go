@@ -16,10 +16,9 @@ type HumanPasswordWriteModel struct {
 	Secret               *crypto.CryptoValue
 	SecretChangeRequired bool
 
-	Code                     *crypto.CryptoValue
-	CodeCreationDate         time.Time
-	CodeExpiry               time.Duration
-	PasswordCheckFailedCount uint64
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
 
 	UserState domain.UserState
 }
@@ -52,7 +51,8 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.Code = nil
-			wm.PasswordCheckFailedCount = 0
+		case *user.HumanPasswordHashUpdatedEvent:
+			wm.Secret = e.Secret
 		case *user.HumanPasswordCodeAddedEvent:
 			wm.Code = e.Code
 			wm.CodeCreationDate = e.CreationDate()
@@ -61,12 +61,6 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			if wm.UserState == domain.UserStateInitial {
 				wm.UserState = domain.UserStateActive
 			}
-		case *user.HumanPasswordCheckFailedEvent:
-			wm.PasswordCheckFailedCount += 1
-		case *user.HumanPasswordCheckSucceededEvent:
-			wm.PasswordCheckFailedCount = 0
-		case *user.UserUnlockedEvent:
-			wm.PasswordCheckFailedCount = 0
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
 		}
@@ -84,21 +78,17 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanInitialCodeAddedType,
 			user.HumanInitializedCheckSucceededType,
 			user.HumanPasswordChangedType,
+			user.HumanPasswordHashUpdatedType,
 			user.HumanPasswordCodeAddedType,
 			user.HumanEmailVerifiedType,
-			user.HumanPasswordCheckFailedType,
-			user.HumanPasswordCheckSucceededType,
 			user.UserRemovedType,
-			user.UserUnlockedType,
 			user.UserV1AddedType,
 			user.UserV1RegisteredType,
 			user.UserV1InitialCodeAddedType,
 			user.UserV1InitializedCheckSucceededType,
 			user.UserV1PasswordChangedType,
 			user.UserV1PasswordCodeAddedType,
-			user.UserV1EmailVerifiedType,
-			user.UserV1PasswordCheckFailedType,
-			user.UserV1PasswordCheckSucceededType).
+			user.UserV1EmailVerifiedType).
 		Builder()
 
 	if wm.ResourceOwner != "" {
@@ -357,7 +357,7 @@ func (c *Commands) beginWebAuthNLogin(ctx context.Context, userID, resourceOwner
 	return userAgg, webAuthNLogin, nil
 }
 
-func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	webAuthNLogin, err := c.getHumanU2FLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
@@ -366,6 +366,18 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 	if err != nil {
 		return err
 	}
+	lockout, err := c.humanLockoutWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	events := make([]eventstore.Command, 0, 3)
+	unlocked, err := lockout.checkLockout(ctx, lockoutPolicy, lockoutCheckOTP, time.Now())
+	if err != nil {
+		return err
+	}
+	if unlocked != nil {
+		events = append(events, unlocked)
+	}
 
 	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, u2fTokens)
 	if err != nil {
@@ -373,18 +385,22 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed u2f check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
+		events = append(events,
 			usr_repo.NewHumanU2FCheckFailedEvent(
 				ctx,
 				userAgg,
 				authRequestDomainToAuthRequestInfo(authRequest),
 			),
 		)
+		if locked := lockout.lockOnFailedCheck(ctx, lockoutPolicy, lockoutCheckOTP); locked != nil {
+			events = append(events, locked)
+		}
+		_, pushErr := c.eventstore.Push(ctx, events...)
 		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed u2f check event")
 		return err
 	}
 
-	_, err = c.eventstore.Push(ctx,
+	events = append(events,
 		usr_repo.NewHumanU2FCheckSucceededEvent(
 			ctx,
 			userAgg,
@@ -397,11 +413,11 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 			signCount,
 		),
 	)
-
+	_, err = c.eventstore.Push(ctx, events...)
 	return err
 }
 
-func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	webAuthNLogin, err := c.getHumanPasswordlessLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
@@ -411,6 +427,18 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 	if err != nil {
 		return err
 	}
+	lockout, err := c.humanLockoutWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	events := make([]eventstore.Command, 0, 3)
+	unlocked, err := lockout.checkLockout(ctx, lockoutPolicy, lockoutCheckOTP, time.Now())
+	if err != nil {
+		return err
+	}
+	if unlocked != nil {
+		events = append(events, unlocked)
+	}
 
 	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, passwordlessTokens)
 	if err != nil {
@@ -418,18 +446,22 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed passwordless check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
+		events = append(events,
 			usr_repo.NewHumanPasswordlessCheckFailedEvent(
 				ctx,
 				userAgg,
 				authRequestDomainToAuthRequestInfo(authRequest),
 			),
 		)
+		if locked := lockout.lockOnFailedCheck(ctx, lockoutPolicy, lockoutCheckOTP); locked != nil {
+			events = append(events, locked)
+		}
+		_, pushErr := c.eventstore.Push(ctx, events...)
 		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed passwordless check event")
 		return err
 	}
 
-	_, err = c.eventstore.Push(ctx,
+	events = append(events,
 		usr_repo.NewHumanPasswordlessCheckSucceededEvent(
 			ctx,
 			userAgg,
@@ -442,6 +474,7 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 			signCount,
 		),
 	)
+	_, err = c.eventstore.Push(ctx, events...)
 	return err
 }
 
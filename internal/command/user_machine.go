@@ -71,6 +71,9 @@ func (c *Commands) AddMachine(ctx context.Context, machine *Machine) (*domain.Ob
 		}
 		machine.AggregateID = userID
 	}
+	if err := c.checkPreMutation(ctx, domain.FlowTypeUser, domain.TriggerTypePreCreation, machine.ResourceOwner, userMutationFromMachine(machine)); err != nil {
+		return nil, err
+	}
 
 	agg := user.NewAggregate(machine.AggregateID, machine.ResourceOwner)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, AddMachineCommand(agg, machine))
@@ -3,11 +3,34 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
+// checkPasswordBreached rejects the password if the policy denies breached passwords
+// and the password is part of a known breach.
+// If the breached passwords can't be checked, the password is rejected unless the check is configured to fail open.
+func (c *Commands) checkPasswordBreached(ctx context.Context, denyBreachedPasswords bool, password string) error {
+	if !denyBreachedPasswords || c.breachedPasswords == nil || password == "" {
+		return nil
+	}
+	breached, err := c.breachedPasswords.IsBreached(ctx, password)
+	if err != nil {
+		logging.WithError(err).WithField("failOpen", c.breachedPasswordsFailOpen).Warn("unable to check password against breached passwords")
+		if c.breachedPasswordsFailOpen {
+			return nil
+		}
+		return errors.ThrowUnavailable(err, "COMMAND-Brch2", "Errors.User.PasswordComplexityPolicy.BreachCheckFailed")
+	}
+	if breached {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Brch1", "Errors.User.PasswordComplexityPolicy.Breached")
+	}
+	return nil
+}
+
 func passwordComplexityPolicyWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer) (*PasswordComplexityPolicyWriteModel, error) {
 	wm, err := customPasswordComplexityPolicy(ctx, filter)
 	if err != nil || wm != nil && wm.State.Exists() {
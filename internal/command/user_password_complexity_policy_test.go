@@ -2,10 +2,14 @@ package command
 
 import (
 	"context"
+	"io"
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -57,6 +61,7 @@ func Test_customPasswordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					}, nil
 				},
@@ -134,6 +139,7 @@ func Test_defaultPasswordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					}, nil
 				},
@@ -202,6 +208,7 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					}, nil
 				},
@@ -253,6 +260,7 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 							),
 						}, nil
 					}).
@@ -298,3 +306,105 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_checkPasswordBreached(t *testing.T) {
+	type fields struct {
+		breachedPasswords         breach.Checker
+		breachedPasswordsFailOpen bool
+	}
+	type args struct {
+		denyBreachedPasswords bool
+		password              string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    func(error) bool
+	}{
+		{
+			name: "not denied, ok",
+			fields: fields{
+				breachedPasswords: mockBreachedPasswords{"password"},
+			},
+			args: args{
+				denyBreachedPasswords: false,
+				password:              "password",
+			},
+		},
+		{
+			name:   "check disabled, ok",
+			fields: fields{},
+			args: args{
+				denyBreachedPasswords: true,
+				password:              "password",
+			},
+		},
+		{
+			name: "not breached, ok",
+			fields: fields{
+				breachedPasswords: mockBreachedPasswords{"password"},
+			},
+			args: args{
+				denyBreachedPasswords: true,
+				password:              "other",
+			},
+		},
+		{
+			name: "breached, invalid argument error",
+			fields: fields{
+				breachedPasswords: mockBreachedPasswords{"password"},
+			},
+			args: args{
+				denyBreachedPasswords: true,
+				password:              "password",
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "check failed, unavailable error",
+			fields: fields{
+				breachedPasswords: mockBreachedPasswordsErr{io.ErrUnexpectedEOF},
+			},
+			args: args{
+				denyBreachedPasswords: true,
+				password:              "password",
+			},
+			err: errors.IsUnavailable,
+		},
+		{
+			name: "check failed, fail open, ok",
+			fields: fields{
+				breachedPasswords:         mockBreachedPasswordsErr{io.ErrUnexpectedEOF},
+				breachedPasswordsFailOpen: true,
+			},
+			args: args{
+				denyBreachedPasswords: true,
+				password:              "password",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				breachedPasswords:         tt.fields.breachedPasswords,
+				breachedPasswordsFailOpen: tt.fields.breachedPasswordsFailOpen,
+			}
+			err := c.checkPasswordBreached(context.Background(), tt.args.denyBreachedPasswords, tt.args.password)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+// mockBreachedPasswordsErr fails every check with the error
+type mockBreachedPasswordsErr struct {
+	err error
+}
+
+func (m mockBreachedPasswordsErr) IsBreached(context.Context, string) (bool, error) {
+	return false, m.err
+}
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"context"
+	"sort"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+var webhookSigningKeyConfig = crypto.GeneratorConfig{
+	Length:              32,
+	IncludeLowerLetters: true,
+	IncludeUpperLetters: true,
+	IncludeDigits:       true,
+}
+
+// AddWebhook adds a webhook to the instance or organization (resourceOwner).
+// The returned signing key is used to sign the deliveries and is only returned once.
+func (c *Commands) AddWebhook(ctx context.Context, addWebhook *domain.Webhook, resourceOwner string) (_ string, _ string, _ *domain.ObjectDetails, err error) {
+	if resourceOwner == "" {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Shoo4", "Errors.ResourceOwnerMissing")
+	}
+	addWebhook.EventTypes = normalizeEventTypes(addWebhook.EventTypes)
+	if !addWebhook.IsValid() {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ooL8a", "Errors.Webhook.Invalid")
+	}
+	if err = checkWebhookEventTypes(addWebhook.EventTypes); err != nil {
+		return "", "", nil, err
+	}
+	webhookID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", "", nil, err
+	}
+	signingKey, plainSigningKey, err := crypto.NewCode(crypto.NewEncryptionGenerator(webhookSigningKeyConfig, c.webhookEncryption))
+	if err != nil {
+		return "", "", nil, err
+	}
+	webhookModel := NewWebhookWriteModel(webhookID, resourceOwner)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewAddedEvent(
+		ctx,
+		WebhookAggregateFromWriteModel(&webhookModel.WriteModel),
+		addWebhook.Name,
+		addWebhook.URL,
+		addWebhook.EventTypes,
+		signingKey,
+	))
+	if err != nil {
+		return "", "", nil, err
+	}
+	err = AppendAndReduce(webhookModel, pushedEvents...)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return webhookModel.AggregateID, plainSigningKey, writeModelToObjectDetails(&webhookModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeWebhook(ctx context.Context, webhookChange *domain.Webhook, resourceOwner string) (*domain.ObjectDetails, error) {
+	webhookChange.EventTypes = normalizeEventTypes(webhookChange.EventTypes)
+	if !webhookChange.IsValid() || webhookChange.AggregateID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-phu7E", "Errors.Webhook.Invalid")
+	}
+	if err := checkWebhookEventTypes(webhookChange.EventTypes); err != nil {
+		return nil, err
+	}
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookChange.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Eeb1u", "Errors.Webhook.NotFound")
+	}
+	changedEvent, err := existingWebhook.NewChangedEvent(
+		ctx,
+		WebhookAggregateFromWriteModel(&existingWebhook.WriteModel),
+		webhookChange.Name,
+		webhookChange.URL,
+		webhookChange.EventTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) RemoveWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Iex5u", "Errors.IDMissing")
+	}
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-oo0Ee", "Errors.Webhook.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewRemovedEvent(ctx, WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// RedeliverWebhookEvent requests another delivery of an event, which could not be delivered to the webhook.
+// An empty resourceOwner allows the redelivery for webhooks of all organizations of the instance.
+func (c *Commands) RedeliverWebhookEvent(ctx context.Context, webhookID, resourceOwner string, eventSequence uint64) (*domain.ObjectDetails, error) {
+	if webhookID == "" || eventSequence == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ahT9o", "Errors.IDMissing")
+	}
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ocie7", "Errors.Webhook.NotFound")
+	}
+	deadLetter, ok := existingWebhook.DeadLetters[eventSequence]
+	if !ok {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-ieT3a", "Errors.Webhook.DeadLetterNotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewRedeliveryRequestedEvent(ctx, WebhookAggregateFromWriteModel(&existingWebhook.WriteModel), deadLetter))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// WebhookDelivered records the successful delivery of an event to the webhook
+func (c *Commands) WebhookDelivered(ctx context.Context, webhookID, resourceOwner string, delivered webhook.DeliveredEvent, attempts uint64) error {
+	_, err := c.eventstore.Push(ctx, webhook.NewDeliverySucceededEvent(
+		ctx,
+		&webhook.NewAggregate(webhookID, resourceOwner).Aggregate,
+		delivered,
+		attempts,
+	))
+	return err
+}
+
+// WebhookDeliveryFailed records the event as dead letter of the webhook after all delivery attempts failed
+func (c *Commands) WebhookDeliveryFailed(ctx context.Context, webhookID, resourceOwner string, delivered webhook.DeliveredEvent, attempts uint64, reason string) error {
+	_, err := c.eventstore.Push(ctx, webhook.NewDeliveryFailedEvent(
+		ctx,
+		&webhook.NewAggregate(webhookID, resourceOwner).Aggregate,
+		delivered,
+		attempts,
+		reason,
+	))
+	return err
+}
+
+func (c *Commands) getWebhookWriteModelByID(ctx context.Context, webhookID, resourceOwner string) (*WebhookWriteModel, error) {
+	webhookModel := NewWebhookWriteModel(webhookID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, webhookModel)
+	if err != nil {
+		return nil, err
+	}
+	return webhookModel, nil
+}
+
+// checkWebhookEventTypes ensures that webhooks only subscribe to event types without secrets
+func checkWebhookEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !webhook.IsSubscribableEventType(eventstore.EventType(eventType)) {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Whk4t", "Errors.Webhook.EventTypeNotAllowed")
+		}
+	}
+	return nil
+}
+
+// normalizeEventTypes sorts the event types and removes empty and duplicate entries
+func normalizeEventTypes(eventTypes []string) []string {
+	normalized := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if eventType != "" {
+			normalized = append(normalized, eventType)
+		}
+	}
+	sort.Strings(normalized)
+	unique := normalized[:0]
+	for _, eventType := range normalized {
+		if len(unique) == 0 || unique[len(unique)-1] != eventType {
+			unique = append(unique, eventType)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+type WebhookWriteModel struct {
+	eventstore.WriteModel
+
+	Name       string
+	URL        string
+	EventTypes []string
+	State      domain.WebhookState
+	// DeadLetters are the events, which could not be delivered, by their sequence
+	DeadLetters map[uint64]webhook.DeliveredEvent
+}
+
+func NewWebhookWriteModel(webhookID string, resourceOwner string) *WebhookWriteModel {
+	return &WebhookWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   webhookID,
+			ResourceOwner: resourceOwner,
+		},
+		DeadLetters: make(map[uint64]webhook.DeliveredEvent),
+	}
+}
+
+func (wm *WebhookWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *webhook.AddedEvent:
+			wm.Name = e.Name
+			wm.URL = e.URL
+			wm.EventTypes = e.EventTypes
+			wm.State = domain.WebhookStateActive
+		case *webhook.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.URL != nil {
+				wm.URL = *e.URL
+			}
+			if e.EventTypes != nil {
+				wm.EventTypes = *e.EventTypes
+			}
+		case *webhook.RemovedEvent:
+			wm.State = domain.WebhookStateRemoved
+		case *webhook.DeliveryFailedEvent:
+			wm.DeadLetters[e.EventSequence] = e.DeliveredEvent
+		case *webhook.DeliverySucceededEvent:
+			delete(wm.DeadLetters, e.EventSequence)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *WebhookWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(webhook.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			webhook.AddedEventType,
+			webhook.ChangedEventType,
+			webhook.RemovedEventType,
+			webhook.DeliveryFailedEventType,
+			webhook.DeliverySucceededEventType,
+		).
+		Builder()
+}
+
+func (wm *WebhookWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name string,
+	url string,
+	eventTypes []string,
+) (*webhook.ChangedEvent, error) {
+	changes := make([]webhook.Changes, 0, 3)
+	if wm.Name != name {
+		changes = append(changes, webhook.ChangeName(name))
+	}
+	if wm.URL != url {
+		changes = append(changes, webhook.ChangeURL(url))
+	}
+	if !equalEventTypes(wm.EventTypes, eventTypes) {
+		changes = append(changes, webhook.ChangeEventTypes(eventTypes))
+	}
+	return webhook.NewChangedEvent(ctx, agg, changes)
+}
+
+func equalEventTypes(existing, eventTypes []string) bool {
+	if len(existing) != len(eventTypes) {
+		return false
+	}
+	for i := range existing {
+		if existing[i] != eventTypes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func WebhookAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, webhook.AggregateType, webhook.AggregateVersion)
+}
@@ -0,0 +1,561 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+func TestCommands_AddWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		addWebhook    *domain.Webhook
+		resourceOwner string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"resource owner missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"user.locked"},
+				},
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid url, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:       "name",
+					URL:        "ftp://example.com/hook",
+					EventTypes: []string{"user.locked"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no event types, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{""},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"event type with secrets, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"user.locked", "user.human.password.code.added"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			_, _, _, err := c.AddWebhook(tt.args.ctx, tt.args.addWebhook, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		changeWebhook *domain.Webhook
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"user.locked"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"user.locked"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"event type with secrets, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"user.human.password.changed", "project.application.config.oidc.secret.changed"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no changes, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user.locked", "user.unlocked"},
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"user.unlocked", "user.locked", "user.locked"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user.locked"},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *webhook.ChangedEvent {
+									event, _ := webhook.NewChangedEvent(context.Background(),
+										&webhook.NewAggregate("id1", "org1").Aggregate,
+										[]webhook.Changes{
+											webhook.ChangeURL("https://example.com/hook2"),
+											webhook.ChangeEventTypes([]string{"user.locked", "user.unlocked"}),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					URL:        "https://example.com/hook2",
+					EventTypes: []string{"user.unlocked", "user.locked"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ChangeWebhook(tt.args.ctx, tt.args.changeWebhook, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user.locked"},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewRemovedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveWebhook(tt.args.ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RedeliverWebhookEvent(t *testing.T) {
+	deliveredEvent := webhook.DeliveredEvent{
+		EventSequence:      12,
+		DeliveredEventType: "user.locked",
+		AggregateType:      "user",
+		AggregateID:        "user1",
+		EventResourceOwner: "org1",
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+		eventSequence uint64
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"sequence missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"dead letter delivered, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user.locked"},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							webhook.NewDeliveryFailedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								deliveredEvent,
+								3,
+								"503 Service Unavailable",
+							),
+						),
+						eventFromEventPusher(
+							webhook.NewDeliverySucceededEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								deliveredEvent,
+								1,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "org1",
+				eventSequence: 12,
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"redelivery ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user.locked"},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							webhook.NewDeliveryFailedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								deliveredEvent,
+								3,
+								"503 Service Unavailable",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewRedeliveryRequestedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									deliveredEvent,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "org1",
+				eventSequence: 12,
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RedeliverWebhookEvent(tt.args.ctx, tt.args.id, tt.args.resourceOwner, tt.args.eventSequence)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
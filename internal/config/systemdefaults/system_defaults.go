@@ -3,11 +3,14 @@ package systemdefaults
 import (
 	"time"
 
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/crypto"
 )
 
 type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
+	PasswordHasher     crypto.PasswordHashConfig
+	BreachedPasswords  breach.Config
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
 	Notifications      Notifications
@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var _ passwordHashAlgorithm = (*Argon2id)(nil)
+
+const (
+	argon2idPrefix = "$argon2id$"
+
+	argon2idMaxTime = 32
+	// argon2idMaxMemory in KiB (1 GiB)
+	argon2idMaxMemory = 1 << 20
+)
+
+// Argon2id hashes passwords in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+type Argon2id struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+func NewArgon2id(time, memory uint32, threads uint8) *Argon2id {
+	return &Argon2id{time: time, memory: memory, threads: threads}
+}
+
+func (a *Argon2id) Algorithm() string {
+	return "argon2id"
+}
+
+func (a *Argon2id) Hash(value []byte) ([]byte, error) {
+	salt, err := passwordSalt()
+	if err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(value, salt, a.time, a.memory, a.threads, passwordKeyLength)
+	return []byte(fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version, a.memory, a.time, a.threads, encodePHC(salt), encodePHC(key))), nil
+}
+
+func (a *Argon2id) CompareHash(hashed, comparer []byte) error {
+	params, err := parseArgon2id(hashed)
+	if err != nil {
+		return err
+	}
+	key := argon2.IDKey(comparer, params.salt, params.time, params.memory, params.threads, uint32(len(params.key)))
+	if subtle.ConstantTimeCompare(key, params.key) != 1 {
+		return errHashMismatch
+	}
+	return nil
+}
+
+func (a *Argon2id) identifies(encoded []byte) bool {
+	return bytes.HasPrefix(encoded, []byte(argon2idPrefix))
+}
+
+func (a *Argon2id) outdated(encoded []byte) bool {
+	params, err := parseArgon2id(encoded)
+	return err != nil || params.time != a.time || params.memory != a.memory || params.threads != a.threads
+}
+
+func (a *Argon2id) validate(encoded []byte) error {
+	_, err := parseArgon2id(encoded)
+	return err
+}
+
+// checkArgon2idParams returns an error for parameters which would let argon2 panic or use excessive resources
+func checkArgon2idParams(time, memory uint32, threads uint8) error {
+	if time == 0 || time > argon2idMaxTime ||
+		memory == 0 || memory > argon2idMaxMemory ||
+		threads == 0 {
+		return errHashParams
+	}
+	return nil
+}
+
+type argon2idParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func parseArgon2id(encoded []byte) (params argon2idParams, err error) {
+	var version int
+	var parts []string
+	if parts, err = splitPHC(encoded, 5); err != nil {
+		return params, err
+	}
+	if _, err = fmt.Sscanf(parts[1], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, errHashFormat
+	}
+	if _, err = fmt.Sscanf(parts[2], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, errHashFormat
+	}
+	if err = checkArgon2idParams(params.time, params.memory, params.threads); err != nil {
+		return params, err
+	}
+	if params.salt, err = decodePHC(parts[3]); err != nil {
+		return params, err
+	}
+	if params.key, err = decodePHC(parts[4]); err != nil {
+		return params, err
+	}
+	return params, checkPasswordKeyLength(params.key)
+}
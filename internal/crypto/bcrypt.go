@@ -1,10 +1,14 @@
 package crypto
 
 import (
+	"bytes"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
-var _ HashAlgorithm = (*BCrypt)(nil)
+var _ passwordHashAlgorithm = (*BCrypt)(nil)
+
+const bcryptMaxCost = 20
 
 type BCrypt struct {
 	cost int
@@ -23,5 +27,35 @@ func (b *BCrypt) Hash(value []byte) ([]byte, error) {
 }
 
 func (b *BCrypt) CompareHash(hashed, value []byte) error {
+	if err := b.validate(hashed); err != nil {
+		return err
+	}
 	return bcrypt.CompareHashAndPassword(hashed, value)
 }
+
+func (b *BCrypt) identifies(encoded []byte) bool {
+	return bytes.HasPrefix(encoded, []byte("$2a$")) ||
+		bytes.HasPrefix(encoded, []byte("$2b$")) ||
+		bytes.HasPrefix(encoded, []byte("$2y$"))
+}
+
+func (b *BCrypt) outdated(encoded []byte) bool {
+	cost, err := bcrypt.Cost(encoded)
+	return err != nil || cost != b.cost
+}
+
+func (b *BCrypt) validate(encoded []byte) error {
+	cost, err := bcrypt.Cost(encoded)
+	if err != nil {
+		return errHashFormat
+	}
+	return checkBCryptCost(cost)
+}
+
+// checkBCryptCost returns an error for costs bcrypt rejects or which would take minutes per password check
+func checkBCryptCost(cost int) error {
+	if cost < bcrypt.MinCost || cost > bcryptMaxCost {
+		return errHashParams
+	}
+	return nil
+}
@@ -119,8 +119,13 @@ func Hash(value []byte, alg HashAlgorithm) (*CryptoValue, error) {
 	}, nil
 }
 
+// hashVerifier is implemented by hash algorithms, which compare hashes of other algorithms too
+type hashVerifier interface {
+	Verifies(algorithm string) bool
+}
+
 func CompareHash(value *CryptoValue, comparer []byte, alg HashAlgorithm) error {
-	if value.Algorithm != alg.Algorithm() {
+	if verifier, ok := alg.(hashVerifier); value.Algorithm != alg.Algorithm() && (!ok || !verifier.Verifies(value.Algorithm)) {
 		return errors.ThrowInvalidArgument(nil, "CRYPT-HF32f", "value was hashed with a different algorithm")
 	}
 	return alg.CompareHash(value.Crypted, comparer)
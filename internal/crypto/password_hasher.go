@@ -0,0 +1,230 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	passwordSaltLength = 16
+	passwordKeyLength  = 32
+
+	// the limits of the key length apply to hashes of all algorithms except bcrypt,
+	// they prevent trivial matches of empty keys and the derivation of excessively long keys
+	passwordMinKeyLength = 16
+	passwordMaxKeyLength = 128
+)
+
+var (
+	errHashMismatch = errors.ThrowInvalidArgument(nil, "CRYPT-Pw9kd", "hash does not match")
+	errHashFormat   = errors.ThrowInvalidArgument(nil, "CRYPT-Pw2fm", "hash has an invalid format")
+	errHashParams   = errors.ThrowInvalidArgument(nil, "CRYPT-Pw7pr", "hash parameters are out of range")
+)
+
+// passwordHashAlgorithm is a HashAlgorithm, which encodes its parameters in the hash
+type passwordHashAlgorithm interface {
+	HashAlgorithm
+	// identifies returns true if the encoded hash was created by the algorithm
+	identifies(encoded []byte) bool
+	// outdated returns true if the encoded hash was created with other parameters
+	outdated(encoded []byte) bool
+	// validate returns an error if the encoded hash can't be compared,
+	// because it's malformed or its parameters are out of range
+	validate(encoded []byte) error
+}
+
+// Rehasher is a HashAlgorithm, which detects hashes that should be updated
+type Rehasher interface {
+	HashAlgorithm
+	NeedsRehash(value *CryptoValue) bool
+}
+
+var _ Rehasher = (*PasswordHasher)(nil)
+
+type PasswordHashConfig struct {
+	// Algorithm used to hash new passwords: bcrypt, argon2id, scrypt or pbkdf2
+	Algorithm string
+	BCrypt    BCryptConfig
+	Argon2id  Argon2idConfig
+	Scrypt    ScryptConfig
+	PBKDF2    PBKDF2Config
+}
+
+type BCryptConfig struct {
+	Cost int
+}
+
+type Argon2idConfig struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+type ScryptConfig struct {
+	Cost      int
+	BlockSize int
+	Parallel  int
+}
+
+type PBKDF2Config struct {
+	Iterations int
+	Hash       string
+}
+
+// PasswordHasher hashes with the configured algorithm
+// and verifies hashes of all supported algorithms
+type PasswordHasher struct {
+	hasher    passwordHashAlgorithm
+	verifiers []passwordHashAlgorithm
+}
+
+func NewPasswordHasher(config PasswordHashConfig) (*PasswordHasher, error) {
+	var hasher passwordHashAlgorithm
+	var err error
+	switch config.Algorithm {
+	case "", "bcrypt":
+		hasher = NewBCrypt(config.BCrypt.Cost)
+		err = checkBCryptCost(config.BCrypt.Cost)
+	case "argon2id":
+		hasher = NewArgon2id(config.Argon2id.Time, config.Argon2id.Memory, config.Argon2id.Threads)
+		err = checkArgon2idParams(config.Argon2id.Time, config.Argon2id.Memory, config.Argon2id.Threads)
+	case "scrypt":
+		hasher = NewScrypt(config.Scrypt.Cost, config.Scrypt.BlockSize, config.Scrypt.Parallel)
+		err = checkScryptParams(config.Scrypt.Cost, config.Scrypt.BlockSize, config.Scrypt.Parallel)
+	case "pbkdf2":
+		if _, _, err := pbkdf2Hash(config.PBKDF2.Hash); err != nil {
+			return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-Pw3hs", "pbkdf2 hash %s not supported", config.PBKDF2.Hash)
+		}
+		hasher = NewPBKDF2(config.PBKDF2.Iterations, config.PBKDF2.Hash)
+		err = checkPBKDF2Iterations(config.PBKDF2.Iterations)
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-Pw1al", "password hash algorithm %s not supported", config.Algorithm)
+	}
+	if err != nil {
+		return nil, errors.ThrowInvalidArgumentf(err, "CRYPT-Pw8cf", "parameters of password hash algorithm %s are out of range", hasher.Algorithm())
+	}
+	return &PasswordHasher{
+		hasher:    hasher,
+		verifiers: passwordVerifiers(hasher),
+	}, nil
+}
+
+func (h *PasswordHasher) Algorithm() string {
+	return h.hasher.Algorithm()
+}
+
+func (h *PasswordHasher) Hash(value []byte) ([]byte, error) {
+	return h.hasher.Hash(value)
+}
+
+func (h *PasswordHasher) CompareHash(hashed, comparer []byte) error {
+	verifier := identifyPasswordHash(h.verifiers, hashed)
+	if verifier == nil {
+		return errHashFormat
+	}
+	return verifier.CompareHash(hashed, comparer)
+}
+
+// Verifies returns true if hashes of the algorithm can be compared
+func (h *PasswordHasher) Verifies(algorithm string) bool {
+	for _, verifier := range h.verifiers {
+		if verifier.Algorithm() == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsRehash returns true if the hash was created by another algorithm
+// or with other parameters than configured
+func (h *PasswordHasher) NeedsRehash(value *CryptoValue) bool {
+	return !h.hasher.identifies(value.Crypted) || h.hasher.outdated(value.Crypted)
+}
+
+// FillPasswordHash creates the CryptoValue of an encoded hash of any supported algorithm
+// e.g. of imported users.
+// The hash is fully parsed, so hashes which could never be compared are rejected on import.
+func FillPasswordHash(encoded []byte) (*CryptoValue, error) {
+	verifier := identifyPasswordHash(passwordVerifiers(nil), encoded)
+	if verifier == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Pw4im", "Errors.User.Password.HashAlgorithmNotSupported")
+	}
+	if err := verifier.validate(encoded); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Pw5iv", "Errors.User.Password.HashInvalid")
+	}
+	return FillHash(encoded, verifier), nil
+}
+
+// passwordVerifiers returns the hasher and all other supported algorithms,
+// the parameters of the others are irrelevant as they are read from the hash
+func passwordVerifiers(hasher passwordHashAlgorithm) []passwordHashAlgorithm {
+	verifiers := []passwordHashAlgorithm{
+		NewBCrypt(0),
+		NewArgon2id(0, 0, 0),
+		NewScrypt(0, 0, 0),
+		NewPBKDF2(0, ""),
+	}
+	if hasher == nil {
+		return verifiers
+	}
+	for i, verifier := range verifiers {
+		if verifier.Algorithm() == hasher.Algorithm() {
+			verifiers[i] = hasher
+		}
+	}
+	return verifiers
+}
+
+func identifyPasswordHash(verifiers []passwordHashAlgorithm, encoded []byte) passwordHashAlgorithm {
+	for _, verifier := range verifiers {
+		if verifier.identifies(encoded) {
+			return verifier
+		}
+	}
+	return nil
+}
+
+func passwordSalt() ([]byte, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// splitPHC splits an encoded hash `$<id>$<param>...$<hash>` into its parts without the leading `$`
+func splitPHC(encoded []byte, parts int) ([]string, error) {
+	if len(encoded) == 0 || encoded[0] != '$' {
+		return nil, errHashFormat
+	}
+	split := strings.Split(string(encoded[1:]), "$")
+	if len(split) != parts {
+		return nil, errHashFormat
+	}
+	return split, nil
+}
+
+// checkPasswordKeyLength returns an error if the length of the derived key is out of range
+func checkPasswordKeyLength(key []byte) error {
+	if len(key) < passwordMinKeyLength || len(key) > passwordMaxKeyLength {
+		return errHashParams
+	}
+	return nil
+}
+
+func encodePHC(value []byte) string {
+	return base64.RawStdEncoding.EncodeToString(value)
+}
+
+// decodePHC decodes standard base64 with or without padding
+// and the adapted base64 of passlib, which uses `.` instead of `+`
+func decodePHC(value string) ([]byte, error) {
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.ReplaceAll(strings.TrimRight(value, "="), ".", "+"))
+	if err != nil {
+		return nil, errHashFormat
+	}
+	return decoded, nil
+}
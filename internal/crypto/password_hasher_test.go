@@ -0,0 +1,325 @@
+package crypto
+
+import (
+	"testing"
+)
+
+func TestPasswordHasher_HashAndCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PasswordHashConfig
+	}{
+		{
+			"bcrypt",
+			PasswordHashConfig{Algorithm: "bcrypt", BCrypt: BCryptConfig{Cost: 4}},
+		},
+		{
+			"argon2id",
+			PasswordHashConfig{Algorithm: "argon2id", Argon2id: Argon2idConfig{Time: 1, Memory: 64, Threads: 1}},
+		},
+		{
+			"scrypt",
+			PasswordHashConfig{Algorithm: "scrypt", Scrypt: ScryptConfig{Cost: 4, BlockSize: 8, Parallel: 1}},
+		},
+		{
+			"pbkdf2",
+			PasswordHashConfig{Algorithm: "pbkdf2", PBKDF2: PBKDF2Config{Iterations: 10, Hash: "sha256"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(tt.config)
+			if err != nil {
+				t.Fatalf("NewPasswordHasher() error = %v", err)
+			}
+			value, err := Hash([]byte("password"), hasher)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if value.Algorithm != tt.config.Algorithm {
+				t.Errorf("Hash() algorithm = %s, want %s", value.Algorithm, tt.config.Algorithm)
+			}
+			if err = CompareHash(value, []byte("password"), hasher); err != nil {
+				t.Errorf("CompareHash() error = %v", err)
+			}
+			if err = CompareHash(value, []byte("wrong"), hasher); err == nil {
+				t.Error("CompareHash() of wrong password must fail")
+			}
+			if hasher.NeedsRehash(value) {
+				t.Error("NeedsRehash() of current hash must be false")
+			}
+		})
+	}
+}
+
+func TestPasswordHasher_CompareHash(t *testing.T) {
+	hasher, err := NewPasswordHasher(PasswordHashConfig{Algorithm: "argon2id", Argon2id: Argon2idConfig{Time: 1, Memory: 64, Threads: 1}})
+	if err != nil {
+		t.Fatalf("NewPasswordHasher() error = %v", err)
+	}
+	tests := []struct {
+		name        string
+		value       *CryptoValue
+		wantErr     bool
+		needsRehash bool
+	}{
+		{
+			"bcrypt",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "bcrypt", Crypted: []byte("$2a$04$3pRNk7pVeE7SgduShliQeeB5XKTI7.R/lgvmmVhBQbx0qRURu/7Hq")},
+			false,
+			true,
+		},
+		{
+			"scrypt",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "scrypt", Crypted: []byte("$scrypt$ln=10,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$BVMRKqdiVYikKAaPR1wucsKUKvw4TuPLkdEYtoSHas4")},
+			false,
+			true,
+		},
+		{
+			"pbkdf2-sha256 passlib",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "pbkdf2", Crypted: []byte("$pbkdf2-sha256$1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA")},
+			false,
+			true,
+		},
+		{
+			"pbkdf2-sha1 passlib",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "pbkdf2", Crypted: []byte("$pbkdf2$1000$c2FsdHNhbHRzYWx0c2FsdA$2FWw/oC7TQkskizC.81lWlmFAMM")},
+			false,
+			true,
+		},
+		{
+			"unknown format",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "argon2id", Crypted: []byte("$md5$password")},
+			true,
+			true,
+		},
+		{
+			"argon2id time zero",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "argon2id", Crypted: []byte("$argon2id$v=19$m=64,t=0,p=0$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g")},
+			true,
+			true,
+		},
+		{
+			"argon2id empty key",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "argon2id", Crypted: []byte("$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$")},
+			true,
+			true,
+		},
+		{
+			"unsupported algorithm",
+			&CryptoValue{CryptoType: TypeHash, Algorithm: "md5", Crypted: []byte("$2a$04$3pRNk7pVeE7SgduShliQeeB5XKTI7.R/lgvmmVhBQbx0qRURu/7Hq")},
+			true,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompareHash(tt.value, []byte("password"), hasher)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CompareHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := hasher.NeedsRehash(tt.value); got != tt.needsRehash {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.needsRehash)
+			}
+		})
+	}
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	old, err := NewPasswordHasher(PasswordHashConfig{Algorithm: "argon2id", Argon2id: Argon2idConfig{Time: 1, Memory: 64, Threads: 1}})
+	if err != nil {
+		t.Fatalf("NewPasswordHasher() error = %v", err)
+	}
+	value, err := Hash([]byte("password"), old)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	hasher, err := NewPasswordHasher(PasswordHashConfig{Algorithm: "argon2id", Argon2id: Argon2idConfig{Time: 2, Memory: 64, Threads: 1}})
+	if err != nil {
+		t.Fatalf("NewPasswordHasher() error = %v", err)
+	}
+	if !hasher.NeedsRehash(value) {
+		t.Error("NeedsRehash() of hash with other parameters must be true")
+	}
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  PasswordHashConfig
+		wantErr bool
+	}{
+		{
+			"unsupported algorithm",
+			PasswordHashConfig{Algorithm: "md5"},
+			true,
+		},
+		{
+			"unsupported pbkdf2 hash",
+			PasswordHashConfig{Algorithm: "pbkdf2", PBKDF2: PBKDF2Config{Iterations: 10, Hash: "md5"}},
+			true,
+		},
+		{
+			"default bcrypt",
+			PasswordHashConfig{BCrypt: BCryptConfig{Cost: 4}},
+			false,
+		},
+		{
+			"bcrypt cost too high",
+			PasswordHashConfig{Algorithm: "bcrypt", BCrypt: BCryptConfig{Cost: 31}},
+			true,
+		},
+		{
+			"argon2id threads zero",
+			PasswordHashConfig{Algorithm: "argon2id", Argon2id: Argon2idConfig{Time: 1, Memory: 64}},
+			true,
+		},
+		{
+			"scrypt memory too high",
+			PasswordHashConfig{Algorithm: "scrypt", Scrypt: ScryptConfig{Cost: 25, BlockSize: 8, Parallel: 1}},
+			true,
+		},
+		{
+			"pbkdf2 iterations zero",
+			PasswordHashConfig{Algorithm: "pbkdf2", PBKDF2: PBKDF2Config{Hash: "sha256"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPasswordHasher(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPasswordHasher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFillPasswordHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		want    string
+		wantErr bool
+	}{
+		{
+			"bcrypt",
+			"$2y$04$3pRNk7pVeE7SgduShliQeeB5XKTI7.R/lgvmmVhBQbx0qRURu/7Hq",
+			"bcrypt",
+			false,
+		},
+		{
+			"argon2id",
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"argon2id",
+			false,
+		},
+		{
+			"pbkdf2",
+			"$pbkdf2-sha512$i=1000$c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"pbkdf2",
+			false,
+		},
+		{
+			"scrypt",
+			"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$BVMRKqdiVYikKAaPR1wucsKUKvw4TuPLkdEYtoSHas4",
+			"scrypt",
+			false,
+		},
+		{
+			"unsupported",
+			"password",
+			"",
+			true,
+		},
+		{
+			"bcrypt malformed",
+			"$2y$04$short",
+			"",
+			true,
+		},
+		{
+			"bcrypt cost too high",
+			"$2y$31$3pRNk7pVeE7SgduShliQeeB5XKTI7.R/lgvmmVhBQbx0qRURu/7Hq",
+			"",
+			true,
+		},
+		{
+			"argon2id time zero",
+			"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"argon2id threads zero",
+			"$argon2id$v=19$m=64,t=1,p=0$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"argon2id memory too high",
+			"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"argon2id empty key",
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$",
+			"",
+			true,
+		},
+		{
+			"argon2id missing salt and key",
+			"$argon2id$v=19$m=64,t=1,p=1",
+			"",
+			true,
+		},
+		{
+			"scrypt cost too high",
+			"$scrypt$ln=40,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"scrypt memory too high",
+			"$scrypt$ln=20,r=16,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"scrypt block size zero",
+			"$scrypt$ln=10,r=0,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"pbkdf2 iterations too high",
+			"$pbkdf2-sha256$i=100000000$c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"pbkdf2 unsupported hash",
+			"$pbkdf2-md5$i=1000$c2FsdA$c2FsdHNhbHRzYWx0c2FsdGhhc2hoYXNoaGFzaGhhc2g",
+			"",
+			true,
+		},
+		{
+			"pbkdf2 key too long",
+			"$pbkdf2-sha256$i=1000$c2FsdA$AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FillPasswordHash([]byte(tt.encoded))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FillPasswordHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Algorithm != tt.want {
+				t.Errorf("FillPasswordHash() algorithm = %s, want %s", got.Algorithm, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var _ passwordHashAlgorithm = (*PBKDF2)(nil)
+
+const (
+	pbkdf2Prefix = "$pbkdf2"
+
+	pbkdf2MaxIterations = 10_000_000
+)
+
+// PBKDF2 hashes passwords in the PHC string format:
+// $pbkdf2-<hash>$i=<iterations>$<salt>$<hash>
+// For verification the iterations are also accepted without the `i=` prefix (passlib format)
+type PBKDF2 struct {
+	iterations int
+	hash       string
+}
+
+// NewPBKDF2 creates a pbkdf2 hasher, the hash must be one of sha1, sha256 or sha512
+func NewPBKDF2(iterations int, hash string) *PBKDF2 {
+	return &PBKDF2{iterations: iterations, hash: hash}
+}
+
+func (p *PBKDF2) Algorithm() string {
+	return "pbkdf2"
+}
+
+func (p *PBKDF2) Hash(value []byte) ([]byte, error) {
+	hashFunc, size, err := pbkdf2Hash(p.hash)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := passwordSalt()
+	if err != nil {
+		return nil, err
+	}
+	key := pbkdf2.Key(value, salt, p.iterations, size, hashFunc)
+	return []byte(fmt.Sprintf("%s-%s$i=%d$%s$%s", pbkdf2Prefix, p.hash, p.iterations, encodePHC(salt), encodePHC(key))), nil
+}
+
+func (p *PBKDF2) CompareHash(hashed, comparer []byte) error {
+	params, err := parsePBKDF2(hashed)
+	if err != nil {
+		return err
+	}
+	hashFunc, _, err := pbkdf2Hash(params.hash)
+	if err != nil {
+		return err
+	}
+	key := pbkdf2.Key(comparer, params.salt, params.iterations, len(params.key), hashFunc)
+	if subtle.ConstantTimeCompare(key, params.key) != 1 {
+		return errHashMismatch
+	}
+	return nil
+}
+
+func (p *PBKDF2) identifies(encoded []byte) bool {
+	return bytes.HasPrefix(encoded, []byte(pbkdf2Prefix))
+}
+
+func (p *PBKDF2) outdated(encoded []byte) bool {
+	params, err := parsePBKDF2(encoded)
+	return err != nil || params.iterations != p.iterations || params.hash != p.hash
+}
+
+func (p *PBKDF2) validate(encoded []byte) error {
+	params, err := parsePBKDF2(encoded)
+	if err != nil {
+		return err
+	}
+	_, _, err = pbkdf2Hash(params.hash)
+	return err
+}
+
+func checkPBKDF2Iterations(iterations int) error {
+	if iterations <= 0 || iterations > pbkdf2MaxIterations {
+		return errHashParams
+	}
+	return nil
+}
+
+type pbkdf2Params struct {
+	hash       string
+	iterations int
+	salt       []byte
+	key        []byte
+}
+
+func parsePBKDF2(encoded []byte) (params pbkdf2Params, err error) {
+	var parts []string
+	if parts, err = splitPHC(encoded, 4); err != nil {
+		return params, err
+	}
+	// pbkdf2 without hash suffix defaults to sha1
+	params.hash = "sha1"
+	if _, hashName, ok := strings.Cut(parts[0], "-"); ok {
+		params.hash = hashName
+	}
+	if _, err = fmt.Sscanf(strings.TrimPrefix(parts[1], "i="), "%d", &params.iterations); err != nil {
+		return params, errHashFormat
+	}
+	if err = checkPBKDF2Iterations(params.iterations); err != nil {
+		return params, err
+	}
+	if params.salt, err = decodePHC(parts[2]); err != nil {
+		return params, err
+	}
+	if params.key, err = decodePHC(parts[3]); err != nil {
+		return params, err
+	}
+	return params, checkPasswordKeyLength(params.key)
+}
+
+func pbkdf2Hash(name string) (func() hash.Hash, int, error) {
+	switch name {
+	case "sha1":
+		return sha1.New, sha1.Size, nil
+	case "sha256":
+		return sha256.New, sha256.Size, nil
+	case "sha512":
+		return sha512.New, sha512.Size, nil
+	default:
+		return nil, 0, errHashFormat
+	}
+}
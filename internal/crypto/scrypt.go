@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+var _ passwordHashAlgorithm = (*Scrypt)(nil)
+
+const (
+	scryptPrefix = "$scrypt$"
+
+	// scryptMaxMemory limits the memory needed by a single derivation (128 * r * N bytes) to 1 GiB
+	scryptMaxMemory   = 1 << 30
+	scryptMaxParallel = 16
+)
+
+// Scrypt hashes passwords in the PHC string format:
+// $scrypt$ln=<log2(N)>,r=<block size>,p=<parallelism>$<salt>$<hash>
+type Scrypt struct {
+	cost      int
+	blockSize int
+	parallel  int
+}
+
+// NewScrypt creates a scrypt hasher, the cost is the exponent of the CPU/memory cost parameter N
+func NewScrypt(cost, blockSize, parallel int) *Scrypt {
+	return &Scrypt{cost: cost, blockSize: blockSize, parallel: parallel}
+}
+
+func (s *Scrypt) Algorithm() string {
+	return "scrypt"
+}
+
+func (s *Scrypt) Hash(value []byte) ([]byte, error) {
+	salt, err := passwordSalt()
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(value, salt, 1<<s.cost, s.blockSize, s.parallel, passwordKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s", scryptPrefix, s.cost, s.blockSize, s.parallel, encodePHC(salt), encodePHC(key))), nil
+}
+
+func (s *Scrypt) CompareHash(hashed, comparer []byte) error {
+	params, err := parseScrypt(hashed)
+	if err != nil {
+		return err
+	}
+	key, err := scrypt.Key(comparer, params.salt, 1<<params.cost, params.blockSize, params.parallel, len(params.key))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(key, params.key) != 1 {
+		return errHashMismatch
+	}
+	return nil
+}
+
+func (s *Scrypt) identifies(encoded []byte) bool {
+	return bytes.HasPrefix(encoded, []byte(scryptPrefix))
+}
+
+func (s *Scrypt) outdated(encoded []byte) bool {
+	params, err := parseScrypt(encoded)
+	return err != nil || params.cost != s.cost || params.blockSize != s.blockSize || params.parallel != s.parallel
+}
+
+func (s *Scrypt) validate(encoded []byte) error {
+	_, err := parseScrypt(encoded)
+	return err
+}
+
+// checkScryptParams returns an error for parameters which scrypt rejects or which would use excessive resources
+func checkScryptParams(cost, blockSize, parallel int) error {
+	if cost <= 0 || cost >= 30 ||
+		blockSize <= 0 || blockSize > scryptMaxMemory/128 ||
+		parallel <= 0 || parallel > scryptMaxParallel {
+		return errHashParams
+	}
+	if int64(128*blockSize)<<cost > scryptMaxMemory {
+		return errHashParams
+	}
+	return nil
+}
+
+type scryptParams struct {
+	cost      int
+	blockSize int
+	parallel  int
+	salt      []byte
+	key       []byte
+}
+
+func parseScrypt(encoded []byte) (params scryptParams, err error) {
+	var parts []string
+	if parts, err = splitPHC(encoded, 4); err != nil {
+		return params, err
+	}
+	if _, err = fmt.Sscanf(parts[1], "ln=%d,r=%d,p=%d", &params.cost, &params.blockSize, &params.parallel); err != nil {
+		return params, errHashFormat
+	}
+	if err = checkScryptParams(params.cost, params.blockSize, params.parallel); err != nil {
+		return params, err
+	}
+	if params.salt, err = decodePHC(parts[2]); err != nil {
+		return params, err
+	}
+	if params.key, err = decodePHC(parts[3]); err != nil {
+		return params, err
+	}
+	return params, checkPasswordKeyLength(params.key)
+}
@@ -0,0 +1,21 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// SignPayload signs the timestamp and body with HMAC-SHA256 as `t=<unix timestamp>,v1=<hex signature>`,
+// the receiver verifies the signature by computing the HMAC of `<timestamp>.<body>`.
+// It's used for the deliveries of the webhooks and the calls of the action targets
+func SignPayload(signingKey string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,13 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignPayload(t *testing.T) {
+	want := "t=1600000000,v1=1877430bcdccfff0a97569ab9b2ccf19db6ae2245caf5a6f1ca96108dc406dd0"
+	if got := SignPayload("signingKey", time.Unix(1600000000, 0), []byte(`{"key":"value"}`)); got != want {
+		t.Errorf("SignPayload() = %s, want %s", got, want)
+	}
+}
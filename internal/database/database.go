@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"reflect"
+	"sync/atomic"
 
 	_ "github.com/zitadel/zitadel/internal/database/cockroach"
 	"github.com/zitadel/zitadel/internal/database/dialect"
@@ -19,9 +20,15 @@ func (c *Config) SetConnector(connector dialect.Connector) {
 	c.connector = connector
 }
 
+// DB is the connection pool of the primary database,
+// the queries of the projections can be routed to read replicas with [DB.Replica]
 type DB struct {
 	*sql.DB
 	dialect.Database
+
+	replicas       []*sql.DB
+	readYourWrites bool
+	nextReplica    atomic.Uint32
 }
 
 func Connect(config Config, useAdmin bool) (*DB, error) {
@@ -34,10 +41,64 @@ func Connect(config Config, useAdmin bool) (*DB, error) {
 		return nil, errors.ThrowPreconditionFailed(err, "DATAB-0pIWD", "Errors.Database.Connection.Failed")
 	}
 
-	return &DB{
+	db := &DB{
 		DB:       client,
 		Database: config.connector,
-	}, nil
+	}
+	if replicaConnector, ok := config.connector.(dialect.ReplicaConnector); ok && !useAdmin {
+		if err = db.connectReplicas(replicaConnector); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+func (db *DB) connectReplicas(connector dialect.ReplicaConnector) error {
+	replicas, err := connector.ConnectReplicas()
+	if err != nil {
+		return err
+	}
+	for _, replica := range replicas {
+		if err = replica.Ping(); err != nil {
+			for _, replica := range replicas {
+				replica.Close()
+			}
+			return errors.ThrowPreconditionFailed(err, "DATAB-Rp1cn", "Errors.Database.Connection.Failed")
+		}
+	}
+	db.replicas = replicas
+	db.readYourWrites = connector.ReplicaConfig().ReadYourWrites
+	return nil
+}
+
+// HasReplicas returns if read replicas are configured
+func (db *DB) HasReplicas() bool {
+	return len(db.replicas) > 0
+}
+
+// Replica returns the connection pool of a read replica, the replicas are used in turns.
+// The primary is returned if no replica is configured.
+// Replicas lag behind the primary, they must only be used for queries which tolerate stale data.
+func (db *DB) Replica() *sql.DB {
+	if len(db.replicas) == 0 {
+		return db.DB
+	}
+	return db.replicas[int(db.nextReplica.Add(1)-1)%len(db.replicas)]
+}
+
+// ReadYourWrites returns if the queries must read the events pushed by this process,
+// the primary is queried if the replica didn't process them yet
+func (db *DB) ReadYourWrites() bool {
+	return db.readYourWrites
+}
+
+// Close closes the primary and the replicas
+func (db *DB) Close() error {
+	for _, replica := range db.replicas {
+		replica.Close()
+	}
+	return db.DB.Close()
 }
 
 func DecodeHook(from, to reflect.Value) (interface{}, error) {
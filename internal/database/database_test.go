@@ -0,0 +1,26 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDB_Replica(t *testing.T) {
+	primary, replica1, replica2 := new(sql.DB), new(sql.DB), new(sql.DB)
+
+	t.Run("no replicas", func(t *testing.T) {
+		db := &DB{DB: primary}
+		assert.False(t, db.HasReplicas())
+		assert.Same(t, primary, db.Replica())
+		assert.Same(t, primary, db.Replica())
+	})
+	t.Run("replicas in turns", func(t *testing.T) {
+		db := &DB{DB: primary, replicas: []*sql.DB{replica1, replica2}}
+		assert.True(t, db.HasReplicas())
+		assert.Same(t, replica1, db.Replica())
+		assert.Same(t, replica2, db.Replica())
+		assert.Same(t, replica1, db.Replica())
+	})
+}
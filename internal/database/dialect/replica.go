@@ -0,0 +1,26 @@
+package dialect
+
+import (
+	"database/sql"
+)
+
+// ReplicaConfig configures the read replicas of a database.
+// The replicas are connected with the database, user and options of the primary.
+type ReplicaConfig struct {
+	Hosts []ReplicaHost
+	// ReadYourWrites queries the primary instead of a replica
+	// if the replica didn't process the events pushed by this process yet, it defaults to true
+	ReadYourWrites bool
+}
+
+type ReplicaHost struct {
+	Host string
+	Port uint16
+}
+
+// ReplicaConnector is implemented by the connectors of dialects supporting read replicas
+type ReplicaConnector interface {
+	ReplicaConfig() ReplicaConfig
+	// ConnectReplicas connects to the configured replicas with the user of the primary
+	ConnectReplicas() ([]*sql.DB, error)
+}
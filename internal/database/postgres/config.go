@@ -29,6 +29,9 @@ type Config struct {
 	//Additional options to be appended as options=<Options>
 	//The value will be taken as is. Multiple options are space separated.
 	Options string
+
+	// Replicas are read replicas of the database, the projections are queried from them
+	Replicas dialect.ReplicaConfig
 }
 
 func (c *Config) MatchName(name string) bool {
@@ -50,6 +53,8 @@ func (c *Config) Decode(configs []interface{}) (dialect.Connector, error) {
 		return nil, err
 	}
 
+	// read your writes is enabled unless it's explicitly disabled
+	c.Replicas.ReadYourWrites = true
 	for _, config := range configs {
 		if err = decoder.Decode(config); err != nil {
 			return nil, err
@@ -73,6 +78,30 @@ func (c *Config) Connect(useAdmin bool) (*sql.DB, error) {
 	return db, nil
 }
 
+var _ dialect.ReplicaConnector = (*Config)(nil)
+
+func (c *Config) ReplicaConfig() dialect.ReplicaConfig {
+	return c.Replicas
+}
+
+func (c *Config) ConnectReplicas() ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0, len(c.Replicas.Hosts))
+	for _, host := range c.Replicas.Hosts {
+		replica := *c
+		replica.Host = host.Host
+		replica.Port = int32(host.Port)
+		client, err := replica.Connect(false)
+		if err != nil {
+			for _, connected := range replicas {
+				connected.Close()
+			}
+			return nil, err
+		}
+		replicas = append(replicas, client)
+	}
+	return replicas, nil
+}
+
 func (c *Config) DatabaseName() string {
 	return c.Database
 }
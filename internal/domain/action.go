@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -14,10 +15,41 @@ type Action struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         ActionState
+	TargetType    ActionTargetType
+	TargetURL     string
 }
 
 func (a *Action) IsValid() bool {
-	return a.Name != ""
+	if a.Name == "" || !a.TargetType.Valid() {
+		return false
+	}
+	if a.TargetType == ActionTargetTypeHTTP {
+		return isValidTargetURL(a.TargetURL)
+	}
+	return a.Script != ""
+}
+
+func isValidTargetURL(targetURL string) bool {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ActionTargetType defines how an action is executed
+type ActionTargetType int32
+
+const (
+	// ActionTargetTypeScript executes the script of the action in the JavaScript runtime
+	ActionTargetTypeScript ActionTargetType = iota
+	// ActionTargetTypeHTTP posts the context of the action to the target URL
+	ActionTargetTypeHTTP
+	actionTargetTypeCount
+)
+
+func (t ActionTargetType) Valid() bool {
+	return t >= 0 && t < actionTargetTypeCount
 }
 
 type ActionState int32
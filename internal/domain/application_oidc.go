@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
@@ -46,6 +47,9 @@ type OIDCApp struct {
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	DPoPBoundAccessTokens    bool
+	RequirePushedAuthRequest bool
+	BackChannelLogoutURI     string
 
 	State AppState
 }
@@ -91,6 +95,7 @@ const (
 	OIDCGrantTypeImplicit
 	OIDCGrantTypeRefreshToken
 	OIDCGrantTypeDeviceCode
+	OIDCGrantTypeTokenExchange
 )
 
 type OIDCApplicationType int32
@@ -123,7 +128,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !a.BackChannelLogoutURIValid() {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -148,6 +153,19 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+// BackChannelLogoutURIValid checks the back-channel logout URI to be an absolute URL without fragment.
+// Only applications in dev mode may receive the logout tokens over http.
+func (a *OIDCApp) BackChannelLogoutURIValid() bool {
+	if a.BackChannelLogoutURI == "" {
+		return true
+	}
+	uri, err := url.Parse(a.BackChannelLogoutURI)
+	if err != nil || uri.Host == "" || uri.Fragment != "" {
+		return false
+	}
+	return uri.Scheme == "https" || (uri.Scheme == "http" && a.DevMode)
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
@@ -21,6 +21,10 @@ const (
 	FlowTypeExternalAuthentication
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
+	FlowTypeCustomiseSAMLResponse
+	FlowTypeUser
+	FlowTypeUserGrant
+	FlowTypeMembership
 	flowTypeCount
 )
 
@@ -56,6 +60,27 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
 		}
+	case FlowTypeCustomiseSAMLResponse:
+		return []TriggerType{
+			TriggerTypePreSAMLResponseCreation,
+		}
+	case FlowTypeUser:
+		return []TriggerType{
+			TriggerTypePreCreation,
+			TriggerTypePostCreation,
+			TriggerTypePreRemoval,
+			TriggerTypePostRemoval,
+		}
+	case FlowTypeUserGrant,
+		FlowTypeMembership:
+		return []TriggerType{
+			TriggerTypePreCreation,
+			TriggerTypePostCreation,
+			TriggerTypePreChange,
+			TriggerTypePostChange,
+			TriggerTypePreRemoval,
+			TriggerTypePostRemoval,
+		}
 	default:
 		return nil
 	}
@@ -80,6 +105,14 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.CustomiseToken"
 	case FlowTypeInternalAuthentication:
 		return "Action.Flow.Type.InternalAuthentication"
+	case FlowTypeCustomiseSAMLResponse:
+		return "Action.Flow.Type.CustomiseSAMLResponse"
+	case FlowTypeUser:
+		return "Action.Flow.Type.User"
+	case FlowTypeUserGrant:
+		return "Action.Flow.Type.UserGrant"
+	case FlowTypeMembership:
+		return "Action.Flow.Type.Membership"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -94,6 +127,11 @@ const (
 	TriggerTypePostCreation
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
+	TriggerTypePreSAMLResponseCreation
+	TriggerTypePreChange
+	TriggerTypePostChange
+	TriggerTypePreRemoval
+	TriggerTypePostRemoval
 	triggerTypeCount
 )
 
@@ -124,6 +162,16 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreUserinfoCreation"
 	case TriggerTypePreAccessTokenCreation:
 		return "Action.TriggerType.PreAccessTokenCreation"
+	case TriggerTypePreSAMLResponseCreation:
+		return "Action.TriggerType.PreSAMLResponseCreation"
+	case TriggerTypePreChange:
+		return "Action.TriggerType.PreChange"
+	case TriggerTypePostChange:
+		return "Action.TriggerType.PostChange"
+	case TriggerTypePreRemoval:
+		return "Action.TriggerType.PreRemoval"
+	case TriggerTypePostRemoval:
+		return "Action.TriggerType.PostRemoval"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
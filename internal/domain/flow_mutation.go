@@ -0,0 +1,49 @@
+package domain
+
+import "context"
+
+// MutationCheck executes the actions of the pre trigger of the flow before the mutation is pushed,
+// the mutation is rejected if an error is returned
+type MutationCheck func(ctx context.Context, flowType FlowType, triggerType TriggerType, resourceOwner string, mutation interface{}) error
+
+// UserMutation is passed to the actions of the [FlowTypeUser]
+type UserMutation struct {
+	ID                string
+	Username          string
+	Machine           bool
+	FirstName         string
+	LastName          string
+	NickName          string
+	DisplayName       string
+	PreferredLanguage string
+	Email             string
+	Phone             string
+	Name              string
+	Description       string
+}
+
+// UserGrantMutation is passed to the actions of the [FlowTypeUserGrant]
+type UserGrantMutation struct {
+	ID             string
+	UserID         string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+const (
+	MembershipTypeInstance     = "instance"
+	MembershipTypeOrg          = "org"
+	MembershipTypeProject      = "project"
+	MembershipTypeProjectGrant = "project_grant"
+)
+
+// MembershipMutation is passed to the actions of the [FlowTypeMembership],
+// the ID is the id of the instance, organisation or project of the membership
+type MembershipMutation struct {
+	Type    string
+	ID      string
+	GrantID string
+	UserID  string
+	Roles   []string
+}
@@ -27,8 +27,11 @@ func (p *Permissions) appendPermission(ctxID, permission string) {
 type PermissionCheck func(ctx context.Context, permission, orgID, resourceID string) (err error)
 
 const (
-	PermissionUserWrite     = "user.write"
-	PermissionUserRead      = "user.read"
-	PermissionSessionWrite  = "session.write"
-	PermissionSessionDelete = "session.delete"
+	PermissionUserWrite          = "user.write"
+	PermissionUserRead           = "user.read"
+	PermissionSessionWrite       = "session.write"
+	PermissionSessionDelete      = "session.delete"
+	PermissionImpersonation      = "impersonation"
+	PermissionAdminImpersonation = "admin.impersonation"
+	PermissionEventsRead         = "events.read"
 )
@@ -22,6 +22,8 @@ type PasswordComplexityPolicy struct {
 	HasUppercase bool
 	HasNumber    bool
 	HasSymbol    bool
+	// DenyBreachedPasswords rejects passwords, which are part of a known breach
+	DenyBreachedPasswords bool
 
 	Default bool
 }
@@ -1,13 +1,52 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
+// maxProgressiveDelay caps the delay between failed checks
+const maxProgressiveDelay = time.Hour
+
 type LockoutPolicy struct {
 	models.ObjectRoot
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	// MaxOTPAttempts counts the failed OTP, U2F and passwordless checks
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	// LockoutDuration unlocks locked users automatically after the duration,
+	// 0 requires an administrator to unlock the user
+	LockoutDuration time.Duration
+	// ProgressiveDelay is the delay after the first failed check,
+	// it's doubled for every further consecutive failed check
+	ProgressiveDelay time.Duration
+}
+
+// LockoutExpired returns true if a user locked at lockedAt can be unlocked automatically
+func (p *LockoutPolicy) LockoutExpired(lockedAt, now time.Time) bool {
+	if p == nil || p.LockoutDuration <= 0 || lockedAt.IsZero() {
+		return false
+	}
+	return !now.Before(lockedAt.Add(p.LockoutDuration))
+}
+
+// CheckDelay returns the time to wait for the next check after the consecutive failed checks
+func (p *LockoutPolicy) CheckDelay(failedChecks uint64) time.Duration {
+	if p == nil || p.ProgressiveDelay <= 0 || failedChecks == 0 {
+		return 0
+	}
+	delay := p.ProgressiveDelay
+	for i := uint64(1); i < failedChecks; i++ {
+		delay *= 2
+		if delay >= maxProgressiveDelay {
+			return maxProgressiveDelay
+		}
+	}
+	if delay > maxProgressiveDelay {
+		return maxProgressiveDelay
+	}
+	return delay
 }
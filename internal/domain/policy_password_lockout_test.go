@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutPolicy_CheckDelay(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       *LockoutPolicy
+		failedChecks uint64
+		want         time.Duration
+	}{
+		{
+			name:         "nil policy, no delay",
+			failedChecks: 3,
+			want:         0,
+		},
+		{
+			name:         "no progressive delay, no delay",
+			policy:       &LockoutPolicy{},
+			failedChecks: 3,
+			want:         0,
+		},
+		{
+			name:         "no failed checks, no delay",
+			policy:       &LockoutPolicy{ProgressiveDelay: time.Second},
+			failedChecks: 0,
+			want:         0,
+		},
+		{
+			name:         "first failed check, base delay",
+			policy:       &LockoutPolicy{ProgressiveDelay: time.Second},
+			failedChecks: 1,
+			want:         time.Second,
+		},
+		{
+			name:         "third failed check, doubled twice",
+			policy:       &LockoutPolicy{ProgressiveDelay: time.Second},
+			failedChecks: 3,
+			want:         4 * time.Second,
+		},
+		{
+			name:         "many failed checks, max delay",
+			policy:       &LockoutPolicy{ProgressiveDelay: time.Second},
+			failedChecks: 100,
+			want:         time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.CheckDelay(tt.failedChecks))
+		})
+	}
+}
+
+func TestLockoutPolicy_LockoutExpired(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		policy   *LockoutPolicy
+		lockedAt time.Time
+		want     bool
+	}{
+		{
+			name:     "nil policy, not expired",
+			lockedAt: now.Add(-time.Hour),
+			want:     false,
+		},
+		{
+			name:     "no lockout duration, not expired",
+			policy:   &LockoutPolicy{},
+			lockedAt: now.Add(-time.Hour),
+			want:     false,
+		},
+		{
+			name:     "within lockout duration, not expired",
+			policy:   &LockoutPolicy{LockoutDuration: time.Hour},
+			lockedAt: now.Add(-time.Minute),
+			want:     false,
+		},
+		{
+			name:     "lockout duration passed, expired",
+			policy:   &LockoutPolicy{LockoutDuration: time.Hour},
+			lockedAt: now.Add(-time.Hour),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.LockoutExpired(tt.lockedAt, now))
+		})
+	}
+}
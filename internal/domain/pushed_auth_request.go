@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+// PushedAuthRequest is an authorization request pushed by a client (RFC 9126).
+// It is referenced by the request_uri on the authorization endpoint and can only be used once.
+type PushedAuthRequest struct {
+	ID           string
+	InstanceID   string
+	ClientID     string
+	Request      map[string][]string
+	CreationDate time.Time
+	Expiration   time.Time
+}
+
+func (r *PushedAuthRequest) IsExpired() bool {
+	return r.Expiration.Before(time.Now())
+}
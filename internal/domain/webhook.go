@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type Webhook struct {
+	models.ObjectRoot
+
+	Name       string
+	URL        string
+	EventTypes []string
+	State      WebhookState
+}
+
+func (w *Webhook) IsValid() bool {
+	if w.Name == "" || len(w.EventTypes) == 0 {
+		return false
+	}
+	callURL, err := url.Parse(w.URL)
+	if err != nil {
+		return false
+	}
+	return (callURL.Scheme == "http" || callURL.Scheme == "https") && callURL.Host != ""
+}
+
+type WebhookState int32
+
+const (
+	WebhookStateUnspecified WebhookState = iota
+	WebhookStateActive
+	WebhookStateRemoved
+	webhookStateCount
+)
+
+func (s WebhookState) Valid() bool {
+	return s >= 0 && s < webhookStateCount
+}
+
+func (s WebhookState) Exists() bool {
+	return s != WebhookStateUnspecified && s != WebhookStateRemoved
+}
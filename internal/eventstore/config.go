@@ -3,6 +3,7 @@ package eventstore
 import (
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	z_sql "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
@@ -12,8 +13,26 @@ type Config struct {
 	PushTimeout              time.Duration
 	Client                   *database.DB
 	AllowOrderByCreationDate bool
+	Snapshots                SnapshotConfig
+	// PersonalData configures the encryption of the personal data in the events
+	PersonalData PersonalDataConfig
+	// PersonalDataEncryption encrypts the keys of the personal data in the events,
+	// encrypted personal data can't be read if it's not set
+	PersonalDataEncryption crypto.EncryptionAlgorithm
+	// Retention prunes the expired aggregates and events declared by the event types
+	Retention RetentionConfig
 
-	repo repository.Repository
+	repo              repository.Repository
+	snapshotRepo      repository.SnapshotRepository
+	personalDataKeys  repository.PersonalDataKeyRepository
+	uniqueConstraints repository.UniqueConstraintRepository
+	retention         repository.RetentionRepository
+}
+
+type PersonalDataConfig struct {
+	// Enabled encrypts the personal data of new events with a key per aggregate,
+	// already encrypted personal data is decrypted even if it's disabled
+	Enabled bool
 }
 
 func TestConfig(repo repository.Repository) *Config {
@@ -21,6 +40,15 @@ func TestConfig(repo repository.Repository) *Config {
 }
 
 func Start(config *Config) (*Eventstore, error) {
-	config.repo = z_sql.NewCRDB(config.Client, config.AllowOrderByCreationDate)
+	repo := z_sql.NewCRDB(config.Client, config.AllowOrderByCreationDate)
+	config.repo = repo
+	config.uniqueConstraints = repo
+	config.retention = repo
+	if config.Snapshots.Enabled {
+		config.snapshotRepo = repo
+	}
+	if config.PersonalDataEncryption != nil {
+		config.personalDataKeys = repo
+	}
 	return NewEventstore(config), nil
 }
@@ -23,6 +23,18 @@ type Eventstore struct {
 	eventTypes        []string
 	aggregateTypes    []string
 	PushTimeout       time.Duration
+	snapshots         *snapshotWriter
+	personalData      *repository.PersonalDataCrypto
+	// personalDataEnabled encrypts the personal data of pushed events,
+	// already encrypted personal data is decrypted regardless
+	personalDataEnabled bool
+	uniqueConstraints   repository.UniqueConstraintRepository
+	pushedSequences     pushedSequences
+
+	retention          repository.RetentionRepository
+	retentionConfig    RetentionConfig
+	aggregateRetention map[AggregateType]*aggregateRetention
+	eventRetention     []*eventRetention
 }
 
 type eventTypeInterceptors struct {
@@ -30,12 +42,25 @@ type eventTypeInterceptors struct {
 }
 
 func NewEventstore(config *Config) *Eventstore {
-	return &Eventstore{
+	es := &Eventstore{
 		repo:              config.repo,
 		eventInterceptors: map[EventType]eventTypeInterceptors{},
 		interceptorMutex:  sync.Mutex{},
 		PushTimeout:       config.PushTimeout,
+		uniqueConstraints: config.uniqueConstraints,
+
+		retention:          config.retention,
+		retentionConfig:    config.Retention,
+		aggregateRetention: make(map[AggregateType]*aggregateRetention),
+	}
+	if config.snapshotRepo != nil {
+		es.snapshots = newSnapshotWriter(config.snapshotRepo, config.Snapshots)
 	}
+	if config.personalDataKeys != nil && config.PersonalDataEncryption != nil {
+		es.personalData = repository.NewPersonalDataCrypto(config.personalDataKeys, config.PersonalDataEncryption)
+		es.personalDataEnabled = config.PersonalData.Enabled
+	}
+	return es
 }
 
 // Health checks if the eventstore can properly work
@@ -58,15 +83,26 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 		defer cancel()
 	}
 
+	plainData, err := es.encryptPersonalData(ctx, cmds, events)
+	if err != nil {
+		return nil, err
+	}
+
 	err = es.repo.Push(ctx, events, constraints...)
 	if err != nil {
 		return nil, err
 	}
 
+	// the pushed events are mapped with their unencrypted payload
+	for i, data := range plainData {
+		events[i].Data = data
+	}
+
 	eventReaders, err := es.mapEvents(events)
 	if err != nil {
 		return nil, err
 	}
+	es.pushedSequences.track(eventReaders)
 
 	go notify(eventReaders)
 	return eventReaders, nil
@@ -151,10 +187,22 @@ func (es *Eventstore) Filter(ctx context.Context, queryFactory *SearchQueryBuild
 	if err != nil {
 		return nil, err
 	}
+	if err = es.decryptPersonalData(ctx, events); err != nil {
+		return nil, err
+	}
 
 	return es.mapEvents(events)
 }
 
+// MapEvent maps the event of the repository to the event struct registered for its type
+func (es *Eventstore) MapEvent(event *repository.Event) (Event, error) {
+	events, err := es.mapEvents([]*repository.Event{event})
+	if err != nil {
+		return nil, err
+	}
+	return events[0], nil
+}
+
 func (es *Eventstore) mapEvents(events []*repository.Event) (mappedEvents []Event, err error) {
 	mappedEvents = make([]Event, len(events))
 
@@ -216,6 +264,28 @@ func (es *Eventstore) InstanceIDs(ctx context.Context, queryFactory *SearchQuery
 	return es.repo.InstanceIDs(ctx, query)
 }
 
+// UniqueConstraints returns the unique constraints stored for the instance,
+// the global unique constraints are returned if the instanceID is empty
+func (es *Eventstore) UniqueConstraints(ctx context.Context, instanceID string) ([]*EventUniqueConstraint, error) {
+	if es.uniqueConstraints == nil {
+		return nil, errors.ThrowUnimplemented(nil, "V2-Uq1nC", "unique constraints are not listed by the repository")
+	}
+	stored, err := es.uniqueConstraints.UniqueConstraints(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	constraints := make([]*EventUniqueConstraint, len(stored))
+	for i, constraint := range stored {
+		constraints[i] = &EventUniqueConstraint{
+			UniqueType:  constraint.UniqueType,
+			UniqueField: constraint.UniqueField,
+			Action:      UniqueConstraintAdd,
+			IsGlobal:    instanceID == "",
+		}
+	}
+	return constraints, nil
+}
+
 type QueryReducer interface {
 	reducer
 	//Query returns the SearchQueryFactory for the events needed in reducer
@@ -223,9 +293,18 @@ type QueryReducer interface {
 }
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
-// appends all events to the reducer and calls it's reduce function
+// appends all events to the reducer and calls it's reduce function.
+// If snapshots are enabled, a [SnapshotQueryReducer] is restored from its latest snapshot
+// and only the events after the snapshot are reduced
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
-	events, err := es.Filter(ctx, r.Query())
+	if snapshotReducer, ok := r.(SnapshotQueryReducer); ok && es.snapshots != nil {
+		return es.filterToSnapshotReducer(ctx, snapshotReducer)
+	}
+	return es.filterToReducer(ctx, r.Query(), r)
+}
+
+func (es *Eventstore) filterToReducer(ctx context.Context, query *SearchQueryBuilder, r reducer) error {
+	events, err := es.Filter(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -103,6 +103,7 @@ func (h *StatementHandler) Start() {
 	close(h.initialized)
 	if !h.reduceScheduledPseudoEvent {
 		h.Subscribe(h.aggregates...)
+		h.SubscribeNotifications(h.aggregates...)
 	}
 }
 
@@ -279,6 +280,11 @@ stmts:
 // executeStmt handles sql statements
 // an error is returned if the statement could not be inserted properly
 func (h *StatementHandler) executeStmt(tx *sql.Tx, stmt *handler.Statement) error {
+	return h.executeStmtOn(tx, h.ProjectionName, stmt)
+}
+
+// executeStmtOn executes the statement on the tables of the given projection name
+func (h *StatementHandler) executeStmtOn(tx *sql.Tx, projectionName string, stmt *handler.Statement) error {
 	if stmt.IsNoop() {
 		return nil
 	}
@@ -286,7 +292,7 @@ func (h *StatementHandler) executeStmt(tx *sql.Tx, stmt *handler.Statement) erro
 	if err != nil {
 		return errors.ThrowInternal(err, "CRDB-i1wp6", "unable to create savepoint")
 	}
-	err = stmt.Execute(tx, h.ProjectionName)
+	err = stmt.Execute(tx, projectionName)
 	if err != nil {
 		logging.WithError(err).Error()
 		_, rollbackErr := tx.Exec("ROLLBACK TO SAVEPOINT push_stmt")
@@ -13,19 +13,29 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 )
 
+const instanceIDColumn = "instance_id"
+
 type Table struct {
-	columns     []*Column
-	primaryKey  PrimaryKey
-	indices     []*Index
-	constraints []*Constraint
-	foreignKeys []*ForeignKey
+	columns        []*Column
+	primaryKey     PrimaryKey
+	indices        []*Index
+	constraints    []*Constraint
+	foreignKeys    []*ForeignKey
+	instanceColumn string
 }
 
+// NewTable creates the definition of a projection table
+// the instance column defaults to the instance_id column if the table has one
 func NewTable(columns []*Column, key PrimaryKey, opts ...TableOption) *Table {
 	t := &Table{
 		columns:    columns,
 		primaryKey: key,
 	}
+	for _, column := range columns {
+		if column.Name == instanceIDColumn {
+			t.instanceColumn = instanceIDColumn
+		}
+	}
 	for _, opt := range opts {
 		opt(t)
 	}
@@ -64,6 +74,14 @@ func WithForeignKey(key *ForeignKey) TableOption {
 	}
 }
 
+// WithInstanceColumn declares the column storing the instance id
+// if the table has no instance_id column
+func WithInstanceColumn(column string) TableOption {
+	return func(table *Table) {
+		table.instanceColumn = column
+	}
+}
+
 type Column struct {
 	Name          string
 	Type          ColumnType
@@ -222,7 +240,8 @@ func NewTableCheck(table *Table, opts ...execOption) *handler.Check {
 		executes[i+1] = execNextIfExists(config, createIndexCheck(index), opts, true)
 	}
 	return &handler.Check{
-		Executes: executes,
+		Executes:        executes,
+		InstanceColumns: instanceColumns(table),
 	}
 }
 
@@ -240,6 +259,7 @@ func NewMultiTableCheck(primaryTable *Table, secondaryTables ...*SuffixedTable)
 		Executes: []func(handler.Executer, string) (bool, error){
 			execNextIfExists(config, create, nil, true),
 		},
+		InstanceColumns: instanceColumns(primaryTable, secondaryTables...),
 	}
 }
 
@@ -258,7 +278,20 @@ func NewViewCheck(selectStmt string, secondaryTables ...*SuffixedTable) *handler
 		Executes: []func(handler.Executer, string) (bool, error){
 			execNextIfExists(config, create, nil, false),
 		},
+		InstanceColumns: instanceColumns(nil, secondaryTables...),
+	}
+}
+
+// instanceColumns maps the suffixes of the tables to their instance column
+func instanceColumns(primaryTable *Table, secondaryTables ...*SuffixedTable) map[string]string {
+	columns := make(map[string]string, len(secondaryTables)+1)
+	if primaryTable != nil {
+		columns[""] = primaryTable.instanceColumn
+	}
+	for _, table := range secondaryTables {
+		columns["_"+table.suffix] = table.instanceColumn
 	}
+	return columns
 }
 
 func execNextIfExists(config execConfig, q query, opts []execOption, executeNext bool) func(handler.Executer, string) (bool, error) {
@@ -1,6 +1,9 @@
 package crdb
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func Test_defaultValue(t *testing.T) {
 	type args struct {
@@ -42,6 +45,59 @@ func Test_defaultValue(t *testing.T) {
 	}
 }
 
+func Test_instanceColumns(t *testing.T) {
+	type args struct {
+		primaryTable    *Table
+		secondaryTables []*SuffixedTable
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]string
+	}{
+		{
+			name: "instance_id column",
+			args: args{
+				primaryTable: NewTable([]*Column{NewColumn("id", ColumnTypeText), NewColumn("instance_id", ColumnTypeText)}, NewPrimaryKey("instance_id", "id")),
+				secondaryTables: []*SuffixedTable{
+					NewSuffixedTable([]*Column{NewColumn("user_id", ColumnTypeText), NewColumn("instance_id", ColumnTypeText)}, NewPrimaryKey("instance_id", "user_id"), "humans"),
+				},
+			},
+			want: map[string]string{"": "instance_id", "_humans": "instance_id"},
+		},
+		{
+			name: "declared instance column",
+			args: args{
+				primaryTable: NewTable([]*Column{NewColumn("id", ColumnTypeText)}, NewPrimaryKey("id"), WithInstanceColumn("id")),
+			},
+			want: map[string]string{"": "id"},
+		},
+		{
+			name: "instance column not declared",
+			args: args{
+				primaryTable: NewTable([]*Column{NewColumn("id", ColumnTypeText)}, NewPrimaryKey("id")),
+			},
+			want: map[string]string{"": ""},
+		},
+		{
+			name: "view",
+			args: args{
+				secondaryTables: []*SuffixedTable{
+					NewSuffixedTable([]*Column{NewColumn("user_id", ColumnTypeText), NewColumn("instance_id", ColumnTypeText)}, NewPrimaryKey("instance_id", "user_id"), "humans"),
+				},
+			},
+			want: map[string]string{"_humans": "instance_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceColumns(tt.args.primaryTable, tt.args.secondaryTables...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 type testStringer int
 
 func (t testStringer) String() string {
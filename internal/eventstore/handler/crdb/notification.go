@@ -0,0 +1,121 @@
+package crdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+
+	"github.com/jackc/pgx/v4/stdlib"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+const (
+	// EventNotificationChannel is the channel notified by postgres for every inserted event,
+	// the payload is the instance id and the aggregate type separated by a colon
+	EventNotificationChannel = "eventstore_events"
+
+	// the changefeed only streams events inserted after it was started
+	eventsChangefeedStmt = "EXPERIMENTAL CHANGEFEED FOR eventstore.events WITH initial_scan = 'no'"
+)
+
+// NewNotificationListener returns the listener of the notifications emitted by the database when events are inserted.
+// Postgres notifies the listeners of the [EventNotificationChannel],
+// the inserted events are streamed by a changefeed on cockroach, which requires rangefeeds to be enabled
+func NewNotificationListener(client *database.DB) handler.NotificationListener {
+	if client.Type() == "cockroach" {
+		return &changefeedListener{client: client}
+	}
+	return &postgresListener{client: client}
+}
+
+type postgresListener struct {
+	client *database.DB
+}
+
+// Listen implements [handler.NotificationListener]
+func (l *postgresListener) Listen(ctx context.Context, notify func(*handler.EventNotification)) error {
+	conn, err := l.client.Conn(ctx)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Lst1c", "unable to get connection")
+	}
+	defer conn.Close()
+
+	var listenErr error
+	// the connection still listens on the channel, so it's never returned to the pool
+	_ = conn.Raw(func(driverConn interface{}) error {
+		pgConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = errors.ThrowUnimplemented(nil, "CRDB-Lst2d", "database driver does not support notifications")
+			return driver.ErrBadConn
+		}
+		if _, err := pgConn.Conn().Exec(ctx, "LISTEN "+EventNotificationChannel); err != nil {
+			listenErr = errors.ThrowInternal(err, "CRDB-Lst3l", "unable to listen for notifications")
+			return driver.ErrBadConn
+		}
+		for {
+			notification, err := pgConn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				listenErr = err
+				return driver.ErrBadConn
+			}
+			instanceID, aggregateType, ok := strings.Cut(notification.Payload, ":")
+			if !ok {
+				continue
+			}
+			notify(&handler.EventNotification{
+				InstanceID:    instanceID,
+				AggregateType: eventstore.AggregateType(aggregateType),
+			})
+		}
+	})
+	return listenErr
+}
+
+type changefeedListener struct {
+	client *database.DB
+}
+
+// changefeedRow is the value of a row of the changefeed,
+// after is nil if the event was deleted
+type changefeedRow struct {
+	After *struct {
+		InstanceID    string `json:"instance_id"`
+		AggregateType string `json:"aggregate_type"`
+	} `json:"after"`
+}
+
+// Listen implements [handler.NotificationListener]
+func (l *changefeedListener) Listen(ctx context.Context, notify func(*handler.EventNotification)) error {
+	rows, err := l.client.QueryContext(ctx, eventsChangefeedStmt)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Cfd1s", "unable to start changefeed")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			table      string
+			key, value []byte
+		)
+		if err = rows.Scan(&table, &key, &value); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Cfd2r", "unable to scan changefeed")
+		}
+		row := new(changefeedRow)
+		if err = json.Unmarshal(value, row); err != nil || row.After == nil {
+			continue
+		}
+		notify(&handler.EventNotification{
+			InstanceID:    row.After.InstanceID,
+			AggregateType: eventstore.AggregateType(row.After.AggregateType),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return errors.ThrowInternal(err, "CRDB-Cfd3e", "changefeed failed")
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	rebuildTablePrefix  = "rebuild_"
+	rebuildLockDuration = 10 * time.Second
+
+	rebuildTablesStmt  = `SELECT table_name, table_type FROM information_schema.tables WHERE table_schema = $1 AND (table_name = $2 OR table_name LIKE $3)`
+	rebuildColumnsStmt = `SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position`
+)
+
+type RebuildPhase string
+
+const (
+	// RebuildPhaseReplay is reported after every bulk of events reduced into the shadow tables
+	RebuildPhaseReplay RebuildPhase = "replay"
+	// RebuildPhaseSwap is reported before the rows of the instance are replaced in the projection tables
+	RebuildPhaseSwap RebuildPhase = "swap"
+	// RebuildPhaseDone is reported after the rebuild of the instance is committed
+	RebuildPhaseDone RebuildPhase = "done"
+)
+
+type RebuildProgress struct {
+	Projection string
+	InstanceID string
+	Phase      RebuildPhase
+	// Sequence is the sequence of the last reduced event
+	Sequence uint64
+	// ProcessedEvents is the amount of events reduced for the instance
+	ProcessedEvents uint64
+}
+
+// Name returns the name of the projection
+func (h *StatementHandler) Name() string {
+	return h.ProjectionName
+}
+
+// Rebuild reduces all events of the instances into shadow tables of the projection
+// and atomically replaces the rows of the instances in the projection tables afterwards.
+// The current sequences of the instances are set to the last reduced events.
+// The projection keeps serving queries while it's rebuilt.
+// If no instance ids are passed, all instances are rebuilt.
+func (h *StatementHandler) Rebuild(ctx context.Context, instanceIDs []string, progress func(*RebuildProgress)) (err error) {
+	if h.reduceScheduledPseudoEvent || h.initCheck == nil || h.initCheck.IsNoop() {
+		return errors.ThrowPreconditionFailed(nil, "CRDB-Rb1Nq", "projection cannot be rebuilt")
+	}
+	for suffix, column := range h.initCheck.InstanceColumns {
+		if column == "" {
+			logging.WithFields("projection", h.ProjectionName, "table", h.ProjectionName+suffix).Warn("instance column of table not declared")
+			return errors.ThrowPreconditionFailed(nil, "CRDB-Rbg3c", "projection cannot be rebuilt")
+		}
+	}
+	if progress == nil {
+		progress = func(*RebuildProgress) {}
+	}
+	if len(instanceIDs) == 0 {
+		instanceIDs, err = h.Eventstore.InstanceIDs(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).
+			AddQuery().
+			AggregateTypes(h.aggregates...).
+			ExcludedInstanceID("").
+			Builder(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	shadowName := rebuildTableName(h.ProjectionName)
+	if err = h.dropRebuildTables(ctx, shadowName); err != nil {
+		return err
+	}
+	defer func() {
+		dropErr := h.dropRebuildTables(context.Background(), shadowName)
+		logging.WithFields("projection", h.ProjectionName).OnError(dropErr).Warn("unable to drop rebuild tables")
+	}()
+	if err = h.createRebuildTables(shadowName); err != nil {
+		return err
+	}
+
+	for _, instanceID := range instanceIDs {
+		if err = h.rebuildInstance(ctx, shadowName, instanceID, progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *StatementHandler) createRebuildTables(shadowName string) error {
+	for i, execute := range h.initCheck.Executes {
+		logging.WithFields("projection", h.ProjectionName, "execute", i).Debug("executing rebuild check")
+		next, err := execute(h.client, shadowName)
+		if err != nil {
+			return err
+		}
+		if !next {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (h *StatementHandler) dropRebuildTables(ctx context.Context, shadowName string) error {
+	tables, views, err := rebuildTables(ctx, h.client.QueryContext, shadowName)
+	if err != nil {
+		return err
+	}
+	for _, view := range views {
+		if _, err = h.client.ExecContext(ctx, "DROP VIEW IF EXISTS "+view); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rb2Vw", "unable to drop rebuild view")
+		}
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err = h.client.ExecContext(ctx, "DROP TABLE IF EXISTS "+tables[i]+" CASCADE"); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rb3Tb", "unable to drop rebuild table")
+		}
+	}
+	return nil
+}
+
+func (h *StatementHandler) rebuildInstance(ctx context.Context, shadowName, instanceID string, progress func(*RebuildProgress)) error {
+	p := &RebuildProgress{
+		Projection: h.ProjectionName,
+		InstanceID: instanceID,
+		Phase:      RebuildPhaseReplay,
+	}
+	sequences := make(currentSequences, len(h.aggregates))
+	for {
+		tx, err := h.client.BeginTx(ctx, nil)
+		if err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rb4Bg", "begin failed")
+		}
+		count, err := h.reduceRebuildEvents(ctx, tx, shadowName, instanceID, h.bulkLimit, sequences, p)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rb5Cm", "commit failed")
+		}
+		progress(p)
+		if count == 0 || (h.bulkLimit > 0 && uint64(count) < h.bulkLimit) {
+			break
+		}
+	}
+
+	p.Phase = RebuildPhaseSwap
+	progress(p)
+	if err := h.swapRebuild(ctx, shadowName, instanceID, sequences, p); err != nil {
+		return err
+	}
+	p.Phase = RebuildPhaseDone
+	progress(p)
+	return nil
+}
+
+// reduceRebuildEvents reduces the events of the instance after the sequence of the progress into the shadow tables
+// the first failing statement aborts the rebuild, the returned count is the amount of events read
+func (h *StatementHandler) reduceRebuildEvents(ctx context.Context, tx *sql.Tx, shadowName, instanceID string, limit uint64, sequences currentSequences, p *RebuildProgress) (int, error) {
+	events, err := h.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		Limit(limit).
+		SetTx(tx).
+		AddQuery().
+		AggregateTypes(h.aggregates...).
+		InstanceID(instanceID).
+		SequenceGreater(p.Sequence).
+		Builder(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		stmt, err := h.reduce(event)
+		if err != nil {
+			return 0, err
+		}
+		if err = h.executeStmtOn(tx, shadowName, stmt); err != nil {
+			logging.WithFields("projection", h.ProjectionName, "instance", instanceID, "sequence", event.Sequence()).WithError(err).Warn("statement failed during rebuild")
+			return 0, errors.ThrowInternal(err, "CRDB-Rbh2f", "unable to rebuild projection")
+		}
+		updateSequences(sequences, stmt)
+		p.ProcessedEvents++
+		p.Sequence = event.Sequence()
+	}
+	return len(events), nil
+}
+
+// swapRebuild reduces the events pushed since the last bulk into the shadow tables
+// and replaces the rows of the instance in the projection tables in a single transaction
+func (h *StatementHandler) swapRebuild(ctx context.Context, shadowName, instanceID string, sequences currentSequences, p *RebuildProgress) (err error) {
+	lockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err = h.lockForRebuild(lockCtx, cancel, instanceID); err != nil {
+		return err
+	}
+	defer func() {
+		unlockErr := h.Unlock(instanceID)
+		logging.WithFields("projection", h.ProjectionName, "instance", instanceID).OnError(unlockErr).Warn("unable to unlock after rebuild")
+	}()
+
+	tx, err := h.client.BeginTx(lockCtx, nil)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Rb6Bg", "begin failed")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	// blocks the statements of the running handlers until the swap is committed
+	if _, err = h.currentSequences(lockCtx, tx.QueryContext, database.StringArray{instanceID}); err != nil {
+		return err
+	}
+	if _, err = h.reduceRebuildEvents(lockCtx, tx, shadowName, instanceID, 0, sequences, p); err != nil {
+		return err
+	}
+	if err = h.copyRebuildTables(lockCtx, tx, shadowName, instanceID); err != nil {
+		return err
+	}
+	if len(sequences) > 0 {
+		if err = h.updateCurrentSequences(tx, sequences); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.ThrowInternal(err, "CRDB-Rb7Cm", "commit failed")
+	}
+	return nil
+}
+
+// lockForRebuild waits until the instance is locked for the projection
+// the lock is renewed until the context is canceled
+func (h *StatementHandler) lockForRebuild(ctx context.Context, cancel func(), instanceID string) error {
+	errs := h.Lock(ctx, rebuildLockDuration, instanceID)
+	for err := range errs {
+		if err == nil {
+			go func() {
+				for err := range errs {
+					if err != nil {
+						logging.WithFields("projection", h.ProjectionName, "instance", instanceID).WithError(err).Warn("rebuild lost lock")
+						cancel()
+					}
+				}
+			}()
+			return nil
+		}
+		if !errors.IsErrorAlreadyExists(err) {
+			cancel()
+			return err
+		}
+		logging.WithFields("projection", h.ProjectionName, "instance", instanceID).Debug("waiting for lock to rebuild")
+	}
+	return ctx.Err()
+}
+
+func (h *StatementHandler) copyRebuildTables(ctx context.Context, tx *sql.Tx, shadowName, instanceID string) error {
+	tables, _, err := rebuildTables(ctx, tx.QueryContext, shadowName)
+	if err != nil {
+		return err
+	}
+	stmts := make([]string, 0, len(tables)*2)
+	inserts := make([]string, 0, len(tables))
+	// secondary tables are cleared first as they can reference the primary table
+	for i := len(tables) - 1; i >= 0; i-- {
+		columns, err := rebuildColumns(ctx, tx, tables[i])
+		if err != nil {
+			return err
+		}
+		target := projectionTableName(h.ProjectionName, shadowName, tables[i])
+		cols := strings.Join(columns, ", ")
+		instanceCol, err := h.instanceColumn(strings.TrimPrefix(tables[i], shadowName), columns)
+		if err != nil {
+			return err
+		}
+		stmts = append(stmts, "DELETE FROM "+target+" WHERE "+instanceCol+" = $1")
+		inserts = append([]string{"INSERT INTO " + target + " (" + cols + ") SELECT " + cols + " FROM " + tables[i] + " WHERE " + instanceCol + " = $1"}, inserts...)
+	}
+	for _, stmt := range append(stmts, inserts...) {
+		if _, err = tx.ExecContext(ctx, stmt, instanceID); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rb8Sw", "unable to swap rebuilt rows")
+		}
+	}
+	return nil
+}
+
+// rebuildTables returns the tables (primary table first) and views of the shadow projection
+func rebuildTables(ctx context.Context, query func(context.Context, string, ...interface{}) (*sql.Rows, error), shadowName string) (tables, views []string, err error) {
+	schema, table := splitTableName(shadowName)
+	rows, err := query(ctx, rebuildTablesStmt, schema, table, strings.ReplaceAll(table, "_", `\_`)+`\_%`)
+	if err != nil {
+		return nil, nil, errors.ThrowInternal(err, "CRDB-Rb9Qt", "unable to query rebuild tables")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, tableType string
+		if err = rows.Scan(&name, &tableType); err != nil {
+			return nil, nil, errors.ThrowInternal(err, "CRDB-RbaSc", "scan failed")
+		}
+		if tableType == "VIEW" {
+			views = append(views, schema+"."+name)
+			continue
+		}
+		tables = append(tables, schema+"."+name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, errors.ThrowInternal(err, "CRDB-RbbRw", "errors in scanning rows")
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		if len(tables[i]) != len(tables[j]) {
+			return len(tables[i]) < len(tables[j])
+		}
+		return tables[i] < tables[j]
+	})
+	return tables, views, nil
+}
+
+func rebuildColumns(ctx context.Context, tx *sql.Tx, tableName string) ([]string, error) {
+	schema, table := splitTableName(tableName)
+	rows, err := tx.QueryContext(ctx, rebuildColumnsStmt, schema, table)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-RbcQc", "unable to query rebuild columns")
+	}
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			return nil, errors.ThrowInternal(err, "CRDB-RbdSc", "scan failed")
+		}
+		columns = append(columns, column)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-RbeRw", "errors in scanning rows")
+	}
+	if len(columns) == 0 {
+		return nil, errors.ThrowInternal(nil, "CRDB-RbfNc", "rebuild table has no columns")
+	}
+	return columns, nil
+}
+
+// instanceColumn returns the declared instance column of the table with the suffix
+func (h *StatementHandler) instanceColumn(suffix string, columns []string) (string, error) {
+	instanceCol := h.initCheck.InstanceColumns[suffix]
+	for _, column := range columns {
+		if instanceCol != "" && column == instanceCol {
+			return column, nil
+		}
+	}
+	logging.WithFields("projection", h.ProjectionName, "table", h.ProjectionName+suffix, "column", instanceCol).Warn("instance column of table not found")
+	return "", errors.ThrowPreconditionFailed(nil, "CRDB-Rbi4c", "projection cannot be rebuilt")
+}
+
+// rebuildTableName returns the name of the shadow table of the projection in the same schema
+func rebuildTableName(projectionName string) string {
+	schema, table := splitTableName(projectionName)
+	return schema + "." + rebuildTablePrefix + table
+}
+
+// projectionTableName maps a shadow table to the projection table
+func projectionTableName(projectionName, shadowName, table string) string {
+	return projectionName + strings.TrimPrefix(table, shadowName)
+}
+
+func splitTableName(name string) (schema, table string) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return "public", name
+	}
+	return name[:i], name[i+1:]
+}
@@ -0,0 +1,294 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+func TestRebuildTableName(t *testing.T) {
+	tests := []struct {
+		name           string
+		projectionName string
+		want           string
+	}{
+		{
+			name:           "with schema",
+			projectionName: "projections.users8",
+			want:           "projections.rebuild_users8",
+		},
+		{
+			name:           "without schema",
+			projectionName: "users",
+			want:           "public.rebuild_users",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebuildTableName(tt.projectionName); got != tt.want {
+				t.Errorf("rebuildTableName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectionTableName(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+		want  string
+	}{
+		{
+			name:  "primary table",
+			table: "projections.rebuild_users8",
+			want:  "projections.users8",
+		},
+		{
+			name:  "suffixed table",
+			table: "projections.rebuild_users8_humans",
+			want:  "projections.users8_humans",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := projectionTableName("projections.users8", "projections.rebuild_users8", tt.table); got != tt.want {
+				t.Errorf("projectionTableName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRebuildTables(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows       *sqlmock.Rows
+		queryErr   error
+		wantTables []string
+		wantViews  []string
+		wantErr    bool
+	}{
+		{
+			name:     "query fails",
+			queryErr: sql.ErrConnDone,
+			wantErr:  true,
+		},
+		{
+			name: "primary table first",
+			rows: sqlmock.NewRows([]string{"table_name", "table_type"}).
+				AddRow("rebuild_users8_humans", "BASE TABLE").
+				AddRow("rebuild_users8", "BASE TABLE").
+				AddRow("rebuild_users8_machines", "BASE TABLE"),
+			wantTables: []string{
+				"projections.rebuild_users8",
+				"projections.rebuild_users8_humans",
+				"projections.rebuild_users8_machines",
+			},
+		},
+		{
+			name: "views",
+			rows: sqlmock.NewRows([]string{"table_name", "table_type"}).
+				AddRow("rebuild_login_names2", "VIEW").
+				AddRow("rebuild_login_names2_users", "BASE TABLE"),
+			wantTables: []string{
+				"projections.rebuild_login_names2_users",
+			},
+			wantViews: []string{
+				"projections.rebuild_login_names2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			query := mock.ExpectQuery(regexp.QuoteMeta(rebuildTablesStmt)).
+				WithArgs("projections", "rebuild_users8", `rebuild\_users8\_%`)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			tables, views, err := rebuildTables(context.Background(), client.QueryContext, "projections.rebuild_users8")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tables, tt.wantTables) {
+				t.Errorf("tables = %v, want %v", tables, tt.wantTables)
+			}
+			if !reflect.DeepEqual(views, tt.wantViews) {
+				t.Errorf("views = %v, want %v", views, tt.wantViews)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func TestStatementHandler_copyRebuildTables(t *testing.T) {
+	tests := []struct {
+		name            string
+		instanceColumns map[string]string
+		expectations    []mockExpectation
+		wantErr         bool
+	}{
+		{
+			name:            "primary and suffixed table",
+			instanceColumns: map[string]string{"": "instance_id", "_humans": "instance_id"},
+			expectations: []mockExpectation{
+				expectRebuildTables(
+					"rebuild_users8", "BASE TABLE",
+					"rebuild_users8_humans", "BASE TABLE",
+				),
+				expectRebuildColumns("rebuild_users8_humans", "user_id", "instance_id", "first_name"),
+				expectRebuildColumns("rebuild_users8", "id", "instance_id"),
+				expectRebuildExec("DELETE FROM projections.users8_humans WHERE instance_id = $1", nil),
+				expectRebuildExec("DELETE FROM projections.users8 WHERE instance_id = $1", nil),
+				expectRebuildExec("INSERT INTO projections.users8 (id, instance_id) SELECT id, instance_id FROM projections.rebuild_users8 WHERE instance_id = $1", nil),
+				expectRebuildExec("INSERT INTO projections.users8_humans (user_id, instance_id, first_name) SELECT user_id, instance_id, first_name FROM projections.rebuild_users8_humans WHERE instance_id = $1", nil),
+			},
+		},
+		{
+			name:            "declared instance column",
+			instanceColumns: map[string]string{"": "id"},
+			expectations: []mockExpectation{
+				expectRebuildTables(
+					"rebuild_users8", "BASE TABLE",
+				),
+				expectRebuildColumns("rebuild_users8", "id", "name"),
+				expectRebuildExec("DELETE FROM projections.users8 WHERE id = $1", nil),
+				expectRebuildExec("INSERT INTO projections.users8 (id, name) SELECT id, name FROM projections.rebuild_users8 WHERE id = $1", nil),
+			},
+		},
+		{
+			name:            "instance column not declared",
+			instanceColumns: map[string]string{"": ""},
+			expectations: []mockExpectation{
+				expectRebuildTables(
+					"rebuild_users8", "BASE TABLE",
+				),
+				expectRebuildColumns("rebuild_users8", "id", "name"),
+			},
+			wantErr: true,
+		},
+		{
+			name:            "instance column of suffixed table unknown",
+			instanceColumns: map[string]string{"": "instance_id"},
+			expectations: []mockExpectation{
+				expectRebuildTables(
+					"rebuild_users8", "BASE TABLE",
+					"rebuild_users8_humans", "BASE TABLE",
+				),
+				expectRebuildColumns("rebuild_users8_humans", "user_id", "instance_id"),
+			},
+			wantErr: true,
+		},
+		{
+			name:            "declared instance column missing",
+			instanceColumns: map[string]string{"": "instance_id"},
+			expectations: []mockExpectation{
+				expectRebuildTables(
+					"rebuild_users8", "BASE TABLE",
+				),
+				expectRebuildColumns("rebuild_users8", "id", "name"),
+			},
+			wantErr: true,
+		},
+		{
+			name:            "swap fails",
+			instanceColumns: map[string]string{"": "instance_id"},
+			expectations: []mockExpectation{
+				expectRebuildTables(
+					"rebuild_users8", "BASE TABLE",
+				),
+				expectRebuildColumns("rebuild_users8", "id", "instance_id"),
+				expectRebuildExec("DELETE FROM projections.users8 WHERE instance_id = $1", sql.ErrConnDone),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &StatementHandler{
+				ProjectionHandler: &handler.ProjectionHandler{
+					ProjectionName: "projections.users8",
+				},
+				initCheck: &handler.Check{
+					InstanceColumns: tt.instanceColumns,
+				},
+			}
+
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			mock.ExpectBegin()
+			for _, expectation := range tt.expectations {
+				expectation(mock)
+			}
+
+			tx, err := client.Begin()
+			if err != nil {
+				t.Fatalf("unexpected err in begin: %v", err)
+			}
+
+			err = h.copyRebuildTables(context.Background(), tx, "projections.rebuild_users8", "instance")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			mock.MatchExpectationsInOrder(true)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func expectRebuildTables(nameAndTypes ...string) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"table_name", "table_type"})
+		for i := 0; i < len(nameAndTypes); i += 2 {
+			rows.AddRow(nameAndTypes[i], nameAndTypes[i+1])
+		}
+		m.ExpectQuery(regexp.QuoteMeta(rebuildTablesStmt)).
+			WithArgs("projections", "rebuild_users8", `rebuild\_users8\_%`).
+			WillReturnRows(rows)
+	}
+}
+
+func expectRebuildColumns(table string, columns ...string) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"column_name"})
+		for _, column := range columns {
+			rows.AddRow(column)
+		}
+		m.ExpectQuery(regexp.QuoteMeta(rebuildColumnsStmt)).
+			WithArgs("projections", table).
+			WillReturnRows(rows)
+	}
+}
+
+func expectRebuildExec(stmt string, err error) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		exec := m.ExpectExec(regexp.QuoteMeta(stmt)).WithArgs("instance")
+		if err != nil {
+			exec.WillReturnError(err)
+			return
+		}
+		exec.WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -66,6 +67,10 @@ type ProjectionHandler struct {
 	handleActiveInstances      time.Duration
 	nowFunc                    NowFunc
 	reduceScheduledPseudoEvent bool
+
+	notification chan struct{}
+	notifiedMu   sync.Mutex
+	notified     map[string]struct{}
 }
 
 func NewProjectionHandler(
@@ -99,12 +104,15 @@ func NewProjectionHandler(
 		handleActiveInstances:      config.HandleActiveInstances,
 		nowFunc:                    time.Now,
 		reduceScheduledPseudoEvent: reduceScheduledPseudoEvent,
+		notification:               make(chan struct{}, 1),
+		notified:                   make(map[string]struct{}),
 	}
 
 	go func() {
 		<-initialized
 		if !h.reduceScheduledPseudoEvent {
 			go h.subscribe(ctx)
+			go h.handleNotifications(ctx)
 		}
 		go h.schedule(ctx)
 	}()
@@ -289,26 +297,9 @@ func (h *ProjectionHandler) schedule(ctx context.Context) {
 			if max > len(ids) {
 				max = len(ids)
 			}
-			instances := ids[i:max]
-			lockInstanceCtx, cancelInstanceLock := context.WithCancel(lockCtx)
-			errs := h.lock(lockInstanceCtx, h.requeueAfter, instances...)
-			//wait until projection is locked
-			if err, ok := <-errs; err != nil || !ok {
-				cancelInstanceLock()
-				logging.WithFields("projection", h.ProjectionName).OnError(err).Debug("initial lock failed")
+			if !h.triggerLocked(lockCtx, ids[i:max]...) {
 				failed = true
-				continue
 			}
-			go h.cancelOnErr(lockInstanceCtx, errs, cancelInstanceLock)
-			_, err = h.TriggerErr(lockInstanceCtx, instances...)
-			if err != nil {
-				logging.WithFields("projection", h.ProjectionName, "instanceIDs", instances).WithError(err).Error("trigger failed")
-				failed = true
-			}
-
-			cancelInstanceLock()
-			unlockErr := h.unlock(instances...)
-			logging.WithFields("projection", h.ProjectionName).OnError(unlockErr).Warn("unable to unlock")
 		}
 		// if the first schedule did not fail, store that in the eventstore, so we can check on later starts
 		if !succeededOnce {
@@ -326,6 +317,31 @@ func (h *ProjectionHandler) schedule(ctx context.Context) {
 	}
 }
 
+// triggerLocked locks the projection for the instances and triggers it,
+// it returns false if the instances could not be locked or the trigger failed
+func (h *ProjectionHandler) triggerLocked(ctx context.Context, instances ...string) bool {
+	lockInstanceCtx, cancelInstanceLock := context.WithCancel(ctx)
+	defer cancelInstanceLock()
+	errs := h.lock(lockInstanceCtx, h.requeueAfter, instances...)
+	//wait until projection is locked
+	if err, ok := <-errs; err != nil || !ok {
+		logging.WithFields("projection", h.ProjectionName).OnError(err).Debug("initial lock failed")
+		return false
+	}
+	go h.cancelOnErr(lockInstanceCtx, errs, cancelInstanceLock)
+	succeeded := true
+	_, err := h.TriggerErr(lockInstanceCtx, instances...)
+	if err != nil {
+		logging.WithFields("projection", h.ProjectionName, "instanceIDs", instances).WithError(err).Error("trigger failed")
+		succeeded = false
+	}
+
+	cancelInstanceLock()
+	unlockErr := h.unlock(instances...)
+	logging.WithFields("projection", h.ProjectionName).OnError(unlockErr).Warn("unable to unlock")
+	return succeeded
+}
+
 func (h *ProjectionHandler) hasSucceededOnce(ctx context.Context) (bool, error) {
 	events, err := h.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -7,6 +7,9 @@ type Init func(context.Context, *Check) error
 
 type Check struct {
 	Executes []func(ex Executer, projectionName string) (bool, error)
+	// InstanceColumns maps the suffix of the tables (empty for the primary table)
+	// to the column storing the instance id
+	InstanceColumns map[string]string
 }
 
 func (c *Check) IsNoop() bool {
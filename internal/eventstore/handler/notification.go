@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// notificationReconnectAfter is the delay before a failed listener is restarted
+const notificationReconnectAfter = 5 * time.Second
+
+// EventNotification signals that events of the aggregate type were inserted for the instance
+type EventNotification struct {
+	InstanceID    string
+	AggregateType eventstore.AggregateType
+}
+
+// NotificationListener receives the notifications about inserted events from the database,
+// so the events pushed by other ZITADEL processes are reduced without waiting for the next schedule.
+// The database can't distinguish the processes, so the events pushed by this process are notified as well.
+// The projections already reduced them by the subscription, the notified trigger is coalesced and finds no new events
+type NotificationListener interface {
+	// Listen passes the notifications to notify until the context is done or the connection fails
+	Listen(ctx context.Context, notify func(*EventNotification)) error
+}
+
+var notifications = &notifier{
+	subscribers: make(map[eventstore.AggregateType][]func(instanceID string)),
+}
+
+type notifier struct {
+	mu          sync.RWMutex
+	subscribers map[eventstore.AggregateType][]func(instanceID string)
+}
+
+func (n *notifier) subscribe(notify func(instanceID string), aggregates ...eventstore.AggregateType) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, aggregate := range aggregates {
+		n.subscribers[aggregate] = append(n.subscribers[aggregate], notify)
+	}
+}
+
+func (n *notifier) notify(notification *EventNotification) {
+	// system events are not reduced per instance
+	if notification.InstanceID == "" {
+		return
+	}
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for _, notify := range n.subscribers[notification.AggregateType] {
+		notify(notification.InstanceID)
+	}
+}
+
+// ListenNotifications passes the notifications of the listener to the subscribed projection handlers until the context is done.
+// The listener is restarted if it fails, the projections are still triggered by their schedule in the meantime
+func ListenNotifications(ctx context.Context, listener NotificationListener) {
+	go func() {
+		for {
+			err := listener.Listen(ctx, notifications.notify)
+			if ctx.Err() != nil {
+				return
+			}
+			logging.WithError(err).Warn("listening for event notifications failed")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(notificationReconnectAfter):
+			}
+		}
+	}()
+}
+
+// SubscribeNotifications triggers the projection for the instances notified about inserted events of the aggregates
+func (h *ProjectionHandler) SubscribeNotifications(aggregates ...eventstore.AggregateType) {
+	notifications.subscribe(h.notifyInstance, aggregates...)
+}
+
+// notifyInstance queues the instance to be triggered without blocking the notifier
+func (h *ProjectionHandler) notifyInstance(instanceID string) {
+	h.notifiedMu.Lock()
+	h.notified[instanceID] = struct{}{}
+	h.notifiedMu.Unlock()
+	select {
+	case h.notification <- struct{}{}:
+	default:
+	}
+}
+
+// handleNotifications triggers the notified instances,
+// the instances notified while the projection is triggered are handled afterwards at once
+func (h *ProjectionHandler) handleNotifications(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.notification:
+		}
+		h.notifiedMu.Lock()
+		instances := make([]string, 0, len(h.notified))
+		for instanceID := range h.notified {
+			instances = append(instances, instanceID)
+		}
+		h.notified = make(map[string]struct{})
+		h.notifiedMu.Unlock()
+
+		for i := 0; i < len(instances); i = i + h.concurrentInstances {
+			max := i + h.concurrentInstances
+			if max > len(instances) {
+				max = len(instances)
+			}
+			// instances locked by another ZITADEL process are reduced by it or by the next schedule
+			h.triggerLocked(ctx, instances[i:max]...)
+		}
+	}
+}
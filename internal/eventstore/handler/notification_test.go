@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_notifier_notify(t *testing.T) {
+	n := &notifier{subscribers: make(map[eventstore.AggregateType][]func(instanceID string))}
+	var notified []string
+	n.subscribe(func(instanceID string) { notified = append(notified, instanceID) }, "user", "org")
+
+	n.notify(&EventNotification{InstanceID: "instance1", AggregateType: "user"})
+	n.notify(&EventNotification{InstanceID: "instance2", AggregateType: "org"})
+	n.notify(&EventNotification{InstanceID: "instance3", AggregateType: "project"})
+	n.notify(&EventNotification{InstanceID: "", AggregateType: "user"})
+
+	assert.Equal(t, []string{"instance1", "instance2"}, notified)
+}
+
+func TestProjectionHandler_notifyInstance(t *testing.T) {
+	h := &ProjectionHandler{
+		notification: make(chan struct{}, 1),
+		notified:     make(map[string]struct{}),
+	}
+	h.notifyInstance("instance1")
+	h.notifyInstance("instance2")
+	h.notifyInstance("instance1")
+
+	assert.Len(t, h.notification, 1)
+	assert.Equal(t, map[string]struct{}{"instance1": {}, "instance2": {}}, h.notified)
+}
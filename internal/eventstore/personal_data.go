@@ -0,0 +1,101 @@
+package eventstore
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// ForgottenPersonalData is the value of the personal data fields of forgotten aggregates
+const ForgottenPersonalData = repository.ForgottenPersonalData
+
+// PersonalDataCommand is a [Command] containing personal data of its aggregate (e.g. the name of a user).
+// If the personal data encryption is enabled, the fields are encrypted with a key of the aggregate.
+type PersonalDataCommand interface {
+	Command
+	// PersonalDataFields returns the json names of the string fields of the payload containing personal data
+	PersonalDataFields() []string
+}
+
+// PersonalDataForgetter is a [Command] after which the personal data of its aggregate is forgotten (e.g. the removal of a user).
+// The key of the aggregate is destroyed, so the personal data of the previous events becomes [ForgottenPersonalData].
+type PersonalDataForgetter interface {
+	Command
+	ForgetsPersonalData() bool
+}
+
+// PersonalDataCrypto returns the encryption of the personal data or nil if no key is configured
+func (es *Eventstore) PersonalDataCrypto() *repository.PersonalDataCrypto {
+	return es.personalData
+}
+
+// encryptPersonalData encrypts the personal data of the events in place
+// and returns the unencrypted payloads.
+// The keys of the aggregates are created and removed in the transaction of the events
+func (es *Eventstore) encryptPersonalData(ctx context.Context, cmds []Command, events []*repository.Event) ([][]byte, error) {
+	if es.personalData == nil {
+		return nil, nil
+	}
+	for i, cmd := range cmds {
+		forgetter, ok := cmd.(PersonalDataForgetter)
+		events[i].ForgetsPersonalData = ok && forgetter.ForgetsPersonalData()
+	}
+	if !es.personalDataEnabled {
+		return nil, nil
+	}
+	plain := make([][]byte, len(events))
+	fields := make([][]string, len(events))
+	for i, cmd := range cmds {
+		plain[i] = events[i].Data
+		if personalDataCmd, ok := cmd.(PersonalDataCommand); ok {
+			fields[i] = personalDataCmd.PersonalDataFields()
+		}
+	}
+	if err := es.personalData.Encrypt(ctx, events, fields); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+func (es *Eventstore) decryptPersonalData(ctx context.Context, events []*repository.Event) error {
+	if es.personalData == nil {
+		return nil
+	}
+	payloads := make([]*repository.PersonalDataPayload, len(events))
+	for i, event := range events {
+		payloads[i] = &repository.PersonalDataPayload{
+			InstanceID:    event.InstanceID,
+			AggregateType: event.AggregateType,
+			AggregateID:   event.AggregateID,
+			Data:          &event.Data,
+		}
+	}
+	return es.personalData.Decrypt(ctx, payloads...)
+}
+
+// NewPersonalDataEncryption loads the algorithm encrypting the keys of the personal data.
+// The key is created if the encryption is enabled.
+// If it's disabled and the key doesn't exist, no personal data was encrypted and nil is returned.
+func NewPersonalDataEncryption(keyConfig *crypto.KeyConfig, keyStorage crypto.KeyStorage, enabled bool) (crypto.EncryptionAlgorithm, error) {
+	if keyConfig == nil || keyConfig.EncryptionKeyID == "" {
+		if enabled {
+			return nil, errors.ThrowPreconditionFailed(nil, "V2-Pd3cf", "personal data encryption key not configured")
+		}
+		return nil, nil
+	}
+	if _, err := crypto.LoadKey(keyConfig.EncryptionKeyID, keyStorage); err != nil {
+		if !enabled {
+			return nil, nil
+		}
+		key, err := crypto.NewKey(keyConfig.EncryptionKeyID)
+		if err != nil {
+			return nil, err
+		}
+		if err = keyStorage.CreateKeys(key); err != nil {
+			return nil, err
+		}
+	}
+	return crypto.NewAESCrypto(keyConfig, keyStorage)
+}
@@ -0,0 +1,45 @@
+package eventstore
+
+import (
+	"sync"
+)
+
+// pushedSequences are the sequences of the last events pushed by this process
+// per instance and aggregate type
+type pushedSequences struct {
+	mutex     sync.RWMutex
+	sequences map[string]map[AggregateType]uint64
+}
+
+// PushedSequences returns the sequence of the last event pushed by this process per aggregate type of the instance,
+// so the queries can check if the projections of a read replica already reduced them
+func (es *Eventstore) PushedSequences(instanceID string) map[AggregateType]uint64 {
+	es.pushedSequences.mutex.RLock()
+	defer es.pushedSequences.mutex.RUnlock()
+
+	sequences := make(map[AggregateType]uint64, len(es.pushedSequences.sequences[instanceID]))
+	for aggregateType, sequence := range es.pushedSequences.sequences[instanceID] {
+		sequences[aggregateType] = sequence
+	}
+	return sequences
+}
+
+func (p *pushedSequences) track(events []Event) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.sequences == nil {
+		p.sequences = make(map[string]map[AggregateType]uint64)
+	}
+	for _, event := range events {
+		aggregate := event.Aggregate()
+		sequences, ok := p.sequences[aggregate.InstanceID]
+		if !ok {
+			sequences = make(map[AggregateType]uint64)
+			p.sequences[aggregate.InstanceID] = sequences
+		}
+		if event.Sequence() > sequences[aggregate.Type] {
+			sequences[aggregate.Type] = event.Sequence()
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package eventstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventstore_PushedSequences(t *testing.T) {
+	pushed := func(instanceID string, aggregateType AggregateType, sequence uint64) Event {
+		return &BaseEvent{
+			aggregate: Aggregate{InstanceID: instanceID, Type: aggregateType},
+			sequence:  sequence,
+		}
+	}
+
+	es := new(Eventstore)
+	if sequences := es.PushedSequences("instance"); len(sequences) != 0 {
+		t.Errorf("expected no sequences, got %v", sequences)
+	}
+
+	// pushes of different calls are tracked
+	es.pushedSequences.track([]Event{
+		pushed("instance", "user", 2),
+		pushed("instance", "org", 3),
+		pushed("other", "user", 4),
+	})
+	es.pushedSequences.track([]Event{pushed("instance", "user", 5)})
+	es.pushedSequences.track([]Event{pushed("instance", "org", 1)})
+
+	want := map[AggregateType]uint64{"user": 5, "org": 3}
+	if sequences := es.PushedSequences("instance"); !reflect.DeepEqual(sequences, want) {
+		t.Errorf("PushedSequences() = %v, want %v", sequences, want)
+	}
+}
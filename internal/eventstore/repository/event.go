@@ -59,6 +59,12 @@ type Event struct {
 	//InstanceID is the instance where this event belongs to
 	// use the ID of the instance
 	InstanceID string
+
+	//PersonalDataKey is the key the personal data of the aggregate was encrypted with,
+	// it's stored in the same transaction as the event if the aggregate had no key yet
+	PersonalDataKey *PersonalDataKey
+	//ForgetsPersonalData removes the personal data key of the aggregate in the same transaction as the event
+	ForgetsPersonalData bool
 }
 
 //EventType is the description of the change
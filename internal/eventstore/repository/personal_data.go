@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// ForgottenPersonalData replaces the personal data of aggregates whose key was removed
+	ForgottenPersonalData = "[forgotten]"
+
+	personalDataField = "personalData"
+)
+
+var personalDataFieldBytes = []byte(`"` + personalDataField + `"`)
+
+// PersonalDataKey is the key encrypting the personal data of an aggregate
+type PersonalDataKey struct {
+	ID  string
+	Key *crypto.CryptoValue
+}
+
+// PersonalDataKeyRepository reads the keys of the aggregates containing personal data,
+// the keys are created and removed in the transaction of the events (see [Event.PersonalDataKey] and [Event.ForgetsPersonalData])
+type PersonalDataKeyRepository interface {
+	// PersonalDataKeys returns the keys of the aggregates mapped by the aggregate id,
+	// the keys of forgotten aggregates are missing
+	PersonalDataKeys(ctx context.Context, instanceID string, aggregateType AggregateType, aggregateIDs ...string) (map[string]*PersonalDataKey, error)
+}
+
+// PersonalDataPayload is the payload of an event, which might contain encrypted personal data
+type PersonalDataPayload struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	Data          *[]byte
+}
+
+type encryptedPersonalData struct {
+	KeyID   string   `json:"keyId"`
+	Fields  []string `json:"fields"`
+	Crypted []byte   `json:"crypted"`
+}
+
+// PersonalDataCrypto encrypts the personal data of event payloads with a key per aggregate,
+// the keys themselves are encrypted with the encryption algorithm.
+// Removing the key of an aggregate makes its personal data unreadable (crypto-shredding)
+type PersonalDataCrypto struct {
+	keys PersonalDataKeyRepository
+	alg  crypto.EncryptionAlgorithm
+}
+
+func NewPersonalDataCrypto(keys PersonalDataKeyRepository, alg crypto.EncryptionAlgorithm) *PersonalDataCrypto {
+	return &PersonalDataCrypto{
+		keys: keys,
+		alg:  alg,
+	}
+}
+
+type personalDataAggregate struct {
+	instanceID    string
+	aggregateType AggregateType
+	aggregateID   string
+}
+
+// Encrypt moves the personal data fields of the events into their encrypted personal data,
+// fields[i] are the json names of the fields of events[i].
+// Only string fields are supported, missing fields are ignored.
+// Aggregates without a key get a new one, which is set on their first encrypted event,
+// so it's stored in the same transaction as the events
+func (c *PersonalDataCrypto) Encrypt(ctx context.Context, events []*Event, fields [][]string) error {
+	// keys of the aggregates in this push, nil if the aggregate has no key (anymore)
+	keys := make(map[personalDataAggregate]*PersonalDataKey)
+	for i, event := range events {
+		aggregate := personalDataAggregate{instanceID: event.InstanceID, aggregateType: event.AggregateType, aggregateID: event.AggregateID}
+		if len(fields[i]) > 0 {
+			if err := c.encrypt(ctx, event, aggregate, fields[i], keys); err != nil {
+				return err
+			}
+		}
+		if event.ForgetsPersonalData {
+			keys[aggregate] = nil
+		}
+	}
+	return nil
+}
+
+func (c *PersonalDataCrypto) encrypt(ctx context.Context, event *Event, aggregate personalDataAggregate, fields []string, keys map[personalDataAggregate]*PersonalDataKey) error {
+	if len(event.Data) == 0 {
+		return nil
+	}
+	payload := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return errors.ThrowInternal(err, "V2-Pd1uj", "unable to unmarshal payload")
+	}
+	personalData := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		value, ok := payload[field]
+		if !ok || bytes.Equal(value, []byte("null")) {
+			continue
+		}
+		personalData[field] = value
+		delete(payload, field)
+	}
+	if len(personalData) == 0 {
+		return nil
+	}
+	key, err := c.aggregateKey(ctx, event, aggregate, keys)
+	if err != nil {
+		return err
+	}
+	dataKey, err := crypto.DecryptString(key.Key, c.alg)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(personalData)
+	if err != nil {
+		return errors.ThrowInternal(err, "V2-Pd2mr", "unable to marshal personal data")
+	}
+	crypted, err := crypto.EncryptAES(plain, dataKey)
+	if err != nil {
+		return errors.ThrowInternal(err, "V2-Pd3ec", "unable to encrypt personal data")
+	}
+	encrypted := &encryptedPersonalData{
+		KeyID:   key.ID,
+		Fields:  make([]string, 0, len(personalData)),
+		Crypted: crypted,
+	}
+	for field := range personalData {
+		encrypted.Fields = append(encrypted.Fields, field)
+	}
+	sort.Strings(encrypted.Fields)
+	payload[personalDataField], err = json.Marshal(encrypted)
+	if err != nil {
+		return errors.ThrowInternal(err, "V2-Pd4mr", "unable to marshal personal data")
+	}
+	event.Data, err = json.Marshal(payload)
+	if err != nil {
+		return errors.ThrowInternal(err, "V2-Pd5mr", "unable to marshal payload")
+	}
+	return nil
+}
+
+// aggregateKey returns the key of the aggregate,
+// if the aggregate has none a new key is generated and set on the event
+func (c *PersonalDataCrypto) aggregateKey(ctx context.Context, event *Event, aggregate personalDataAggregate, keys map[personalDataAggregate]*PersonalDataKey) (*PersonalDataKey, error) {
+	key, ok := keys[aggregate]
+	if !ok {
+		stored, err := c.keys.PersonalDataKeys(ctx, aggregate.instanceID, aggregate.aggregateType, aggregate.aggregateID)
+		if err != nil {
+			return nil, err
+		}
+		key = stored[aggregate.aggregateID]
+	}
+	if key != nil {
+		keys[aggregate] = key
+		return key, nil
+	}
+	keyID, err := newPersonalDataKeyID()
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := crypto.NewKey(keyID)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Pd6ky", "unable to generate personal data key")
+	}
+	encryptedKey, err := crypto.Encrypt([]byte(dataKey.Value), c.alg)
+	if err != nil {
+		return nil, err
+	}
+	key = &PersonalDataKey{ID: keyID, Key: encryptedKey}
+	keys[aggregate] = key
+	event.PersonalDataKey = key
+	return key, nil
+}
+
+func newPersonalDataKeyID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", errors.ThrowInternal(err, "V2-Pd7id", "unable to generate personal data key id")
+	}
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
+
+// Decrypt restores the personal data fields of the payloads.
+// The fields of aggregates whose key was removed are set to [ForgottenPersonalData]
+func (c *PersonalDataCrypto) Decrypt(ctx context.Context, payloads ...*PersonalDataPayload) error {
+	type aggregateKey struct {
+		instanceID    string
+		aggregateType AggregateType
+	}
+	type encryptedPayload struct {
+		*PersonalDataPayload
+		payload   map[string]json.RawMessage
+		encrypted *encryptedPersonalData
+	}
+	encryptedPayloads := make([]*encryptedPayload, 0, len(payloads))
+	aggregates := make(map[aggregateKey][]string)
+	for _, p := range payloads {
+		if p.Data == nil || !bytes.Contains(*p.Data, personalDataFieldBytes) {
+			continue
+		}
+		payload := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(*p.Data, &payload); err != nil {
+			return errors.ThrowInternal(err, "V2-Pd8uj", "unable to unmarshal payload")
+		}
+		field, ok := payload[personalDataField]
+		if !ok {
+			continue
+		}
+		encrypted := new(encryptedPersonalData)
+		if err := json.Unmarshal(field, encrypted); err != nil {
+			return errors.ThrowInternal(err, "V2-Pd9uj", "unable to unmarshal personal data")
+		}
+		encryptedPayloads = append(encryptedPayloads, &encryptedPayload{PersonalDataPayload: p, payload: payload, encrypted: encrypted})
+		key := aggregateKey{instanceID: p.InstanceID, aggregateType: p.AggregateType}
+		aggregates[key] = append(aggregates[key], p.AggregateID)
+	}
+	if len(encryptedPayloads) == 0 {
+		return nil
+	}
+
+	keys := make(map[aggregateKey]map[string]*PersonalDataKey, len(aggregates))
+	for aggregate, ids := range aggregates {
+		aggregateKeys, err := c.keys.PersonalDataKeys(ctx, aggregate.instanceID, aggregate.aggregateType, ids...)
+		if err != nil {
+			return err
+		}
+		keys[aggregate] = aggregateKeys
+	}
+	dataKeys := make(map[string]string)
+	for _, p := range encryptedPayloads {
+		key := keys[aggregateKey{instanceID: p.InstanceID, aggregateType: p.AggregateType}][p.AggregateID]
+		personalData, err := c.decryptPersonalData(key, p.encrypted, dataKeys)
+		if err != nil {
+			return err
+		}
+		delete(p.payload, personalDataField)
+		for field, value := range personalData {
+			p.payload[field] = value
+		}
+		data, err := json.Marshal(p.payload)
+		if err != nil {
+			return errors.ThrowInternal(err, "V2-Pd0mr", "unable to marshal payload")
+		}
+		*p.Data = data
+	}
+	return nil
+}
+
+// decryptPersonalData returns the fields of the personal data,
+// which are marked as forgotten if the key doesn't exist anymore
+func (c *PersonalDataCrypto) decryptPersonalData(key *PersonalDataKey, encrypted *encryptedPersonalData, dataKeys map[string]string) (map[string]json.RawMessage, error) {
+	personalData := make(map[string]json.RawMessage, len(encrypted.Fields))
+	if key == nil || key.ID != encrypted.KeyID {
+		forgotten, _ := json.Marshal(ForgottenPersonalData)
+		for _, field := range encrypted.Fields {
+			personalData[field] = forgotten
+		}
+		return personalData, nil
+	}
+	dataKey, ok := dataKeys[key.ID]
+	if !ok {
+		var err error
+		dataKey, err = crypto.DecryptString(key.Key, c.alg)
+		if err != nil {
+			return nil, err
+		}
+		dataKeys[key.ID] = dataKey
+	}
+	plain, err := crypto.DecryptAES(encrypted.Crypted, dataKey)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Pd1ec", "unable to decrypt personal data")
+	}
+	if err = json.Unmarshal(plain, &personalData); err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Pd2uj", "unable to unmarshal personal data")
+	}
+	return personalData, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+type testPersonalDataKeys struct {
+	keys map[string]*PersonalDataKey
+}
+
+func (r *testPersonalDataKeys) PersonalDataKeys(_ context.Context, instanceID string, aggregateType AggregateType, aggregateIDs ...string) (map[string]*PersonalDataKey, error) {
+	keys := make(map[string]*PersonalDataKey, len(aggregateIDs))
+	for _, id := range aggregateIDs {
+		if key, ok := r.keys[instanceID+string(aggregateType)+id]; ok {
+			keys[id] = key
+		}
+	}
+	return keys, nil
+}
+
+// store simulates the push of the events
+func (r *testPersonalDataKeys) store(events ...*Event) {
+	for _, event := range events {
+		if event.PersonalDataKey != nil {
+			r.keys[event.InstanceID+string(event.AggregateType)+event.AggregateID] = event.PersonalDataKey
+		}
+		if event.ForgetsPersonalData {
+			delete(r.keys, event.InstanceID+string(event.AggregateType)+event.AggregateID)
+		}
+	}
+}
+
+func TestPersonalDataCrypto(t *testing.T) {
+	ctx := context.Background()
+	keys := &testPersonalDataKeys{keys: make(map[string]*PersonalDataKey)}
+	c := NewPersonalDataCrypto(keys, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	fields := []string{"firstName", "email", "phone"}
+
+	added := testPersonalDataEvent(`{"userName":"gigi","firstName":"Gigi","email":"gigi@zitadel.com"}`)
+	unchanged := testPersonalDataEvent(`{"userName":"gigi"}`)
+	changed := testPersonalDataEvent(`{"phone":"+41791234567"}`)
+	if err := c.Encrypt(ctx, []*Event{added, unchanged, changed}, [][]string{fields, fields, fields}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertPayload(t, added.Data, map[string]interface{}{"userName": "gigi"}, []string{"email", "firstName"})
+	if string(unchanged.Data) != `{"userName":"gigi"}` {
+		t.Errorf("payload without personal data must not be changed, got %s", unchanged.Data)
+	}
+	assertPayload(t, changed.Data, map[string]interface{}{}, []string{"phone"})
+	if added.PersonalDataKey == nil || unchanged.PersonalDataKey != nil || changed.PersonalDataKey != nil {
+		t.Fatalf("the key must only be created with the first encrypted event")
+	}
+	keys.store(added, unchanged, changed)
+
+	decrypted := added.Data
+	if err := c.Decrypt(ctx, &PersonalDataPayload{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", Data: &decrypted}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertPayload(t, decrypted, map[string]interface{}{"userName": "gigi", "firstName": "Gigi", "email": "gigi@zitadel.com"}, nil)
+
+	removed := testPersonalDataEvent(`{"firstName":"Gigi"}`)
+	removed.ForgetsPersonalData = true
+	readded := testPersonalDataEvent(`{"firstName":"Gigi"}`)
+	if err := c.Encrypt(ctx, []*Event{removed, readded}, [][]string{fields, fields}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed.PersonalDataKey != nil || readded.PersonalDataKey == nil {
+		t.Fatalf("a new key must be created after the personal data was forgotten")
+	}
+	keys.store(removed, readded)
+
+	forgotten := added.Data
+	reencrypted := readded.Data
+	if err := c.Decrypt(ctx,
+		&PersonalDataPayload{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", Data: &forgotten},
+		&PersonalDataPayload{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", Data: &reencrypted},
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertPayload(t, forgotten, map[string]interface{}{"userName": "gigi", "firstName": ForgottenPersonalData, "email": ForgottenPersonalData}, nil)
+	assertPayload(t, reencrypted, map[string]interface{}{"firstName": "Gigi"}, nil)
+}
+
+func testPersonalDataEvent(data string) *Event {
+	return &Event{
+		InstanceID:    "instance",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		Data:          []byte(data),
+	}
+}
+
+func assertPayload(t *testing.T, data []byte, want map[string]interface{}, wantEncrypted []string) {
+	t.Helper()
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("unable to unmarshal payload: %v", err)
+	}
+	personalData, ok := payload[personalDataField].(map[string]interface{})
+	delete(payload, personalDataField)
+	if !reflect.DeepEqual(payload, want) {
+		t.Errorf("payload = %v, want %v", payload, want)
+	}
+	if wantEncrypted == nil {
+		if ok {
+			t.Errorf("unexpected personal data: %v", personalData)
+		}
+		return
+	}
+	var encryptedFields []string
+	for _, field := range personalData["fields"].([]interface{}) {
+		encryptedFields = append(encryptedFields, field.(string))
+	}
+	if !reflect.DeepEqual(encryptedFields, wantEncrypted) {
+		t.Errorf("encrypted fields = %v, want %v", encryptedFields, wantEncrypted)
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Prune describes the events of an instance removed from the eventstore in a single transaction
+type Prune struct {
+	InstanceID string
+	// Events are removed from the eventstore,
+	// the previous sequences of their successors are linked to the previous sequences of the removed events
+	Events []*Event
+	// Aggregates are removed completely, their snapshots and, if not archived, their personal data keys are removed as well.
+	// The events of the aggregates must be part of Events
+	Aggregates []*PrunedAggregate
+	// UniqueConstraints are the constraints removed together with the events
+	UniqueConstraints []*UniqueConstraint
+	// Archive copies the events into the archive table before they are removed
+	Archive bool
+}
+
+// PrunedAggregate identifies an aggregate removed by a [Prune]
+type PrunedAggregate struct {
+	Type AggregateType
+	ID   string
+}
+
+// RetentionRepository finds and removes expired events
+type RetentionRepository interface {
+	// ExpiredAggregates returns all events of the aggregates of the type whose latest event was created before the given date.
+	// If latest event types are passed, only aggregates whose latest event is of one of the types are returned.
+	// The events are ordered by instance, aggregate and sequence,
+	// the limit restricts the amount of aggregates starting with the longest expired ones.
+	// All instances are searched if no instance ids are passed
+	ExpiredAggregates(ctx context.Context, aggregateType AggregateType, latestEventTypes []EventType, before time.Time, limit uint64, instanceIDs ...string) ([]*Event, error)
+	// ExpiredEvents returns the events of the event types created before the given date.
+	// Events with an expiry (duration in nanoseconds) in their payload are returned once their expiry is before the given date.
+	// The latest event of an aggregate is never returned.
+	// All instances are searched if no instance ids are passed
+	ExpiredEvents(ctx context.Context, aggregateType AggregateType, eventTypes []EventType, before time.Time, limit uint64, instanceIDs ...string) ([]*Event, error)
+	// PruneWatermark returns the sequence before which events of the aggregate type can be removed.
+	// It's the lowest sequence reduced by all projections of the aggregate type
+	// but never higher than the latest sequence of the aggregate type,
+	// so removed events never break the sequence checks of the projections
+	PruneWatermark(ctx context.Context, instanceID string, aggregateType AggregateType) (uint64, error)
+	// PruneEvents removes the events and unique constraints in a single transaction
+	PruneEvents(ctx context.Context, prune *Prune) error
+}
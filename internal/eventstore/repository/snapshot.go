@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Snapshot is the serialized state of a reducer of an aggregate
+// after all events up to the sequence of the snapshot were reduced
+type Snapshot struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	// Type identifies the reducer the snapshot was taken of
+	Type string
+	// Sequence is the sequence of the last event reduced into the snapshot
+	Sequence      uint64
+	ResourceOwner string
+	// ChangeDate is the creation date of the last event reduced into the snapshot
+	ChangeDate time.Time
+	Payload    []byte
+}
+
+// SnapshotRepository stores and loads snapshots of reducers
+type SnapshotRepository interface {
+	// LatestSnapshot returns the snapshot of the reducer with the highest sequence
+	// or nil if there is no snapshot
+	LatestSnapshot(ctx context.Context, instanceID string, aggregateType AggregateType, aggregateID, snapshotType string) (*Snapshot, error)
+	// PushSnapshot stores the snapshot and removes the older snapshots of the reducer
+	PushSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
@@ -118,6 +118,9 @@ func (db *CRDB) Push(ctx context.Context, events []*repository.Event, uniqueCons
 			previousAggregateTypeSequence Sequence
 		)
 		for _, event := range events {
+			if err := createPersonalDataKey(ctx, tx, event); err != nil {
+				return err
+			}
 			err := tx.QueryRowContext(ctx, crdbInsert,
 				event.Type,
 				event.AggregateType,
@@ -143,6 +146,9 @@ func (db *CRDB) Push(ctx context.Context, events []*repository.Event, uniqueCons
 				).WithError(err).Debug("query failed")
 				return caos_errs.ThrowInternal(err, "SQL-SBP37", "unable to create event")
 			}
+			if err = removePersonalDataKey(ctx, tx, event); err != nil {
+				return err
+			}
 		}
 
 		err := db.handleUniqueConstraints(ctx, tx, uniqueConstraints...)
@@ -270,22 +276,23 @@ func (db *CRDB) orderByEventSequence(desc bool) string {
 	return " ORDER BY event_sequence"
 }
 
+// eventColumns are the columns scanned by eventsScanner
+const eventColumns = "creation_date" +
+	", event_type" +
+	", event_sequence" +
+	", previous_aggregate_sequence" +
+	", previous_aggregate_type_sequence" +
+	", event_data" +
+	", editor_service" +
+	", editor_user" +
+	", resource_owner" +
+	", instance_id" +
+	", aggregate_type" +
+	", aggregate_id" +
+	", aggregate_version"
+
 func (db *CRDB) eventQuery() string {
-	return "SELECT" +
-		" creation_date" +
-		", event_type" +
-		", event_sequence" +
-		", previous_aggregate_sequence" +
-		", previous_aggregate_type_sequence" +
-		", event_data" +
-		", editor_service" +
-		", editor_user" +
-		", resource_owner" +
-		", instance_id" +
-		", aggregate_type" +
-		", aggregate_id" +
-		", aggregate_version" +
-		" FROM eventstore.events"
+	return "SELECT " + eventColumns + " FROM eventstore.events"
 }
 
 func (db *CRDB) maxSequenceQuery() string {
@@ -0,0 +1,76 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	personalDataKeysStmt = "SELECT aggregate_id, key_id, key FROM eventstore.personal_data_keys" +
+		" WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = ANY($3)"
+	createPersonalDataKeyStmt = "INSERT INTO eventstore.personal_data_keys" +
+		" (instance_id, aggregate_type, aggregate_id, key_id, key, creation_date)" +
+		" VALUES ($1, $2, $3, $4, $5, statement_timestamp())" +
+		" ON CONFLICT (instance_id, aggregate_type, aggregate_id) DO NOTHING"
+	removePersonalDataKeyStmt = "DELETE FROM eventstore.personal_data_keys" +
+		" WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3"
+)
+
+var _ repository.PersonalDataKeyRepository = (*CRDB)(nil)
+
+// PersonalDataKeys implements [repository.PersonalDataKeyRepository]
+func (db *CRDB) PersonalDataKeys(ctx context.Context, instanceID string, aggregateType repository.AggregateType, aggregateIDs ...string) (map[string]*repository.PersonalDataKey, error) {
+	rows, err := db.QueryContext(ctx, personalDataKeysStmt, instanceID, aggregateType, database.StringArray(aggregateIDs))
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Pdk1q", "unable to query personal data keys")
+	}
+	defer rows.Close()
+
+	keys := make(map[string]*repository.PersonalDataKey, len(aggregateIDs))
+	for rows.Next() {
+		var aggregateID string
+		key := &repository.PersonalDataKey{Key: new(crypto.CryptoValue)}
+		if err = rows.Scan(&aggregateID, &key.ID, key.Key); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "SQL-Pdk2s", "unable to scan personal data key")
+		}
+		keys[aggregateID] = key
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Pdk3q", "unable to query personal data keys")
+	}
+	return keys, nil
+}
+
+// createPersonalDataKey stores the new personal data key of the event's aggregate
+func createPersonalDataKey(ctx context.Context, tx *sql.Tx, event *repository.Event) error {
+	if event.PersonalDataKey == nil {
+		return nil
+	}
+	result, err := tx.ExecContext(ctx, createPersonalDataKeyStmt, event.InstanceID, event.AggregateType, event.AggregateID, event.PersonalDataKey.ID, event.PersonalDataKey.Key)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Pdk4c", "unable to create personal data key")
+	}
+	// the personal data of the event is encrypted with the new key,
+	// so the key of a concurrent push must not be used instead
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return caos_errs.ThrowInternal(err, "SQL-Pdk5n", "personal data key created concurrently")
+	}
+	return nil
+}
+
+// removePersonalDataKey destroys the personal data key of the event's aggregate
+func removePersonalDataKey(ctx context.Context, tx *sql.Tx, event *repository.Event) error {
+	if !event.ForgetsPersonalData {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, removePersonalDataKeyStmt, event.InstanceID, event.AggregateType, event.AggregateID)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Pdk7r", "unable to remove personal data key")
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+	"github.com/lib/pq"
+
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	expiredAggregatesStmt = "SELECT " + eventColumns + " FROM eventstore.events" +
+		" WHERE aggregate_type = $1 AND (instance_id, aggregate_id) IN (" +
+		"SELECT instance_id, aggregate_id FROM eventstore.events e" +
+		" WHERE aggregate_type = $1 AND creation_date < $2" +
+		" AND ($4::TEXT[] IS NULL OR instance_id = ANY($4))" +
+		" AND ($5::TEXT[] IS NULL OR event_type = ANY($5))" +
+		" AND NOT EXISTS (SELECT 1 FROM eventstore.events l" +
+		" WHERE l.instance_id = e.instance_id AND l.aggregate_type = e.aggregate_type AND l.aggregate_id = e.aggregate_id AND l.event_sequence > e.event_sequence)" +
+		" ORDER BY creation_date" +
+		" LIMIT $3" +
+		")" +
+		" ORDER BY instance_id, aggregate_id, event_sequence"
+	expiredEventsStmt = "SELECT " + eventColumns + " FROM eventstore.events e" +
+		" WHERE aggregate_type = $1 AND event_type = ANY($2) AND creation_date < $3" +
+		// the expiry of codes is stored as nanoseconds
+		" AND creation_date + COALESCE((event_data->>'expiry')::FLOAT, 0) / 1000000000 * INTERVAL '1 second' < $3" +
+		" AND ($5::TEXT[] IS NULL OR instance_id = ANY($5))" +
+		" AND EXISTS (SELECT 1 FROM eventstore.events l" +
+		" WHERE l.instance_id = e.instance_id AND l.aggregate_type = e.aggregate_type AND l.aggregate_id = e.aggregate_id AND l.event_sequence > e.event_sequence)" +
+		" ORDER BY creation_date" +
+		" LIMIT $4"
+	// the projections only reduce an event if its previous aggregate type sequence matches their current sequence
+	pruneWatermarkStmt = "WITH latest AS (" +
+		"SELECT COALESCE(MAX(event_sequence), 0) AS seq FROM eventstore.events WHERE instance_id = $1 AND aggregate_type = $2" +
+		"), reduced AS (" +
+		"SELECT MIN(current_sequence) AS seq FROM projections.current_sequences WHERE instance_id = $1 AND aggregate_type = $2" +
+		") SELECT LEAST(latest.seq, COALESCE(reduced.seq, latest.seq)) FROM latest, reduced"
+
+	archiveEventsStmt = "INSERT INTO eventstore.events_archive (" + eventColumns + ", id, archive_date)" +
+		" SELECT " + eventColumns + ", id, statement_timestamp() FROM eventstore.events" +
+		" WHERE instance_id = $1 AND event_sequence = ANY($2)" +
+		" ON CONFLICT DO NOTHING"
+	pruneEventStmt = "DELETE FROM eventstore.events" +
+		" WHERE instance_id = $1 AND event_sequence = $2" +
+		" RETURNING aggregate_type, aggregate_id, previous_aggregate_sequence, previous_aggregate_type_sequence"
+	// the successor of a removed event is the next event of its aggregate (or aggregate type),
+	// it's looked up by the indexes of the aggregate and updated by its primary key
+	relinkAggregateSequenceStmt = "UPDATE eventstore.events SET previous_aggregate_sequence = $5" +
+		" WHERE instance_id = $1 AND previous_aggregate_sequence = $4 AND event_sequence = (" +
+		"SELECT event_sequence FROM eventstore.events" +
+		" WHERE aggregate_type = $2 AND aggregate_id = $3 AND instance_id = $1 AND event_sequence > $4" +
+		" ORDER BY event_sequence LIMIT 1" +
+		")"
+	relinkAggregateTypeSequenceStmt = "UPDATE eventstore.events SET previous_aggregate_type_sequence = $4" +
+		" WHERE instance_id = $1 AND previous_aggregate_type_sequence = $3 AND event_sequence = (" +
+		"SELECT event_sequence FROM eventstore.events" +
+		" WHERE aggregate_type = $2 AND instance_id = $1 AND event_sequence > $3" +
+		" ORDER BY event_sequence LIMIT 1" +
+		")"
+	pruneSnapshotsStmt = "DELETE FROM eventstore.snapshots" +
+		" WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3"
+)
+
+var _ repository.RetentionRepository = (*CRDB)(nil)
+
+// ExpiredAggregates implements [repository.RetentionRepository]
+func (db *CRDB) ExpiredAggregates(ctx context.Context, aggregateType repository.AggregateType, latestEventTypes []repository.EventType, before time.Time, limit uint64, instanceIDs ...string) ([]*repository.Event, error) {
+	return db.queryEvents(ctx, expiredAggregatesStmt, aggregateType, before, limit, database.StringArray(instanceIDs), eventTypesToArray(latestEventTypes))
+}
+
+// ExpiredEvents implements [repository.RetentionRepository]
+func (db *CRDB) ExpiredEvents(ctx context.Context, aggregateType repository.AggregateType, eventTypes []repository.EventType, before time.Time, limit uint64, instanceIDs ...string) ([]*repository.Event, error) {
+	return db.queryEvents(ctx, expiredEventsStmt, aggregateType, eventTypesToArray(eventTypes), before, limit, database.StringArray(instanceIDs))
+}
+
+func eventTypesToArray(eventTypes []repository.EventType) database.StringArray {
+	types := make(database.StringArray, len(eventTypes))
+	for i, eventType := range eventTypes {
+		types[i] = string(eventType)
+	}
+	return types
+}
+
+func (db *CRDB) queryEvents(ctx context.Context, stmt string, args ...interface{}) ([]*repository.Event, error) {
+	rows, err := db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Rtn1q", "unable to query expired events")
+	}
+	defer rows.Close()
+
+	events := make([]*repository.Event, 0)
+	for rows.Next() {
+		if err = eventsScanner(rows.Scan, &events); err != nil {
+			return nil, err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Rtn2r", "unable to query expired events")
+	}
+	return events, nil
+}
+
+// PruneWatermark implements [repository.RetentionRepository]
+func (db *CRDB) PruneWatermark(ctx context.Context, instanceID string, aggregateType repository.AggregateType) (uint64, error) {
+	var watermark Sequence
+	if err := db.QueryRowContext(ctx, pruneWatermarkStmt, instanceID, aggregateType).Scan(&watermark); err != nil {
+		return 0, caos_errs.ThrowInternal(err, "SQL-Rtn3w", "unable to query prune watermark")
+	}
+	return uint64(watermark), nil
+}
+
+// PruneEvents implements [repository.RetentionRepository]
+func (db *CRDB) PruneEvents(ctx context.Context, prune *repository.Prune) error {
+	sequences := make([]int64, len(prune.Events))
+	for i, event := range prune.Events {
+		sequences[i] = int64(event.Sequence)
+	}
+	err := crdb.ExecuteTx(ctx, db.DB.DB, nil, func(tx *sql.Tx) error {
+		if prune.Archive {
+			if _, err := tx.ExecContext(ctx, archiveEventsStmt, prune.InstanceID, pq.Array(sequences)); err != nil {
+				return caos_errs.ThrowInternal(err, "SQL-Rtn4a", "unable to archive events")
+			}
+		}
+		// the events are removed in order of their sequence,
+		// so the successor of a removed event is linked to the closest remaining event
+		sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+		for _, sequence := range sequences {
+			if err := pruneEvent(ctx, tx, prune.InstanceID, sequence); err != nil {
+				return err
+			}
+		}
+		for _, aggregate := range prune.Aggregates {
+			if _, err := tx.ExecContext(ctx, pruneSnapshotsStmt, prune.InstanceID, aggregate.Type, aggregate.ID); err != nil {
+				return caos_errs.ThrowInternal(err, "SQL-Rtn5s", "unable to remove snapshots")
+			}
+			// the key is needed to read the personal data of the archived events
+			if prune.Archive {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, removePersonalDataKeyStmt, prune.InstanceID, aggregate.Type, aggregate.ID); err != nil {
+				return caos_errs.ThrowInternal(err, "SQL-Rtn6k", "unable to remove personal data key")
+			}
+		}
+		return db.handleUniqueConstraints(ctx, tx, prune.UniqueConstraints...)
+	})
+	if err != nil && !errors.Is(err, &caos_errs.CaosError{}) {
+		err = caos_errs.ThrowInternal(err, "SQL-Rtn7p", "unable to prune events")
+	}
+	return err
+}
+
+func pruneEvent(ctx context.Context, tx *sql.Tx, instanceID string, sequence int64) error {
+	var (
+		aggregateType, aggregateID                               string
+		previousAggregateSequence, previousAggregateTypeSequence sql.NullInt64
+	)
+	err := tx.QueryRowContext(ctx, pruneEventStmt, instanceID, sequence).Scan(&aggregateType, &aggregateID, &previousAggregateSequence, &previousAggregateTypeSequence)
+	if errors.Is(err, sql.ErrNoRows) {
+		// already pruned by a concurrent run
+		return nil
+	}
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Rtn8d", "unable to remove event")
+	}
+	if _, err = tx.ExecContext(ctx, relinkAggregateSequenceStmt, instanceID, aggregateType, aggregateID, sequence, previousAggregateSequence); err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Rtn9l", "unable to link aggregate sequence")
+	}
+	if _, err = tx.ExecContext(ctx, relinkAggregateTypeSequenceStmt, instanceID, aggregateType, sequence, previousAggregateTypeSequence); err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Rtn0l", "unable to link aggregate type sequence")
+	}
+	return nil
+}
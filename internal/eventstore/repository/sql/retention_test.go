@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func Test_pruneEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "already pruned",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(pruneEventStmt)).
+					WithArgs("instance", int64(42)).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "relinked by aggregate",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(pruneEventStmt)).
+					WithArgs("instance", int64(42)).
+					WillReturnRows(sqlmock.NewRows([]string{"aggregate_type", "aggregate_id", "previous_aggregate_sequence", "previous_aggregate_type_sequence"}).
+						AddRow("user", "user1", int64(40), int64(41)))
+				mock.ExpectExec(regexp.QuoteMeta(relinkAggregateSequenceStmt)).
+					WithArgs("instance", "user", "user1", int64(42), int64(40)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(relinkAggregateTypeSequenceStmt)).
+					WithArgs("instance", "user", int64(42), int64(41)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "first event of the aggregate",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(pruneEventStmt)).
+					WithArgs("instance", int64(42)).
+					WillReturnRows(sqlmock.NewRows([]string{"aggregate_type", "aggregate_id", "previous_aggregate_sequence", "previous_aggregate_type_sequence"}).
+						AddRow("user", "user1", nil, nil))
+				mock.ExpectExec(regexp.QuoteMeta(relinkAggregateSequenceStmt)).
+					WithArgs("instance", "user", "user1", int64(42), nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(relinkAggregateTypeSequenceStmt)).
+					WithArgs("instance", "user", int64(42), nil).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "relink fails",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(pruneEventStmt)).
+					WithArgs("instance", int64(42)).
+					WillReturnRows(sqlmock.NewRows([]string{"aggregate_type", "aggregate_id", "previous_aggregate_sequence", "previous_aggregate_type_sequence"}).
+						AddRow("user", "user1", int64(40), int64(41)))
+				mock.ExpectExec(regexp.QuoteMeta(relinkAggregateSequenceStmt)).
+					WithArgs("instance", "user", "user1", int64(42), int64(40)).
+					WillReturnError(errors.New("relink failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("unable to create mock client: %v", err)
+			}
+			defer db.Close()
+			mock.ExpectBegin()
+			tt.expect(mock)
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+			if err = pruneEvent(context.Background(), tx, "instance", 42); (err != nil) != tt.wantErr {
+				t.Errorf("pruneEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("not all expectations met: %v", err)
+			}
+		})
+	}
+}
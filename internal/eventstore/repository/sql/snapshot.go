@@ -0,0 +1,76 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	latestSnapshotStmt = "SELECT event_sequence, resource_owner, change_date, payload FROM eventstore.snapshots" +
+		" WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND snapshot_type = $4" +
+		" ORDER BY event_sequence DESC LIMIT 1"
+	pushSnapshotStmt = "INSERT INTO eventstore.snapshots" +
+		" (instance_id, aggregate_type, aggregate_id, snapshot_type, event_sequence, resource_owner, change_date, payload, creation_date)" +
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, statement_timestamp())" +
+		" ON CONFLICT DO NOTHING"
+	removeOlderSnapshotsStmt = "DELETE FROM eventstore.snapshots" +
+		" WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND snapshot_type = $4 AND event_sequence < $5"
+)
+
+var _ repository.SnapshotRepository = (*CRDB)(nil)
+
+// LatestSnapshot implements [repository.SnapshotRepository]
+func (db *CRDB) LatestSnapshot(ctx context.Context, instanceID string, aggregateType repository.AggregateType, aggregateID, snapshotType string) (*repository.Snapshot, error) {
+	snapshot := &repository.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          snapshotType,
+	}
+	err := db.QueryRowContext(ctx, latestSnapshotStmt, instanceID, aggregateType, aggregateID, snapshotType).
+		Scan(&snapshot.Sequence, &snapshot.ResourceOwner, &snapshot.ChangeDate, &snapshot.Payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Snp1q", "unable to query snapshot")
+	}
+	return snapshot, nil
+}
+
+// PushSnapshot implements [repository.SnapshotRepository]
+func (db *CRDB) PushSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	err := crdb.ExecuteTx(ctx, db.DB.DB, nil, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, pushSnapshotStmt,
+			snapshot.InstanceID,
+			snapshot.AggregateType,
+			snapshot.AggregateID,
+			snapshot.Type,
+			snapshot.Sequence,
+			snapshot.ResourceOwner,
+			snapshot.ChangeDate,
+			snapshot.Payload,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, removeOlderSnapshotsStmt,
+			snapshot.InstanceID,
+			snapshot.AggregateType,
+			snapshot.AggregateID,
+			snapshot.Type,
+			snapshot.Sequence,
+		)
+		return err
+	})
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Snp2p", "unable to push snapshot")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package sql
+
+import (
+	"context"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const uniqueConstraintsStmt = "SELECT unique_type, unique_field FROM eventstore.unique_constraints" +
+	" WHERE instance_id = $1" +
+	" ORDER BY unique_type, unique_field"
+
+var _ repository.UniqueConstraintRepository = (*CRDB)(nil)
+
+// UniqueConstraints implements [repository.UniqueConstraintRepository]
+func (db *CRDB) UniqueConstraints(ctx context.Context, instanceID string) ([]*repository.UniqueConstraint, error) {
+	rows, err := db.QueryContext(ctx, uniqueConstraintsStmt, instanceID)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Uq2lt", "unable to query unique constraints")
+	}
+	defer rows.Close()
+
+	constraints := make([]*repository.UniqueConstraint, 0)
+	for rows.Next() {
+		constraint := &repository.UniqueConstraint{InstanceID: instanceID}
+		if err := rows.Scan(&constraint.UniqueType, &constraint.UniqueField); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "SQL-Uq3sc", "unable to scan unique constraint")
+		}
+		constraints = append(constraints, constraint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Uq4rw", "unable to query unique constraints")
+	}
+	return constraints, nil
+}
@@ -1,5 +1,7 @@
 package repository
 
+import "context"
+
 // UniqueCheck represents all information about a unique attribute
 type UniqueConstraint struct {
 	//UniqueField is the field which should be unique
@@ -31,3 +33,10 @@ const (
 func (f UniqueConstraintAction) Valid() bool {
 	return f >= 0 && f < uniqueConstraintActionCount
 }
+
+// UniqueConstraintRepository lists the stored unique constraints
+type UniqueConstraintRepository interface {
+	// UniqueConstraints returns the unique constraints of the instance,
+	// the global unique constraints are returned if the instanceID is empty
+	UniqueConstraints(ctx context.Context, instanceID string) ([]*UniqueConstraint, error)
+}
@@ -0,0 +1,372 @@
+package eventstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const defaultPruneBulkLimit = 100
+
+type RetentionConfig struct {
+	// Enabled prunes the expired aggregates and events of all instances periodically
+	Enabled bool
+	// Interval between two prune runs
+	Interval time.Duration
+	// Archive moves the pruned events into the eventstore.events_archive table,
+	// the events are deleted otherwise
+	Archive bool
+	// BulkLimit is the amount of aggregates or events pruned at once
+	BulkLimit uint64
+}
+
+// PruneResult contains the amount of pruned aggregates and events
+type PruneResult struct {
+	// Aggregates is the amount of aggregates pruned completely
+	Aggregates uint64
+	// Events is the amount of pruned events including the events of the pruned aggregates
+	Events uint64
+}
+
+// ArchiveFunc receives the events of an instance before they are pruned, e.g. to write them into a file.
+// The personal data of the events is decrypted.
+// The events are kept if an error is returned
+type ArchiveFunc func(ctx context.Context, events []*repository.Event) error
+
+type aggregateRetention struct {
+	ttl              time.Duration
+	latestEventTypes []EventType
+}
+
+type eventRetention struct {
+	aggregateType AggregateType
+	eventTypes    []EventType
+	ttl           time.Duration
+}
+
+// RegisterAggregateRetention prunes the aggregates of the type
+// once their latest event is older than the ttl.
+// If latest event types are passed, only aggregates whose latest event is of one of the types are pruned (e.g. terminated sessions),
+// so aggregates which are still valid are never removed
+func (es *Eventstore) RegisterAggregateRetention(aggregateType AggregateType, ttl time.Duration, latestEventTypes ...EventType) *Eventstore {
+	if aggregateType == "" || ttl <= 0 {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	es.aggregateRetention[aggregateType] = &aggregateRetention{
+		ttl:              ttl,
+		latestEventTypes: latestEventTypes,
+	}
+	return es
+}
+
+// RegisterEventRetention prunes the events of the types once they are older than the ttl.
+// The ttl of events with an expiry in their payload (e.g. codes) starts after their expiry,
+// so the retention never conflicts with an expiry configured per instance.
+// The latest event of an aggregate is never pruned,
+// so the events must not change the state of an aggregate after they expired
+func (es *Eventstore) RegisterEventRetention(aggregateType AggregateType, ttl time.Duration, eventTypes ...EventType) *Eventstore {
+	if aggregateType == "" || ttl <= 0 || len(eventTypes) == 0 {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	es.eventRetention = append(es.eventRetention, &eventRetention{
+		aggregateType: aggregateType,
+		eventTypes:    eventTypes,
+		ttl:           ttl,
+	})
+	return es
+}
+
+// StartPruning prunes the expired aggregates and events of all instances
+// in the configured interval until the context is done
+func (es *Eventstore) StartPruning(ctx context.Context) {
+	if es.retention == nil || !es.retentionConfig.Enabled || es.retentionConfig.Interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(es.retentionConfig.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := es.Prune(ctx, nil)
+				if err != nil {
+					logging.WithError(err).Warn("unable to prune events")
+					continue
+				}
+				logging.WithFields("aggregates", result.Aggregates, "events", result.Events).Debug("events pruned")
+			}
+		}
+	}()
+}
+
+// Prune removes the expired aggregates and events of the instances,
+// all instances are pruned if no instance ids are passed.
+// Events are only pruned after all projections reduced a later event of the aggregate type,
+// so the projections keep working and are rebuilt without the pruned events.
+// The unique constraints still added by a pruned aggregate are removed.
+// If archive is set, it's called with the events before they are pruned
+func (es *Eventstore) Prune(ctx context.Context, archive ArchiveFunc, instanceIDs ...string) (*PruneResult, error) {
+	if es.retention == nil {
+		return nil, errors.ThrowUnimplemented(nil, "V2-Rtn1n", "pruning is not supported by the repository")
+	}
+	p := &pruner{
+		es:          es,
+		archive:     archive,
+		instanceIDs: instanceIDs,
+		now:         time.Now(),
+		bulkLimit:   es.retentionConfig.BulkLimit,
+		watermarks:  make(map[watermarkKey]uint64),
+		result:      new(PruneResult),
+	}
+	if p.bulkLimit == 0 {
+		p.bulkLimit = defaultPruneBulkLimit
+	}
+
+	es.interceptorMutex.Lock()
+	aggregateRetentions := make(map[AggregateType]*aggregateRetention, len(es.aggregateRetention))
+	aggregateTypes := make([]AggregateType, 0, len(es.aggregateRetention))
+	for aggregateType, retention := range es.aggregateRetention {
+		aggregateRetentions[aggregateType] = retention
+		aggregateTypes = append(aggregateTypes, aggregateType)
+	}
+	eventRetentions := es.eventRetention
+	es.interceptorMutex.Unlock()
+	sort.Slice(aggregateTypes, func(i, j int) bool { return aggregateTypes[i] < aggregateTypes[j] })
+
+	for _, aggregateType := range aggregateTypes {
+		if err := p.pruneAggregates(ctx, aggregateType, aggregateRetentions[aggregateType]); err != nil {
+			return p.result, err
+		}
+	}
+	for _, retention := range eventRetentions {
+		if err := p.pruneEvents(ctx, retention); err != nil {
+			return p.result, err
+		}
+	}
+	return p.result, nil
+}
+
+type watermarkKey struct {
+	instanceID    string
+	aggregateType repository.AggregateType
+}
+
+// pruner prunes the expired events of a single run
+type pruner struct {
+	es          *Eventstore
+	archive     ArchiveFunc
+	instanceIDs []string
+	now         time.Time
+	bulkLimit   uint64
+	watermarks  map[watermarkKey]uint64
+	result      *PruneResult
+}
+
+func (p *pruner) pruneAggregates(ctx context.Context, aggregateType AggregateType, retention *aggregateRetention) error {
+	latestEventTypes := eventTypesToRepository(retention.latestEventTypes)
+	for {
+		events, err := p.es.retention.ExpiredAggregates(ctx, repository.AggregateType(aggregateType), latestEventTypes, p.now.Add(-retention.ttl), p.bulkLimit, p.instanceIDs...)
+		if err != nil {
+			return err
+		}
+		if err = p.es.decryptPersonalData(ctx, events); err != nil {
+			return err
+		}
+		aggregates := groupByAggregate(events)
+		prunes := make(map[string]*repository.Prune)
+		instanceIDs := make([]string, 0)
+		for _, aggregateEvents := range aggregates {
+			latest := aggregateEvents[len(aggregateEvents)-1]
+			prunable, err := p.prunable(ctx, latest)
+			if err != nil {
+				return err
+			}
+			if !prunable {
+				continue
+			}
+			mapped, err := p.es.mapEvents(aggregateEvents)
+			if err != nil {
+				return err
+			}
+			prune, ok := prunes[latest.InstanceID]
+			if !ok {
+				prune = &repository.Prune{InstanceID: latest.InstanceID}
+				prunes[latest.InstanceID] = prune
+				instanceIDs = append(instanceIDs, latest.InstanceID)
+			}
+			prune.Events = append(prune.Events, aggregateEvents...)
+			prune.Aggregates = append(prune.Aggregates, &repository.PrunedAggregate{Type: latest.AggregateType, ID: latest.AggregateID})
+			prune.UniqueConstraints = append(prune.UniqueConstraints, uniqueConstraintsToRepository(latest.InstanceID, remainingUniqueConstraints(mapped))...)
+		}
+		if len(prunes) == 0 {
+			return nil
+		}
+		for _, instanceID := range instanceIDs {
+			if err = p.prune(ctx, prunes[instanceID]); err != nil {
+				return err
+			}
+			p.result.Aggregates += uint64(len(prunes[instanceID].Aggregates))
+		}
+		if uint64(len(aggregates)) < p.bulkLimit {
+			return nil
+		}
+	}
+}
+
+func (p *pruner) pruneEvents(ctx context.Context, retention *eventRetention) error {
+	eventTypes := eventTypesToRepository(retention.eventTypes)
+	for {
+		events, err := p.es.retention.ExpiredEvents(ctx, repository.AggregateType(retention.aggregateType), eventTypes, p.now.Add(-retention.ttl), p.bulkLimit, p.instanceIDs...)
+		if err != nil {
+			return err
+		}
+		prunes := make(map[string]*repository.Prune)
+		instanceIDs := make([]string, 0)
+		for _, event := range events {
+			prunable, err := p.prunable(ctx, event)
+			if err != nil {
+				return err
+			}
+			if !prunable {
+				continue
+			}
+			prune, ok := prunes[event.InstanceID]
+			if !ok {
+				prune = &repository.Prune{InstanceID: event.InstanceID}
+				prunes[event.InstanceID] = prune
+				instanceIDs = append(instanceIDs, event.InstanceID)
+			}
+			prune.Events = append(prune.Events, event)
+		}
+		if len(prunes) == 0 {
+			return nil
+		}
+		for _, instanceID := range instanceIDs {
+			if p.archive != nil {
+				if err = p.es.decryptPersonalData(ctx, prunes[instanceID].Events); err != nil {
+					return err
+				}
+			}
+			if err = p.prune(ctx, prunes[instanceID]); err != nil {
+				return err
+			}
+		}
+		if uint64(len(events)) < p.bulkLimit {
+			return nil
+		}
+	}
+}
+
+// prunable checks if all projections reduced a later event of the aggregate type
+func (p *pruner) prunable(ctx context.Context, event *repository.Event) (bool, error) {
+	key := watermarkKey{instanceID: event.InstanceID, aggregateType: event.AggregateType}
+	watermark, ok := p.watermarks[key]
+	if !ok {
+		var err error
+		watermark, err = p.es.retention.PruneWatermark(ctx, event.InstanceID, event.AggregateType)
+		if err != nil {
+			return false, err
+		}
+		p.watermarks[key] = watermark
+	}
+	return event.Sequence < watermark, nil
+}
+
+func (p *pruner) prune(ctx context.Context, prune *repository.Prune) error {
+	if p.archive != nil {
+		if err := p.archive(ctx, prune.Events); err != nil {
+			return err
+		}
+	}
+	prune.Archive = p.es.retentionConfig.Archive
+	if err := p.es.retention.PruneEvents(ctx, prune); err != nil {
+		return err
+	}
+	p.result.Events += uint64(len(prune.Events))
+	return nil
+}
+
+func eventTypesToRepository(eventTypes []EventType) []repository.EventType {
+	types := make([]repository.EventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		types[i] = repository.EventType(eventType)
+	}
+	return types
+}
+
+// groupByAggregate splits the events ordered by instance and aggregate into the events of each aggregate
+func groupByAggregate(events []*repository.Event) [][]*repository.Event {
+	aggregates := make([][]*repository.Event, 0)
+	for i, event := range events {
+		if i == 0 || event.InstanceID != events[i-1].InstanceID || event.AggregateID != events[i-1].AggregateID {
+			aggregates = append(aggregates, make([]*repository.Event, 0, 1))
+		}
+		aggregates[len(aggregates)-1] = append(aggregates[len(aggregates)-1], event)
+	}
+	return aggregates
+}
+
+// remainingUniqueConstraints replays the unique constraints of the events of an aggregate
+// and returns the removals of the constraints which are still added after its latest event
+func remainingUniqueConstraints(events []Event) []*EventUniqueConstraint {
+	type constraintKey struct {
+		uniqueType  string
+		uniqueField string
+		isGlobal    bool
+	}
+	added := make(map[constraintKey]bool)
+	keys := make([]constraintKey, 0)
+	for _, event := range events {
+		command, ok := event.(interface {
+			UniqueConstraints() []*EventUniqueConstraint
+		})
+		if !ok {
+			continue
+		}
+		for _, constraint := range command.UniqueConstraints() {
+			if constraint == nil {
+				continue
+			}
+			key := constraintKey{
+				uniqueType:  constraint.UniqueType,
+				uniqueField: strings.ToLower(constraint.UniqueField),
+				isGlobal:    constraint.IsGlobal,
+			}
+			switch constraint.Action {
+			case UniqueConstraintAdd:
+				if _, ok := added[key]; !ok {
+					keys = append(keys, key)
+				}
+				added[key] = true
+			case UniqueConstraintRemove:
+				added[key] = false
+			}
+		}
+	}
+	removals := make([]*EventUniqueConstraint, 0)
+	for _, key := range keys {
+		if !added[key] {
+			continue
+		}
+		if key.isGlobal {
+			removals = append(removals, NewRemoveGlobalEventUniqueConstraint(key.uniqueType, key.uniqueField))
+			continue
+		}
+		removals = append(removals, NewRemoveEventUniqueConstraint(key.uniqueType, key.uniqueField))
+	}
+	return removals
+}
@@ -0,0 +1,212 @@
+package eventstore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+type constraintEvent struct {
+	BaseEvent
+	constraints []*EventUniqueConstraint
+}
+
+func (e *constraintEvent) UniqueConstraints() []*EventUniqueConstraint {
+	return e.constraints
+}
+
+func Test_remainingUniqueConstraints(t *testing.T) {
+	event := func(constraints ...*EventUniqueConstraint) Event {
+		return &constraintEvent{constraints: constraints}
+	}
+	tests := []struct {
+		name   string
+		events []Event
+		want   []*EventUniqueConstraint
+	}{
+		{
+			name:   "no constraints",
+			events: []Event{&BaseEvent{}, event()},
+			want:   []*EventUniqueConstraint{},
+		},
+		{
+			name: "added constraints are removed",
+			events: []Event{
+				event(
+					NewAddEventUniqueConstraint("device_code", "Code", "error"),
+					NewAddGlobalEventUniqueConstraint("domain", "example.com", "error"),
+				),
+				&BaseEvent{},
+			},
+			want: []*EventUniqueConstraint{
+				NewRemoveEventUniqueConstraint("device_code", "code"),
+				NewRemoveGlobalEventUniqueConstraint("domain", "example.com"),
+			},
+		},
+		{
+			name: "removed constraints are ignored",
+			events: []Event{
+				event(NewAddEventUniqueConstraint("device_code", "code", "error")),
+				event(NewAddEventUniqueConstraint("user_code", "code", "error")),
+				event(NewRemoveEventUniqueConstraint("device_code", "CODE")),
+			},
+			want: []*EventUniqueConstraint{
+				NewRemoveEventUniqueConstraint("user_code", "code"),
+			},
+		},
+		{
+			name: "readded constraints are removed",
+			events: []Event{
+				event(NewAddEventUniqueConstraint("device_code", "code", "error")),
+				event(NewRemoveEventUniqueConstraint("device_code", "code")),
+				event(NewAddEventUniqueConstraint("device_code", "code", "error")),
+			},
+			want: []*EventUniqueConstraint{
+				NewRemoveEventUniqueConstraint("device_code", "code"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingUniqueConstraints(tt.events); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("remainingUniqueConstraints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testRetentionRepo struct {
+	aggregates []*repository.Event
+	events     []*repository.Event
+	watermarks map[repository.AggregateType]uint64
+	pruned     []*repository.Prune
+}
+
+func (r *testRetentionRepo) ExpiredAggregates(_ context.Context, aggregateType repository.AggregateType, latestEventTypes []repository.EventType, _ time.Time, _ uint64, _ ...string) ([]*repository.Event, error) {
+	events := make([]*repository.Event, 0)
+	for _, aggregateEvents := range groupByAggregate(r.aggregates) {
+		latest := aggregateEvents[len(aggregateEvents)-1]
+		if latest.AggregateType != aggregateType || r.isPruned(latest) || !containsEventType(latestEventTypes, latest.Type) {
+			continue
+		}
+		events = append(events, aggregateEvents...)
+	}
+	return events, nil
+}
+
+func containsEventType(eventTypes []repository.EventType, eventType repository.EventType) bool {
+	if len(eventTypes) == 0 {
+		return true
+	}
+	for _, typ := range eventTypes {
+		if typ == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *testRetentionRepo) ExpiredEvents(_ context.Context, aggregateType repository.AggregateType, _ []repository.EventType, _ time.Time, _ uint64, _ ...string) ([]*repository.Event, error) {
+	events := make([]*repository.Event, 0)
+	for _, event := range r.events {
+		if event.AggregateType == aggregateType && !r.isPruned(event) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *testRetentionRepo) PruneWatermark(_ context.Context, _ string, aggregateType repository.AggregateType) (uint64, error) {
+	return r.watermarks[aggregateType], nil
+}
+
+func (r *testRetentionRepo) PruneEvents(_ context.Context, prune *repository.Prune) error {
+	r.pruned = append(r.pruned, prune)
+	return nil
+}
+
+func (r *testRetentionRepo) isPruned(event *repository.Event) bool {
+	for _, prune := range r.pruned {
+		for _, pruned := range prune.Events {
+			if pruned == event {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestEventstore_Prune(t *testing.T) {
+	event := func(instanceID string, aggregateType repository.AggregateType, aggregateID string, sequence uint64) *repository.Event {
+		return &repository.Event{
+			InstanceID:    instanceID,
+			AggregateType: aggregateType,
+			AggregateID:   aggregateID,
+			Type:          "test.event",
+			Sequence:      sequence,
+		}
+	}
+	terminated := func(event *repository.Event) *repository.Event {
+		event.Type = "test.terminated"
+		return event
+	}
+	repo := &testRetentionRepo{
+		aggregates: []*repository.Event{
+			event("instance", "session", "1", 1),
+			terminated(event("instance", "session", "1", 2)),
+			terminated(event("instance", "session", "2", 3)),
+			// not terminated
+			event("instance", "session", "4", 4),
+			// not reduced by all projections yet
+			terminated(event("instance", "session", "3", 10)),
+		},
+		events: []*repository.Event{
+			event("instance", "user", "4", 5),
+			event("other", "user", "5", 6),
+		},
+		watermarks: map[repository.AggregateType]uint64{
+			"session": 10,
+			"user":    7,
+		},
+	}
+	es := NewEventstore(&Config{retention: repo, Retention: RetentionConfig{Archive: true}})
+	es.RegisterAggregateRetention("session", time.Hour, "test.terminated").
+		RegisterEventRetention("user", time.Hour, "test.event")
+
+	var archived int
+	result, err := es.Prune(context.Background(), func(_ context.Context, events []*repository.Event) error {
+		archived += len(events)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (&PruneResult{Aggregates: 2, Events: 5}); !reflect.DeepEqual(result, want) {
+		t.Errorf("Prune() = %+v, want %+v", result, want)
+	}
+	if archived != 5 {
+		t.Errorf("expected 5 archived events, got %d", archived)
+	}
+	if len(repo.pruned) != 3 {
+		t.Fatalf("expected 3 prunes, got %d", len(repo.pruned))
+	}
+	wantAggregates := []*repository.PrunedAggregate{{Type: "session", ID: "1"}, {Type: "session", ID: "2"}}
+	if !reflect.DeepEqual(repo.pruned[0].Aggregates, wantAggregates) {
+		t.Errorf("pruned aggregates = %v, want %v", repo.pruned[0].Aggregates, wantAggregates)
+	}
+	for _, prune := range repo.pruned {
+		if !prune.Archive {
+			t.Errorf("expected events of %s to be archived", prune.InstanceID)
+		}
+	}
+}
+
+func TestEventstore_Prune_unsupported(t *testing.T) {
+	_, err := NewEventstore(&Config{}).Prune(context.Background(), nil)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
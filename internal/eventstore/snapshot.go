@@ -0,0 +1,167 @@
+package eventstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const snapshotPushTimeout = 10 * time.Second
+
+type SnapshotConfig struct {
+	// Enabled restores the reducers supporting snapshots from their latest snapshot
+	Enabled bool
+	// EventsThreshold is the amount of events reduced on top of the latest snapshot,
+	// after which a new snapshot is written in the background.
+	// A threshold of 0 disables writing snapshots
+	EventsThreshold uint64
+	// QueueSize is the amount of snapshots waiting to be written,
+	// further snapshots are dropped until the queue is processed
+	QueueSize uint64
+}
+
+// SnapshotQueryReducer is a [QueryReducer] whose state can be stored as snapshot,
+// so only the events after the snapshot are reduced.
+// It's implemented by write models embedding [WriteModel].
+//
+// Only reducers querying a single aggregate without sequence, creation date or limit restrictions use snapshots.
+type SnapshotQueryReducer interface {
+	QueryReducer
+	// SnapshotType identifies the snapshots of the reducer for the aggregate of the query.
+	// It must contain all parameters of the state besides the aggregate (e.g. the language of a text)
+	// and must be changed if the state or the query of the reducer changes (e.g. by a version suffix)
+	SnapshotType() string
+	// MarshalSnapshot serializes the state of the reducer
+	MarshalSnapshot() ([]byte, error)
+	// UnmarshalSnapshot restores the state of the reducer
+	UnmarshalSnapshot([]byte) error
+
+	writeModel() *WriteModel
+}
+
+type snapshotWriter struct {
+	repo      repository.SnapshotRepository
+	threshold uint64
+	queue     chan *repository.Snapshot
+}
+
+func newSnapshotWriter(repo repository.SnapshotRepository, config SnapshotConfig) *snapshotWriter {
+	w := &snapshotWriter{
+		repo:      repo,
+		threshold: config.EventsThreshold,
+		queue:     make(chan *repository.Snapshot, config.QueueSize),
+	}
+	go w.run()
+	return w
+}
+
+// run writes the queued snapshots
+func (w *snapshotWriter) run() {
+	for snapshot := range w.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), snapshotPushTimeout)
+		err := w.repo.PushSnapshot(ctx, snapshot)
+		cancel()
+		logging.WithFields("aggregateType", snapshot.AggregateType, "aggregateID", snapshot.AggregateID, "type", snapshot.Type, "sequence", snapshot.Sequence).
+			OnError(err).Warn("unable to write snapshot")
+	}
+}
+
+// enqueue hands the snapshot to the background writer without blocking the caller
+func (w *snapshotWriter) enqueue(snapshot *repository.Snapshot) {
+	select {
+	case w.queue <- snapshot:
+	default:
+		logging.WithFields("aggregateType", snapshot.AggregateType, "aggregateID", snapshot.AggregateID, "type", snapshot.Type).
+			Debug("snapshot queue full, snapshot dropped")
+	}
+}
+
+// filterToSnapshotReducer restores the reducer from the latest snapshot and reduces the events after it.
+// If more events than the threshold were reduced, a new snapshot is written in the background
+func (es *Eventstore) filterToSnapshotReducer(ctx context.Context, r SnapshotQueryReducer) error {
+	query := r.Query()
+	key := snapshotKey(authz.GetInstance(ctx).InstanceID(), query, r.SnapshotType())
+	if key == nil {
+		return es.filterToReducer(ctx, query, r)
+	}
+	snapshot, err := es.snapshots.repo.LatestSnapshot(ctx, key.InstanceID, key.AggregateType, key.AggregateID, key.Type)
+	logging.WithFields("aggregateType", key.AggregateType, "aggregateID", key.AggregateID, "type", key.Type).OnError(err).Warn("unable to load snapshot")
+	if err == nil && snapshot != nil {
+		if err = restoreSnapshot(r, snapshot); err != nil {
+			return err
+		}
+		query.queries[0].eventSequenceGreater = snapshot.Sequence
+	}
+	events, err := es.Filter(ctx, query)
+	if err != nil {
+		return err
+	}
+	r.AppendEvents(events...)
+	if err = r.Reduce(); err != nil {
+		return err
+	}
+	if es.snapshots.threshold == 0 || uint64(len(events)) < es.snapshots.threshold {
+		return nil
+	}
+	lastEvent := events[len(events)-1]
+	key.Sequence = lastEvent.Sequence()
+	key.ChangeDate = lastEvent.CreationDate()
+	key.ResourceOwner = lastEvent.Aggregate().ResourceOwner
+	key.Payload, err = r.MarshalSnapshot()
+	if err != nil {
+		logging.WithFields("aggregateType", key.AggregateType, "aggregateID", key.AggregateID, "type", key.Type).WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	es.snapshots.enqueue(key)
+	return nil
+}
+
+func restoreSnapshot(r SnapshotQueryReducer, snapshot *repository.Snapshot) error {
+	if err := r.UnmarshalSnapshot(snapshot.Payload); err != nil {
+		return errors.ThrowInternal(err, "V2-Snp3r", "unable to restore snapshot")
+	}
+	wm := r.writeModel()
+	wm.AggregateID = snapshot.AggregateID
+	wm.InstanceID = snapshot.InstanceID
+	wm.ResourceOwner = snapshot.ResourceOwner
+	wm.ProcessedSequence = snapshot.Sequence
+	wm.ChangeDate = snapshot.ChangeDate
+	return nil
+}
+
+// snapshotKey returns the key of the snapshots of the reducer
+// or nil if the query cannot be resumed from a snapshot
+func snapshotKey(instanceID string, builder *SearchQueryBuilder, snapshotType string) *repository.Snapshot {
+	if instanceID == "" ||
+		snapshotType == "" ||
+		builder == nil ||
+		builder.columns != repository.ColumnsEvent ||
+		builder.limit > 0 ||
+		builder.desc ||
+		builder.editorUser != "" ||
+		len(builder.queries) != 1 {
+		return nil
+	}
+	query := builder.queries[0]
+	if len(query.aggregateTypes) != 1 ||
+		len(query.aggregateIDs) != 1 ||
+		(query.instanceID != "" && query.instanceID != instanceID) ||
+		len(query.excludedInstanceIDs) > 0 ||
+		query.eventSequenceGreater > 0 ||
+		query.eventSequenceLess > 0 ||
+		len(query.eventData) > 0 ||
+		!query.creationDateAfter.IsZero() {
+		return nil
+	}
+	return &repository.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: repository.AggregateType(query.aggregateTypes[0]),
+		AggregateID:   query.aggregateIDs[0],
+		Type:          snapshotType,
+	}
+}
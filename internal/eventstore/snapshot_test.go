@@ -0,0 +1,360 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+type testSnapshotRepo struct {
+	latest *repository.Snapshot
+	err    error
+	pushed []*repository.Snapshot
+}
+
+func (repo *testSnapshotRepo) LatestSnapshot(ctx context.Context, instanceID string, aggregateType repository.AggregateType, aggregateID, snapshotType string) (*repository.Snapshot, error) {
+	return repo.latest, repo.err
+}
+
+func (repo *testSnapshotRepo) PushSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	repo.pushed = append(repo.pushed, snapshot)
+	return nil
+}
+
+// testSnapshotRepoFilter returns the events after the sequence of the query
+type testSnapshotRepoFilter struct {
+	testRepo
+	sequenceGreater uint64
+}
+
+func (repo *testSnapshotRepoFilter) Filter(ctx context.Context, searchQuery *repository.SearchQuery) ([]*repository.Event, error) {
+	for _, filter := range searchQuery.Filters[0] {
+		if filter.Field == repository.FieldSequence && filter.Operation == repository.OperationGreater {
+			repo.sequenceGreater = filter.Value.(uint64)
+		}
+	}
+	events := make([]*repository.Event, 0, len(repo.events))
+	for _, event := range repo.events {
+		if event.Sequence > repo.sequenceGreater {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type testSnapshotReducer struct {
+	WriteModel
+
+	Count int `json:"count"`
+}
+
+func (r *testSnapshotReducer) Reduce() error {
+	r.Count += len(r.Events)
+	return r.WriteModel.Reduce()
+}
+
+func (r *testSnapshotReducer) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs("id").
+		Builder()
+}
+
+func (r *testSnapshotReducer) SnapshotType() string {
+	return "test.v1"
+}
+
+func (r *testSnapshotReducer) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *testSnapshotReducer) UnmarshalSnapshot(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func Test_snapshotKey(t *testing.T) {
+	type args struct {
+		instanceID   string
+		builder      *SearchQueryBuilder
+		snapshotType string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *repository.Snapshot
+	}{
+		{
+			name: "single aggregate",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					ResourceOwner("ro").
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					EventTypes("test.event").
+					Builder(),
+				snapshotType: "test.v1",
+			},
+			want: &repository.Snapshot{
+				InstanceID:    "instance",
+				AggregateType: "test.aggregate",
+				AggregateID:   "id",
+				Type:          "test.v1",
+			},
+		},
+		{
+			name: "no instance",
+			args: args{
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					Builder(),
+				snapshotType: "test.v1",
+			},
+		},
+		{
+			name: "no snapshot type",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					Builder(),
+			},
+		},
+		{
+			name: "multiple aggregates",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id", "id2").
+					Builder(),
+				snapshotType: "test.v1",
+			},
+		},
+		{
+			name: "multiple queries",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					Or().
+					AggregateTypes("test.aggregate2").
+					AggregateIDs("id").
+					Builder(),
+				snapshotType: "test.v1",
+			},
+		},
+		{
+			name: "sequence",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					SequenceGreater(2).
+					Builder(),
+				snapshotType: "test.v1",
+			},
+		},
+		{
+			name: "limit",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsEvent).
+					Limit(1).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					Builder(),
+				snapshotType: "test.v1",
+			},
+		},
+		{
+			name: "max sequence",
+			args: args{
+				instanceID: "instance",
+				builder: NewSearchQueryBuilder(ColumnsMaxSequence).
+					AddQuery().
+					AggregateTypes("test.aggregate").
+					AggregateIDs("id").
+					Builder(),
+				snapshotType: "test.v1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snapshotKey(tt.args.instanceID, tt.args.builder, tt.args.snapshotType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snapshotKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventstore_filterToSnapshotReducer(t *testing.T) {
+	changeDate := time.Now()
+	type fields struct {
+		events    []*repository.Event
+		snapshot  *repository.Snapshot
+		err       error
+		threshold uint64
+	}
+	type res struct {
+		count           int
+		sequence        uint64
+		sequenceGreater uint64
+		pushed          []uint64
+		wantErr         bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "no snapshot",
+			fields: fields{
+				events:    testSnapshotEvents(1, 2, 3),
+				threshold: 5,
+			},
+			res: res{
+				count:    3,
+				sequence: 3,
+			},
+		},
+		{
+			name: "no snapshot, threshold reached",
+			fields: fields{
+				events:    testSnapshotEvents(1, 2, 3),
+				threshold: 3,
+			},
+			res: res{
+				count:    3,
+				sequence: 3,
+				pushed:   []uint64{3},
+			},
+		},
+		{
+			name: "resume from snapshot",
+			fields: fields{
+				events: testSnapshotEvents(1, 2, 3),
+				snapshot: &repository.Snapshot{
+					InstanceID:    "instance",
+					AggregateType: "test.aggregate",
+					AggregateID:   "id",
+					Type:          "test.v1",
+					Sequence:      2,
+					ResourceOwner: "ro",
+					ChangeDate:    changeDate,
+					Payload:       []byte(`{"count": 10}`),
+				},
+				threshold: 3,
+			},
+			res: res{
+				count:           11,
+				sequence:        3,
+				sequenceGreater: 2,
+			},
+		},
+		{
+			name: "snapshot error ignored",
+			fields: fields{
+				events:    testSnapshotEvents(1, 2),
+				err:       errors.ThrowInternal(nil, "V2-Snp9t", "test err"),
+				threshold: 3,
+			},
+			res: res{
+				count:    2,
+				sequence: 2,
+			},
+		},
+		{
+			name: "invalid snapshot",
+			fields: fields{
+				events: testSnapshotEvents(1, 2),
+				snapshot: &repository.Snapshot{
+					Sequence: 1,
+					Payload:  []byte(`invalid`),
+				},
+			},
+			res: res{
+				wantErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &testSnapshotRepoFilter{testRepo: testRepo{events: tt.fields.events, t: t}}
+			snapshotRepo := &testSnapshotRepo{latest: tt.fields.snapshot, err: tt.fields.err}
+			es := &Eventstore{
+				repo: repo,
+				snapshots: &snapshotWriter{
+					repo:      snapshotRepo,
+					threshold: tt.fields.threshold,
+					queue:     make(chan *repository.Snapshot, 1),
+				},
+			}
+			reducer := new(testSnapshotReducer)
+			err := es.FilterToQueryReducer(authz.WithInstanceID(context.Background(), "instance"), reducer)
+			if (err != nil) != tt.res.wantErr {
+				t.Fatalf("Eventstore.FilterToQueryReducer() error = %v, wantErr %v", err, tt.res.wantErr)
+			}
+			if tt.res.wantErr {
+				return
+			}
+			if reducer.Count != tt.res.count {
+				t.Errorf("wrong count: want %d, got %d", tt.res.count, reducer.Count)
+			}
+			if reducer.ProcessedSequence != tt.res.sequence {
+				t.Errorf("wrong sequence: want %d, got %d", tt.res.sequence, reducer.ProcessedSequence)
+			}
+			if repo.sequenceGreater != tt.res.sequenceGreater {
+				t.Errorf("wrong sequence filter: want %d, got %d", tt.res.sequenceGreater, repo.sequenceGreater)
+			}
+			close(es.snapshots.queue)
+			pushed := make([]uint64, 0, len(tt.res.pushed))
+			for snapshot := range es.snapshots.queue {
+				if snapshot.Type != "test.v1" || snapshot.AggregateID != "id" || snapshot.InstanceID != "instance" {
+					t.Errorf("unexpected snapshot key: %+v", snapshot)
+				}
+				pushed = append(pushed, snapshot.Sequence)
+			}
+			if len(pushed) != len(tt.res.pushed) || (len(pushed) > 0 && !reflect.DeepEqual(pushed, tt.res.pushed)) {
+				t.Errorf("wrong snapshots: want %v, got %v", tt.res.pushed, pushed)
+			}
+		})
+	}
+}
+
+func testSnapshotEvents(sequences ...uint64) []*repository.Event {
+	events := make([]*repository.Event, len(sequences))
+	for i, sequence := range sequences {
+		events[i] = &repository.Event{
+			Sequence:      sequence,
+			Type:          "test.event",
+			AggregateType: "test.aggregate",
+			AggregateID:   "id",
+			ResourceOwner: sql.NullString{String: "ro", Valid: true},
+			InstanceID:    "instance",
+			CreationDate:  time.Now(),
+		}
+	}
+	return events
+}
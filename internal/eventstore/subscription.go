@@ -15,6 +15,7 @@ var (
 type Subscription struct {
 	Events chan Event
 	types  map[AggregateType][]EventType
+	closed bool
 }
 
 //SubscribeAggregates subscribes for all events on the given aggregates
@@ -41,7 +42,10 @@ func SubscribeAggregates(eventQueue chan Event, aggregates ...AggregateType) *Su
 //SubscribeEventTypes subscribes for the given event types
 // if no event types are provided the subscription is for all events of the aggregate
 func SubscribeEventTypes(eventQueue chan Event, types map[AggregateType][]EventType) *Subscription {
-	aggregates := make([]AggregateType, len(types))
+	aggregates := make([]AggregateType, 0, len(types))
+	for aggregate := range types {
+		aggregates = append(aggregates, aggregate)
+	}
 	sub := &Subscription{
 		Events: eventQueue,
 		types:  types,
@@ -99,9 +103,11 @@ func (s *Subscription) Unsubscribe() {
 				subs = subs[:len(subs)-1]
 			}
 		}
+		subscriptions[aggregate] = subs
 	}
-	_, ok := <-s.Events
-	if ok {
+	// events already queued stay readable after the channel is closed
+	if !s.closed {
+		s.closed = true
 		close(s.Events)
 	}
 }
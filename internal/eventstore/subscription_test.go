@@ -0,0 +1,41 @@
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeEventTypes(t *testing.T) {
+	queue := make(chan Event, 2)
+	sub := SubscribeEventTypes(queue, map[AggregateType][]EventType{
+		"test.aggregate": {"test.event"},
+	})
+
+	notify([]Event{
+		newTestEvent("id", "matching", nil, false),
+	})
+	assert.Len(t, queue, 1)
+
+	sub.Unsubscribe()
+	subsMutext.Lock()
+	defer subsMutext.Unlock()
+	assert.Empty(t, subscriptions["test.aggregate"])
+	_, ok := <-queue
+	assert.True(t, ok, "pending event must still be readable")
+	_, ok = <-queue
+	assert.False(t, ok, "queue must be closed")
+}
+
+func TestSubscription_Unsubscribe_empty(t *testing.T) {
+	queue := make(chan Event)
+	sub := SubscribeAggregates(queue, "test.aggregate")
+
+	sub.Unsubscribe()
+
+	_, ok := <-queue
+	assert.False(t, ok, "queue must be closed")
+	subsMutext.Lock()
+	defer subsMutext.Unlock()
+	assert.Empty(t, subscriptions["test.aggregate"])
+}
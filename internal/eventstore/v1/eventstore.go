@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/database"
+	es_repo "github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/internal/repository"
 	z_sql "github.com/zitadel/zitadel/internal/eventstore/v1/internal/repository/sql"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -19,12 +20,15 @@ type Eventstore interface {
 var _ Eventstore = (*eventstore)(nil)
 
 type eventstore struct {
-	repo repository.Repository
+	repo         repository.Repository
+	personalData *es_repo.PersonalDataCrypto
 }
 
-func Start(db *database.DB, allowOrderByCreationDate bool) (Eventstore, error) {
+// Start creates the eventstore, the personal data of the events is decrypted if personalData is set
+func Start(db *database.DB, allowOrderByCreationDate bool, personalData *es_repo.PersonalDataCrypto) (Eventstore, error) {
 	return &eventstore{
-		repo: z_sql.Start(db, allowOrderByCreationDate),
+		repo:         z_sql.Start(db, allowOrderByCreationDate),
+		personalData: personalData,
 	}, nil
 }
 
@@ -32,7 +36,30 @@ func (es *eventstore) FilterEvents(ctx context.Context, searchQuery *models.Sear
 	if err := searchQuery.Validate(); err != nil {
 		return nil, err
 	}
-	return es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	events, err := es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	if err != nil {
+		return nil, err
+	}
+	if err = es.decryptPersonalData(ctx, events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (es *eventstore) decryptPersonalData(ctx context.Context, events []*models.Event) error {
+	if es.personalData == nil {
+		return nil
+	}
+	payloads := make([]*es_repo.PersonalDataPayload, len(events))
+	for i, event := range events {
+		payloads[i] = &es_repo.PersonalDataPayload{
+			InstanceID:    event.InstanceID,
+			AggregateType: es_repo.AggregateType(event.AggregateType),
+			AggregateID:   event.AggregateID,
+			Data:          &event.Data,
+		}
+	}
+	return es.personalData.Decrypt(ctx, payloads...)
 }
 
 func (es *eventstore) Health(ctx context.Context) error {
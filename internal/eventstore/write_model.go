@@ -20,6 +20,11 @@ func (rm *WriteModel) AppendEvents(events ...Event) {
 	rm.Events = append(rm.Events, events...)
 }
 
+// writeModel is used to restore the base state of a [SnapshotQueryReducer]
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
+
 //Reduce is the basic implementaion of reducer
 // If this function is extended the extending function should be the last step
 func (wm *WriteModel) Reduce() error {
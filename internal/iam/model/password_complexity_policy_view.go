@@ -15,6 +15,8 @@ type PasswordComplexityPolicyView struct {
 	HasSymbol    bool
 	Default      bool
 
+	DenyBreachedPasswords bool
+
 	CreationDate time.Time
 	ChangeDate   time.Time
 	Sequence     uint64
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -9,5 +11,8 @@ type LockoutPolicy struct {
 
 	State               PolicyState
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	LockoutDuration     time.Duration
+	ProgressiveDelay    time.Duration
 }
@@ -9,7 +9,10 @@ import (
 type LockoutPolicyView struct {
 	AggregateID         string
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	LockoutDuration     time.Duration
+	ProgressiveDelay    time.Duration
 	Default             bool
 
 	CreationDate time.Time
@@ -47,6 +47,8 @@ func PasswordComplexityViewToModel(policy *query.PasswordComplexityPolicy) *mode
 		HasSymbol:    policy.HasSymbol,
 		HasNumber:    policy.HasNumber,
 		Default:      policy.IsDefault,
+
+		DenyBreachedPasswords: policy.DenyBreachedPasswords,
 	}
 }
 
@@ -0,0 +1,411 @@
+package saml
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"html/template"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/xml_dsig"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	BindingRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	protocolSAML2       = "urn:oasis:names:tc:SAML:2.0:protocol"
+	nameIDFormatPersist = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	nameIDFormatEntity  = "urn:oasis:names:tc:SAML:2.0:nameid-format:entity"
+	statusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	signatureAlgorithm  = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	requestIDPrefix     = "id-"
+	metadataPath        = "/metadata"
+	acsPath             = "/acs"
+	postPath            = "/post"
+	defaultClockSkew    = 3 * time.Minute
+	samlVersion         = "2.0"
+	timeFormat          = "2006-01-02T15:04:05Z"
+)
+
+var (
+	ErrMissingSSOEndpoint = errors.New("idp metadata does not provide a single sign on service for the binding")
+	ErrMissingCertificate = errors.New("idp metadata does not provide a signing certificate")
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for a generic SAML 2.0 identity provider.
+// ZITADEL acts as service provider, which sends (optionally signed) AuthnRequests
+// and validates the Response posted to the assertion consumer service (ACS).
+type Provider struct {
+	name string
+
+	// rootURL is the base of the service provider endpoints of this provider,
+	// the metadata URL is used as entityID and the ACS receives the responses
+	rootURL string
+
+	idpMetadata *md.EntityDescriptorType
+	idpCerts    []*x509.Certificate
+
+	certificate []byte
+	key         *rsa.PrivateKey
+
+	binding           string
+	withSignedRequest bool
+	clockSkew         time.Duration
+	attributeMapping  AttributeMapping
+
+	isLinkingAllowed  bool
+	isCreationAllowed bool
+	isAutoCreation    bool
+	isAutoUpdate      bool
+}
+
+type ProviderOpts func(provider *Provider)
+
+// WithLinkingAllowed allows end users to link the federated user to an existing one.
+func WithLinkingAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isLinkingAllowed = true
+	}
+}
+
+// WithCreationAllowed allows end users to create a new user using the federated information.
+func WithCreationAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isCreationAllowed = true
+	}
+}
+
+// WithAutoCreation enables that federated users are automatically created if not already existing.
+func WithAutoCreation() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoCreation = true
+	}
+}
+
+// WithAutoUpdate enables that information retrieved from the provider is automatically used to update
+// the existing user on each authentication.
+func WithAutoUpdate() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoUpdate = true
+	}
+}
+
+// WithBinding sets the binding used to send the AuthnRequest to the identity provider, default is HTTP-Redirect.
+func WithBinding(binding string) ProviderOpts {
+	return func(p *Provider) {
+		p.binding = binding
+	}
+}
+
+// WithSignedRequest enables signing of the AuthnRequest with the key of the service provider.
+func WithSignedRequest() ProviderOpts {
+	return func(p *Provider) {
+		p.withSignedRequest = true
+	}
+}
+
+// WithClockSkew sets the tolerated time difference when validating the conditions of the assertion.
+func WithClockSkew(skew time.Duration) ProviderOpts {
+	return func(p *Provider) {
+		p.clockSkew = skew
+	}
+}
+
+// WithAttributeMapping overwrites the default mapping of the attributes of the assertion to the user.
+func WithAttributeMapping(mapping AttributeMapping) ProviderOpts {
+	return func(p *Provider) {
+		p.attributeMapping = mapping
+	}
+}
+
+// New creates a SAML provider using the metadata of the identity provider
+// and the PEM encoded certificate and (PKCS#1) private key of the service provider.
+// The rootURL is the base of the endpoints provided by ZITADEL (metadata and ACS).
+func New(
+	name,
+	rootURL string,
+	metadata []byte,
+	certificate []byte,
+	key []byte,
+	options ...ProviderOpts,
+) (*Provider, error) {
+	idpMetadata, err := saml_xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if idpMetadata.IDPSSODescriptor == nil {
+		return nil, ErrMissingSSOEndpoint
+	}
+	idpCerts, err := signature.ParseCertificates(saml_xml.GetCertsFromKeyDescriptors(idpMetadata.IDPSSODescriptor.KeyDescriptor))
+	if err != nil {
+		return nil, err
+	}
+	if len(idpCerts) == 0 {
+		return nil, ErrMissingCertificate
+	}
+	certBlock, _ := pem.Decode(certificate)
+	if certBlock == nil {
+		return nil, errors.New("invalid certificate")
+	}
+	keyBlock, _ := pem.Decode(key)
+	if keyBlock == nil {
+		return nil, errors.New("invalid key")
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	provider := &Provider{
+		name:             name,
+		rootURL:          rootURL,
+		idpMetadata:      idpMetadata,
+		idpCerts:         idpCerts,
+		certificate:      certBlock.Bytes,
+		key:              privateKey,
+		binding:          BindingRedirect,
+		clockSkew:        defaultClockSkew,
+		attributeMapping: DefaultAttributeMapping,
+	}
+	for _, option := range options {
+		option(provider)
+	}
+	return provider, nil
+}
+
+// Name implements the [idp.Provider] interface
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// EntityID is the identifier of ZITADEL as service provider, which is the URL of the metadata.
+func (p *Provider) EntityID() string {
+	return p.rootURL + metadataPath
+}
+
+// ACSURL is the URL of the assertion consumer service, where the identity provider posts the response to.
+func (p *Provider) ACSURL() string {
+	return p.rootURL + acsPath
+}
+
+// BeginAuth implements the [idp.Provider] interface.
+// It creates an AuthnRequest with an ID derived from the state.
+// For the HTTP-Redirect binding the request is encoded into the returned auth URL,
+// for HTTP-POST the auth URL points to ZITADEL, which will render the form for the state (see [Provider.PostForm]).
+func (p *Provider) BeginAuth(_ context.Context, state string, _ ...any) (idp.Session, error) {
+	endpoint := p.ssoEndpoint()
+	if endpoint == "" {
+		return nil, ErrMissingSSOEndpoint
+	}
+	request := p.authnRequest(RequestID(state), endpoint)
+	if p.binding == BindingPost {
+		return p.postSession(request, state)
+	}
+	return p.redirectSession(request, endpoint, state)
+}
+
+// RequestID returns the ID of the AuthnRequest created for the state,
+// which has to be referenced by the Response (InResponseTo).
+func RequestID(state string) string {
+	return requestIDPrefix + state
+}
+
+// IsLinkingAllowed implements the [idp.Provider] interface.
+func (p *Provider) IsLinkingAllowed() bool {
+	return p.isLinkingAllowed
+}
+
+// IsCreationAllowed implements the [idp.Provider] interface.
+func (p *Provider) IsCreationAllowed() bool {
+	return p.isCreationAllowed
+}
+
+// IsAutoCreation implements the [idp.Provider] interface.
+func (p *Provider) IsAutoCreation() bool {
+	return p.isAutoCreation
+}
+
+// IsAutoUpdate implements the [idp.Provider] interface.
+func (p *Provider) IsAutoUpdate() bool {
+	return p.isAutoUpdate
+}
+
+// Metadata returns the XML metadata of ZITADEL as service provider,
+// which has to be registered on the identity provider.
+func (p *Provider) Metadata() ([]byte, error) {
+	keyInfo := xml_dsig.KeyInfoType{
+		X509Data: []xml_dsig.X509DataType{{
+			X509Certificate: base64.StdEncoding.EncodeToString(p.certificate),
+		}},
+	}
+	metadata := &md.EntityDescriptorType{
+		EntityID: md.EntityIDType(p.EntityID()),
+		SPSSODescriptor: &md.SPSSODescriptorType{
+			AuthnRequestsSigned:        boolString(p.withSignedRequest),
+			WantAssertionsSigned:       "true",
+			ProtocolSupportEnumeration: protocolSAML2,
+			NameIDFormat:               []string{nameIDFormatPersist},
+			KeyDescriptor: []md.KeyDescriptorType{
+				{Use: md.KeyTypesSigning, KeyInfo: keyInfo},
+				{Use: md.KeyTypesEncryption, KeyInfo: keyInfo},
+			},
+			AssertionConsumerService: []md.IndexedEndpointType{{
+				Index:     "1",
+				IsDefault: "true",
+				Binding:   BindingPost,
+				Location:  p.ACSURL(),
+			}},
+		},
+	}
+	data, err := saml_xml.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(data), nil
+}
+
+// ssoEndpoint returns the location of the single sign on service of the identity provider for the configured binding
+func (p *Provider) ssoEndpoint() string {
+	for _, service := range p.idpMetadata.IDPSSODescriptor.SingleSignOnService {
+		if service.Binding == p.binding {
+			return service.Location
+		}
+	}
+	return ""
+}
+
+func (p *Provider) authnRequest(id, destination string) *samlp.AuthnRequestType {
+	return &samlp.AuthnRequestType{
+		Id:                          id,
+		Version:                     samlVersion,
+		IssueInstant:                time.Now().UTC().Format(timeFormat),
+		Destination:                 destination,
+		ProtocolBinding:             BindingPost,
+		AssertionConsumerServiceURL: p.ACSURL(),
+		Issuer: &saml.NameIDType{
+			Format: nameIDFormatEntity,
+			Text:   p.EntityID(),
+		},
+		NameIDPolicy: &samlp.NameIDPolicyType{
+			AllowCreate: true,
+		},
+	}
+}
+
+func (p *Provider) redirectSession(request *samlp.AuthnRequestType, endpoint, state string) (*Session, error) {
+	data, err := saml_xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := saml_xml.DeflateAndBase64([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+	// the order of the parameters is defined by the spec and relevant for the signature
+	query := "SAMLRequest=" + url.QueryEscape(string(encoded)) +
+		"&RelayState=" + url.QueryEscape(state)
+	if p.withSignedRequest {
+		query += "&SigAlg=" + url.QueryEscape(signatureAlgorithm)
+		signingContext, _, err := signature.GetSigningContextAndSigner(p.certificate, p.key, signatureAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := signature.CreateRedirect(signingContext, query)
+		if err != nil {
+			return nil, err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+	authURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if authURL.RawQuery != "" {
+		query = authURL.RawQuery + "&" + query
+	}
+	authURL.RawQuery = query
+	return &Session{
+		Provider:  p,
+		RequestID: request.Id,
+		authURL:   authURL.String(),
+	}, nil
+}
+
+func (p *Provider) postSession(request *samlp.AuthnRequestType, state string) (*Session, error) {
+	// the browser will be sent to ZITADEL, which renders the form posting the request to the identity provider,
+	// only the state is passed, so that ZITADEL will only ever post requests it created itself
+	values := url.Values{
+		"RelayState": {state},
+	}
+	return &Session{
+		Provider:  p,
+		RequestID: request.Id,
+		authURL:   p.rootURL + postPath + "?" + values.Encode(),
+	}, nil
+}
+
+// PostForm writes an auto submitting HTML form, which posts the AuthnRequest for the state
+// to the single sign on service of the identity provider (HTTP-POST binding).
+// The state must belong to an intent started for this provider, which the caller has to verify.
+func (p *Provider) PostForm(w io.Writer, state string) error {
+	endpoint := p.ssoEndpoint()
+	if endpoint == "" {
+		return ErrMissingSSOEndpoint
+	}
+	request := p.authnRequest(RequestID(state), endpoint)
+	if p.withSignedRequest {
+		_, signer, err := signature.GetSigningContextAndSigner(p.certificate, p.key, signatureAlgorithm)
+		if err != nil {
+			return err
+		}
+		request.Signature, err = signature.Create(signer, request)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := saml_xml.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return postFormTemplate.Execute(w, struct {
+		URL         string
+		SAMLRequest string
+		RelayState  string
+	}{
+		URL:         endpoint,
+		SAMLRequest: base64.StdEncoding.EncodeToString([]byte(data)),
+		RelayState:  state,
+	})
+}
+
+var postFormTemplate = template.Must(template.New("post").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.URL}}">
+<input type="hidden" name="SAMLRequest" value="{{.SAMLRequest}}" />
+<input type="hidden" name="RelayState" value="{{.RelayState}}" />
+<noscript><input type="submit" value="Continue" /></noscript>
+</form>
+</body>
+</html>`))
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
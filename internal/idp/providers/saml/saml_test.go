@@ -0,0 +1,414 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"html"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	testRootURL     = "https://localhost:8080/idps/idp/saml"
+	testIDPEntityID = "https://idp.example.com/metadata"
+	testSSOURL      = "https://idp.example.com/sso"
+)
+
+func TestProvider_Options(t *testing.T) {
+	type fields struct {
+		name string
+		opts []ProviderOpts
+	}
+	type want struct {
+		name              string
+		binding           string
+		withSignedRequest bool
+		linkingAllowed    bool
+		creationAllowed   bool
+		autoCreation      bool
+		autoUpdate        bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "default",
+			fields: fields{
+				name: "saml",
+			},
+			want: want{
+				name:    "saml",
+				binding: BindingRedirect,
+			},
+		},
+		{
+			name: "all true",
+			fields: fields{
+				name: "saml",
+				opts: []ProviderOpts{
+					WithBinding(BindingPost),
+					WithSignedRequest(),
+					WithLinkingAllowed(),
+					WithCreationAllowed(),
+					WithAutoCreation(),
+					WithAutoUpdate(),
+				},
+			},
+			want: want{
+				name:              "saml",
+				binding:           BindingPost,
+				withSignedRequest: true,
+				linkingAllowed:    true,
+				creationAllowed:   true,
+				autoCreation:      true,
+				autoUpdate:        true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			idpCert, _ := newTestKeyPair(t)
+			cert, key := newTestKeyPair(t)
+
+			provider, err := New(tt.fields.name, testRootURL, testIDPMetadata(idpCert), cert, key, tt.fields.opts...)
+			require.NoError(t, err)
+
+			a.Equal(tt.want.name, provider.Name())
+			a.Equal(tt.want.binding, provider.binding)
+			a.Equal(tt.want.withSignedRequest, provider.withSignedRequest)
+			a.Equal(tt.want.linkingAllowed, provider.IsLinkingAllowed())
+			a.Equal(tt.want.creationAllowed, provider.IsCreationAllowed())
+			a.Equal(tt.want.autoCreation, provider.IsAutoCreation())
+			a.Equal(tt.want.autoUpdate, provider.IsAutoUpdate())
+			a.Equal(testRootURL+"/metadata", provider.EntityID())
+			a.Equal(testRootURL+"/acs", provider.ACSURL())
+		})
+	}
+}
+
+func TestProvider_BeginAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []ProviderOpts
+		wantHost    string
+		wantRequest bool
+		wantSig     bool
+	}{
+		{
+			name:        "redirect",
+			wantHost:    "idp.example.com",
+			wantRequest: true,
+		},
+		{
+			name:        "redirect signed",
+			opts:        []ProviderOpts{WithSignedRequest()},
+			wantHost:    "idp.example.com",
+			wantRequest: true,
+			wantSig:     true,
+		},
+		{
+			name:        "post",
+			opts:        []ProviderOpts{WithBinding(BindingPost)},
+			wantHost:    "localhost:8080",
+			wantRequest: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idpCert, _ := newTestKeyPair(t)
+			cert, key := newTestKeyPair(t)
+			provider, err := New("saml", testRootURL, testIDPMetadata(idpCert), cert, key, tt.opts...)
+			require.NoError(t, err)
+
+			session, err := provider.BeginAuth(context.Background(), "state")
+			require.NoError(t, err)
+			assert.Equal(t, "id-state", session.(*Session).RequestID)
+
+			authURL, err := url.Parse(session.GetAuthURL())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, authURL.Host)
+			assert.Equal(t, "state", authURL.Query().Get("RelayState"))
+			assert.Equal(t, tt.wantRequest, authURL.Query().Get("SAMLRequest") != "")
+			assert.Equal(t, tt.wantSig, authURL.Query().Get("Signature") != "")
+		})
+	}
+}
+
+func TestProvider_PostForm(t *testing.T) {
+	idpCert, _ := newTestKeyPair(t)
+	cert, key := newTestKeyPair(t)
+	provider, err := New("saml", testRootURL, testIDPMetadata(idpCert), cert, key, WithBinding(BindingPost), WithSignedRequest())
+	require.NoError(t, err)
+
+	form := new(strings.Builder)
+	err = provider.PostForm(form, "state")
+	require.NoError(t, err)
+	assert.Contains(t, form.String(), `action="`+testSSOURL+`"`)
+	assert.Contains(t, form.String(), `name="RelayState" value="state"`)
+
+	start := strings.Index(form.String(), `name="SAMLRequest" value="`) + len(`name="SAMLRequest" value="`)
+	encoded := form.String()[start : start+strings.Index(form.String()[start:], `"`)]
+	request, err := base64.StdEncoding.DecodeString(html.UnescapeString(encoded))
+	require.NoError(t, err)
+	assert.Contains(t, string(request), `ID="id-state"`)
+	assert.Contains(t, string(request), "SignatureValue")
+}
+
+func TestProvider_Metadata(t *testing.T) {
+	idpCert, _ := newTestKeyPair(t)
+	cert, key := newTestKeyPair(t)
+	provider, err := New("saml", testRootURL, testIDPMetadata(idpCert), cert, key)
+	require.NoError(t, err)
+
+	metadata, err := provider.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), `entityID="`+testRootURL+`/metadata"`)
+	assert.Contains(t, string(metadata), `Location="`+testRootURL+`/acs"`)
+}
+
+func TestSession_FetchUser(t *testing.T) {
+	type args struct {
+		requestID  string
+		modify     func(string) string
+		sign       bool
+		useOther   bool
+		audience   string
+		noAudience bool
+	}
+	type want struct {
+		err  error
+		user *User
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "unsigned response, error",
+			args: args{
+				requestID: "id-state",
+				sign:      false,
+			},
+			want: want{
+				err: ErrMissingSignature,
+			},
+		},
+		{
+			name: "signed by other key, error",
+			args: args{
+				requestID: "id-state",
+				sign:      true,
+				useOther:  true,
+			},
+			want: want{
+				err: errAny,
+			},
+		},
+		{
+			name: "tampered assertion, error",
+			args: args{
+				requestID: "id-state",
+				sign:      true,
+				modify: func(s string) string {
+					return strings.Replace(s, "john@example.com", "admin@example.com", 1)
+				},
+			},
+			want: want{
+				err: errAny,
+			},
+		},
+		{
+			name: "other request, error",
+			args: args{
+				requestID: "id-other",
+				sign:      true,
+			},
+			want: want{
+				err: ErrInvalidRequestID,
+			},
+		},
+		{
+			name: "other audience, error",
+			args: args{
+				requestID: "id-state",
+				sign:      true,
+				audience:  "https://other.example.com/metadata",
+			},
+			want: want{
+				err: ErrInvalidAudience,
+			},
+		},
+		{
+			name: "missing audience restriction, error",
+			args: args{
+				requestID:  "id-state",
+				sign:       true,
+				noAudience: true,
+			},
+			want: want{
+				err: ErrMissingAudience,
+			},
+		},
+		{
+			name: "successful",
+			args: args{
+				requestID: "id-state",
+				sign:      true,
+			},
+			want: want{
+				user: &User{
+					ID:        "nameID",
+					FirstName: "John",
+					LastName:  "Doe",
+					Email:     domain.EmailAddress("john@example.com"),
+					Attributes: map[string][]string{
+						"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname":    {"John"},
+						"urn:oid:2.5.4.4":                                                    {"Doe"},
+						"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress": {"john@example.com"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idpCert, idpKey := newTestKeyPair(t)
+			cert, key := newTestKeyPair(t)
+			provider, err := New("saml", testRootURL, testIDPMetadata(idpCert), cert, key)
+			require.NoError(t, err)
+
+			audience := provider.EntityID()
+			if tt.args.audience != "" {
+				audience = tt.args.audience
+			}
+			if tt.args.noAudience {
+				audience = ""
+			}
+			response := testResponse("id-state", provider.ACSURL(), audience, time.Now())
+			if tt.args.sign {
+				signingCert, signingKey := idpCert, idpKey
+				if tt.args.useOther {
+					signingCert, signingKey = newTestKeyPair(t)
+				}
+				response = signAssertion(t, response, signingCert, signingKey)
+			}
+			if tt.args.modify != nil {
+				response = tt.args.modify(response)
+			}
+			session := &Session{
+				Provider:  provider,
+				RequestID: tt.args.requestID,
+				Response:  base64.StdEncoding.EncodeToString([]byte(response)),
+			}
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				if tt.want.err != errAny {
+					assert.ErrorIs(t, err, tt.want.err)
+				}
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.user, user)
+		})
+	}
+}
+
+var errAny = &struct{ error }{}
+
+func newTestKeyPair(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func testIDPMetadata(certPEM []byte) []byte {
+	block, _ := pem.Decode(certPEM)
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="` + testIDPEntityID + `">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data>
+          <ds:X509Certificate>` + base64.StdEncoding.EncodeToString(block.Bytes) + `</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="` + testSSOURL + `"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="` + testSSOURL + `"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`)
+}
+
+func testResponse(requestID, acs, audience string, now time.Time) string {
+	notBefore := now.Add(-time.Minute).UTC().Format(timeFormat)
+	notOnOrAfter := now.Add(5 * time.Minute).UTC().Format(timeFormat)
+	var audienceRestriction string
+	if audience != "" {
+		audienceRestriction = `<saml:AudienceRestriction><saml:Audience>` + audience + `</saml:Audience></saml:AudienceRestriction>`
+	}
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="response" Version="2.0" IssueInstant="` + notBefore + `" Destination="` + acs + `" InResponseTo="` + requestID + `">` +
+		`<saml:Issuer>` + testIDPEntityID + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="assertion" Version="2.0" IssueInstant="` + notBefore + `">` +
+		`<saml:Issuer>` + testIDPEntityID + `</saml:Issuer>` +
+		`<saml:Subject><saml:NameID>nameID</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer"><saml:SubjectConfirmationData InResponseTo="` + requestID + `" Recipient="` + acs + `" NotOnOrAfter="` + notOnOrAfter + `"/></saml:SubjectConfirmation>` +
+		`</saml:Subject>` +
+		`<saml:Conditions NotBefore="` + notBefore + `" NotOnOrAfter="` + notOnOrAfter + `">` + audienceRestriction + `</saml:Conditions>` +
+		`<saml:AttributeStatement>` +
+		`<saml:Attribute Name="http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname"><saml:AttributeValue>John</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="urn:oid:2.5.4.4"><saml:AttributeValue>Doe</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"><saml:AttributeValue>john@example.com</saml:AttributeValue></saml:Attribute>` +
+		`</saml:AttributeStatement>` +
+		`</saml:Assertion>` +
+		`</samlp:Response>`
+}
+
+func signAssertion(t *testing.T, response string, certPEM, keyPEM []byte) string {
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	signingContext := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(keyPair))
+	signingContext.IdAttribute = "ID"
+	signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(response))
+	assertion := doc.Root().SelectElement("Assertion")
+	signed, err := signingContext.SignEnveloped(assertion)
+	require.NoError(t, err)
+	doc.Root().RemoveChild(assertion)
+	doc.Root().AddChild(signed)
+	out, err := doc.WriteToString()
+	require.NoError(t, err)
+	return out
+}
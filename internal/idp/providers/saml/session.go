@@ -0,0 +1,188 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var (
+	ErrMissingResponse    = errors.New("no SAMLResponse provided")
+	ErrInvalidResponse    = errors.New("invalid SAMLResponse")
+	ErrMissingSignature   = errors.New("neither response nor assertion is signed")
+	ErrStatusNotSuccess   = errors.New("response status is not success")
+	ErrInvalidIssuer      = errors.New("issuer does not match the identity provider")
+	ErrInvalidDestination = errors.New("destination does not match the assertion consumer service")
+	ErrInvalidRequestID   = errors.New("response does not belong to the request")
+	ErrInvalidAudience    = errors.New("assertion is not issued for the service provider")
+	ErrMissingAudience    = errors.New("assertion does not contain an audience restriction")
+	ErrAssertionExpired   = errors.New("assertion is expired or not yet valid")
+	ErrMissingNameID      = errors.New("assertion does not contain a NameID")
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session is the [idp.Session] implementation for the SAML provider.
+type Session struct {
+	Provider *Provider
+	// RequestID is the ID of the AuthnRequest, which the Response must reference (InResponseTo)
+	RequestID string
+	// Response is the base64 encoded SAMLResponse posted to the ACS
+	Response string
+
+	authURL string
+}
+
+// GetAuthURL implements the [idp.Session] interface.
+func (s *Session) GetAuthURL() string {
+	return s.authURL
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It validates the signature and the conditions of the posted Response and maps its assertion to a [User].
+func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
+	if s.Response == "" {
+		return nil, ErrMissingResponse
+	}
+	data, err := base64.StdEncoding.DecodeString(s.Response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if err = s.Provider.validateSignature(data); err != nil {
+		return nil, err
+	}
+	response := new(samlp.ResponseType)
+	if err = xml.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if err = s.Provider.validateResponse(response, s.RequestID, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.Provider.attributeMapping.user(&response.Assertion), nil
+}
+
+// validateSignature checks that either the response or its (single) assertion
+// is signed by one of the certificates of the identity provider.
+func (p *Provider) validateSignature(data []byte) error {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	root := doc.Root()
+	if root == nil || root.Tag != "Response" {
+		return ErrInvalidResponse
+	}
+	if len(root.SelectElements("EncryptedAssertion")) > 0 {
+		return fmt.Errorf("%w: encrypted assertions are not supported", ErrInvalidResponse)
+	}
+	// prevent signature wrapping by only allowing exactly one assertion
+	assertions := root.SelectElements("Assertion")
+	if len(assertions) != 1 {
+		return fmt.Errorf("%w: response must contain exactly one assertion", ErrInvalidResponse)
+	}
+	if root.SelectElement("Signature") != nil {
+		return signature.ValidatePost(p.idpCerts, root)
+	}
+	if assertions[0].SelectElement("Signature") != nil {
+		return signature.ValidatePost(p.idpCerts, assertions[0])
+	}
+	return ErrMissingSignature
+}
+
+func (p *Provider) validateResponse(response *samlp.ResponseType, requestID string, now time.Time) error {
+	if response.Status.StatusCode.Value != statusSuccess {
+		return fmt.Errorf("%w: %s %s", ErrStatusNotSuccess, response.Status.StatusCode.Value, response.Status.StatusMessage)
+	}
+	if response.InResponseTo != requestID {
+		return ErrInvalidRequestID
+	}
+	if response.Destination != "" && response.Destination != p.ACSURL() {
+		return ErrInvalidDestination
+	}
+	entityID := string(p.idpMetadata.EntityID)
+	if response.Issuer != nil && response.Issuer.Text != entityID {
+		return ErrInvalidIssuer
+	}
+	assertion := response.Assertion
+	if assertion.Issuer.Text != entityID {
+		return ErrInvalidIssuer
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Text == "" {
+		return ErrMissingNameID
+	}
+	if err := p.validateSubjectConfirmation(assertion.Subject, requestID, now); err != nil {
+		return err
+	}
+	return p.validateConditions(assertion.Conditions, now)
+}
+
+func (p *Provider) validateSubjectConfirmation(subject *saml.SubjectType, requestID string, now time.Time) error {
+	for _, confirmation := range subject.SubjectConfirmation {
+		data := confirmation.SubjectConfirmationData
+		if data == nil {
+			continue
+		}
+		if data.InResponseTo != "" && data.InResponseTo != requestID {
+			return ErrInvalidRequestID
+		}
+		if data.Recipient != "" && data.Recipient != p.ACSURL() {
+			return ErrInvalidDestination
+		}
+		if err := p.validateTime("", data.NotOnOrAfter, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateConditions checks the validity period and requires the assertion
+// to be restricted to ZITADEL as audience, so assertions issued for other service providers cannot be replayed.
+func (p *Provider) validateConditions(conditions *saml.ConditionsType, now time.Time) error {
+	if conditions == nil || len(conditions.AudienceRestriction) == 0 {
+		return ErrMissingAudience
+	}
+	if err := p.validateTime(conditions.NotBefore, conditions.NotOnOrAfter, now); err != nil {
+		return err
+	}
+	for _, restriction := range conditions.AudienceRestriction {
+		if !containsAudience(restriction.Audience, p.EntityID()) {
+			return ErrInvalidAudience
+		}
+	}
+	return nil
+}
+
+func (p *Provider) validateTime(notBefore, notOnOrAfter string, now time.Time) error {
+	if notBefore != "" {
+		t, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil || now.Add(p.clockSkew).Before(t) {
+			return ErrAssertionExpired
+		}
+	}
+	if notOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339, notOnOrAfter)
+		if err != nil || !now.Add(-p.clockSkew).Before(t) {
+			return ErrAssertionExpired
+		}
+	}
+	return nil
+}
+
+func containsAudience(audiences []string, audience string) bool {
+	for _, aud := range audiences {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package saml
+
+import (
+	"strings"
+
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var _ idp.User = (*User)(nil)
+
+// AttributeMapping defines the names of the assertion attributes used for the user information.
+// Multiple names can be provided per information, the first one present in the assertion is used.
+type AttributeMapping struct {
+	FirstName         []string
+	LastName          []string
+	DisplayName       []string
+	NickName          []string
+	PreferredUsername []string
+	Email             []string
+	Phone             []string
+	PreferredLanguage []string
+	AvatarURL         []string
+	Profile           []string
+}
+
+// DefaultAttributeMapping maps the well-known attribute names
+// (claim URIs used by ADFS / Azure AD and the OIDs of the eduPerson / inetOrgPerson schema)
+var DefaultAttributeMapping = AttributeMapping{
+	FirstName: []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+		"urn:oid:2.5.4.42",
+		"givenName",
+		"firstName",
+	},
+	LastName: []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+		"urn:oid:2.5.4.4",
+		"sn",
+		"surname",
+		"lastName",
+	},
+	DisplayName: []string{
+		"http://schemas.microsoft.com/identity/claims/displayname",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+		"displayName",
+	},
+	NickName: []string{
+		"nickname",
+	},
+	PreferredUsername: []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+		"urn:oid:0.9.2342.19200300.100.1.1",
+		"uid",
+		"username",
+	},
+	Email: []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+		"mail",
+		"email",
+	},
+	Phone: []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/mobilephone",
+		"urn:oid:2.5.4.20",
+		"telephoneNumber",
+		"phone",
+	},
+	PreferredLanguage: []string{
+		"urn:oid:2.16.840.1.113730.3.1.39",
+		"preferredLanguage",
+	},
+	AvatarURL: []string{
+		"avatarURL",
+	},
+	Profile: []string{
+		"profile",
+	},
+}
+
+func (m AttributeMapping) user(assertion *saml.AssertionType) *User {
+	attributes := make(map[string][]string)
+	for _, statement := range assertion.AttributeStatement {
+		for _, attribute := range statement.Attribute {
+			if attribute == nil {
+				continue
+			}
+			values := make([]string, 0, len(attribute.AttributeValue))
+			for _, value := range attribute.AttributeValue {
+				values = append(values, strings.TrimSpace(value))
+			}
+			attributes[attribute.Name] = append(attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				attributes[attribute.FriendlyName] = append(attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+	user := &User{
+		ID:                assertion.Subject.NameID.Text,
+		FirstName:         lookup(attributes, m.FirstName),
+		LastName:          lookup(attributes, m.LastName),
+		DisplayName:       lookup(attributes, m.DisplayName),
+		NickName:          lookup(attributes, m.NickName),
+		PreferredUsername: lookup(attributes, m.PreferredUsername),
+		Email:             domain.EmailAddress(lookup(attributes, m.Email)),
+		Phone:             domain.PhoneNumber(lookup(attributes, m.Phone)),
+		PreferredLanguage: lookup(attributes, m.PreferredLanguage),
+		AvatarURL:         lookup(attributes, m.AvatarURL),
+		Profile:           lookup(attributes, m.Profile),
+		Attributes:        attributes,
+	}
+	return user
+}
+
+func lookup(attributes map[string][]string, names []string) string {
+	for _, name := range names {
+		for _, value := range attributes[name] {
+			if value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// User represents the subject and the attributes of the SAML assertion.
+type User struct {
+	ID                string              `json:"id,omitempty"`
+	FirstName         string              `json:"firstName,omitempty"`
+	LastName          string              `json:"lastName,omitempty"`
+	DisplayName       string              `json:"displayName,omitempty"`
+	NickName          string              `json:"nickName,omitempty"`
+	PreferredUsername string              `json:"preferredUsername,omitempty"`
+	Email             domain.EmailAddress `json:"email,omitempty"`
+	Phone             domain.PhoneNumber  `json:"phone,omitempty"`
+	PreferredLanguage string              `json:"preferredLanguage,omitempty"`
+	AvatarURL         string              `json:"avatarURL,omitempty"`
+	Profile           string              `json:"profile,omitempty"`
+	Attributes        map[string][]string `json:"attributes,omitempty"`
+}
+
+// GetID is an implementation of the [idp.User] interface.
+// It returns the NameID of the subject.
+func (u *User) GetID() string {
+	return u.ID
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return u.FirstName
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return u.LastName
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.DisplayName
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return u.NickName
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredUsername() string {
+	return u.PreferredUsername
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return u.Email
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+// It returns false, since SAML does not provide a standardized verification attribute.
+func (u *User) IsEmailVerified() bool {
+	return false
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return u.Phone
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface.
+// It returns false, since SAML does not provide a standardized verification attribute.
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Make(u.PreferredLanguage)
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return u.AvatarURL
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+func (u *User) GetProfile() string {
+	return u.Profile
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	ActionNotificationsProjectionTable = "projections.notifications_actions"
+)
+
+// actionNotifier executes the actions of the post triggers of the user, user grant and membership flows
+// asynchronously, after the events of the mutation are pushed
+type actionNotifier struct {
+	crdb.StatementHandler
+	queries *NotificationQueries
+}
+
+func NewActionNotifier(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	queries *NotificationQueries,
+) *actionNotifier {
+	p := new(actionNotifier)
+	p.queries = queries
+	config.ProjectionName = ActionNotificationsProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	projection.ActionNotificationsProjection = p
+	return p
+}
+
+func (u *actionNotifier) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{Event: user.UserV1AddedType, Reduce: u.reduce(domain.FlowTypeUser, domain.TriggerTypePostCreation)},
+				{Event: user.UserV1RegisteredType, Reduce: u.reduce(domain.FlowTypeUser, domain.TriggerTypePostCreation)},
+				{Event: user.HumanAddedType, Reduce: u.reduce(domain.FlowTypeUser, domain.TriggerTypePostCreation)},
+				{Event: user.HumanRegisteredType, Reduce: u.reduce(domain.FlowTypeUser, domain.TriggerTypePostCreation)},
+				{Event: user.MachineAddedEventType, Reduce: u.reduce(domain.FlowTypeUser, domain.TriggerTypePostCreation)},
+				{Event: user.UserRemovedType, Reduce: u.reduce(domain.FlowTypeUser, domain.TriggerTypePostRemoval)},
+			},
+		},
+		{
+			Aggregate: usergrant.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{Event: usergrant.UserGrantAddedType, Reduce: u.reduce(domain.FlowTypeUserGrant, domain.TriggerTypePostCreation)},
+				{Event: usergrant.UserGrantChangedType, Reduce: u.reduce(domain.FlowTypeUserGrant, domain.TriggerTypePostChange)},
+				{Event: usergrant.UserGrantCascadeChangedType, Reduce: u.reduce(domain.FlowTypeUserGrant, domain.TriggerTypePostChange)},
+				{Event: usergrant.UserGrantRemovedType, Reduce: u.reduce(domain.FlowTypeUserGrant, domain.TriggerTypePostRemoval)},
+				{Event: usergrant.UserGrantCascadeRemovedType, Reduce: u.reduce(domain.FlowTypeUserGrant, domain.TriggerTypePostRemoval)},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{Event: instance.MemberAddedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostCreation)},
+				{Event: instance.MemberChangedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostChange)},
+				{Event: instance.MemberRemovedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+				{Event: instance.MemberCascadeRemovedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{Event: org.MemberAddedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostCreation)},
+				{Event: org.MemberChangedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostChange)},
+				{Event: org.MemberRemovedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+				{Event: org.MemberCascadeRemovedEventType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{Event: project.MemberAddedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostCreation)},
+				{Event: project.MemberChangedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostChange)},
+				{Event: project.MemberRemovedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+				{Event: project.MemberCascadeRemovedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+				{Event: project.GrantMemberAddedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostCreation)},
+				{Event: project.GrantMemberChangedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostChange)},
+				{Event: project.GrantMemberRemovedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+				{Event: project.GrantMemberCascadeRemovedType, Reduce: u.reduce(domain.FlowTypeMembership, domain.TriggerTypePostRemoval)},
+			},
+		},
+	}
+}
+
+// reduce executes the actions of the trigger on the event,
+// failing actions are logged and not retried, as they are executed only once
+func (u *actionNotifier) reduce(flowType domain.FlowType, triggerType domain.TriggerType) handler.Reduce {
+	return func(event eventstore.Event) (*handler.Statement, error) {
+		ctx := HandlerContext(event.Aggregate())
+		resourceOwner, err := u.resourceOwner(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+		queriedActions, err := u.queries.GetActiveActionsByFlowAndTriggerType(ctx, flowType, triggerType, resourceOwner, false)
+		if err != nil {
+			return nil, err
+		}
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("event", object.EventFromEventstore(event)),
+			),
+		)
+		for _, action := range queriedActions {
+			// only events created after the action are passed
+			if !action.CreationDate.Before(event.CreationDate()) {
+				continue
+			}
+			actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+			err = actions.Run(
+				actionCtx,
+				ctxFields,
+				nil,
+				action.Script,
+				action.Name,
+				append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+			)
+			cancel()
+			logging.WithFields("action", action.ID, "event", event.Type(), "sequence", event.Sequence()).OnError(err).Warn("post action failed")
+		}
+		return crdb.NewNoOpStatement(event), nil
+	}
+}
+
+// resourceOwner returns the organisation of the flow,
+// the default organisation is used for instance members, as instances have no flows of their own
+func (u *actionNotifier) resourceOwner(ctx context.Context, event eventstore.Event) (string, error) {
+	if event.Aggregate().Type != instance.AggregateType {
+		return event.Aggregate().ResourceOwner, nil
+	}
+	inst, err := u.queries.InstanceByID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return inst.DefaultOrganisationID(), nil
+}
@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	oidc_crypto "github.com/zitadel/oidc/v2/pkg/crypto"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	BackChannelLogoutProjectionTable = "projections.notifications_back_channel_logout"
+
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+	logoutTokenType        = "logout+jwt"
+	logoutTokenLifetime    = 2 * time.Minute
+	logoutRequestTimeout   = 5 * time.Second
+)
+
+// backChannelLogoutNotifier sends logout tokens (OpenID Connect Back-Channel Logout 1.0)
+// to the clients the user received tokens for, when the session of the user ends.
+type backChannelLogoutNotifier struct {
+	crdb.StatementHandler
+	commands      backChannelLogoutCommands
+	queries       backChannelLogoutQueries
+	events        eventFilterer
+	keyEncryption crypto.EncryptionAlgorithm
+	idGenerator   id.Generator
+}
+
+type backChannelLogoutCommands interface {
+	BackChannelLogoutSent(ctx context.Context, orgID, userID, clientID, sessionID string, logoutSequence uint64) error
+}
+
+type backChannelLogoutQueries interface {
+	AppByOIDCClientID(ctx context.Context, clientID string, withOwnerRemoved bool) (*query.App, error)
+	ActivePrivateSigningKey(ctx context.Context, t time.Time) (*query.PrivateKeys, error)
+	IsAlreadyHandled(ctx context.Context, event eventstore.Event, data map[string]interface{}, aggregateType eventstore.AggregateType, eventTypes ...eventstore.EventType) (bool, error)
+	Origin(ctx context.Context) (context.Context, string, error)
+}
+
+type eventFilterer interface {
+	Filter(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+}
+
+func NewBackChannelLogoutNotifier(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+	keyEncryption crypto.EncryptionAlgorithm,
+) *backChannelLogoutNotifier {
+	p := new(backChannelLogoutNotifier)
+	config.ProjectionName = BackChannelLogoutProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	p.commands = commands
+	p.queries = queries
+	p.events = queries.es
+	p.keyEncryption = keyEncryption
+	p.idGenerator = id.SonyFlakeGenerator()
+	projection.BackChannelLogoutProjection = p
+	return p
+}
+
+func (u *backChannelLogoutNotifier) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: u.reduceSignedOut,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: u.reduceSignedOut,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceUserEnded,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: u.reduceUserEnded,
+				},
+				{
+					Event:  user.HumanRefreshTokenRemovedType,
+					Reduce: u.reduceRefreshTokenRemoved,
+				},
+			},
+		},
+	}
+}
+
+// backChannelLogoutSession is the session of a user agent (sid) at a client
+type backChannelLogoutSession struct {
+	clientID  string
+	sessionID string
+}
+
+func (u *backChannelLogoutNotifier) reduceSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bcl1a", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	sessions, err := u.activeSessions(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	agentSessions := make([]backChannelLogoutSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session.sessionID == e.UserAgentID {
+			agentSessions = append(agentSessions, session)
+		}
+	}
+	return u.logout(ctx, event, agentSessions)
+}
+
+// reduceUserEnded ends all sessions of a locked or deactivated user
+func (u *backChannelLogoutNotifier) reduceUserEnded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent, *user.UserDeactivatedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bcl1b", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType})
+	}
+	ctx := HandlerContext(event.Aggregate())
+	sessions, err := u.activeSessions(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return u.logout(ctx, event, sessions)
+}
+
+// reduceRefreshTokenRemoved notifies the client the revoked refresh token was issued to
+func (u *backChannelLogoutNotifier) reduceRefreshTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bcl1c", "reduce.wrong.event.type %s", user.HumanRefreshTokenRemovedType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	events, err := u.events.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			SequenceLess(event.Sequence()).
+			EventTypes(user.HumanRefreshTokenAddedType).
+			EventData(map[string]interface{}{"tokenId": e.TokenID}).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]backChannelLogoutSession, 0, 1)
+	for _, tokenEvent := range events {
+		added, ok := tokenEvent.(*user.HumanRefreshTokenAddedEvent)
+		if !ok || added.UserAgentID == "" {
+			continue
+		}
+		sessions = append(sessions, backChannelLogoutSession{clientID: added.ClientID, sessionID: added.UserAgentID})
+	}
+	return u.logout(ctx, event, sessions)
+}
+
+// activeSessions returns the sessions of the user, which were not ended before the event.
+// A session starts with a token issued to a client for a user agent
+// and ends with the sign out of the user agent or the lock or deactivation of the user.
+func (u *backChannelLogoutNotifier) activeSessions(ctx context.Context, event eventstore.Event) ([]backChannelLogoutSession, error) {
+	events, err := u.events.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			SequenceLess(event.Sequence()).
+			EventTypes(
+				user.UserTokenAddedType,
+				user.HumanRefreshTokenAddedType,
+				user.UserV1SignedOutType,
+				user.HumanSignedOutType,
+				user.UserLockedType,
+				user.UserDeactivatedType,
+			).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]backChannelLogoutSession, 0)
+	add := func(clientID, sessionID string) {
+		if clientID == "" || sessionID == "" {
+			return
+		}
+		session := backChannelLogoutSession{clientID: clientID, sessionID: sessionID}
+		for _, existing := range sessions {
+			if existing == session {
+				return
+			}
+		}
+		sessions = append(sessions, session)
+	}
+	for _, e := range events {
+		switch e := e.(type) {
+		case *user.UserTokenAddedEvent:
+			add(e.ApplicationID, e.UserAgentID)
+		case *user.HumanRefreshTokenAddedEvent:
+			add(e.ClientID, e.UserAgentID)
+		case *user.HumanSignedOutEvent:
+			remaining := sessions[:0]
+			for _, session := range sessions {
+				if session.sessionID != e.UserAgentID {
+					remaining = append(remaining, session)
+				}
+			}
+			sessions = remaining
+		case *user.UserLockedEvent, *user.UserDeactivatedEvent:
+			sessions = sessions[:0]
+		}
+	}
+	return sessions, nil
+}
+
+// logout sends the logout tokens to all clients with a back-channel logout uri.
+// Already notified clients are skipped, so a failed delivery can be retried for the whole event.
+func (u *backChannelLogoutNotifier) logout(ctx context.Context, event eventstore.Event, sessions []backChannelLogoutSession) (*handler.Statement, error) {
+	if len(sessions) == 0 {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	var issuer string
+	for _, session := range sessions {
+		app, err := u.queries.AppByOIDCClientID(ctx, session.clientID, false)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if app.OIDCConfig == nil || app.OIDCConfig.BackChannelLogoutURI == "" {
+			continue
+		}
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event,
+			map[string]interface{}{
+				"clientId":       session.clientID,
+				"sessionId":      session.sessionID,
+				"logoutSequence": event.Sequence(),
+			},
+			user.AggregateType, user.BackChannelLogoutSentType,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if alreadyHandled {
+			continue
+		}
+		if issuer == "" {
+			ctx, issuer, err = u.queries.Origin(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		token, err := u.logoutToken(ctx, issuer, event.Aggregate().ID, session)
+		if err != nil {
+			return nil, err
+		}
+		if err = sendLogoutToken(ctx, app.OIDCConfig.BackChannelLogoutURI, token); err != nil {
+			logging.WithFields("instance", event.Aggregate().InstanceID, "client", session.clientID).WithError(err).Info("back-channel logout failed")
+			return nil, err
+		}
+		err = u.commands.BackChannelLogoutSent(ctx, event.Aggregate().ResourceOwner, event.Aggregate().ID, session.clientID, session.sessionID, event.Sequence())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
+
+type logoutTokenClaims struct {
+	Issuer     string                 `json:"iss"`
+	Subject    string                 `json:"sub"`
+	Audience   []string               `json:"aud"`
+	IssuedAt   int64                  `json:"iat"`
+	Expiration int64                  `json:"exp"`
+	JWTID      string                 `json:"jti"`
+	SessionID  string                 `json:"sid"`
+	Events     map[string]interface{} `json:"events"`
+}
+
+// logoutToken creates the logout token signed by the current signing key of the instance
+func (u *backChannelLogoutNotifier) logoutToken(ctx context.Context, issuer, userID string, session backChannelLogoutSession) (string, error) {
+	keys, err := u.queries.ActivePrivateSigningKey(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if len(keys.Keys) == 0 {
+		return "", errors.ThrowPreconditionFailed(nil, "HANDL-Bcl2k", "no active signing key")
+	}
+	key := keys.Keys[len(keys.Keys)-1]
+	keyData, err := crypto.Decrypt(key.Key(), u.keyEncryption)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	if err != nil {
+		return "", err
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.SignatureAlgorithm(key.Algorithm()),
+			Key:       &jose.JSONWebKey{Key: privateKey, KeyID: key.ID()},
+		},
+		(&jose.SignerOptions{}).WithType(logoutTokenType),
+	)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "HANDL-Bcl3s", "unable to create signer")
+	}
+	tokenID, err := u.idGenerator.Next()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	token, err := oidc_crypto.Sign(&logoutTokenClaims{
+		Issuer:     issuer,
+		Subject:    userID,
+		Audience:   []string{session.clientID},
+		IssuedAt:   now.Unix(),
+		Expiration: now.Add(logoutTokenLifetime).Unix(),
+		JWTID:      tokenID,
+		SessionID:  session.sessionID,
+		Events:     map[string]interface{}{backChannelLogoutEvent: struct{}{}},
+	}, signer)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "HANDL-Bcl4t", "unable to sign logout token")
+	}
+	return token, nil
+}
+
+func sendLogoutToken(ctx context.Context, logoutURI, token string) error {
+	requestCtx, cancel := context.WithTimeout(ctx, logoutRequestTimeout)
+	defer cancel()
+	body := url.Values{"logout_token": {token}}.Encode()
+	req, err := http.NewRequestWithContext(requestCtx, http.MethodPost, logoutURI, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if err = resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.ThrowUnknown(fmt.Errorf("calling url %s returned %s", logoutURI, resp.Status), "HANDL-Bcl5r", "back-channel logout didn't return a success status")
+	}
+	return nil
+}
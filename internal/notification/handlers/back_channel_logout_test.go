@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	testLogoutIssuer = "https://issuer.example.com"
+	testLogoutUserID = "userID"
+)
+
+func TestBackChannelLogoutNotifier_reduce(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	type fields struct {
+		// events are the previous events of the user
+		events []eventstore.Event
+		// clients maps the client ids to whether they have a back-channel logout uri
+		clients        map[string]bool
+		alreadyHandled bool
+		status         int
+	}
+	type want struct {
+		// sessions are the notified sessions as clientID and sid
+		sessions []backChannelLogoutSession
+		err      bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		event  eventstore.Event
+		want   want
+	}{
+		{
+			name: "signed out, session of user agent notified",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("client1", "agent1"),
+					testTokenAddedEvent("client1", "agent2"),
+					testRefreshTokenAddedEvent("client2", "agent1"),
+				},
+				clients: map[string]bool{"client1": true, "client2": true},
+			},
+			event: testLogoutEvent(t, user.HumanSignedOutType, user.HumanSignedOutEventMapper, `{"userAgentID": "agent1"}`),
+			want: want{
+				sessions: []backChannelLogoutSession{
+					{clientID: "client1", sessionID: "agent1"},
+					{clientID: "client2", sessionID: "agent1"},
+				},
+			},
+		},
+		{
+			name: "signed out before, no logout",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("client1", "agent1"),
+					user.NewHumanSignedOutEvent(context.Background(), &user.NewAggregate(testLogoutUserID, "org1").Aggregate, "agent1"),
+				},
+				clients: map[string]bool{"client1": true},
+			},
+			event: testLogoutEvent(t, user.HumanSignedOutType, user.HumanSignedOutEventMapper, `{"userAgentID": "agent1"}`),
+			want:  want{},
+		},
+		{
+			name: "user deactivated, all sessions notified",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("client1", "agent1"),
+					testTokenAddedEvent("client1", "agent2"),
+				},
+				clients: map[string]bool{"client1": true},
+			},
+			event: testLogoutEvent(t, user.UserDeactivatedType, user.UserDeactivatedEventMapper, ""),
+			want: want{
+				sessions: []backChannelLogoutSession{
+					{clientID: "client1", sessionID: "agent1"},
+					{clientID: "client1", sessionID: "agent2"},
+				},
+			},
+		},
+		{
+			name: "client without back-channel logout uri, skipped",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("client1", "agent1"),
+					testTokenAddedEvent("client2", "agent1"),
+				},
+				clients: map[string]bool{"client1": false, "client2": true},
+			},
+			event: testLogoutEvent(t, user.HumanSignedOutType, user.HumanSignedOutEventMapper, `{"userAgentID": "agent1"}`),
+			want: want{
+				sessions: []backChannelLogoutSession{
+					{clientID: "client2", sessionID: "agent1"},
+				},
+			},
+		},
+		{
+			name: "removed client, skipped",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("removed", "agent1"),
+				},
+				clients: map[string]bool{},
+			},
+			event: testLogoutEvent(t, user.HumanSignedOutType, user.HumanSignedOutEventMapper, `{"userAgentID": "agent1"}`),
+			want:  want{},
+		},
+		{
+			name: "already notified, skipped",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("client1", "agent1"),
+				},
+				clients:        map[string]bool{"client1": true},
+				alreadyHandled: true,
+			},
+			event: testLogoutEvent(t, user.HumanSignedOutType, user.HumanSignedOutEventMapper, `{"userAgentID": "agent1"}`),
+			want:  want{},
+		},
+		{
+			name: "delivery failed, error",
+			fields: fields{
+				events: []eventstore.Event{
+					testTokenAddedEvent("client1", "agent1"),
+				},
+				clients: map[string]bool{"client1": true},
+				status:  http.StatusInternalServerError,
+			},
+			event: testLogoutEvent(t, user.HumanSignedOutType, user.HumanSignedOutEventMapper, `{"userAgentID": "agent1"}`),
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				tokens = make(map[string][]string)
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				clientID := r.URL.Query().Get("client")
+				tokens[clientID] = append(tokens[clientID], r.FormValue("logout_token"))
+				if tt.fields.status != 0 {
+					w.WriteHeader(tt.fields.status)
+				}
+			}))
+			defer server.Close()
+
+			apps := make(map[string]*query.App, len(tt.fields.clients))
+			for clientID, backChannelLogout := range tt.fields.clients {
+				app := &query.App{OIDCConfig: &query.OIDCApp{ClientID: clientID}}
+				if backChannelLogout {
+					app.OIDCConfig.BackChannelLogoutURI = server.URL + "?client=" + clientID
+				}
+				apps[clientID] = app
+			}
+			idGenerator := id_mock.NewMockGenerator(gomock.NewController(t))
+			idGenerator.EXPECT().Next().AnyTimes().Return("tokenID", nil)
+			commands := new(testBackChannelLogoutCommands)
+			u := &backChannelLogoutNotifier{
+				commands: commands,
+				queries: &testBackChannelLogoutQueries{
+					apps:           apps,
+					key:            &testSigningKey{key: signingKey},
+					alreadyHandled: tt.fields.alreadyHandled,
+				},
+				events:        testEventFilter(tt.fields.events),
+				keyEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				idGenerator:   idGenerator,
+			}
+
+			var (
+				stmt *handler.Statement
+				err  error
+			)
+			switch tt.event.Type() {
+			case user.HumanSignedOutType:
+				stmt, err = u.reduceSignedOut(tt.event)
+			default:
+				stmt, err = u.reduceUserEnded(tt.event)
+			}
+			if tt.want.err {
+				assert.Error(t, err)
+				assert.Empty(t, commands.sent, "failed deliveries must not be recorded")
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, stmt)
+			assert.ElementsMatch(t, tt.want.sessions, commands.sent)
+
+			var notified []backChannelLogoutSession
+			for clientID, clientTokens := range tokens {
+				for _, token := range clientTokens {
+					claims := verifyTestLogoutToken(t, token, &signingKey.PublicKey)
+					assert.Equal(t, []string{clientID}, claims.Audience)
+					notified = append(notified, backChannelLogoutSession{clientID: clientID, sessionID: claims.SessionID})
+				}
+			}
+			assert.ElementsMatch(t, tt.want.sessions, notified)
+		})
+	}
+}
+
+func verifyTestLogoutToken(t *testing.T, token string, key *rsa.PublicKey) *logoutTokenClaims {
+	t.Helper()
+	jws, err := jose.ParseSigned(token)
+	require.NoError(t, err)
+	require.Len(t, jws.Signatures, 1)
+	assert.Equal(t, logoutTokenType, jws.Signatures[0].Header.ExtraHeaders[jose.HeaderType])
+	assert.Equal(t, "keyID", jws.Signatures[0].Header.KeyID)
+	payload, err := jws.Verify(key)
+	require.NoError(t, err)
+
+	// a logout token must not contain a nonce (OpenID Connect Back-Channel Logout 1.0, section 2.4)
+	raw := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(payload, &raw))
+	assert.NotContains(t, raw, "nonce")
+
+	claims := new(logoutTokenClaims)
+	require.NoError(t, json.Unmarshal(payload, claims))
+	assert.Equal(t, testLogoutIssuer, claims.Issuer)
+	assert.Equal(t, testLogoutUserID, claims.Subject)
+	assert.Equal(t, "tokenID", claims.JWTID)
+	assert.NotEmpty(t, claims.SessionID)
+	assert.Equal(t, int64(logoutTokenLifetime/time.Second), claims.Expiration-claims.IssuedAt)
+	assert.Contains(t, claims.Events, backChannelLogoutEvent)
+	assert.Equal(t, map[string]interface{}{}, claims.Events[backChannelLogoutEvent])
+	return claims
+}
+
+func testLogoutEvent(t *testing.T, eventType eventstore.EventType, mapper func(*repository.Event) (eventstore.Event, error), data string) eventstore.Event {
+	event, err := mapper(&repository.Event{
+		Sequence:      15,
+		CreationDate:  time.Now(),
+		Type:          repository.EventType(eventType),
+		AggregateType: repository.AggregateType(user.AggregateType),
+		Data:          []byte(data),
+		Version:       repository.Version(user.AggregateVersion),
+		AggregateID:   testLogoutUserID,
+		ResourceOwner: sql.NullString{String: "org1", Valid: true},
+		InstanceID:    "instanceID",
+	})
+	require.NoError(t, err)
+	return event
+}
+
+func testTokenAddedEvent(clientID, userAgentID string) eventstore.Event {
+	return user.NewUserTokenAddedEvent(context.Background(), &user.NewAggregate(testLogoutUserID, "org1").Aggregate,
+		"accessTokenID", clientID, userAgentID, "en", "", []string{clientID}, []string{"openid"}, time.Now().Add(time.Hour), "")
+}
+
+func testRefreshTokenAddedEvent(clientID, userAgentID string) eventstore.Event {
+	return user.NewHumanRefreshTokenAddedEvent(context.Background(), &user.NewAggregate(testLogoutUserID, "org1").Aggregate,
+		"refreshTokenID", clientID, userAgentID, "en", []string{clientID}, []string{"openid", "offline_access"}, []string{"pwd"}, time.Now(), time.Hour, 24*time.Hour, "")
+}
+
+type testEventFilter []eventstore.Event
+
+func (f testEventFilter) Filter(context.Context, *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+	return f, nil
+}
+
+type testBackChannelLogoutCommands struct {
+	sent []backChannelLogoutSession
+}
+
+func (c *testBackChannelLogoutCommands) BackChannelLogoutSent(_ context.Context, _, _, clientID, sessionID string, _ uint64) error {
+	c.sent = append(c.sent, backChannelLogoutSession{clientID: clientID, sessionID: sessionID})
+	return nil
+}
+
+type testBackChannelLogoutQueries struct {
+	apps           map[string]*query.App
+	key            query.PrivateKey
+	alreadyHandled bool
+}
+
+func (q *testBackChannelLogoutQueries) AppByOIDCClientID(_ context.Context, clientID string, _ bool) (*query.App, error) {
+	app, ok := q.apps[clientID]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "TEST-Bcl1n", "Errors.App.NotFound")
+	}
+	return app, nil
+}
+
+func (q *testBackChannelLogoutQueries) ActivePrivateSigningKey(context.Context, time.Time) (*query.PrivateKeys, error) {
+	return &query.PrivateKeys{Keys: []query.PrivateKey{q.key}}, nil
+}
+
+func (q *testBackChannelLogoutQueries) IsAlreadyHandled(context.Context, eventstore.Event, map[string]interface{}, eventstore.AggregateType, ...eventstore.EventType) (bool, error) {
+	return q.alreadyHandled, nil
+}
+
+func (q *testBackChannelLogoutQueries) Origin(ctx context.Context) (context.Context, string, error) {
+	return ctx, testLogoutIssuer, nil
+}
+
+type testSigningKey struct {
+	key *rsa.PrivateKey
+}
+
+func (k *testSigningKey) ID() string           { return "keyID" }
+func (k *testSigningKey) Algorithm() string    { return string(jose.RS256) }
+func (k *testSigningKey) Use() domain.KeyUsage { return domain.KeyUsageSigning }
+func (k *testSigningKey) Sequence() uint64     { return 1 }
+func (k *testSigningKey) Expiry() time.Time    { return time.Now().Add(time.Hour) }
+func (k *testSigningKey) Key() *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    crypto.PrivateKeyToBytes(k.key),
+	}
+}
@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+const (
+	WebhookNotificationsProjectionTable = "projections.notifications_webhooks"
+
+	webhookIDHeader            = "X-Zitadel-Webhook-Id"
+	webhookEventTypeHeader     = "X-Zitadel-Event-Type"
+	webhookEventSequenceHeader = "X-Zitadel-Event-Sequence"
+	webhookSignatureHeader     = "X-Zitadel-Signature"
+)
+
+type WebhookDeliveryConfig struct {
+	// MaxAttempts is the amount of delivery attempts of an event, before it's no longer retried automatically
+	MaxAttempts uint64
+	// InitialBackoff is the delay before the second attempt, it's doubled for every further attempt
+	InitialBackoff time.Duration
+	// MaxBackoff limits the delay between two attempts
+	MaxBackoff time.Duration
+	// Timeout of a single attempt
+	Timeout time.Duration
+}
+
+type webhookNotifier struct {
+	crdb.StatementHandler
+	cfg               WebhookDeliveryConfig
+	commands          *command.Commands
+	queries           *NotificationQueries
+	webhookEncryption crypto.EncryptionAlgorithm
+	client            *http.Client
+}
+
+func NewWebhookNotifier(
+	ctx context.Context,
+	deliveryCfg WebhookDeliveryConfig,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+	webhookEncryption crypto.EncryptionAlgorithm,
+) *webhookNotifier {
+	p := new(webhookNotifier)
+	p.cfg = deliveryCfg
+	p.commands = commands
+	p.queries = queries
+	p.webhookEncryption = webhookEncryption
+	p.client = &http.Client{Timeout: deliveryCfg.Timeout, Transport: actions.NewDenyListTransport()}
+	config.ProjectionName = WebhookNotificationsProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	projection.WebhookNotificationsProjection = p
+	return p
+}
+
+// reducers subscribes to all events known by the eventstore,
+// the webhooks subscribed to an event are queried on delivery
+func (u *webhookNotifier) reducers() []handler.AggregateReducer {
+	eventTypes := u.queries.es.EventTypes()
+	eventReducers := make([]handler.EventReducer, len(eventTypes))
+	for i, eventType := range eventTypes {
+		eventReducers[i] = handler.EventReducer{
+			Event:  eventstore.EventType(eventType),
+			Reduce: u.reduceEvent,
+		}
+	}
+	aggregateTypes := u.queries.es.AggregateTypes()
+	reducers := make([]handler.AggregateReducer, len(aggregateTypes))
+	for i, aggregateType := range aggregateTypes {
+		reducers[i] = handler.AggregateReducer{
+			Aggregate:     eventstore.AggregateType(aggregateType),
+			EventRedusers: eventReducers,
+		}
+	}
+	return reducers
+}
+
+func (u *webhookNotifier) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	if event.Aggregate().Type == webhook.AggregateType {
+		if e, ok := event.(*webhook.RedeliveryRequestedEvent); ok {
+			return u.reduceRedeliveryRequested(e)
+		}
+		// events of the webhooks themselves are never delivered to prevent loops
+		return crdb.NewNoOpStatement(event), nil
+	}
+	// webhooks subscribed before the event type was restricted don't receive it anymore
+	if !webhook.IsSubscribableEventType(event.Type()) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	webhooks, err := u.queries.ActiveWebhooksByEventType(ctx, string(event.Type()), event.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range webhooks {
+		// only events created after the webhook are delivered
+		if !hook.CreationDate.Before(event.CreationDate()) {
+			continue
+		}
+		if err = u.deliver(ctx, hook, event, event.Sequence()); err != nil {
+			return nil, err
+		}
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
+
+func (u *webhookNotifier) reduceRedeliveryRequested(e *webhook.RedeliveryRequestedEvent) (*handler.Statement, error) {
+	ctx := HandlerContext(e.Aggregate())
+	hook, err := u.queries.GetWebhookByID(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hook.State != domain.WebhookStateActive {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	events, err := u.queries.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(e.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(e.AggregateType).
+			AggregateIDs(e.AggregateID).
+			EventTypes(e.DeliveredEventType).
+			SequenceGreater(e.EventSequence-1).
+			SequenceLess(e.EventSequence+1).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		logging.WithFields("webhook", hook.ID, "sequence", e.EventSequence).Warn("event of dead letter not found")
+		return crdb.NewNoOpStatement(e), nil
+	}
+	if err = u.deliver(ctx, hook, events[0], e.Sequence()); err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// deliver sends the event to the webhook, unless the delivery was already recorded after the sequence `after`.
+// Only a single attempt is made, a failed attempt is recorded as dead letter
+// and retried asynchronously through a redelivery request, so the projection is never blocked.
+func (u *webhookNotifier) deliver(ctx context.Context, hook *query.Webhook, event eventstore.Event, after uint64) error {
+	alreadyHandled, failedAttempts, err := u.deliveryState(ctx, hook, event, after)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	body, err := webhookPayload(hook, event)
+	if err != nil {
+		return err
+	}
+	signingKey, err := crypto.DecryptString(hook.SigningKey, u.webhookEncryption)
+	if err != nil {
+		return err
+	}
+	delivered := webhook.NewDeliveredEvent(event)
+	attempt := failedAttempts + 1
+	retry, sendErr := u.send(ctx, hook, event, body, signingKey)
+	if sendErr == nil {
+		return u.commands.WebhookDelivered(ctx, hook.ID, hook.ResourceOwner, delivered, attempt)
+	}
+	logging.WithFields("webhook", hook.ID, "sequence", event.Sequence(), "attempt", attempt).WithError(sendErr).Info("webhook delivery failed")
+	if err = u.commands.WebhookDeliveryFailed(ctx, hook.ID, hook.ResourceOwner, delivered, attempt, sendErr.Error()); err != nil {
+		return err
+	}
+	if retry && attempt < u.cfg.MaxAttempts {
+		u.scheduleRedelivery(ctx, hook, delivered.EventSequence, u.backoff(attempt))
+	}
+	return nil
+}
+
+// scheduleRedelivery requests another delivery of the dead letter after the backoff.
+// Redeliveries pending when the process stops are not requested,
+// the event is kept as dead letter and can be redelivered manually.
+func (u *webhookNotifier) scheduleRedelivery(ctx context.Context, hook *query.Webhook, eventSequence uint64, backoff time.Duration) {
+	time.AfterFunc(backoff, func() {
+		_, err := u.commands.RedeliverWebhookEvent(ctx, hook.ID, hook.ResourceOwner, eventSequence)
+		logging.WithFields("webhook", hook.ID, "sequence", eventSequence).OnError(err).Warn("unable to request redelivery of webhook event")
+	})
+}
+
+// deliveryState returns if the delivery of the event was already recorded after the sequence `after`
+// and the amount of failed attempts before
+func (u *webhookNotifier) deliveryState(ctx context.Context, hook *query.Webhook, event eventstore.Event, after uint64) (alreadyHandled bool, failedAttempts uint64, err error) {
+	events, err := u.queries.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(webhook.AggregateType).
+			AggregateIDs(hook.ID).
+			EventTypes(webhook.DeliverySucceededEventType, webhook.DeliveryFailedEventType).
+			EventData(map[string]interface{}{"eventSequence": event.Sequence()}).
+			Builder(),
+	)
+	if err != nil {
+		return false, 0, err
+	}
+	for _, e := range events {
+		if e.Sequence() > after {
+			return true, 0, nil
+		}
+		if failed, ok := e.(*webhook.DeliveryFailedEvent); ok {
+			failedAttempts = failed.Attempts
+		}
+	}
+	return false, failedAttempts, nil
+}
+
+// send executes a single delivery attempt and returns if a failed attempt should be retried
+func (u *webhookNotifier) send(ctx context.Context, hook *query.Webhook, event eventstore.Event, body []byte, signingKey string) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookIDHeader, hook.ID)
+	req.Header.Set(webhookEventTypeHeader, string(event.Type()))
+	req.Header.Set(webhookEventSequenceHeader, strconv.FormatUint(event.Sequence(), 10))
+	req.Header.Set(webhookSignatureHeader, crypto.SignPayload(signingKey, time.Now(), body))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected response status %s", resp.Status)
+}
+
+func (u *webhookNotifier) backoff(attempt uint64) time.Duration {
+	backoff := u.cfg.InitialBackoff
+	for i := uint64(1); i < attempt; i++ {
+		backoff *= 2
+		if u.cfg.MaxBackoff > 0 && backoff >= u.cfg.MaxBackoff {
+			return u.cfg.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+type webhookEventPayload struct {
+	WebhookID    string                `json:"webhookId"`
+	Sequence     uint64                `json:"sequence"`
+	CreationDate time.Time             `json:"creationDate"`
+	EventType    eventstore.EventType  `json:"eventType"`
+	Aggregate    webhookEventAggregate `json:"aggregate"`
+	Editor       webhookEventEditor    `json:"editor"`
+	Payload      json.RawMessage       `json:"payload,omitempty"`
+}
+
+type webhookEventAggregate struct {
+	ID            string                   `json:"id"`
+	Type          eventstore.AggregateType `json:"type"`
+	ResourceOwner string                   `json:"resourceOwner"`
+	InstanceID    string                   `json:"instanceId"`
+}
+
+type webhookEventEditor struct {
+	UserID  string `json:"userId"`
+	Service string `json:"service"`
+}
+
+func webhookPayload(hook *query.Webhook, event eventstore.Event) ([]byte, error) {
+	var payload json.RawMessage
+	if data := event.DataAsBytes(); len(data) > 0 {
+		var value interface{}
+		// numbers are kept as they are, e.g. ids and sequences exceeding the precision of a float
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err == nil {
+			payload, err = json.Marshal(withoutSecrets(value))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return json.Marshal(&webhookEventPayload{
+		WebhookID:    hook.ID,
+		Sequence:     event.Sequence(),
+		CreationDate: event.CreationDate(),
+		EventType:    event.Type(),
+		Aggregate: webhookEventAggregate{
+			ID:            event.Aggregate().ID,
+			Type:          event.Aggregate().Type,
+			ResourceOwner: event.Aggregate().ResourceOwner,
+			InstanceID:    event.Aggregate().InstanceID,
+		},
+		Editor: webhookEventEditor{
+			UserID:  event.EditorUser(),
+			Service: event.EditorService(),
+		},
+		Payload: payload,
+	})
+}
+
+// withoutSecrets removes the encrypted and hashed values ([crypto.CryptoValue]) from the payload,
+// e.g. the password hash of user.human.password.changed
+func withoutSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isCryptoValue(field) {
+				delete(v, key)
+				continue
+			}
+			v[key] = withoutSecrets(field)
+		}
+	case []interface{}:
+		for i, element := range v {
+			if isCryptoValue(element) {
+				v[i] = nil
+				continue
+			}
+			v[i] = withoutSecrets(element)
+		}
+	}
+	return value
+}
+
+// isCryptoValue checks if the value is a marshalled [crypto.CryptoValue],
+// its field names are matched case insensitive like by the json decoder
+func isCryptoValue(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for key := range object {
+		if strings.EqualFold(key, "crypted") {
+			return true
+		}
+	}
+	return false
+}
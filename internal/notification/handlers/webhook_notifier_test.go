@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_webhookPayload_passwordChanged(t *testing.T) {
+	hash := []byte("$2a$14$hashOfThePassword")
+	data, err := json.Marshal(&user.HumanPasswordChangedEvent{
+		Secret: &crypto.CryptoValue{
+			CryptoType: crypto.TypeHash,
+			Algorithm:  "bcrypt",
+			Crypted:    hash,
+		},
+		ChangeRequired: true,
+		UserAgentID:    "agent",
+	})
+	require.NoError(t, err)
+	event, err := user.HumanPasswordChangedEventMapper(&repository.Event{
+		AggregateID:   "user1",
+		AggregateType: user.AggregateType,
+		Type:          repository.EventType(user.HumanPasswordChangedType),
+		Sequence:      12,
+		Data:          data,
+	})
+	require.NoError(t, err)
+
+	body, err := webhookPayload(&query.Webhook{ID: "hook1"}, event)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(body), string(hash))
+	assert.NotContains(t, string(body), base64.StdEncoding.EncodeToString(hash))
+	var delivered struct {
+		EventType string                 `json:"eventType"`
+		Payload   map[string]interface{} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(body, &delivered))
+	assert.Equal(t, string(user.HumanPasswordChangedType), delivered.EventType)
+	assert.Equal(t, map[string]interface{}{"changeRequired": true, "userAgentID": "agent"}, delivered.Payload)
+}
+
+func Test_withoutSecrets(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "no secrets",
+			value: `{"userName":"user","id":123456789012345678901}`,
+			want:  `{"id":123456789012345678901,"userName":"user"}`,
+		},
+		{
+			name:  "nested crypto value",
+			value: `{"config":{"clientId":"id","clientSecret":{"cryptoType":0,"algorithm":"aes","keyId":"key","crypted":"c2VjcmV0"}}}`,
+			want:  `{"config":{"clientId":"id"}}`,
+		},
+		{
+			name:  "crypto value in list",
+			value: `{"keys":[{"Crypted":"c2VjcmV0"},"key"]}`,
+			want:  `{"keys":[null,"key"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			decoder := json.NewDecoder(strings.NewReader(tt.value))
+			decoder.UseNumber()
+			require.NoError(t, decoder.Decode(&value))
+			got, err := json.Marshal(withoutSecrets(value))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
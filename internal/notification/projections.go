@@ -30,7 +30,11 @@ func Start(
 	userHandlerCustomConfig projection.CustomConfig,
 	quotaHandlerCustomConfig projection.CustomConfig,
 	telemetryHandlerCustomConfig projection.CustomConfig,
+	backChannelLogoutHandlerCustomConfig projection.CustomConfig,
+	webhookHandlerCustomConfig projection.CustomConfig,
+	actionHandlerCustomConfig projection.CustomConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	webhookCfg handlers.WebhookDeliveryConfig,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
@@ -41,7 +45,9 @@ func Start(
 	fileSystemPath string,
 	userEncryption,
 	smtpEncryption,
-	smsEncryption crypto.EncryptionAlgorithm,
+	smsEncryption,
+	keyEncryption,
+	webhookEncryption crypto.EncryptionAlgorithm,
 ) {
 	statikFS, err := statik_fs.NewWithNamespace("notification")
 	logging.OnError(err).Panic("unable to start listener")
@@ -77,6 +83,26 @@ func Start(
 		metricSuccessfulDeliveriesJSON,
 		metricFailedDeliveriesJSON,
 	).Start()
+	handlers.NewBackChannelLogoutNotifier(
+		ctx,
+		projection.ApplyCustomConfig(backChannelLogoutHandlerCustomConfig),
+		commands,
+		q,
+		keyEncryption,
+	).Start()
+	handlers.NewWebhookNotifier(
+		ctx,
+		webhookCfg,
+		projection.ApplyCustomConfig(webhookHandlerCustomConfig),
+		commands,
+		q,
+		webhookEncryption,
+	).Start()
+	handlers.NewActionNotifier(
+		ctx,
+		projection.ApplyCustomConfig(actionHandlerCustomConfig),
+		q,
+	).Start()
 	if telemetryCfg.Enabled {
 		handlers.NewTelemetryPusher(
 			ctx,
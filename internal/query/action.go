@@ -10,6 +10,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -73,6 +74,18 @@ var (
 		name:  projection.ActionOwnerRemovedCol,
 		table: actionTable,
 	}
+	ActionColumnTargetType = Column{
+		name:  projection.ActionTargetTypeCol,
+		table: actionTable,
+	}
+	ActionColumnTargetURL = Column{
+		name:  projection.ActionTargetURLCol,
+		table: actionTable,
+	}
+	ActionColumnSigningKey = Column{
+		name:  projection.ActionSigningKeyCol,
+		table: actionTable,
+	}
 )
 
 type Actions struct {
@@ -92,6 +105,10 @@ type Action struct {
 	Script        string
 	timeout       time.Duration
 	AllowedToFail bool
+	TargetType    domain.ActionTargetType
+	TargetURL     string
+	// SigningKey is only queried for the execution and the test of the actions
+	SigningKey *crypto.CryptoValue
 }
 
 func (a *Action) Timeout() time.Duration {
@@ -192,6 +209,8 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnTargetType.identifier(),
+			ActionColumnTargetURL.identifier(),
 			countColumn.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -211,6 +230,8 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 					&action.Script,
 					&action.timeout,
 					&action.AllowedToFail,
+					&action.TargetType,
+					&action.TargetURL,
 					&count,
 				)
 				if err != nil {
@@ -244,6 +265,9 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnTargetType.identifier(),
+			ActionColumnTargetURL.identifier(),
+			ActionColumnSigningKey.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Action, error) {
@@ -259,6 +283,9 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&action.Script,
 				&action.timeout,
 				&action.AllowedToFail,
+				&action.TargetType,
+				&action.TargetURL,
+				&action.SigningKey,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
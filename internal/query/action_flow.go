@@ -179,6 +179,9 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnTargetType.identifier(),
+			ActionColumnTargetURL.identifier(),
+			ActionColumnSigningKey.identifier(),
 		).
 			From(flowsTriggersTable.name).
 			LeftJoin(join(ActionColumnID, FlowsTriggersColumnActionID) + db.Timetravel(call.Took(ctx))).
@@ -198,6 +201,9 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 					&action.Script,
 					&action.AllowedToFail,
 					&action.timeout,
+					&action.TargetType,
+					&action.TargetURL,
+					&action.SigningKey,
 				)
 				if err != nil {
 					return nil, err
@@ -225,6 +231,8 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnTargetType.identifier(),
+			ActionColumnTargetURL.identifier(),
 			FlowsTriggersColumnTriggerType.identifier(),
 			FlowsTriggersColumnTriggerSequence.identifier(),
 			FlowsTriggersColumnFlowType.identifier(),
@@ -252,6 +260,8 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					actionScript        sql.NullString
 					actionAllowedToFail sql.NullBool
 					actionTimeout       sql.NullInt64
+					actionTargetType    sql.NullInt32
+					actionTargetURL     sql.NullString
 
 					triggerType     domain.TriggerType
 					triggerSequence int
@@ -267,6 +277,8 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					&actionScript,
 					&actionAllowedToFail,
 					&actionTimeout,
+					&actionTargetType,
+					&actionTargetURL,
 					&triggerType,
 					&triggerSequence,
 					&flow.Type,
@@ -291,6 +303,8 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					Script:        actionScript.String,
 					AllowedToFail: actionAllowedToFail.Bool,
 					timeout:       time.Duration(actionTimeout.Int64),
+					TargetType:    domain.ActionTargetType(actionTargetType.Int32),
+					TargetURL:     actionTargetURL.String,
 				})
 			}
 
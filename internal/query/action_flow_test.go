@@ -12,20 +12,23 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
 var (
-	prepareFlowStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout,` +
+	prepareFlowStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.target_type,` +
+		` projections.actions4.target_url,` +
 		` projections.flow_triggers2.trigger_type,` +
 		` projections.flow_triggers2.trigger_sequence,` +
 		` projections.flow_triggers2.flow_type,` +
@@ -33,7 +36,7 @@ var (
 		` projections.flow_triggers2.sequence,` +
 		` projections.flow_triggers2.resource_owner` +
 		` FROM projections.flow_triggers2` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers2.action_id = projections.actions3.id AND projections.flow_triggers2.instance_id = projections.actions3.instance_id` +
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers2.action_id = projections.actions4.id AND projections.flow_triggers2.instance_id = projections.actions4.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareFlowCols = []string{
 		"id",
@@ -46,6 +49,8 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"target_type",
+		"target_url",
 		// flow
 		"trigger_type",
 		"trigger_sequence",
@@ -55,18 +60,21 @@ var (
 		"resource_owner",
 	}
 
-	prepareTriggerActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout` +
+	prepareTriggerActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.target_type,` +
+		` projections.actions4.target_url,` +
+		` projections.actions4.signing_key` +
 		` FROM projections.flow_triggers2` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers2.action_id = projections.actions3.id AND projections.flow_triggers2.instance_id = projections.actions3.instance_id` +
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers2.action_id = projections.actions4.id AND projections.flow_triggers2.instance_id = projections.actions4.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 
 	prepareTriggerActionCols = []string{
@@ -80,6 +88,9 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"target_type",
+		"target_url",
+		"signing_key",
 	}
 
 	prepareFlowTypeStmt = `SELECT projections.flow_triggers2.flow_type` +
@@ -140,6 +151,8 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTargetTypeScript,
+							"",
 							domain.TriggerTypePreCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -194,6 +207,8 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTargetTypeScript,
+							"",
 							domain.TriggerTypePreCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -212,6 +227,8 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							false,
 							5000000000,
+							domain.ActionTargetTypeScript,
+							"",
 							domain.TriggerTypePostCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -280,6 +297,8 @@ func Test_FlowPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							domain.TriggerTypePostCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -348,6 +367,9 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTargetTypeScript,
+							"",
+							nil,
 						},
 					},
 				),
@@ -386,6 +408,9 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTargetTypeScript,
+							"",
+							nil,
 						},
 						{
 							"action-id-2",
@@ -398,6 +423,9 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							false,
 							5000000000,
+							domain.ActionTargetTypeHTTP,
+							"https://example.com/action",
+							[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"Y3J5cHRlZA=="}`),
 						},
 					},
 				),
@@ -426,6 +454,14 @@ func Test_FlowPrepares(t *testing.T) {
 					Script:        "script",
 					AllowedToFail: false,
 					timeout:       5 * time.Second,
+					TargetType:    domain.ActionTargetTypeHTTP,
+					TargetURL:     "https://example.com/action",
+					SigningKey: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("crypted"),
+					},
 				},
 			},
 		},
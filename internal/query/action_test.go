@@ -9,23 +9,26 @@ import (
 	"testing"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 var (
-	prepareActionsStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail,` +
+	prepareActionsStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.target_type,` +
+		` projections.actions4.target_url,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.actions3` +
+		` FROM projections.actions4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionsCols = []string{
 		"id",
@@ -38,20 +41,25 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"target_type",
+		"target_url",
 		"count",
 	}
 
-	prepareActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail` +
-		` FROM projections.actions3` +
+	prepareActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.target_type,` +
+		` projections.actions4.target_url,` +
+		` projections.actions4.signing_key` +
+		` FROM projections.actions4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionCols = []string{
 		"id",
@@ -64,6 +72,9 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"target_type",
+		"target_url",
+		"signing_key",
 	}
 )
 
@@ -109,6 +120,8 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionTargetTypeScript,
+							"",
 						},
 					},
 				),
@@ -152,6 +165,8 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionTargetTypeScript,
+							"",
 						},
 						{
 							"id-2",
@@ -164,6 +179,8 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionTargetTypeScript,
+							"",
 						},
 					},
 				),
@@ -253,6 +270,9 @@ func Test_ActionPrepares(t *testing.T) {
 						"script",
 						1 * time.Second,
 						true,
+						domain.ActionTargetTypeHTTP,
+						"https://example.com/action",
+						[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"Y3J5cHRlZA=="}`),
 					},
 				),
 			},
@@ -267,6 +287,14 @@ func Test_ActionPrepares(t *testing.T) {
 				Script:        "script",
 				timeout:       1 * time.Second,
 				AllowedToFail: true,
+				TargetType:    domain.ActionTargetTypeHTTP,
+				TargetURL:     "https://example.com/action",
+				SigningKey: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("crypted"),
+				},
 			},
 		},
 		{
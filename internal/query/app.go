@@ -58,6 +58,9 @@ type OIDCApp struct {
 	AdditionalOrigins        database.StringArray
 	AllowedOrigins           database.StringArray
 	SkipNativeAppSuccessPage bool
+	DPoPBoundAccessTokens    bool
+	RequirePushedAuthRequest bool
+	BackChannelLogoutURI     string
 }
 
 type SAMLApp struct {
@@ -246,6 +249,18 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnDPoPBoundAccessTokens = Column{
+		name:  projection.AppOIDCConfigColumnDPoPBoundAccessTokens,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequirePushedAuthRequest = Column{
+		name:  projection.AppOIDCConfigColumnRequirePushedAuthRequest,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string, withOwnerRemoved bool) (_ *App, err error) {
@@ -541,6 +556,9 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnDPoPBoundAccessTokens.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequest.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -590,6 +608,9 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.dpopBoundAccessTokens,
+				&oidcConfig.requirePushedAuthRequest,
+				&oidcConfig.backChannelLogoutURI,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
@@ -711,6 +732,9 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnDPoPBoundAccessTokens.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequest.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -763,6 +787,9 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.dpopBoundAccessTokens,
+					&oidcConfig.requirePushedAuthRequest,
+					&oidcConfig.backChannelLogoutURI,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -835,6 +862,9 @@ type sqlOIDCConfig struct {
 	responseTypes            database.EnumArray[domain.OIDCResponseType]
 	grantTypes               database.EnumArray[domain.OIDCGrantType]
 	skipNativeAppSuccessPage sql.NullBool
+	dpopBoundAccessTokens    sql.NullBool
+	requirePushedAuthRequest sql.NullBool
+	backChannelLogoutURI     sql.NullString
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -858,6 +888,9 @@ func (c sqlOIDCConfig) set(app *App) {
 		ResponseTypes:            c.responseTypes,
 		GrantTypes:               c.grantTypes,
 		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		DPoPBoundAccessTokens:    c.dpopBoundAccessTokens.Bool,
+		RequirePushedAuthRequest: c.requirePushedAuthRequest.Bool,
+		BackChannelLogoutURI:     c.backChannelLogoutURI.String,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -15,98 +15,104 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps8.id,` +
+		` projections.apps8.name,` +
+		` projections.apps8.project_id,` +
+		` projections.apps8.creation_date,` +
+		` projections.apps8.change_date,` +
+		` projections.apps8.resource_owner,` +
+		` projections.apps8.state,` +
+		` projections.apps8.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps8_api_configs.app_id,` +
+		` projections.apps8_api_configs.client_id,` +
+		` projections.apps8_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps8_oidc_configs.app_id,` +
+		` projections.apps8_oidc_configs.version,` +
+		` projections.apps8_oidc_configs.client_id,` +
+		` projections.apps8_oidc_configs.redirect_uris,` +
+		` projections.apps8_oidc_configs.response_types,` +
+		` projections.apps8_oidc_configs.grant_types,` +
+		` projections.apps8_oidc_configs.application_type,` +
+		` projections.apps8_oidc_configs.auth_method_type,` +
+		` projections.apps8_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps8_oidc_configs.is_dev_mode,` +
+		` projections.apps8_oidc_configs.access_token_type,` +
+		` projections.apps8_oidc_configs.access_token_role_assertion,` +
+		` projections.apps8_oidc_configs.id_token_role_assertion,` +
+		` projections.apps8_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps8_oidc_configs.clock_skew,` +
+		` projections.apps8_oidc_configs.additional_origins,` +
+		` projections.apps8_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps8_oidc_configs.dpop_bound_access_tokens,` +
+		` projections.apps8_oidc_configs.require_pushed_auth_request,` +
+		` projections.apps8_oidc_configs.back_channel_logout_uri,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` projections.apps8_saml_configs.app_id,` +
+		` projections.apps8_saml_configs.entity_id,` +
+		` projections.apps8_saml_configs.metadata,` +
+		` projections.apps8_saml_configs.metadata_url` +
+		` FROM projections.apps8` +
+		` LEFT JOIN projections.apps8_api_configs ON projections.apps8.id = projections.apps8_api_configs.app_id AND projections.apps8.instance_id = projections.apps8_api_configs.instance_id` +
+		` LEFT JOIN projections.apps8_oidc_configs ON projections.apps8.id = projections.apps8_oidc_configs.app_id AND projections.apps8.instance_id = projections.apps8_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps8_saml_configs ON projections.apps8.id = projections.apps8_saml_configs.app_id AND projections.apps8.instance_id = projections.apps8_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps8.id,` +
+		` projections.apps8.name,` +
+		` projections.apps8.project_id,` +
+		` projections.apps8.creation_date,` +
+		` projections.apps8.change_date,` +
+		` projections.apps8.resource_owner,` +
+		` projections.apps8.state,` +
+		` projections.apps8.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps8_api_configs.app_id,` +
+		` projections.apps8_api_configs.client_id,` +
+		` projections.apps8_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps8_oidc_configs.app_id,` +
+		` projections.apps8_oidc_configs.version,` +
+		` projections.apps8_oidc_configs.client_id,` +
+		` projections.apps8_oidc_configs.redirect_uris,` +
+		` projections.apps8_oidc_configs.response_types,` +
+		` projections.apps8_oidc_configs.grant_types,` +
+		` projections.apps8_oidc_configs.application_type,` +
+		` projections.apps8_oidc_configs.auth_method_type,` +
+		` projections.apps8_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps8_oidc_configs.is_dev_mode,` +
+		` projections.apps8_oidc_configs.access_token_type,` +
+		` projections.apps8_oidc_configs.access_token_role_assertion,` +
+		` projections.apps8_oidc_configs.id_token_role_assertion,` +
+		` projections.apps8_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps8_oidc_configs.clock_skew,` +
+		` projections.apps8_oidc_configs.additional_origins,` +
+		` projections.apps8_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps8_oidc_configs.dpop_bound_access_tokens,` +
+		` projections.apps8_oidc_configs.require_pushed_auth_request,` +
+		` projections.apps8_oidc_configs.back_channel_logout_uri,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url,` +
+		` projections.apps8_saml_configs.app_id,` +
+		` projections.apps8_saml_configs.entity_id,` +
+		` projections.apps8_saml_configs.metadata,` +
+		` projections.apps8_saml_configs.metadata_url,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` FROM projections.apps8` +
+		` LEFT JOIN projections.apps8_api_configs ON projections.apps8.id = projections.apps8_api_configs.app_id AND projections.apps8.instance_id = projections.apps8_api_configs.instance_id` +
+		` LEFT JOIN projections.apps8_oidc_configs ON projections.apps8.id = projections.apps8_oidc_configs.app_id AND projections.apps8.instance_id = projections.apps8_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps8_saml_configs ON projections.apps8.id = projections.apps8_saml_configs.app_id AND projections.apps8.instance_id = projections.apps8_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps5_api_configs.client_id,` +
-		` projections.apps5_oidc_configs.client_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps8_api_configs.client_id,` +
+		` projections.apps8_oidc_configs.client_id` +
+		` FROM projections.apps8` +
+		` LEFT JOIN projections.apps8_api_configs ON projections.apps8.id = projections.apps8_api_configs.app_id AND projections.apps8.instance_id = projections.apps8_api_configs.instance_id` +
+		` LEFT JOIN projections.apps8_oidc_configs ON projections.apps8.id = projections.apps8_oidc_configs.app_id AND projections.apps8.instance_id = projections.apps8_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.project_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps8.project_id` +
+		` FROM projections.apps8` +
+		` LEFT JOIN projections.apps8_api_configs ON projections.apps8.id = projections.apps8_api_configs.app_id AND projections.apps8.instance_id = projections.apps8_api_configs.instance_id` +
+		` LEFT JOIN projections.apps8_oidc_configs ON projections.apps8.id = projections.apps8_oidc_configs.app_id AND projections.apps8.instance_id = projections.apps8_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps8_saml_configs ON projections.apps8.id = projections.apps8_saml_configs.app_id AND projections.apps8.instance_id = projections.apps8_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects3.id,` +
 		` projections.projects3.creation_date,` +
@@ -120,10 +126,10 @@ var (
 		` projections.projects3.has_project_check,` +
 		` projections.projects3.private_labeling_setting` +
 		` FROM projections.projects3` +
-		` JOIN projections.apps5 ON projections.projects3.id = projections.apps5.project_id AND projections.projects3.instance_id = projections.apps5.instance_id` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` JOIN projections.apps8 ON projections.projects3.id = projections.apps8.project_id AND projections.projects3.instance_id = projections.apps8.instance_id` +
+		` LEFT JOIN projections.apps8_api_configs ON projections.apps8.id = projections.apps8_api_configs.app_id AND projections.apps8.instance_id = projections.apps8_api_configs.instance_id` +
+		` LEFT JOIN projections.apps8_oidc_configs ON projections.apps8.id = projections.apps8_oidc_configs.app_id AND projections.apps8.instance_id = projections.apps8_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps8_saml_configs ON projections.apps8.id = projections.apps8_saml_configs.app_id AND projections.apps8.instance_id = projections.apps8_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.StringArray{
@@ -157,6 +163,9 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"dpop_bound_access_tokens",
+		"require_pushed_auth_request",
+		"back_channel_logout_uri",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -228,6 +237,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -294,6 +306,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -363,6 +378,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -434,6 +452,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -520,6 +541,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -606,6 +630,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -692,6 +719,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -778,6 +808,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -864,6 +897,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							true,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -911,6 +947,276 @@ func Test_AppsPrepare(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareAppsQuery oidc app dpop bound access tokens",
+			prepare: prepareAppsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAppsQuery,
+					appsCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							// oidc config
+							"app-id",
+							domain.OIDCVersionV1,
+							"oidc-client-id",
+							database.StringArray{"https://redirect.to/me"},
+							database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							domain.OIDCApplicationTypeWeb,
+							domain.OIDCAuthMethodTypeNone,
+							database.StringArray{"post.logout.ch"},
+							false,
+							domain.OIDCTokenTypeJWT,
+							false,
+							false,
+							true,
+							1 * time.Second,
+							database.StringArray{"additional.origin"},
+							false,
+							true,
+							false,
+							"",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &Apps{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Apps: []*App{
+					{
+						ID:            "app-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						State:         domain.AppStateActive,
+						Sequence:      20211109,
+						Name:          "app-name",
+						ProjectID:     "project-id",
+						OIDCConfig: &OIDCApp{
+							Version:                  domain.OIDCVersionV1,
+							ClientID:                 "oidc-client-id",
+							RedirectURIs:             database.StringArray{"https://redirect.to/me"},
+							ResponseTypes:            database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:               database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                  domain.OIDCApplicationTypeWeb,
+							AuthMethodType:           domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:   database.StringArray{"post.logout.ch"},
+							IsDevMode:                false,
+							AccessTokenType:          domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:    false,
+							AssertIDTokenRole:        false,
+							AssertIDTokenUserinfo:    true,
+							ClockSkew:                1 * time.Second,
+							AdditionalOrigins:        database.StringArray{"additional.origin"},
+							ComplianceProblems:       nil,
+							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
+							SkipNativeAppSuccessPage: false,
+							DPoPBoundAccessTokens:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAppsQuery oidc app require pushed auth request",
+			prepare: prepareAppsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAppsQuery,
+					appsCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							// oidc config
+							"app-id",
+							domain.OIDCVersionV1,
+							"oidc-client-id",
+							database.StringArray{"https://redirect.to/me"},
+							database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							domain.OIDCApplicationTypeWeb,
+							domain.OIDCAuthMethodTypeNone,
+							database.StringArray{"post.logout.ch"},
+							false,
+							domain.OIDCTokenTypeJWT,
+							false,
+							false,
+							true,
+							1 * time.Second,
+							database.StringArray{"additional.origin"},
+							false,
+							false,
+							true,
+							"",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &Apps{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Apps: []*App{
+					{
+						ID:            "app-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						State:         domain.AppStateActive,
+						Sequence:      20211109,
+						Name:          "app-name",
+						ProjectID:     "project-id",
+						OIDCConfig: &OIDCApp{
+							Version:                  domain.OIDCVersionV1,
+							ClientID:                 "oidc-client-id",
+							RedirectURIs:             database.StringArray{"https://redirect.to/me"},
+							ResponseTypes:            database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:               database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                  domain.OIDCApplicationTypeWeb,
+							AuthMethodType:           domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:   database.StringArray{"post.logout.ch"},
+							IsDevMode:                false,
+							AccessTokenType:          domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:    false,
+							AssertIDTokenRole:        false,
+							AssertIDTokenUserinfo:    true,
+							ClockSkew:                1 * time.Second,
+							AdditionalOrigins:        database.StringArray{"additional.origin"},
+							ComplianceProblems:       nil,
+							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
+							SkipNativeAppSuccessPage: false,
+							RequirePushedAuthRequest: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAppsQuery oidc app back-channel logout uri",
+			prepare: prepareAppsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAppsQuery,
+					appsCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							// oidc config
+							"app-id",
+							domain.OIDCVersionV1,
+							"oidc-client-id",
+							database.StringArray{"https://redirect.to/me"},
+							database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							domain.OIDCApplicationTypeWeb,
+							domain.OIDCAuthMethodTypeNone,
+							database.StringArray{"post.logout.ch"},
+							false,
+							domain.OIDCTokenTypeJWT,
+							false,
+							false,
+							true,
+							1 * time.Second,
+							database.StringArray{"additional.origin"},
+							false,
+							false,
+							false,
+							"https://test.ch/logout",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &Apps{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Apps: []*App{
+					{
+						ID:            "app-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						State:         domain.AppStateActive,
+						Sequence:      20211109,
+						Name:          "app-name",
+						ProjectID:     "project-id",
+						OIDCConfig: &OIDCApp{
+							Version:                  domain.OIDCVersionV1,
+							ClientID:                 "oidc-client-id",
+							RedirectURIs:             database.StringArray{"https://redirect.to/me"},
+							ResponseTypes:            database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:               database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                  domain.OIDCApplicationTypeWeb,
+							AuthMethodType:           domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:   database.StringArray{"post.logout.ch"},
+							IsDevMode:                false,
+							AccessTokenType:          domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:    false,
+							AssertIDTokenRole:        false,
+							AssertIDTokenUserinfo:    true,
+							ClockSkew:                1 * time.Second,
+							AdditionalOrigins:        database.StringArray{"additional.origin"},
+							ComplianceProblems:       nil,
+							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
+							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "https://test.ch/logout",
+						},
+					},
+				},
+			},
+		},
 		{
 			name:    "prepareAppsQuery multiple result",
 			prepare: prepareAppsQuery,
@@ -950,6 +1256,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -987,6 +1296,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1024,6 +1336,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1192,6 +1507,9 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1250,6 +1568,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1313,6 +1634,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1391,6 +1715,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1455,6 +1782,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1534,6 +1864,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1613,6 +1946,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1692,6 +2028,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -0,0 +1,247 @@
+package query
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	instanceCachePrefix           = "instance:"
+	instanceHostCachePrefix       = "instance_host:"
+	membershipsCachePrefix        = "memberships:"
+	membershipsVersionCachePrefix = "memberships_version:"
+)
+
+// caches stores the results of hot lookups,
+// the lookups are queried directly if no cache is configured.
+// The entries are invalidated by the events pushed,
+// the projections are triggered beforehand so the entries are not refilled with outdated data
+type caches struct {
+	cache cache.Cache
+}
+
+func (q *Queries) startCaches(ctx context.Context, c cache.Cache) {
+	if c == nil {
+		return
+	}
+	q.caches = &caches{cache: c}
+	cache.Invalidate(ctx, map[eventstore.AggregateType][]eventstore.EventType{
+		iam_repo.AggregateType: nil,
+	}, q.caches.invalidateInstances)
+	cache.Invalidate(ctx, map[eventstore.AggregateType][]eventstore.EventType{
+		iam_repo.AggregateType: {
+			iam_repo.MemberAddedEventType,
+			iam_repo.MemberChangedEventType,
+			iam_repo.MemberRemovedEventType,
+			iam_repo.MemberCascadeRemovedEventType,
+			iam_repo.InstanceRemovedEventType,
+		},
+		org.AggregateType: {
+			org.MemberAddedEventType,
+			org.MemberChangedEventType,
+			org.MemberRemovedEventType,
+			org.MemberCascadeRemovedEventType,
+			org.OrgRemovedEventType,
+		},
+		project.AggregateType: {
+			project.MemberAddedType,
+			project.MemberChangedType,
+			project.MemberRemovedType,
+			project.MemberCascadeRemovedType,
+			project.GrantMemberAddedType,
+			project.GrantMemberChangedType,
+			project.GrantMemberRemovedType,
+			project.GrantMemberCascadeRemovedType,
+			project.ProjectRemovedType,
+			project.GrantRemovedType,
+		},
+		usr_repo.AggregateType: {
+			usr_repo.UserRemovedType,
+		},
+	}, q.caches.invalidateMemberships)
+}
+
+// cachedInstance contains the fields of the [Instance] which can be encoded
+type cachedInstance struct {
+	ID             string
+	ChangeDate     time.Time
+	CreationDate   time.Time
+	Sequence       uint64
+	Name           string
+	DefaultOrgID   string
+	IAMProjectID   string
+	ConsoleID      string
+	ConsoleAppID   string
+	DefaultLang    string
+	Domains        []*InstanceDomain
+	CSPEnabled     bool
+	AllowedOrigins []string
+}
+
+func (c *caches) instanceByHost(domain string) *Instance {
+	if c == nil {
+		return nil
+	}
+	var instanceID string
+	if err := c.get(instanceHostCachePrefix+domain, &instanceID); err != nil {
+		return nil
+	}
+	cached := new(cachedInstance)
+	if err := c.get(instanceCachePrefix+instanceID, cached); err != nil {
+		return nil
+	}
+	return &Instance{
+		ID:           cached.ID,
+		ChangeDate:   cached.ChangeDate,
+		CreationDate: cached.CreationDate,
+		Sequence:     cached.Sequence,
+		Name:         cached.Name,
+		DefaultOrgID: cached.DefaultOrgID,
+		IAMProjectID: cached.IAMProjectID,
+		ConsoleID:    cached.ConsoleID,
+		ConsoleAppID: cached.ConsoleAppID,
+		DefaultLang:  language.Make(cached.DefaultLang),
+		Domains:      cached.Domains,
+		csp: csp{
+			enabled:        cached.CSPEnabled,
+			allowedOrigins: cached.AllowedOrigins,
+		},
+	}
+}
+
+func (c *caches) setInstance(domain string, instance *Instance) {
+	if c == nil {
+		return
+	}
+	c.set(instanceCachePrefix+instance.ID, &cachedInstance{
+		ID:             instance.ID,
+		ChangeDate:     instance.ChangeDate,
+		CreationDate:   instance.CreationDate,
+		Sequence:       instance.Sequence,
+		Name:           instance.Name,
+		DefaultOrgID:   instance.DefaultOrgID,
+		IAMProjectID:   instance.IAMProjectID,
+		ConsoleID:      instance.ConsoleID,
+		ConsoleAppID:   instance.ConsoleAppID,
+		DefaultLang:    instance.DefaultLang.String(),
+		Domains:        instance.Domains,
+		CSPEnabled:     instance.csp.enabled,
+		AllowedOrigins: instance.csp.allowedOrigins,
+	})
+	c.set(instanceHostCachePrefix+domain, &instance.ID)
+}
+
+func (c *caches) invalidateInstances(ctx context.Context, events []eventstore.Event) {
+	instanceIDs := make(map[string]bool)
+	for _, event := range events {
+		if removed, ok := event.(*iam_repo.DomainRemovedEvent); ok {
+			c.delete(instanceHostCachePrefix + removed.Domain)
+		}
+		if instanceIDs[event.Aggregate().InstanceID] {
+			continue
+		}
+		instanceIDs[event.Aggregate().InstanceID] = true
+		instanceCtx := authz.WithInstanceID(ctx, event.Aggregate().InstanceID)
+		triggerProjections(instanceCtx,
+			projection.InstanceProjection,
+			projection.InstanceDomainProjection,
+			projection.SecurityPolicyProjection,
+		)
+		c.delete(instanceCachePrefix + event.Aggregate().InstanceID)
+	}
+}
+
+type cachedMemberships struct {
+	Memberships []*Membership
+}
+
+// memberships returns the cached memberships of the user used to resolve the permissions on the organisation
+// and the key to cache the memberships if they are not cached yet.
+// The version of the memberships of the instance is part of the key,
+// so all memberships of an instance are invalidated by changing the version
+func (c *caches) memberships(instanceID, userID, orgID string) (_ []*Membership, key string, ok bool) {
+	if c == nil {
+		return nil, "", false
+	}
+	var version uint64
+	if err := c.get(membershipsVersionCachePrefix+instanceID, &version); err != nil && !errors.IsNotFound(err) {
+		return nil, "", false
+	}
+	key = membershipsCachePrefix + instanceID + ":" + strconv.FormatUint(version, 10) + ":" + userID + ":" + orgID
+	cached := new(cachedMemberships)
+	if err := c.get(key, cached); err != nil {
+		return nil, key, false
+	}
+	return cached.Memberships, key, true
+}
+
+func (c *caches) setMemberships(key string, memberships []*Membership) {
+	if c == nil || key == "" {
+		return
+	}
+	c.set(key, &cachedMemberships{Memberships: memberships})
+}
+
+func (c *caches) invalidateMemberships(ctx context.Context, events []eventstore.Event) {
+	versions := make(map[string]uint64)
+	instanceIDs := make([]string, 0)
+	for _, event := range events {
+		instanceID := event.Aggregate().InstanceID
+		if _, ok := versions[instanceID]; !ok {
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+		if event.Sequence() > versions[instanceID] {
+			versions[instanceID] = event.Sequence()
+		}
+	}
+	for _, instanceID := range instanceIDs {
+		triggerProjections(authz.WithInstanceID(ctx, instanceID),
+			projection.InstanceMemberProjection,
+			projection.OrgMemberProjection,
+			projection.ProjectMemberProjection,
+			projection.ProjectGrantMemberProjection,
+		)
+		version := versions[instanceID]
+		c.set(membershipsVersionCachePrefix+instanceID, &version)
+	}
+}
+
+type triggerer interface {
+	Trigger(ctx context.Context, instances ...string) context.Context
+}
+
+func triggerProjections(ctx context.Context, projections ...triggerer) {
+	for _, handler := range projections {
+		handler.Trigger(ctx)
+	}
+}
+
+func (c *caches) get(key string, ptrToObject interface{}) error {
+	err := c.cache.Get(key, ptrToObject)
+	logging.WithFields("key", key).OnError(err).Debug("cache miss")
+	return err
+}
+
+func (c *caches) set(key string, object interface{}) {
+	err := c.cache.Set(key, object)
+	logging.WithFields("key", key).OnError(err).Warn("unable to write to cache")
+}
+
+func (c *caches) delete(key string) {
+	err := c.cache.Delete(key)
+	logging.WithFields("key", key).OnError(err).Warn("unable to invalidate cache entry")
+}
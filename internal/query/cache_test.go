@@ -0,0 +1,82 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+)
+
+func newTestCaches(t *testing.T) *caches {
+	t.Helper()
+	c, err := (&bigcache.Config{MaxCacheSizeInMB: 1}).NewCache()
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	return &caches{cache: c}
+}
+
+func Test_caches_instanceByHost(t *testing.T) {
+	c := newTestCaches(t)
+	if instance := c.instanceByHost("zitadel.cloud"); instance != nil {
+		t.Fatalf("expected miss, got %v", instance)
+	}
+	want := &Instance{
+		ID:          "instance",
+		Name:        "ZITADEL",
+		DefaultLang: language.German,
+		Domains:     []*InstanceDomain{{Domain: "zitadel.cloud", InstanceID: "instance", IsPrimary: true}},
+		csp: csp{
+			enabled:        true,
+			allowedOrigins: []string{"zitadel.cloud"},
+		},
+	}
+	c.setInstance("zitadel.cloud", want)
+	got := c.instanceByHost("zitadel.cloud")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("instanceByHost() = %+v, want %+v", got, want)
+	}
+
+	c.delete(instanceCachePrefix + "instance")
+	if instance := c.instanceByHost("zitadel.cloud"); instance != nil {
+		t.Errorf("expected invalidated instance, got %v", instance)
+	}
+}
+
+func Test_caches_memberships(t *testing.T) {
+	c := newTestCaches(t)
+	_, key, ok := c.memberships("instance", "user", "org")
+	if ok {
+		t.Fatal("expected miss")
+	}
+	c.setMemberships(key, nil)
+	if memberships, _, ok := c.memberships("instance", "user", "org"); !ok || memberships != nil {
+		t.Errorf("expected cached empty memberships, got %v (%t)", memberships, ok)
+	}
+
+	want := []*Membership{{UserID: "user", Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org"}}}
+	c.setMemberships(key, want)
+	got, _, ok := c.memberships("instance", "user", "org")
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("memberships() = %v, want %v", got, want)
+	}
+
+	version := uint64(42)
+	c.set(membershipsVersionCachePrefix+"instance", &version)
+	if _, _, ok = c.memberships("instance", "user", "org"); ok {
+		t.Error("expected memberships to be invalidated by the version")
+	}
+}
+
+func Test_caches_disabled(t *testing.T) {
+	var c *caches
+	c.setInstance("zitadel.cloud", &Instance{ID: "instance"})
+	if instance := c.instanceByHost("zitadel.cloud"); instance != nil {
+		t.Errorf("expected no instance, got %v", instance)
+	}
+	if _, key, ok := c.memberships("instance", "user", "org"); ok || key != "" {
+		t.Errorf("expected no memberships, got key %q", key)
+	}
+}
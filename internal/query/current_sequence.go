@@ -12,6 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
@@ -116,6 +117,15 @@ func (q *Queries) ClearCurrentSequence(ctx context.Context, projectionName strin
 	return tx.Commit()
 }
 
+// RebuildProjection rebuilds the projection into shadow tables without interrupting it
+// and replaces the rows of the instances afterwards, see [crdb.StatementHandler.Rebuild]
+func (q *Queries) RebuildProjection(ctx context.Context, projectionName string, instanceIDs []string, progress func(*crdb.RebuildProgress)) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return projection.Rebuild(ctx, projectionName, instanceIDs, progress)
+}
+
 func (q *Queries) checkAndLock(ctx context.Context, projectionName string) error {
 	projectionQuery, args, err := sq.Select("count(*)").
 		From("[show tables from projections]").
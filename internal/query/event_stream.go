@@ -0,0 +1,159 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventStreamBatchSize = 100
+	// eventStreamPollInterval defines how often the eventstore is checked for new events
+	// which were not signalled by the subscription (e.g. pushed by another ZITADEL process)
+	eventStreamPollInterval = time.Second
+)
+
+// EventStreamFilter restricts the events delivered by [Queries.StreamEvents]
+type EventStreamFilter struct {
+	AggregateTypes []eventstore.AggregateType
+	EventTypes     []eventstore.EventType
+	ResourceOwner  string
+	// Sequence is the position to resume from, only events with a greater sequence are delivered
+	Sequence uint64
+}
+
+// StreamEvents delivers all events of the instance matching the filter to send, ordered by their sequence.
+// It first catches up from the requested sequence and then waits for new events,
+// which are either signalled by the in-process subscription or found by polling the eventstore.
+// Events of resource owners the caller is not allowed to read the events of are skipped.
+// It returns as soon as the context is done or send returns an error.
+func (q *Queries) StreamEvents(ctx context.Context, filter *EventStreamFilter, auditLogRetention time.Duration, send func(*Event) error) error {
+	queue := make(chan eventstore.Event, eventStreamBatchSize)
+	sub := eventstore.SubscribeEventTypes(queue, filter.subscribedTypes(q.eventstore.AggregateTypes()))
+	defer sub.Unsubscribe()
+	notified := make(chan struct{}, 1)
+	go signalEvents(queue, notified)
+
+	ticker := time.NewTicker(eventStreamPollInterval)
+	defer ticker.Stop()
+
+	sequence := filter.Sequence
+	for {
+		var (
+			hasMore bool
+			err     error
+		)
+		sequence, hasMore, err = q.sendEventBatch(ctx, filter, sequence, auditLogRetention, send)
+		if err != nil {
+			return err
+		}
+		if hasMore {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notified:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendEventBatch sends the next batch of events after the sequence
+// and returns the sequence of the last event and if there might be more events to send
+func (q *Queries) sendEventBatch(ctx context.Context, filter *EventStreamFilter, sequence uint64, auditLogRetention time.Duration, send func(*Event) error) (_ uint64, hasMore bool, err error) {
+	if ctx.Err() != nil {
+		return sequence, false, nil
+	}
+	events, err := q.eventstore.Filter(ctx, filter.searchQuery(authz.GetInstance(ctx).InstanceID(), sequence, retentionStart(auditLogRetention)))
+	if err != nil {
+		return sequence, false, err
+	}
+	if len(events) == 0 {
+		return sequence, false, nil
+	}
+	hasMore = len(events) == eventStreamBatchSize
+	sequence = events[len(events)-1].Sequence()
+	events = filterReadableEvents(events, q.eventPermissionCheck(ctx))
+	for _, event := range q.convertEvents(ctx, events) {
+		if err = send(event); err != nil {
+			return sequence, false, err
+		}
+	}
+	return sequence, hasMore, nil
+}
+
+// eventPermissionCheck checks the permission to read the events of a resource owner once per resource owner
+func (q *Queries) eventPermissionCheck(ctx context.Context) func(resourceOwner string) bool {
+	allowed := make(map[string]bool)
+	return func(resourceOwner string) bool {
+		ok, checked := allowed[resourceOwner]
+		if !checked {
+			ok = q.checkPermission(ctx, domain.PermissionEventsRead, resourceOwner, "") == nil
+			allowed[resourceOwner] = ok
+		}
+		return ok
+	}
+}
+
+func filterReadableEvents(events []eventstore.Event, canRead func(resourceOwner string) bool) []eventstore.Event {
+	readable := make([]eventstore.Event, 0, len(events))
+	for _, event := range events {
+		if canRead(event.Aggregate().ResourceOwner) {
+			readable = append(readable, event)
+		}
+	}
+	return readable
+}
+
+// signalEvents translates every received event into a non-blocking signal,
+// so the subscription never blocks the push of events
+func signalEvents(queue <-chan eventstore.Event, notified chan<- struct{}) {
+	for range queue {
+		select {
+		case notified <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func retentionStart(auditLogRetention time.Duration) time.Time {
+	if auditLogRetention == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-auditLogRetention)
+}
+
+func (f *EventStreamFilter) searchQuery(instanceID string, sequence uint64, creationDateAfter time.Time) *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		InstanceID(instanceID).
+		Limit(eventStreamBatchSize).
+		ResourceOwner(f.ResourceOwner).
+		AddQuery().
+		AggregateTypes(f.AggregateTypes...).
+		EventTypes(f.EventTypes...).
+		CreationDateAfter(creationDateAfter).
+		SequenceGreater(sequence).
+		Builder()
+}
+
+// subscribedTypes maps the filter to the types of the in-process subscription,
+// all known aggregates are subscribed if the filter is not restricted to certain aggregates
+func (f *EventStreamFilter) subscribedTypes(knownAggregateTypes []string) map[eventstore.AggregateType][]eventstore.EventType {
+	aggregateTypes := f.AggregateTypes
+	if len(aggregateTypes) == 0 {
+		aggregateTypes = make([]eventstore.AggregateType, len(knownAggregateTypes))
+		for i, aggregateType := range knownAggregateTypes {
+			aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+		}
+	}
+	types := make(map[eventstore.AggregateType][]eventstore.EventType, len(aggregateTypes))
+	for _, aggregateType := range aggregateTypes {
+		types[aggregateType] = f.EventTypes
+	}
+	return types
+}
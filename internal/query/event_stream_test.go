@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestEventStreamFilter_subscribedTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *EventStreamFilter
+		known  []string
+		want   map[eventstore.AggregateType][]eventstore.EventType
+	}{
+		{
+			name:   "no aggregate types, all known aggregates",
+			filter: &EventStreamFilter{},
+			known:  []string{"org", "user"},
+			want: map[eventstore.AggregateType][]eventstore.EventType{
+				"org":  nil,
+				"user": nil,
+			},
+		},
+		{
+			name: "aggregate and event types",
+			filter: &EventStreamFilter{
+				AggregateTypes: []eventstore.AggregateType{"user"},
+				EventTypes:     []eventstore.EventType{"user.human.added"},
+			},
+			known: []string{"org", "user"},
+			want: map[eventstore.AggregateType][]eventstore.EventType{
+				"user": {"user.human.added"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.subscribedTypes(tt.known))
+		})
+	}
+}
+
+func TestQueries_eventPermissionCheck(t *testing.T) {
+	checks := 0
+	q := &Queries{
+		checkPermission: func(_ context.Context, permission, orgID, _ string) error {
+			checks++
+			assert.Equal(t, domain.PermissionEventsRead, permission)
+			if orgID != "org1" {
+				return errors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+			}
+			return nil
+		},
+	}
+	ctx := context.Background()
+	events := []eventstore.Event{
+		org.NewOrgAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "org1"),
+		org.NewOrgAddedEvent(ctx, &org.NewAggregate("org2").Aggregate, "org2"),
+		org.NewOrgAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "renamed"),
+	}
+
+	readable := filterReadableEvents(events, q.eventPermissionCheck(ctx))
+
+	assert.Equal(t, []eventstore.Event{events[0], events[2]}, readable)
+	assert.Equal(t, 2, checks, "permission must be checked once per resource owner")
+}
@@ -204,10 +204,15 @@ func (q *Queries) InstanceByHost(ctx context.Context, host string) (_ authz.Inst
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	domain := strings.Split(host, ":")[0] //remove possible port
+	if instance := q.caches.instanceByHost(domain); instance != nil {
+		instance.host = host
+		return instance, nil
+	}
+
 	stmt, scan := prepareAuthzInstanceQuery(ctx, q.client, host)
-	host = strings.Split(host, ":")[0] //remove possible port
 	query, args, err := stmt.Where(sq.Eq{
-		InstanceDomainDomainCol.identifier(): host,
+		InstanceDomainDomainCol.identifier(): domain,
 	}).ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-SAfg2", "Errors.Query.SQLStatement")
@@ -217,7 +222,12 @@ func (q *Queries) InstanceByHost(ctx context.Context, host string) (_ authz.Inst
 	if err != nil {
 		return nil, err
 	}
-	return scan(row)
+	instance, err := scan(row)
+	if err != nil {
+		return nil, err
+	}
+	q.caches.setInstance(domain, instance)
+	return instance, nil
 }
 
 func (q *Queries) InstanceByID(ctx context.Context) (_ authz.Instance, err error) {
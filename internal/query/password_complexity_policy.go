@@ -30,6 +30,8 @@ type PasswordComplexityPolicy struct {
 	HasNumber    bool
 	HasSymbol    bool
 
+	DenyBreachedPasswords bool
+
 	IsDefault bool
 }
 
@@ -135,6 +137,10 @@ var (
 		name:  projection.ComplexityPolicyHasSymbolCol,
 		table: passwordComplexityTable,
 	}
+	PasswordComplexityColDenyBreachedPasswords = Column{
+		name:  projection.ComplexityPolicyDenyBreachedCol,
+		table: passwordComplexityTable,
+	}
 	PasswordComplexityColIsDefault = Column{
 		name:  projection.ComplexityPolicyIsDefaultCol,
 		table: passwordComplexityTable,
@@ -161,6 +167,7 @@ func preparePasswordComplexityPolicyQuery(ctx context.Context, db prepareDatabas
 			PasswordComplexityColHasUpperCase.identifier(),
 			PasswordComplexityColHasNumber.identifier(),
 			PasswordComplexityColHasSymbol.identifier(),
+			PasswordComplexityColDenyBreachedPasswords.identifier(),
 			PasswordComplexityColIsDefault.identifier(),
 			PasswordComplexityColState.identifier(),
 		).
@@ -179,6 +186,7 @@ func preparePasswordComplexityPolicyQuery(ctx context.Context, db prepareDatabas
 				&policy.HasUppercase,
 				&policy.HasNumber,
 				&policy.HasSymbol,
+				&policy.DenyBreachedPasswords,
 				&policy.IsDefault,
 				&policy.State,
 			)
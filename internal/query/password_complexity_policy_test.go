@@ -13,19 +13,20 @@ import (
 )
 
 var (
-	preparePasswordComplexityPolicyStmt = `SELECT projections.password_complexity_policies2.id,` +
-		` projections.password_complexity_policies2.sequence,` +
-		` projections.password_complexity_policies2.creation_date,` +
-		` projections.password_complexity_policies2.change_date,` +
-		` projections.password_complexity_policies2.resource_owner,` +
-		` projections.password_complexity_policies2.min_length,` +
-		` projections.password_complexity_policies2.has_lowercase,` +
-		` projections.password_complexity_policies2.has_uppercase,` +
-		` projections.password_complexity_policies2.has_number,` +
-		` projections.password_complexity_policies2.has_symbol,` +
-		` projections.password_complexity_policies2.is_default,` +
-		` projections.password_complexity_policies2.state` +
-		` FROM projections.password_complexity_policies2` +
+	preparePasswordComplexityPolicyStmt = `SELECT projections.password_complexity_policies3.id,` +
+		` projections.password_complexity_policies3.sequence,` +
+		` projections.password_complexity_policies3.creation_date,` +
+		` projections.password_complexity_policies3.change_date,` +
+		` projections.password_complexity_policies3.resource_owner,` +
+		` projections.password_complexity_policies3.min_length,` +
+		` projections.password_complexity_policies3.has_lowercase,` +
+		` projections.password_complexity_policies3.has_uppercase,` +
+		` projections.password_complexity_policies3.has_number,` +
+		` projections.password_complexity_policies3.has_symbol,` +
+		` projections.password_complexity_policies3.deny_breached_passwords,` +
+		` projections.password_complexity_policies3.is_default,` +
+		` projections.password_complexity_policies3.state` +
+		` FROM projections.password_complexity_policies3` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	preparePasswordComplexityPolicyCols = []string{
 		"id",
@@ -38,6 +39,7 @@ var (
 		"has_uppercase",
 		"has_number",
 		"has_symbol",
+		"deny_breached_passwords",
 		"is_default",
 		"state",
 	}
@@ -91,6 +93,7 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						true,
 						domain.PolicyStateActive,
 					},
 				),
@@ -108,6 +111,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 				HasNumber:     true,
 				HasSymbol:     true,
 				IsDefault:     true,
+
+				DenyBreachedPasswords: true,
 			},
 		},
 		{
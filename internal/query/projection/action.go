@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	ActionTable            = "projections.actions3"
+	ActionTable            = "projections.actions4"
 	ActionIDCol            = "id"
 	ActionCreationDateCol  = "creation_date"
 	ActionChangeDateCol    = "change_date"
@@ -27,6 +27,9 @@ const (
 	ActionTimeoutCol       = "timeout"
 	ActionAllowedToFailCol = "allowed_to_fail"
 	ActionOwnerRemovedCol  = "owner_removed"
+	ActionTargetTypeCol    = "target_type"
+	ActionTargetURLCol     = "target_url"
+	ActionSigningKeyCol    = "signing_key"
 )
 
 type actionProjection struct {
@@ -51,6 +54,9 @@ func newActionProjection(ctx context.Context, config crdb.StatementHandlerConfig
 			crdb.NewColumn(ActionTimeoutCol, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(ActionAllowedToFailCol, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(ActionOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(ActionTargetTypeCol, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(ActionTargetURLCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(ActionSigningKeyCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
 		},
 			crdb.NewPrimaryKey(ActionInstanceIDCol, ActionIDCol),
 			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{ActionResourceOwnerCol})),
@@ -128,6 +134,9 @@ func (p *actionProjection) reduceActionAdded(event eventstore.Event) (*handler.S
 			handler.NewCol(ActionTimeoutCol, e.Timeout),
 			handler.NewCol(ActionAllowedToFailCol, e.AllowedToFail),
 			handler.NewCol(ActionStateCol, domain.ActionStateActive),
+			handler.NewCol(ActionTargetTypeCol, e.TargetType),
+			handler.NewCol(ActionTargetURLCol, e.TargetURL),
+			handler.NewCol(ActionSigningKeyCol, e.SigningKey),
 		},
 	), nil
 }
@@ -153,6 +162,15 @@ func (p *actionProjection) reduceActionChanged(event eventstore.Event) (*handler
 	if e.AllowedToFail != nil {
 		values = append(values, handler.NewCol(ActionAllowedToFailCol, *e.AllowedToFail))
 	}
+	if e.TargetType != nil {
+		values = append(values, handler.NewCol(ActionTargetTypeCol, *e.TargetType))
+	}
+	if e.TargetURL != nil {
+		values = append(values, handler.NewCol(ActionTargetURLCol, *e.TargetURL))
+	}
+	if e.SigningKey != nil {
+		values = append(values, handler.NewCol(ActionSigningKeyCol, e.SigningKey))
+	}
 	return crdb.NewUpdateStatement(
 		e,
 		values,
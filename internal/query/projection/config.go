@@ -12,6 +12,11 @@ type Config struct {
 	BulkLimit             uint64
 	Customizations        map[string]CustomConfig
 	HandleActiveInstances time.Duration
+	// EventNotifications triggers the projections as soon as the database signals inserted events,
+	// so the events pushed by other ZITADEL processes are reduced without waiting for RequeueEvery.
+	// The events pushed by this process are notified as well, they are already reduced by the subscription,
+	// so the additional trigger only checks for newer events
+	EventNotifications bool
 }
 
 type CustomConfig struct {
@@ -47,6 +47,7 @@ func newInstanceProjection(ctx context.Context, config crdb.StatementHandlerConf
 			crdb.NewColumn(InstanceColumnDefaultLanguage, crdb.ColumnTypeText, crdb.Default("")),
 		},
 			crdb.NewPrimaryKey(InstanceColumnID),
+			crdb.WithInstanceColumn(InstanceColumnID),
 		),
 	)
 	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
@@ -65,9 +66,13 @@ var (
 	NotificationsProjection             interface{}
 	NotificationsQuotaProjection        interface{}
 	TelemetryPusherProjection           interface{}
+	BackChannelLogoutProjection         interface{}
 	DeviceAuthProjection                *deviceAuthProjection
 	SessionProjection                   *sessionProjection
 	MilestoneProjection                 *milestoneProjection
+	WebhookProjection                   *webhookProjection
+	WebhookNotificationsProjection      interface{}
+	ActionNotificationsProjection       interface{}
 )
 
 type projection interface {
@@ -146,6 +151,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
 	MilestoneProjection = newMilestoneProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["milestones"]))
+	WebhookProjection = newWebhookProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["webhooks"]))
 	newProjectionsList()
 	return nil
 }
@@ -165,6 +171,31 @@ func Start() {
 	}
 }
 
+// ListenEventNotifications triggers the started projections for the events inserted by other ZITADEL processes
+// as soon as the database notifies about them until the context is done
+func ListenEventNotifications(ctx context.Context, client *database.DB) {
+	handler.ListenNotifications(ctx, crdb.NewNotificationListener(client))
+}
+
+type rebuildableProjection interface {
+	Name() string
+	Rebuild(ctx context.Context, instanceIDs []string, progress func(*crdb.RebuildProgress)) error
+}
+
+// Rebuild rebuilds the projection with the given name (with or without the schema) for the passed instances or all instances if none are passed
+func Rebuild(ctx context.Context, projectionName string, instanceIDs []string, progress func(*crdb.RebuildProgress)) error {
+	for _, p := range projections {
+		rebuildable, ok := p.(rebuildableProjection)
+		if !ok {
+			continue
+		}
+		if name := rebuildable.Name(); name == projectionName || name == "projections."+projectionName {
+			return rebuildable.Rebuild(ctx, instanceIDs, progress)
+		}
+	}
+	return errors.ThrowNotFound(nil, "HANDL-Rb2aP", "Errors.ProjectionName.Invalid")
+}
+
 func ApplyCustomConfig(customConfig CustomConfig) crdb.StatementHandlerConfig {
 	return applyCustomConfig(projectionConfig, customConfig)
 }
@@ -194,7 +225,7 @@ func applyCustomConfig(config crdb.StatementHandlerConfig, customConfig CustomCo
 // as setup and start currently create them individually, we make sure we get the right one
 // will be refactored when changing to new id based projections
 //
-// Event handlers NotificationsProjection, NotificationsQuotaProjection and NotificationsProjection are not added here, because they do not reduce to database statements
+// Event handlers NotificationsProjection, NotificationsQuotaProjection, BackChannelLogoutProjection, WebhookNotificationsProjection, ActionNotificationsProjection and NotificationsProjection are not added here, because they do not reduce to database statements
 func newProjectionsList() {
 	projections = []projection{
 		OrgProjection,
@@ -244,5 +275,6 @@ func newProjectionsList() {
 		DeviceAuthProjection,
 		SessionProjection,
 		MilestoneProjection,
+		WebhookProjection,
 	}
 }
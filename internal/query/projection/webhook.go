@@ -0,0 +1,268 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+const (
+	WebhookTable            = "projections.webhooks"
+	WebhookIDCol            = "id"
+	WebhookCreationDateCol  = "creation_date"
+	WebhookChangeDateCol    = "change_date"
+	WebhookResourceOwnerCol = "resource_owner"
+	WebhookInstanceIDCol    = "instance_id"
+	WebhookStateCol         = "state"
+	WebhookSequenceCol      = "sequence"
+	WebhookNameCol          = "name"
+	WebhookURLCol           = "url"
+	WebhookEventTypesCol    = "event_types"
+	WebhookSigningKeyCol    = "signing_key"
+
+	webhookDeadLetterTableSuffix        = "dead_letters"
+	WebhookDeadLetterTable              = WebhookTable + "_" + webhookDeadLetterTableSuffix
+	WebhookDeadLetterWebhookIDCol       = "webhook_id"
+	WebhookDeadLetterInstanceIDCol      = "instance_id"
+	WebhookDeadLetterResourceOwnerCol   = "resource_owner"
+	WebhookDeadLetterChangeDateCol      = "change_date"
+	WebhookDeadLetterSequenceCol        = "sequence"
+	WebhookDeadLetterEventSequenceCol   = "event_sequence"
+	WebhookDeadLetterEventTypeCol       = "event_type"
+	WebhookDeadLetterAggregateTypeCol   = "aggregate_type"
+	WebhookDeadLetterAggregateIDCol     = "aggregate_id"
+	WebhookDeadLetterEventResourceOwner = "event_resource_owner"
+	WebhookDeadLetterAttemptsCol        = "attempts"
+	WebhookDeadLetterReasonCol          = "reason"
+)
+
+type webhookProjection struct {
+	crdb.StatementHandler
+}
+
+func newWebhookProjection(ctx context.Context, config crdb.StatementHandlerConfig) *webhookProjection {
+	p := new(webhookProjection)
+	config.ProjectionName = WebhookTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(WebhookIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(WebhookSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(WebhookNameCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookURLCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookEventTypesCol, crdb.ColumnTypeTextArray),
+			crdb.NewColumn(WebhookSigningKeyCol, crdb.ColumnTypeJSONB),
+		},
+			crdb.NewPrimaryKey(WebhookInstanceIDCol, WebhookIDCol),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{WebhookResourceOwnerCol})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(WebhookDeadLetterWebhookIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookDeadLetterSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(WebhookDeadLetterEventSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(WebhookDeadLetterEventTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterAggregateTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterEventResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeadLetterAttemptsCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(WebhookDeadLetterReasonCol, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(WebhookDeadLetterInstanceIDCol, WebhookDeadLetterWebhookIDCol, WebhookDeadLetterEventSequenceCol),
+			webhookDeadLetterTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("webhook", []string{WebhookDeadLetterInstanceIDCol, WebhookDeadLetterWebhookIDCol}, []string{WebhookInstanceIDCol, WebhookIDCol})),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{WebhookDeadLetterResourceOwnerCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *webhookProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: webhook.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  webhook.AddedEventType,
+					Reduce: p.reduceWebhookAdded,
+				},
+				{
+					Event:  webhook.ChangedEventType,
+					Reduce: p.reduceWebhookChanged,
+				},
+				{
+					Event:  webhook.RemovedEventType,
+					Reduce: p.reduceWebhookRemoved,
+				},
+				{
+					Event:  webhook.DeliveryFailedEventType,
+					Reduce: p.reduceDeliveryFailed,
+				},
+				{
+					Event:  webhook.DeliverySucceededEventType,
+					Reduce: p.reduceDeliverySucceeded,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(WebhookInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *webhookProjection) reduceWebhookAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ohGh3", "reduce.wrong.event.type %s", webhook.AddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCol(WebhookCreationDateCol, e.CreationDate()),
+			handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(WebhookSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookStateCol, domain.WebhookStateActive),
+			handler.NewCol(WebhookNameCol, e.Name),
+			handler.NewCol(WebhookURLCol, e.URL),
+			handler.NewCol(WebhookEventTypesCol, database.StringArray(e.EventTypes)),
+			handler.NewCol(WebhookSigningKeyCol, e.SigningKey),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.ChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Yoh4e", "reduce.wrong.event.type %s", webhook.ChangedEventType)
+	}
+	values := []handler.Column{
+		handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+		handler.NewCol(WebhookSequenceCol, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(WebhookNameCol, *e.Name))
+	}
+	if e.URL != nil {
+		values = append(values, handler.NewCol(WebhookURLCol, *e.URL))
+	}
+	if e.EventTypes != nil {
+		values = append(values, handler.NewCol(WebhookEventTypesCol, database.StringArray(*e.EventTypes)))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ahph7", "reduce.wrong.event.type %s", webhook.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceDeliveryFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.DeliveryFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eik5a", "reduce.wrong.event.type %s", webhook.DeliveryFailedEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookDeadLetterInstanceIDCol, nil),
+			handler.NewCol(WebhookDeadLetterWebhookIDCol, nil),
+			handler.NewCol(WebhookDeadLetterEventSequenceCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(WebhookDeadLetterInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(WebhookDeadLetterWebhookIDCol, e.Aggregate().ID),
+			handler.NewCol(WebhookDeadLetterEventSequenceCol, e.EventSequence),
+			handler.NewCol(WebhookDeadLetterResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(WebhookDeadLetterChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookDeadLetterSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookDeadLetterEventTypeCol, e.DeliveredEventType),
+			handler.NewCol(WebhookDeadLetterAggregateTypeCol, e.AggregateType),
+			handler.NewCol(WebhookDeadLetterAggregateIDCol, e.AggregateID),
+			handler.NewCol(WebhookDeadLetterEventResourceOwner, e.EventResourceOwner),
+			handler.NewCol(WebhookDeadLetterAttemptsCol, e.Attempts),
+			handler.NewCol(WebhookDeadLetterReasonCol, e.Reason),
+		},
+		crdb.WithTableSuffix(webhookDeadLetterTableSuffix),
+	), nil
+}
+
+func (p *webhookProjection) reduceDeliverySucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.DeliverySucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gee0i", "reduce.wrong.event.type %s", webhook.DeliverySucceededEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(WebhookDeadLetterInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(WebhookDeadLetterWebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookDeadLetterEventSequenceCol, e.EventSequence),
+		},
+		crdb.WithTableSuffix(webhookDeadLetterTableSuffix),
+	), nil
+}
+
+// reduceOwnerRemoved removes the webhooks of the organization, the dead letters are removed by the foreign key
+func (p *webhookProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hah2u", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(WebhookResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
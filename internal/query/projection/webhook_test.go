@@ -0,0 +1,253 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+func TestWebhookProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceWebhookAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.AddedEventType),
+					webhook.AggregateType,
+					[]byte(`{"name": "name", "url": "https://example.com/hook", "eventTypes": ["user.locked"], "signingKey": {"cryptoType": 0, "algorithm": "enc", "keyId": "key", "crypted": "Y3J5cHRlZA=="}}`),
+				), webhook.AddedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.webhooks (id, creation_date, change_date, resource_owner, instance_id, sequence, state, name, url, event_types, signing_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								domain.WebhookStateActive,
+								"name",
+								"https://example.com/hook",
+								database.StringArray{"user.locked"},
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.ChangedEventType),
+					webhook.AggregateType,
+					[]byte(`{"url": "https://example.com/hook2", "eventTypes": ["user.locked", "user.unlocked"]}`),
+				), webhook.ChangedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.webhooks SET (change_date, sequence, url, event_types) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"https://example.com/hook2",
+								database.StringArray{"user.locked", "user.unlocked"},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.RemovedEventType),
+					webhook.AggregateType,
+					nil,
+				), webhook.RemovedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webhooks WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.DeliveryFailedEventType),
+					webhook.AggregateType,
+					[]byte(`{"eventSequence": 12, "eventType": "user.locked", "aggregateType": "user", "aggregateId": "user-id", "eventResourceOwner": "org-id", "attempts": 3, "reason": "503 Service Unavailable"}`),
+				), webhook.DeliveryFailedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceDeliveryFailed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.webhooks_dead_letters (instance_id, webhook_id, event_sequence, resource_owner, change_date, sequence, event_type, aggregate_type, aggregate_id, event_resource_owner, attempts, reason) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, webhook_id, event_sequence) DO UPDATE SET (resource_owner, change_date, sequence, event_type, aggregate_type, aggregate_id, event_resource_owner, attempts, reason) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.event_type, EXCLUDED.aggregate_type, EXCLUDED.aggregate_id, EXCLUDED.event_resource_owner, EXCLUDED.attempts, EXCLUDED.reason)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(12),
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								eventstore.EventType("user.locked"),
+								eventstore.AggregateType("user"),
+								"user-id",
+								"org-id",
+								uint64(3),
+								"503 Service Unavailable",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliverySucceeded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.DeliverySucceededEventType),
+					webhook.AggregateType,
+					[]byte(`{"eventSequence": 12, "eventType": "user.locked", "aggregateType": "user", "aggregateId": "user-id", "eventResourceOwner": "org-id", "attempts": 1}`),
+				), webhook.DeliverySucceededEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceDeliverySucceeded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webhooks_dead_letters WHERE (instance_id = $1) AND (webhook_id = $2) AND (event_sequence = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(12),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webhooks WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(WebhookInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webhooks WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, WebhookTable, tt.want)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
 	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
@@ -21,16 +22,19 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/milestone"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
 )
 
 type Queries struct {
 	eventstore *eventstore.Eventstore
 	client     *database.DB
+	caches     *caches
 
 	idpConfigEncryption  crypto.EncryptionAlgorithm
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error)
@@ -57,6 +61,7 @@ func StartQueries(
 	zitadelRoles []authz.RoleMapping,
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error),
 	permissionCheck func(q *Queries) domain.PermissionCheck,
+	lookupCache cache.Cache,
 ) (repo *Queries, err error) {
 	statikLoginFS, err := fs.NewWithNamespace("login")
 	if err != nil {
@@ -79,15 +84,7 @@ func StartQueries(
 		zitadelRoles:                        zitadelRoles,
 		sessionTokenVerifier:                sessionTokenVerifier,
 	}
-	iam_repo.RegisterEventMappers(repo.eventstore)
-	usr_repo.RegisterEventMappers(repo.eventstore)
-	org.RegisterEventMappers(repo.eventstore)
-	project.RegisterEventMappers(repo.eventstore)
-	action.RegisterEventMappers(repo.eventstore)
-	keypair.RegisterEventMappers(repo.eventstore)
-	usergrant.RegisterEventMappers(repo.eventstore)
-	session.RegisterEventMappers(repo.eventstore)
-	idpintent.RegisterEventMappers(repo.eventstore)
+	RegisterEventMappers(repo.eventstore)
 
 	repo.idpConfigEncryption = idpConfigEncryption
 	repo.multifactors = domain.MultifactorConfigs{
@@ -104,10 +101,29 @@ func StartQueries(
 		return nil, err
 	}
 	projection.Start()
+	if projections.EventNotifications {
+		projection.ListenEventNotifications(ctx, sqlClient)
+	}
+	repo.startCaches(ctx, lookupCache)
 
 	return repo, nil
 }
 
+// RegisterEventMappers registers the mappers of all events reduced by the projections
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	iam_repo.RegisterEventMappers(es)
+	usr_repo.RegisterEventMappers(es)
+	org.RegisterEventMappers(es)
+	project.RegisterEventMappers(es)
+	action.RegisterEventMappers(es)
+	keypair.RegisterEventMappers(es)
+	usergrant.RegisterEventMappers(es)
+	session.RegisterEventMappers(es)
+	idpintent.RegisterEventMappers(es)
+	milestone.RegisterEventMappers(es)
+	webhook.RegisterEventMappers(es)
+}
+
 func (q *Queries) Health(ctx context.Context) error {
 	return q.client.Ping()
 }
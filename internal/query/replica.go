@@ -0,0 +1,133 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// replicaClient returns the client to query the projections from.
+// The primary is used if the projections were triggered during the call,
+// because the triggered events are only reduced on the primary.
+// A read replica is used if configured, except if read your writes is enabled
+// and the replica didn't reduce the events pushed by this process into the projections yet.
+func (q *Queries) replicaClient(ctx context.Context, triggered bool, projections ...table) *sql.DB {
+	if triggered || !q.client.HasReplicas() {
+		return q.client.DB
+	}
+	replica := q.client.Replica()
+	if !q.client.ReadYourWrites() {
+		return replica
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	pushed := q.eventstore.PushedSequences(instanceID)
+	if len(pushed) == 0 {
+		return replica
+	}
+	behind, err := replicaBehind(ctx, q.client.DB, replica, instanceID, pushed, projections)
+	if err != nil {
+		logging.WithError(err).Warn("unable to check sequences of replica, primary is queried")
+		return q.client.DB
+	}
+	if behind {
+		return q.client.DB
+	}
+	return replica
+}
+
+type projectionAggregate struct {
+	projection    string
+	aggregateType string
+}
+
+// replicaBehind checks if the current sequence of any of the projections on the replica
+// is lower than the sequence pushed for its aggregate type.
+// Current sequences missing on the replica or lower than the pushed sequence are compared with the primary,
+// the replica is only behind if the primary reduced more events of the aggregate type.
+// If the primary has no current sequence either, the projection doesn't reduce the aggregate type.
+func replicaBehind(ctx context.Context, primary, replica *sql.DB, instanceID string, pushed map[eventstore.AggregateType]uint64, projections []table) (bool, error) {
+	names := make([]string, len(projections))
+	for i, projection := range projections {
+		names[i] = projection.name
+	}
+	aggregateTypes := make([]string, 0, len(pushed))
+	for aggregateType := range pushed {
+		aggregateTypes = append(aggregateTypes, string(aggregateType))
+	}
+	sort.Strings(aggregateTypes)
+
+	replicaSequences, err := projectionSequences(ctx, replica, instanceID, names, aggregateTypes)
+	if err != nil {
+		return false, err
+	}
+	outdated := make([]projectionAggregate, 0, len(names)*len(aggregateTypes))
+	for _, name := range names {
+		for _, aggregateType := range aggregateTypes {
+			key := projectionAggregate{projection: name, aggregateType: aggregateType}
+			if replicaSequences[key] < pushed[eventstore.AggregateType(aggregateType)] {
+				outdated = append(outdated, key)
+			}
+		}
+	}
+	if len(outdated) == 0 {
+		return false, nil
+	}
+	primarySequences, err := projectionSequences(ctx, primary, instanceID, names, aggregateTypes)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range outdated {
+		if replicaSequences[key] < primarySequences[key] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// projectionSequences returns the current sequences of the projections per aggregate type
+func projectionSequences(ctx context.Context, client *sql.DB, instanceID string, projections, aggregateTypes []string) (map[projectionAggregate]uint64, error) {
+	stmt, args, err := sq.Select(
+		CurrentSequenceColProjectionName.identifier(),
+		CurrentSequenceColAggregateType.identifier(),
+		CurrentSequenceColCurrentSequence.identifier(),
+	).
+		From(currentSequencesTable.identifier()).
+		Where(sq.Eq{
+			CurrentSequenceColProjectionName.identifier(): projections,
+			CurrentSequenceColInstanceID.identifier():     instanceID,
+			CurrentSequenceColAggregateType.identifier():  aggregateTypes,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rpl1s", "Errors.Query.SQLStatement")
+	}
+	rows, err := client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rpl2c", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	sequences := make(map[projectionAggregate]uint64, len(projections)*len(aggregateTypes))
+	for rows.Next() {
+		var (
+			key      projectionAggregate
+			sequence uint64
+		)
+		if err = rows.Scan(&key.projection, &key.aggregateType, &sequence); err != nil {
+			return nil, errors.ThrowInternal(err, "QUERY-Rpl3s", "Errors.Internal")
+		}
+		sequences[key] = sequence
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rpl4r", "Errors.Internal")
+	}
+	return sequences, nil
+}
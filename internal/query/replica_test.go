@@ -0,0 +1,144 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type currentSequenceRow struct {
+	projection    string
+	aggregateType string
+	sequence      uint64
+}
+
+func Test_replicaBehind(t *testing.T) {
+	stmt := regexp.QuoteMeta(`SELECT projections.current_sequences.projection_name, projections.current_sequences.aggregate_type, projections.current_sequences.current_sequence` +
+		` FROM projections.current_sequences` +
+		` WHERE projections.current_sequences.aggregate_type IN ($1,$2) AND projections.current_sequences.instance_id = $3` +
+		` AND projections.current_sequences.projection_name IN ($4,$5)`)
+	tests := []struct {
+		name        string
+		replicaRows []currentSequenceRow
+		primaryRows []currentSequenceRow
+		want        bool
+	}{
+		{
+			name: "up to date",
+			replicaRows: []currentSequenceRow{
+				{userTable.name, "user", 5},
+				{userTable.name, "org", 3},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			want: false,
+		},
+		{
+			name: "behind",
+			replicaRows: []currentSequenceRow{
+				{userTable.name, "user", 4},
+				{userTable.name, "org", 3},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			primaryRows: []currentSequenceRow{
+				{userTable.name, "user", 5},
+				{userTable.name, "org", 3},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			want: true,
+		},
+		{
+			name: "current sequence missing on replica, behind",
+			replicaRows: []currentSequenceRow{
+				{userTable.name, "user", 5},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			primaryRows: []currentSequenceRow{
+				{userTable.name, "user", 5},
+				{userTable.name, "org", 3},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			want: true,
+		},
+		{
+			name: "aggregate type not reduced by projection",
+			replicaRows: []currentSequenceRow{
+				{userTable.name, "user", 5},
+				{loginNameTable.name, "user", 6},
+			},
+			primaryRows: []currentSequenceRow{
+				{userTable.name, "user", 5},
+				{loginNameTable.name, "user", 6},
+			},
+			want: false,
+		},
+		{
+			name: "primary not reduced either",
+			replicaRows: []currentSequenceRow{
+				{userTable.name, "user", 4},
+				{userTable.name, "org", 3},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			primaryRows: []currentSequenceRow{
+				{userTable.name, "user", 4},
+				{userTable.name, "org", 3},
+				{loginNameTable.name, "user", 6},
+				{loginNameTable.name, "org", 4},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replica, replicaMock := mockCurrentSequences(t, stmt, tt.replicaRows)
+			primary, primaryMock := mockCurrentSequences(t, stmt, tt.primaryRows)
+
+			got, err := replicaBehind(context.Background(), primary, replica, "instance",
+				map[eventstore.AggregateType]uint64{"user": 5, "org": 3},
+				[]table{userTable, loginNameTable},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("replicaBehind() = %v, want %v", got, tt.want)
+			}
+			if err = replicaMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if err = primaryMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// mockCurrentSequences expects the query of the current sequences if rows are passed
+func mockCurrentSequences(t *testing.T, stmt string, rows []currentSequenceRow) (*sql.DB, sqlmock.Sqlmock) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to mock db: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if rows == nil {
+		return client, mock
+	}
+	result := sqlmock.NewRows([]string{"projection_name", "aggregate_type", "current_sequence"})
+	for _, row := range rows {
+		result.AddRow(row.projection, row.aggregateType, row.sequence)
+	}
+	mock.ExpectQuery(stmt).
+		WithArgs("org", "user", "instance", userTable.name, loginNameTable.name).
+		WillReturnRows(result)
+	return client, mock
+}
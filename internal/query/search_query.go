@@ -110,6 +110,67 @@ func (q *orQuery) comp() sq.Sqlizer {
 	return or
 }
 
+// NewOrQuery combines the queries with a logical or
+func NewOrQuery(queries ...SearchQuery) (SearchQuery, error) {
+	return newOrQuery(queries...)
+}
+
+type andQuery struct {
+	queries []SearchQuery
+}
+
+// NewAndQuery combines the queries with a logical and
+func NewAndQuery(queries ...SearchQuery) (SearchQuery, error) {
+	if len(queries) == 0 {
+		return nil, ErrMissingColumn
+	}
+	return &andQuery{queries: queries}, nil
+}
+
+func (q *andQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *andQuery) comp() sq.Sqlizer {
+	and := make(sq.And, len(q.queries))
+	for i, query := range q.queries {
+		and[i] = query.comp()
+	}
+	return and
+}
+
+type notQuery struct {
+	query SearchQuery
+}
+
+// NewNotQuery negates the query
+func NewNotQuery(query SearchQuery) (SearchQuery, error) {
+	if query == nil {
+		return nil, ErrMissingColumn
+	}
+	return &notQuery{query: query}, nil
+}
+
+func (q *notQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *notQuery) comp() sq.Sqlizer {
+	return notSqlizer{q.query.comp()}
+}
+
+type notSqlizer struct {
+	sq.Sqlizer
+}
+
+func (n notSqlizer) ToSql() (string, []interface{}, error) {
+	query, args, err := n.Sqlizer.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return "NOT (" + query + ")", args, nil
+}
+
 type ColumnComparisonQuery struct {
 	Column1 Column
 	Compare ColumnComparison
@@ -207,6 +268,8 @@ func (s *TextQuery) comp() sq.Sqlizer {
 		return sq.ILike{s.Column.identifier(): "%" + s.Text + "%"}
 	case TextListContains:
 		return &listContains{col: s.Column, args: []interface{}{s.Text}}
+	case TextNotEquals:
+		return sq.NotEq{s.Column.identifier(): s.Text}
 	}
 	return nil
 }
@@ -974,6 +974,17 @@ func TestTextQuery_comp(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "not equals",
+			fields: fields{
+				Column:  testCol,
+				Text:    "Hurst",
+				Compare: TextNotEquals,
+			},
+			want: want{
+				query: sq.NotEq{"test_table.test_col": "Hurst"},
+			},
+		},
 		{
 			name: "too high comparison",
 			fields: fields{
@@ -1018,6 +1029,75 @@ func TestTextQuery_comp(t *testing.T) {
 	}
 }
 
+func TestLogicalQueries_comp(t *testing.T) {
+	equals, err := NewTextQuery(testCol, "Hurst", TextEquals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startsWith, err := NewTextQuery(testCol, "Hu", TextStartsWith)
+	if err != nil {
+		t.Fatal(err)
+	}
+	or, err := NewOrQuery(equals, startsWith)
+	if err != nil {
+		t.Fatal(err)
+	}
+	and, err := NewAndQuery(or, equals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	not, err := NewNotQuery(and)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		query     SearchQuery
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "or",
+			query:     or,
+			wantQuery: "(test_table.test_col = ? OR test_table.test_col LIKE ?)",
+			wantArgs:  []interface{}{"Hurst", "Hu%"},
+		},
+		{
+			name:      "and",
+			query:     and,
+			wantQuery: "((test_table.test_col = ? OR test_table.test_col LIKE ?) AND test_table.test_col = ?)",
+			wantArgs:  []interface{}{"Hurst", "Hu%", "Hurst"},
+		},
+		{
+			name:      "not",
+			query:     not,
+			wantQuery: "NOT (((test_table.test_col = ? OR test_table.test_col LIKE ?) AND test_table.test_col = ?))",
+			wantArgs:  []interface{}{"Hurst", "Hu%", "Hurst"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.comp().ToSql()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("wrong query: want: %q, got: %q", tt.wantQuery, query)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("wrong args: want: %v, got: %v", tt.wantArgs, args)
+			}
+		})
+	}
+
+	if _, err := NewAndQuery(); err == nil {
+		t.Error("expected error for empty and query")
+	}
+	if _, err := NewNotQuery(nil); err == nil {
+		t.Error("expected error for empty not query")
+	}
+}
+
 func TestTextComparisonFromMethod(t *testing.T) {
 	type args struct {
 		m domain.SearchMethod
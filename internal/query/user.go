@@ -358,7 +358,7 @@ func (q *Queries) GetUserByID(ctx context.Context, shouldTriggerBulk bool, userI
 		return nil, errors.ThrowInternal(err, "QUERY-FBg21", "Errors.Query.SQLStatment")
 	}
 
-	row := q.client.QueryRowContext(ctx, stmt, args...)
+	row := q.replicaClient(ctx, shouldTriggerBulk, userTable, loginNameTable).QueryRowContext(ctx, stmt, args...)
 	return scan(row)
 }
 
@@ -386,7 +386,7 @@ func (q *Queries) GetUser(ctx context.Context, shouldTriggerBulk bool, withOwner
 		return nil, errors.ThrowInternal(err, "QUERY-Dnhr2", "Errors.Query.SQLStatment")
 	}
 
-	row := q.client.QueryRowContext(ctx, stmt, args...)
+	row := q.replicaClient(ctx, shouldTriggerBulk, userTable, loginNameTable).QueryRowContext(ctx, stmt, args...)
 	return scan(row)
 }
 
@@ -534,7 +534,7 @@ func (q *Queries) SearchUsers(ctx context.Context, queries *UserSearchQueries, w
 		return nil, errors.ThrowInternal(err, "QUERY-Dgbg2", "Errors.Query.SQLStatment")
 	}
 
-	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	rows, err := q.replicaClient(ctx, false, userTable, loginNameTable).QueryContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-AG4gs", "Errors.Internal")
 	}
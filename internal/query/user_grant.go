@@ -249,7 +249,7 @@ func (q *Queries) UserGrant(ctx context.Context, shouldTriggerBulk bool, withOwn
 		return nil, errors.ThrowInternal(err, "QUERY-Fa1KW", "Errors.Query.SQLStatement")
 	}
 
-	row := q.client.QueryRowContext(ctx, stmt, args...)
+	row := q.replicaClient(ctx, shouldTriggerBulk, userGrantTable, userTable, orgsTable, projectsTable, loginNameTable).QueryRowContext(ctx, stmt, args...)
 	return scan(row)
 }
 
@@ -276,7 +276,7 @@ func (q *Queries) UserGrants(ctx context.Context, queries *UserGrantsQueries, sh
 		return nil, err
 	}
 
-	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	rows, err := q.replicaClient(ctx, shouldTriggerBulk, userGrantTable, userTable, orgsTable, projectsTable, loginNameTable).QueryContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
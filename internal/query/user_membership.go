@@ -409,3 +409,37 @@ func prepareProjectGrantMember(withOwnerRemoved bool) (string, []interface{}) {
 	}
 	return builder.MustSql()
 }
+
+// PermissionMemberships returns the memberships of the user on the instance, the organisation
+// and the projects granted to the organisation, which are used to resolve the permissions of the user.
+// The memberships are cached if a cache is configured
+func (q *Queries) PermissionMemberships(ctx context.Context, userID, orgID string) (_ []*Membership, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	cached, key, ok := q.caches.memberships(instanceID, userID, orgID)
+	if ok {
+		return cached, nil
+	}
+	userIDQuery, err := NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	orgIDsQuery, err := NewMembershipResourceOwnersSearchQuery(orgID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	grantedIDQuery, err := NewMembershipGrantedOrgIDSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := q.Memberships(ctx, &MembershipSearchQuery{
+		Queries: []SearchQuery{userIDQuery, Or(orgIDsQuery, grantedIDQuery)},
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	q.caches.setMemberships(key, memberships.Memberships)
+	return memberships.Memberships, nil
+}
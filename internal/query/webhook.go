@@ -0,0 +1,456 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	webhookTable = table{
+		name:          projection.WebhookTable,
+		instanceIDCol: projection.WebhookInstanceIDCol,
+	}
+	WebhookColumnID = Column{
+		name:  projection.WebhookIDCol,
+		table: webhookTable,
+	}
+	WebhookColumnCreationDate = Column{
+		name:  projection.WebhookCreationDateCol,
+		table: webhookTable,
+	}
+	WebhookColumnChangeDate = Column{
+		name:  projection.WebhookChangeDateCol,
+		table: webhookTable,
+	}
+	WebhookColumnResourceOwner = Column{
+		name:  projection.WebhookResourceOwnerCol,
+		table: webhookTable,
+	}
+	WebhookColumnInstanceID = Column{
+		name:  projection.WebhookInstanceIDCol,
+		table: webhookTable,
+	}
+	WebhookColumnSequence = Column{
+		name:  projection.WebhookSequenceCol,
+		table: webhookTable,
+	}
+	WebhookColumnState = Column{
+		name:  projection.WebhookStateCol,
+		table: webhookTable,
+	}
+	WebhookColumnName = Column{
+		name:  projection.WebhookNameCol,
+		table: webhookTable,
+	}
+	WebhookColumnURL = Column{
+		name:  projection.WebhookURLCol,
+		table: webhookTable,
+	}
+	WebhookColumnEventTypes = Column{
+		name:  projection.WebhookEventTypesCol,
+		table: webhookTable,
+	}
+	WebhookColumnSigningKey = Column{
+		name:  projection.WebhookSigningKeyCol,
+		table: webhookTable,
+	}
+)
+
+var (
+	webhookDeadLetterTable = table{
+		name:          projection.WebhookDeadLetterTable,
+		instanceIDCol: projection.WebhookDeadLetterInstanceIDCol,
+	}
+	WebhookDeadLetterColumnWebhookID = Column{
+		name:  projection.WebhookDeadLetterWebhookIDCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnInstanceID = Column{
+		name:  projection.WebhookDeadLetterInstanceIDCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnResourceOwner = Column{
+		name:  projection.WebhookDeadLetterResourceOwnerCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnChangeDate = Column{
+		name:  projection.WebhookDeadLetterChangeDateCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnSequence = Column{
+		name:  projection.WebhookDeadLetterSequenceCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnEventSequence = Column{
+		name:  projection.WebhookDeadLetterEventSequenceCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnEventType = Column{
+		name:  projection.WebhookDeadLetterEventTypeCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnAggregateType = Column{
+		name:  projection.WebhookDeadLetterAggregateTypeCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnAggregateID = Column{
+		name:  projection.WebhookDeadLetterAggregateIDCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnEventResourceOwner = Column{
+		name:  projection.WebhookDeadLetterEventResourceOwner,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnAttempts = Column{
+		name:  projection.WebhookDeadLetterAttemptsCol,
+		table: webhookDeadLetterTable,
+	}
+	WebhookDeadLetterColumnReason = Column{
+		name:  projection.WebhookDeadLetterReasonCol,
+		table: webhookDeadLetterTable,
+	}
+)
+
+type Webhooks struct {
+	SearchResponse
+	Webhooks []*Webhook
+}
+
+type Webhook struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.WebhookState
+	Sequence      uint64
+
+	Name       string
+	URL        string
+	EventTypes database.StringArray
+	SigningKey *crypto.CryptoValue
+}
+
+type WebhookSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *WebhookSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type WebhookDeadLetters struct {
+	SearchResponse
+	DeadLetters []*WebhookDeadLetter
+}
+
+// WebhookDeadLetter is an event, which could not be delivered to the webhook
+type WebhookDeadLetter struct {
+	WebhookID     string
+	ResourceOwner string
+	ChangeDate    time.Time
+	Sequence      uint64
+
+	EventSequence      uint64
+	EventType          string
+	AggregateType      string
+	AggregateID        string
+	EventResourceOwner string
+	Attempts           uint64
+	Reason             string
+}
+
+type WebhookDeadLetterSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *WebhookDeadLetterSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchWebhooks(ctx context.Context, queries *WebhookSearchQueries) (webhooks *Webhooks, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhooksQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		WebhookColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-eeK4a", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ahc2E", "Errors.Internal")
+	}
+	webhooks, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	webhooks.LatestSequence, err = q.latestSequence(ctx, webhookTable)
+	return webhooks, err
+}
+
+func (q *Queries) GetWebhookByID(ctx context.Context, id, resourceOwner string) (_ *Webhook, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareWebhookQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		WebhookColumnID.identifier():            id,
+		WebhookColumnResourceOwner.identifier(): resourceOwner,
+		WebhookColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ohd7u", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+// ActiveWebhooksByEventType returns the webhooks subscribed to the event type,
+// which are either defined on the instance or the organization (resourceOwner) of the event
+func (q *Queries) ActiveWebhooksByEventType(ctx context.Context, eventType, resourceOwner string) (_ []*Webhook, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	query, scan := prepareWebhooksQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.And{
+		sq.Eq{
+			WebhookColumnInstanceID.identifier():    instanceID,
+			WebhookColumnState.identifier():         domain.WebhookStateActive,
+			WebhookColumnResourceOwner.identifier(): []string{instanceID, resourceOwner},
+		},
+		&listContains{col: WebhookColumnEventTypes, args: []interface{}{eventType}},
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Wai6e", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Jae3u", "Errors.Internal")
+	}
+	webhooks, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks.Webhooks, nil
+}
+
+func (q *Queries) SearchWebhookDeadLetters(ctx context.Context, queries *WebhookDeadLetterSearchQueries) (deadLetters *WebhookDeadLetters, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhookDeadLettersQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		WebhookDeadLetterColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Aim3e", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ooV6i", "Errors.Internal")
+	}
+	deadLetters, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters.LatestSequence, err = q.latestSequence(ctx, webhookTable)
+	return deadLetters, err
+}
+
+func NewWebhookResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnResourceOwner, id, TextEquals)
+}
+
+func NewWebhookNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnName, value, method)
+}
+
+func NewWebhookIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnID, id, TextEquals)
+}
+
+func NewWebhookDeadLetterWebhookIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookDeadLetterColumnWebhookID, id, TextEquals)
+}
+
+func NewWebhookDeadLetterResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookDeadLetterColumnResourceOwner, id, TextEquals)
+}
+
+func NewWebhookDeadLetterEventTypeSearchQuery(eventType string) (SearchQuery, error) {
+	return NewTextQuery(WebhookDeadLetterColumnEventType, eventType, TextEquals)
+}
+
+func prepareWebhooksQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Webhooks, error)) {
+	return sq.Select(
+			WebhookColumnID.identifier(),
+			WebhookColumnCreationDate.identifier(),
+			WebhookColumnChangeDate.identifier(),
+			WebhookColumnResourceOwner.identifier(),
+			WebhookColumnSequence.identifier(),
+			WebhookColumnState.identifier(),
+			WebhookColumnName.identifier(),
+			WebhookColumnURL.identifier(),
+			WebhookColumnEventTypes.identifier(),
+			WebhookColumnSigningKey.identifier(),
+			countColumn.identifier(),
+		).From(webhookTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Webhooks, error) {
+			webhooks := make([]*Webhook, 0)
+			var count uint64
+			for rows.Next() {
+				webhook := new(Webhook)
+				err := rows.Scan(
+					&webhook.ID,
+					&webhook.CreationDate,
+					&webhook.ChangeDate,
+					&webhook.ResourceOwner,
+					&webhook.Sequence,
+					&webhook.State,
+					&webhook.Name,
+					&webhook.URL,
+					&webhook.EventTypes,
+					&webhook.SigningKey,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				webhooks = append(webhooks, webhook)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-shu2E", "Errors.Query.CloseRows")
+			}
+
+			return &Webhooks{
+				Webhooks: webhooks,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareWebhookQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Webhook, error)) {
+	return sq.Select(
+			WebhookColumnID.identifier(),
+			WebhookColumnCreationDate.identifier(),
+			WebhookColumnChangeDate.identifier(),
+			WebhookColumnResourceOwner.identifier(),
+			WebhookColumnSequence.identifier(),
+			WebhookColumnState.identifier(),
+			WebhookColumnName.identifier(),
+			WebhookColumnURL.identifier(),
+			WebhookColumnEventTypes.identifier(),
+			WebhookColumnSigningKey.identifier(),
+		).From(webhookTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Webhook, error) {
+			webhook := new(Webhook)
+			err := row.Scan(
+				&webhook.ID,
+				&webhook.CreationDate,
+				&webhook.ChangeDate,
+				&webhook.ResourceOwner,
+				&webhook.Sequence,
+				&webhook.State,
+				&webhook.Name,
+				&webhook.URL,
+				&webhook.EventTypes,
+				&webhook.SigningKey,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ueb9a", "Errors.Webhook.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-ieN4o", "Errors.Internal")
+			}
+			return webhook, nil
+		}
+}
+
+func prepareWebhookDeadLettersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*WebhookDeadLetters, error)) {
+	return sq.Select(
+			WebhookDeadLetterColumnWebhookID.identifier(),
+			WebhookDeadLetterColumnResourceOwner.identifier(),
+			WebhookDeadLetterColumnChangeDate.identifier(),
+			WebhookDeadLetterColumnSequence.identifier(),
+			WebhookDeadLetterColumnEventSequence.identifier(),
+			WebhookDeadLetterColumnEventType.identifier(),
+			WebhookDeadLetterColumnAggregateType.identifier(),
+			WebhookDeadLetterColumnAggregateID.identifier(),
+			WebhookDeadLetterColumnEventResourceOwner.identifier(),
+			WebhookDeadLetterColumnAttempts.identifier(),
+			WebhookDeadLetterColumnReason.identifier(),
+			countColumn.identifier(),
+		).From(webhookDeadLetterTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*WebhookDeadLetters, error) {
+			deadLetters := make([]*WebhookDeadLetter, 0)
+			var count uint64
+			for rows.Next() {
+				deadLetter := new(WebhookDeadLetter)
+				err := rows.Scan(
+					&deadLetter.WebhookID,
+					&deadLetter.ResourceOwner,
+					&deadLetter.ChangeDate,
+					&deadLetter.Sequence,
+					&deadLetter.EventSequence,
+					&deadLetter.EventType,
+					&deadLetter.AggregateType,
+					&deadLetter.AggregateID,
+					&deadLetter.EventResourceOwner,
+					&deadLetter.Attempts,
+					&deadLetter.Reason,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				deadLetters = append(deadLetters, deadLetter)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Xoo8a", "Errors.Query.CloseRows")
+			}
+
+			return &WebhookDeadLetters{
+				DeadLetters: deadLetters,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,318 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareWebhooksStmt = `SELECT projections.webhooks.id,` +
+		` projections.webhooks.creation_date,` +
+		` projections.webhooks.change_date,` +
+		` projections.webhooks.resource_owner,` +
+		` projections.webhooks.sequence,` +
+		` projections.webhooks.state,` +
+		` projections.webhooks.name,` +
+		` projections.webhooks.url,` +
+		` projections.webhooks.event_types,` +
+		` projections.webhooks.signing_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.webhooks` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareWebhooksCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"name",
+		"url",
+		"event_types",
+		"signing_key",
+		"count",
+	}
+
+	prepareWebhookStmt = `SELECT projections.webhooks.id,` +
+		` projections.webhooks.creation_date,` +
+		` projections.webhooks.change_date,` +
+		` projections.webhooks.resource_owner,` +
+		` projections.webhooks.sequence,` +
+		` projections.webhooks.state,` +
+		` projections.webhooks.name,` +
+		` projections.webhooks.url,` +
+		` projections.webhooks.event_types,` +
+		` projections.webhooks.signing_key` +
+		` FROM projections.webhooks` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareWebhookCols = prepareWebhooksCols[:len(prepareWebhooksCols)-1]
+
+	prepareWebhookDeadLettersStmt = `SELECT projections.webhooks_dead_letters.webhook_id,` +
+		` projections.webhooks_dead_letters.resource_owner,` +
+		` projections.webhooks_dead_letters.change_date,` +
+		` projections.webhooks_dead_letters.sequence,` +
+		` projections.webhooks_dead_letters.event_sequence,` +
+		` projections.webhooks_dead_letters.event_type,` +
+		` projections.webhooks_dead_letters.aggregate_type,` +
+		` projections.webhooks_dead_letters.aggregate_id,` +
+		` projections.webhooks_dead_letters.event_resource_owner,` +
+		` projections.webhooks_dead_letters.attempts,` +
+		` projections.webhooks_dead_letters.reason,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.webhooks_dead_letters` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareWebhookDeadLettersCols = []string{
+		"webhook_id",
+		"resource_owner",
+		"change_date",
+		"sequence",
+		"event_sequence",
+		"event_type",
+		"aggregate_type",
+		"aggregate_id",
+		"event_resource_owner",
+		"attempts",
+		"reason",
+		"count",
+	}
+)
+
+func Test_WebhookPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareWebhooksQuery no result",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhooksStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Webhooks{Webhooks: []*Webhook{}},
+		},
+		{
+			name:    "prepareWebhooksQuery one result",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhooksStmt),
+					prepareWebhooksCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							domain.WebhookStateActive,
+							"webhook-name",
+							"https://example.com/hook",
+							database.StringArray{"user.locked"},
+							nil,
+						},
+					},
+				),
+			},
+			object: &Webhooks{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Webhooks: []*Webhook{
+					{
+						ID:            "id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						State:         domain.WebhookStateActive,
+						Sequence:      20211109,
+						Name:          "webhook-name",
+						URL:           "https://example.com/hook",
+						EventTypes:    database.StringArray{"user.locked"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareWebhooksQuery sql err",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareWebhooksStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareWebhookQuery no result",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhookStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Webhook)(nil),
+		},
+		{
+			name:    "prepareWebhookQuery found",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareWebhookStmt),
+					prepareWebhookCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						domain.WebhookStateActive,
+						"webhook-name",
+						"https://example.com/hook",
+						database.StringArray{"user.locked", "user.unlocked"},
+						nil,
+					},
+				),
+			},
+			object: &Webhook{
+				ID:            "id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.WebhookStateActive,
+				Sequence:      20211109,
+				Name:          "webhook-name",
+				URL:           "https://example.com/hook",
+				EventTypes:    database.StringArray{"user.locked", "user.unlocked"},
+			},
+		},
+		{
+			name:    "prepareWebhookQuery sql err",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareWebhookStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareWebhookDeadLettersQuery no result",
+			prepare: prepareWebhookDeadLettersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhookDeadLettersStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &WebhookDeadLetters{DeadLetters: []*WebhookDeadLetter{}},
+		},
+		{
+			name:    "prepareWebhookDeadLettersQuery one result",
+			prepare: prepareWebhookDeadLettersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhookDeadLettersStmt),
+					prepareWebhookDeadLettersCols,
+					[][]driver.Value{
+						{
+							"webhook-id",
+							"ro",
+							testNow,
+							uint64(20211109),
+							uint64(20211108),
+							"user.locked",
+							"user",
+							"user-id",
+							"org-id",
+							uint64(3),
+							"503 Service Unavailable",
+						},
+					},
+				),
+			},
+			object: &WebhookDeadLetters{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				DeadLetters: []*WebhookDeadLetter{
+					{
+						WebhookID:          "webhook-id",
+						ResourceOwner:      "ro",
+						ChangeDate:         testNow,
+						Sequence:           20211109,
+						EventSequence:      20211108,
+						EventType:          "user.locked",
+						AggregateType:      "user",
+						AggregateID:        "user-id",
+						EventResourceOwner: "org-id",
+						Attempts:           3,
+						Reason:             "503 Service Unavailable",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareWebhookDeadLettersQuery sql err",
+			prepare: prepareWebhookDeadLettersQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareWebhookDeadLettersStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	countStmt = "SELECT hits FROM auth.rate_limits WHERE instance_id = $1 AND key = $2 AND window_start = $3"
+	// the counter is created or incremented in one statement,
+	// so concurrent hits of multiple ZITADEL instances are counted correctly
+	incrementStmt = "INSERT INTO auth.rate_limits (instance_id, key, window_start, hits, expiration) VALUES ($1, $2, $3, 1, $4)" +
+		" ON CONFLICT (instance_id, key, window_start) DO UPDATE SET hits = auth.rate_limits.hits + 1"
+	cleanupStmt = "DELETE FROM auth.rate_limits WHERE expiration < now()"
+)
+
+// DatabaseStore keeps the counters in the auth.rate_limits table,
+// so they are shared between all ZITADEL instances of a cluster
+type DatabaseStore struct {
+	client *database.DB
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+func NewDatabaseStore(client *database.DB) *DatabaseStore {
+	return &DatabaseStore{client: client}
+}
+
+func (s *DatabaseStore) Count(ctx context.Context, instanceID, key string, window time.Time) (hits uint64, err error) {
+	err = s.client.QueryRowContext(ctx, countStmt, instanceID, key, window).Scan(&hits)
+	if errs.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "RATEL-Db2co", "Errors.Internal")
+	}
+	return hits, nil
+}
+
+func (s *DatabaseStore) Increment(ctx context.Context, instanceID, key string, window time.Time, expiration time.Time) error {
+	if err := s.cleanup(ctx); err != nil {
+		return err
+	}
+	if _, err := s.client.ExecContext(ctx, incrementStmt, instanceID, key, window, expiration); err != nil {
+		return errors.ThrowInternal(err, "RATEL-Db3in", "Errors.Internal")
+	}
+	return nil
+}
+
+// cleanup removes the expired counters at most once per cleanupInterval
+func (s *DatabaseStore) cleanup(ctx context.Context) error {
+	s.mu.Lock()
+	now := time.Now()
+	if now.Sub(s.lastCleanup) < cleanupInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastCleanup = now
+	s.mu.Unlock()
+	if _, err := s.client.ExecContext(ctx, cleanupStmt); err != nil {
+		return errors.ThrowInternal(err, "RATEL-Db4cl", "Errors.Internal")
+	}
+	return nil
+}
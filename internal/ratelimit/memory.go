@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const cleanupInterval = time.Minute
+
+// MemoryStore keeps the counters in the memory of the process,
+// it's only suitable for single ZITADEL deployments
+type MemoryStore struct {
+	mu          sync.Mutex
+	counters    map[memoryKey]*memoryCounter
+	lastCleanup time.Time
+}
+
+type memoryKey struct {
+	instanceID string
+	key        string
+	window     time.Time
+}
+
+type memoryCounter struct {
+	hits       uint64
+	expiration time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[memoryKey]*memoryCounter),
+	}
+}
+
+func (s *MemoryStore) Count(_ context.Context, instanceID, key string, window time.Time) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counter, ok := s.counters[memoryKey{instanceID: instanceID, key: key, window: window}]
+	if !ok {
+		return 0, nil
+	}
+	return counter.hits, nil
+}
+
+func (s *MemoryStore) Increment(_ context.Context, instanceID, key string, window time.Time, expiration time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanup()
+	k := memoryKey{instanceID: instanceID, key: key, window: window}
+	counter, ok := s.counters[k]
+	if !ok {
+		counter = &memoryCounter{expiration: expiration}
+		s.counters[k] = counter
+	}
+	counter.hits++
+	return nil
+}
+
+// cleanup removes the expired counters at most once per cleanupInterval
+func (s *MemoryStore) cleanup() {
+	now := time.Now()
+	if now.Sub(s.lastCleanup) < cleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+	for k, counter := range s.counters {
+		if counter.expiration.Before(now) {
+			delete(s.counters, k)
+		}
+	}
+}
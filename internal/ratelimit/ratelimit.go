@@ -0,0 +1,181 @@
+// Package ratelimit protects endpoints, which check credentials or issue tokens,
+// against brute-force and credential stuffing attacks.
+// Attempts are counted in fixed windows per client ip, per user and per instance.
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type Endpoint string
+
+const (
+	// EndpointLoginPassword are the password checks of the login UI
+	EndpointLoginPassword Endpoint = "login_password"
+	// EndpointLoginOTP are the one time password checks of the login UI
+	EndpointLoginOTP Endpoint = "login_otp"
+	// EndpointSessionCheck are the password checks of the session API
+	EndpointSessionCheck Endpoint = "session_check"
+	// EndpointOIDCToken is the token endpoint of the OIDC provider
+	EndpointOIDCToken Endpoint = "oidc_token"
+	// EndpointClientSecret are the authentications of clients with id and secret
+	EndpointClientSecret Endpoint = "client_secret"
+)
+
+const (
+	StoreMemory   = "memory"
+	StoreDatabase = "database"
+)
+
+type Config struct {
+	Enabled bool
+	// Store is either memory or database,
+	// the database store shares the counters between all ZITADEL instances of a cluster
+	Store         string
+	LoginPassword EndpointConfig
+	LoginOTP      EndpointConfig
+	SessionCheck  EndpointConfig
+	OIDCToken     EndpointConfig
+	ClientSecret  EndpointConfig
+}
+
+type EndpointConfig struct {
+	PerIP       Limit
+	PerUser     Limit
+	PerInstance Limit
+}
+
+// Limit allows Max attempts per Interval,
+// a Max of 0 disables the limit
+type Limit struct {
+	Max      uint64
+	Interval time.Duration
+}
+
+func (l Limit) enabled() bool {
+	return l.Max > 0 && l.Interval > 0
+}
+
+// Store counts the hits of a key in a window
+type Store interface {
+	Count(ctx context.Context, instanceID, key string, window time.Time) (uint64, error)
+	Increment(ctx context.Context, instanceID, key string, window time.Time, expiration time.Time) error
+}
+
+// Limiter checks the limits of the endpoints.
+// All methods can be called on a nil Limiter, which allows all requests.
+type Limiter struct {
+	store     Store
+	endpoints map[Endpoint]EndpointConfig
+	now       func() time.Time
+}
+
+// NewLimiter returns the limiter of the configured store,
+// nil is returned if rate limiting is disabled
+func NewLimiter(config *Config, db *database.DB) (*Limiter, error) {
+	if config == nil || !config.Enabled {
+		return nil, nil
+	}
+	var store Store
+	switch strings.ToLower(config.Store) {
+	case StoreMemory, "":
+		store = NewMemoryStore()
+	case StoreDatabase:
+		if db == nil {
+			return nil, errors.ThrowInvalidArgument(nil, "RATEL-Db1nl", "database is required for the database store")
+		}
+		store = NewDatabaseStore(db)
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "RATEL-St1nf", "rate limit store %s not supported", config.Store)
+	}
+	return New(store, config), nil
+}
+
+func New(store Store, config *Config) *Limiter {
+	return &Limiter{
+		store: store,
+		endpoints: map[Endpoint]EndpointConfig{
+			EndpointLoginPassword: config.LoginPassword,
+			EndpointLoginOTP:      config.LoginOTP,
+			EndpointSessionCheck:  config.SessionCheck,
+			EndpointOIDCToken:     config.OIDCToken,
+			EndpointClientSecret:  config.ClientSecret,
+		},
+		now: time.Now,
+	}
+}
+
+// Allow returns a resource exhausted error if one of the limits of the endpoint is reached
+// for the client ip, the user or the instance.
+// Errors of the store are logged and the request is allowed.
+func (l *Limiter) Allow(ctx context.Context, endpoint Endpoint, ip, userID string) error {
+	if l == nil {
+		return nil
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	now := l.now()
+	for _, subject := range l.subjects(endpoint, ip, userID) {
+		count, err := l.store.Count(ctx, instanceID, subject.key, now.Truncate(subject.limit.Interval))
+		if err != nil {
+			logging.WithFields("endpoint", endpoint).WithError(err).Warn("unable to check rate limit")
+			return nil
+		}
+		if count >= subject.limit.Max {
+			return errors.ThrowResourceExhausted(nil, "RATEL-Ex1ce", "Errors.RateLimit.Exceeded")
+		}
+	}
+	return nil
+}
+
+// Hit counts an attempt on the endpoint for the client ip, the user and the instance
+func (l *Limiter) Hit(ctx context.Context, endpoint Endpoint, ip, userID string) {
+	if l == nil {
+		return
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	now := l.now()
+	for _, subject := range l.subjects(endpoint, ip, userID) {
+		window := now.Truncate(subject.limit.Interval)
+		err := l.store.Increment(ctx, instanceID, subject.key, window, window.Add(subject.limit.Interval))
+		logging.WithFields("endpoint", endpoint).OnError(err).Warn("unable to count rate limit hit")
+	}
+}
+
+// Take checks the limits and counts the attempt if it's allowed
+func (l *Limiter) Take(ctx context.Context, endpoint Endpoint, ip, userID string) error {
+	if err := l.Allow(ctx, endpoint, ip, userID); err != nil {
+		return err
+	}
+	l.Hit(ctx, endpoint, ip, userID)
+	return nil
+}
+
+type subject struct {
+	key   string
+	limit Limit
+}
+
+// subjects returns the enabled limits of the endpoint,
+// limits without a subject (e.g. unknown user) are skipped
+func (l *Limiter) subjects(endpoint Endpoint, ip, userID string) []subject {
+	config := l.endpoints[endpoint]
+	subjects := make([]subject, 0, 3)
+	if config.PerIP.enabled() && ip != "" {
+		subjects = append(subjects, subject{key: string(endpoint) + ":ip:" + ip, limit: config.PerIP})
+	}
+	if config.PerUser.enabled() && userID != "" {
+		subjects = append(subjects, subject{key: string(endpoint) + ":user:" + userID, limit: config.PerUser})
+	}
+	if config.PerInstance.enabled() {
+		subjects = append(subjects, subject{key: string(endpoint) + ":instance", limit: config.PerInstance})
+	}
+	return subjects
+}
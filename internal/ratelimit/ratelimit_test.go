@@ -0,0 +1,173 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	config := &Config{
+		LoginPassword: EndpointConfig{
+			PerIP:       Limit{Max: 3, Interval: time.Minute},
+			PerUser:     Limit{Max: 2, Interval: time.Hour},
+			PerInstance: Limit{Max: 5, Interval: time.Minute},
+		},
+	}
+	type hit struct {
+		ip     string
+		userID string
+	}
+	tests := []struct {
+		name     string
+		hits     []hit
+		ip       string
+		userID   string
+		endpoint Endpoint
+		wantErr  bool
+	}{
+		{
+			name:     "no hits, ok",
+			ip:       "1.1.1.1",
+			userID:   "user1",
+			endpoint: EndpointLoginPassword,
+		},
+		{
+			name:     "ip limit reached, error",
+			hits:     []hit{{"1.1.1.1", "user1"}, {"1.1.1.1", "user2"}, {"1.1.1.1", "user3"}},
+			ip:       "1.1.1.1",
+			userID:   "user4",
+			endpoint: EndpointLoginPassword,
+			wantErr:  true,
+		},
+		{
+			name:     "ip limit reached by other ip, ok",
+			hits:     []hit{{"1.1.1.1", "user1"}, {"1.1.1.1", "user2"}, {"1.1.1.1", "user3"}},
+			ip:       "2.2.2.2",
+			userID:   "user4",
+			endpoint: EndpointLoginPassword,
+		},
+		{
+			name:     "user limit reached, error",
+			hits:     []hit{{"1.1.1.1", "user1"}, {"2.2.2.2", "user1"}},
+			ip:       "3.3.3.3",
+			userID:   "user1",
+			endpoint: EndpointLoginPassword,
+			wantErr:  true,
+		},
+		{
+			name:     "user limit without user, ok",
+			hits:     []hit{{"1.1.1.1", "user1"}, {"2.2.2.2", "user1"}},
+			ip:       "3.3.3.3",
+			endpoint: EndpointLoginPassword,
+		},
+		{
+			name:     "instance limit reached, error",
+			hits:     []hit{{"1.1.1.1", "user1"}, {"2.2.2.2", "user2"}, {"3.3.3.3", "user3"}, {"4.4.4.4", "user4"}, {"5.5.5.5", "user5"}},
+			ip:       "6.6.6.6",
+			userID:   "user6",
+			endpoint: EndpointLoginPassword,
+			wantErr:  true,
+		},
+		{
+			name:     "endpoint without limits, ok",
+			hits:     []hit{{"1.1.1.1", "user1"}, {"1.1.1.1", "user1"}, {"1.1.1.1", "user1"}},
+			ip:       "1.1.1.1",
+			userID:   "user1",
+			endpoint: EndpointOIDCToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithInstanceID(context.Background(), "instance1")
+			l := New(NewMemoryStore(), config)
+			now := time.Date(2023, 6, 1, 12, 0, 30, 0, time.UTC)
+			l.now = func() time.Time { return now }
+			for _, h := range tt.hits {
+				l.Hit(ctx, EndpointLoginPassword, h.ip, h.userID)
+			}
+			err := l.Allow(ctx, tt.endpoint, tt.ip, tt.userID)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsResourceExhausted(err), "want resource exhausted, got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLimiter_Allow_window(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	l := New(NewMemoryStore(), &Config{
+		OIDCToken: EndpointConfig{
+			PerIP: Limit{Max: 1, Interval: time.Minute},
+		},
+	})
+	now := time.Date(2023, 6, 1, 12, 0, 30, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	assert.NoError(t, l.Take(ctx, EndpointOIDCToken, "1.1.1.1", ""))
+	assert.Error(t, l.Take(ctx, EndpointOIDCToken, "1.1.1.1", ""))
+	// other instances are counted separately
+	assert.NoError(t, l.Take(authz.WithInstanceID(context.Background(), "instance2"), EndpointOIDCToken, "1.1.1.1", ""))
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, l.Take(ctx, EndpointOIDCToken, "1.1.1.1", ""))
+}
+
+func TestLimiter_nil(t *testing.T) {
+	var l *Limiter
+	ctx := context.Background()
+	l.Hit(ctx, EndpointLoginPassword, "1.1.1.1", "user1")
+	assert.NoError(t, l.Allow(ctx, EndpointLoginPassword, "1.1.1.1", "user1"))
+	assert.NoError(t, l.Take(ctx, EndpointLoginPassword, "1.1.1.1", "user1"))
+}
+
+func TestNewLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name:    "nil config, disabled",
+			wantNil: true,
+		},
+		{
+			name:    "disabled",
+			config:  &Config{Enabled: false, Store: StoreMemory},
+			wantNil: true,
+		},
+		{
+			name:   "memory",
+			config: &Config{Enabled: true, Store: StoreMemory},
+		},
+		{
+			name:    "database without client, error",
+			config:  &Config{Enabled: true, Store: StoreDatabase},
+			wantErr: true,
+		},
+		{
+			name:    "unknown store, error",
+			config:  &Config{Enabled: true, Store: "redis"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLimiter(tt.config, nil)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
@@ -36,10 +38,13 @@ func NewRemoveActionNameUniqueConstraint(actionName, resourceOwner string) *even
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name          string        `json:"name"`
-	Script        string        `json:"script,omitempty"`
-	Timeout       time.Duration `json:"timeout,omitempty"`
-	AllowedToFail bool          `json:"allowedToFail"`
+	Name          string                  `json:"name"`
+	Script        string                  `json:"script,omitempty"`
+	Timeout       time.Duration           `json:"timeout,omitempty"`
+	AllowedToFail bool                    `json:"allowedToFail"`
+	TargetType    domain.ActionTargetType `json:"targetType,omitempty"`
+	TargetURL     string                  `json:"targetUrl,omitempty"`
+	SigningKey    *crypto.CryptoValue     `json:"signingKey,omitempty"`
 }
 
 func (e *AddedEvent) Data() interface{} {
@@ -57,6 +62,9 @@ func NewAddedEvent(
 	script string,
 	timeout time.Duration,
 	allowedToFail bool,
+	targetType domain.ActionTargetType,
+	targetURL string,
+	signingKey *crypto.CryptoValue,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -68,6 +76,9 @@ func NewAddedEvent(
 		Script:        script,
 		Timeout:       timeout,
 		AllowedToFail: allowedToFail,
+		TargetType:    targetType,
+		TargetURL:     targetURL,
+		SigningKey:    signingKey,
 	}
 }
 
@@ -87,10 +98,13 @@ func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
 type ChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name          *string        `json:"name,omitempty"`
-	Script        *string        `json:"script,omitempty"`
-	Timeout       *time.Duration `json:"timeout,omitempty"`
-	AllowedToFail *bool          `json:"allowedToFail,omitempty"`
+	Name          *string                  `json:"name,omitempty"`
+	Script        *string                  `json:"script,omitempty"`
+	Timeout       *time.Duration           `json:"timeout,omitempty"`
+	AllowedToFail *bool                    `json:"allowedToFail,omitempty"`
+	TargetType    *domain.ActionTargetType `json:"targetType,omitempty"`
+	TargetURL     *string                  `json:"targetUrl,omitempty"`
+	SigningKey    *crypto.CryptoValue      `json:"signingKey,omitempty"`
 	oldName       string
 }
 
@@ -156,6 +170,24 @@ func ChangeAllowedToFail(allowedToFail bool) func(event *ChangedEvent) {
 	}
 }
 
+func ChangeTargetType(targetType domain.ActionTargetType) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.TargetType = &targetType
+	}
+}
+
+func ChangeTargetURL(targetURL string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.TargetURL = &targetURL
+	}
+}
+
+func ChangeSigningKey(signingKey *crypto.CryptoValue) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.SigningKey = signingKey
+	}
+}
+
 func ChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &ChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
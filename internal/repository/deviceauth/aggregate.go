@@ -1,10 +1,17 @@
 package deviceauth
 
-import "github.com/zitadel/zitadel/internal/eventstore"
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
 
 const (
 	AggregateType    = "device_auth"
 	AggregateVersion = "v1"
+
+	// Retention is the time after the latest event of a device authorization until it's pruned from the eventstore
+	Retention = 24 * time.Hour
 )
 
 func NewAggregate(aggrID, instanceID string) *eventstore.Aggregate {
@@ -1,11 +1,17 @@
 package idpintent
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
+// retention is the time after the latest event of an intent until it's pruned from the eventstore
+const retention = 24 * time.Hour
+
 func RegisterEventMappers(es *eventstore.Eventstore) {
 	es.RegisterFilterEventMapper(AggregateType, StartedEventType, StartedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SucceededEventType, SucceededEventMapper).
-		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper)
+		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper).
+		RegisterAggregateRetention(AggregateType, retention)
 }
@@ -23,6 +23,8 @@ type PasswordComplexityPolicyAddedEvent struct {
 	HasUppercase bool   `json:"hasUppercase,omitempty"`
 	HasNumber    bool   `json:"hasNumber,omitempty"`
 	HasSymbol    bool   `json:"hasSymbol,omitempty"`
+	// DenyBreachedPasswords rejects passwords, which are part of a known breach
+	DenyBreachedPasswords bool `json:"denyBreachedPasswords,omitempty"`
 }
 
 func (e *PasswordComplexityPolicyAddedEvent) Data() interface{} {
@@ -39,7 +41,8 @@ func NewPasswordComplexityPolicyAddedEvent(
 	hasLowerCase,
 	hasUpperCase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	denyBreachedPasswords bool,
 ) *PasswordComplexityPolicyAddedEvent {
 	return &PasswordComplexityPolicyAddedEvent{
 		BaseEvent:    *base,
@@ -48,6 +51,8 @@ func NewPasswordComplexityPolicyAddedEvent(
 		HasUppercase: hasUpperCase,
 		HasNumber:    hasNumber,
 		HasSymbol:    hasSymbol,
+
+		DenyBreachedPasswords: denyBreachedPasswords,
 	}
 }
 
@@ -72,6 +77,8 @@ type PasswordComplexityPolicyChangedEvent struct {
 	HasUppercase *bool   `json:"hasUppercase,omitempty"`
 	HasNumber    *bool   `json:"hasNumber,omitempty"`
 	HasSymbol    *bool   `json:"hasSymbol,omitempty"`
+
+	DenyBreachedPasswords *bool `json:"denyBreachedPasswords,omitempty"`
 }
 
 func (e *PasswordComplexityPolicyChangedEvent) Data() interface{} {
@@ -130,6 +137,12 @@ func ChangeHasSymbol(hasSymbol bool) func(*PasswordComplexityPolicyChangedEvent)
 	}
 }
 
+func ChangeDenyBreachedPasswords(denyBreachedPasswords bool) func(*PasswordComplexityPolicyChangedEvent) {
+	return func(e *PasswordComplexityPolicyChangedEvent) {
+		e.DenyBreachedPasswords = &denyBreachedPasswords
+	}
+}
+
 func PasswordComplexityPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &PasswordComplexityPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -41,6 +41,9 @@ type OIDCConfigAddedEvent struct {
 	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
 	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	DPoPBoundAccessTokens    bool                       `json:"dpopBoundAccessTokens,omitempty"`
+	RequirePushedAuthRequest bool                       `json:"requirePushedAuthRequest,omitempty"`
+	BackChannelLogoutURI     string                     `json:"backChannelLogoutURI,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -72,6 +75,9 @@ func NewOIDCConfigAddedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	dpopBoundAccessTokens bool,
+	requirePushedAuthRequest bool,
+	backChannelLogoutURI string,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -97,6 +103,9 @@ func NewOIDCConfigAddedEvent(
 		ClockSkew:                clockSkew,
 		AdditionalOrigins:        additionalOrigins,
 		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
+		DPoPBoundAccessTokens:    dpopBoundAccessTokens,
+		RequirePushedAuthRequest: requirePushedAuthRequest,
+		BackChannelLogoutURI:     backChannelLogoutURI,
 	}
 }
 
@@ -182,7 +191,16 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
-	return e.SkipNativeAppSuccessPage == c.SkipNativeAppSuccessPage
+	if e.SkipNativeAppSuccessPage != c.SkipNativeAppSuccessPage {
+		return false
+	}
+	if e.DPoPBoundAccessTokens != c.DPoPBoundAccessTokens {
+		return false
+	}
+	if e.RequirePushedAuthRequest != c.RequirePushedAuthRequest {
+		return false
+	}
+	return e.BackChannelLogoutURI == c.BackChannelLogoutURI
 }
 
 func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
@@ -217,6 +235,9 @@ type OIDCConfigChangedEvent struct {
 	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
 	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	DPoPBoundAccessTokens    *bool                       `json:"dpopBoundAccessTokens,omitempty"`
+	RequirePushedAuthRequest *bool                       `json:"requirePushedAuthRequest,omitempty"`
+	BackChannelLogoutURI     *string                     `json:"backChannelLogoutURI,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -343,6 +364,24 @@ func ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage bool) func(event *O
 	}
 }
 
+func ChangeDPoPBoundAccessTokens(dpopBoundAccessTokens bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.DPoPBoundAccessTokens = &dpopBoundAccessTokens
+	}
+}
+
+func ChangeRequirePushedAuthRequest(requirePushedAuthRequest bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequirePushedAuthRequest = &requirePushedAuthRequest
+	}
+}
+
+func ChangeBackChannelLogoutURI(backChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackChannelLogoutURI = &backChannelLogoutURI
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -1,6 +1,14 @@
 package session
 
-import "github.com/zitadel/zitadel/internal/eventstore"
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// retention is the time after the termination of a session until it's pruned from the eventstore,
+// sessions which are not terminated are kept because they are still valid
+const retention = 30 * 24 * time.Hour
 
 func RegisterEventMappers(es *eventstore.Eventstore) {
 	es.RegisterFilterEventMapper(AggregateType, AddedType, AddedEventMapper).
@@ -11,5 +19,6 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, PasskeyCheckedType, eventstore.GenericEventMapper[PasskeyCheckedEvent]).
 		RegisterFilterEventMapper(AggregateType, TokenSetType, TokenSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper).
-		RegisterFilterEventMapper(AggregateType, TerminateType, TerminateEventMapper)
+		RegisterFilterEventMapper(AggregateType, TerminateType, TerminateEventMapper).
+		RegisterAggregateRetention(AggregateType, retention, TerminateType)
 }
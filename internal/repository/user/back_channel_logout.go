@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	BackChannelLogoutSentType = userEventTypePrefix + "backchannel.logout.sent"
+)
+
+// BackChannelLogoutSentEvent records the delivery of a logout token to the back-channel logout URI of the client.
+// LogoutSequence is the sequence of the event, which ended the session.
+type BackChannelLogoutSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID       string `json:"clientId"`
+	SessionID      string `json:"sessionId"`
+	LogoutSequence uint64 `json:"logoutSequence"`
+}
+
+func (e *BackChannelLogoutSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *BackChannelLogoutSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewBackChannelLogoutSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	sessionID string,
+	logoutSequence uint64,
+) *BackChannelLogoutSentEvent {
+	return &BackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			BackChannelLogoutSentType,
+		),
+		ClientID:       clientID,
+		SessionID:      sessionID,
+		LogoutSequence: logoutSequence,
+	}
+}
+
+func BackChannelLogoutSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	logoutSent := &BackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, logoutSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Bc3lS", "unable to unmarshal back-channel logout sent")
+	}
+
+	return logoutSent, nil
+}
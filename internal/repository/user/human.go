@@ -25,6 +25,14 @@ const (
 	HumanSignedOutType                 = humanEventPrefix + "signed.out"
 )
 
+var (
+	profilePersonalDataFields = []string{"firstName", "lastName", "nickName", "displayName"}
+	addressPersonalDataFields = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	// humanPersonalDataFields are encrypted if the personal data encryption is enabled,
+	// the unique constraint of the user name is stored separately and removed with the user
+	humanPersonalDataFields = append(append([]string{"userName", "email", "phone"}, profilePersonalDataFields...), addressPersonalDataFields...)
+)
+
 type HumanAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -60,6 +68,10 @@ func (e *HumanAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstrain
 	return []*eventstore.EventUniqueConstraint{NewAddUsernameUniqueConstraint(e.UserName, e.Aggregate().ResourceOwner, e.userLoginMustBeDomain)}
 }
 
+func (e *HumanAddedEvent) PersonalDataFields() []string {
+	return humanPersonalDataFields
+}
+
 func (e *HumanAddedEvent) AddAddressData(
 	country,
 	locality,
@@ -161,6 +173,10 @@ func (e *HumanRegisteredEvent) UniqueConstraints() []*eventstore.EventUniqueCons
 	return []*eventstore.EventUniqueConstraint{NewAddUsernameUniqueConstraint(e.UserName, e.Aggregate().ResourceOwner, e.userLoginMustBeDomain)}
 }
 
+func (e *HumanRegisteredEvent) PersonalDataFields() []string {
+	return humanPersonalDataFields
+}
+
 func (e *HumanRegisteredEvent) AddAddressData(
 	country,
 	locality,
@@ -33,6 +33,10 @@ func (e *HumanAddressChangedEvent) UniqueConstraints() []*eventstore.EventUnique
 	return nil
 }
 
+func (e *HumanAddressChangedEvent) PersonalDataFields() []string {
+	return addressPersonalDataFields
+}
+
 func NewAddressChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
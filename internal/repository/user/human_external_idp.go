@@ -51,6 +51,10 @@ func (e *UserIDPLinkAddedEvent) UniqueConstraints() []*eventstore.EventUniqueCon
 	return []*eventstore.EventUniqueConstraint{NewAddUserIDPLinkUniqueConstraint(e.IDPConfigID, e.ExternalUserID)}
 }
 
+func (e *UserIDPLinkAddedEvent) PersonalDataFields() []string {
+	return []string{"displayName"}
+}
+
 func NewUserIDPLinkAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -36,6 +36,10 @@ func (e *HumanProfileChangedEvent) UniqueConstraints() []*eventstore.EventUnique
 	return nil
 }
 
+func (e *HumanProfileChangedEvent) PersonalDataFields() []string {
+	return profilePersonalDataFields
+}
+
 func NewHumanProfileChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
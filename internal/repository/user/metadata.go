@@ -18,6 +18,12 @@ type MetadataSetEvent struct {
 	metadata.SetEvent
 }
 
+// PersonalDataFields encrypts the value, which might contain personal data,
+// the key is needed to identify the metadata
+func (e *MetadataSetEvent) PersonalDataFields() []string {
+	return []string{"value"}
+}
+
 func NewMetadataSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, key string, value []byte) *MetadataSetEvent {
 	return &MetadataSetEvent{
 		SetEvent: *metadata.NewSetEvent(
@@ -22,6 +22,7 @@ const (
 	UserRemovedType           = userEventTypePrefix + "removed"
 	UserTokenAddedType        = userEventTypePrefix + "token.added"
 	UserTokenRemovedType      = userEventTypePrefix + "token.removed"
+	UserTokenExchangedType    = userEventTypePrefix + "token.exchanged"
 	UserDomainClaimedType     = userEventTypePrefix + "domain.claimed"
 	UserDomainClaimedSentType = userEventTypePrefix + "domain.claimed.sent"
 	UserUserNameChangedType   = userEventTypePrefix + "username.changed"
@@ -183,6 +184,12 @@ func (e *UserRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstrai
 	return events
 }
 
+// ForgetsPersonalData destroys the key of the personal data of the user,
+// the personal data of the previous events can't be read anymore
+func (e *UserRemovedEvent) ForgetsPersonalData() bool {
+	return true
+}
+
 func NewUserRemovedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -219,6 +226,7 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	DPoPJKT           string    `json:"dpopJkt,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -240,6 +248,7 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	dpopJKT string,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -255,6 +264,7 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		DPoPJKT:           dpopJKT,
 	}
 }
 
@@ -311,6 +321,65 @@ func UserTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, err
 	return tokenRemoved, nil
 }
 
+type UserTokenExchangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID           string   `json:"clientId"`
+	ActorUserID        string   `json:"actorUserId,omitempty"`
+	SubjectTokenType   string   `json:"subjectTokenType"`
+	ActorTokenType     string   `json:"actorTokenType,omitempty"`
+	RequestedTokenType string   `json:"requestedTokenType"`
+	Audience           []string `json:"audience"`
+	Scopes             []string `json:"scopes"`
+}
+
+func (e *UserTokenExchangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserTokenExchangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserTokenExchangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	actorUserID,
+	subjectTokenType,
+	actorTokenType,
+	requestedTokenType string,
+	audience,
+	scopes []string,
+) *UserTokenExchangedEvent {
+	return &UserTokenExchangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserTokenExchangedType,
+		),
+		ClientID:           clientID,
+		ActorUserID:        actorUserID,
+		SubjectTokenType:   subjectTokenType,
+		ActorTokenType:     actorTokenType,
+		RequestedTokenType: requestedTokenType,
+		Audience:           audience,
+		Scopes:             scopes,
+	}
+}
+
+func UserTokenExchangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	tokenExchanged := &UserTokenExchangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, tokenExchanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ghe3q", "unable to unmarshal token exchanged")
+	}
+
+	return tokenExchanged, nil
+}
+
 type DomainClaimedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -330,6 +399,10 @@ func (e *DomainClaimedEvent) UniqueConstraints() []*eventstore.EventUniqueConstr
 	}
 }
 
+func (e *DomainClaimedEvent) PersonalDataFields() []string {
+	return []string{"userName"}
+}
+
 func NewDomainClaimedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -412,6 +485,10 @@ func (e *UsernameChangedEvent) UniqueConstraints() []*eventstore.EventUniqueCons
 	}
 }
 
+func (e *UsernameChangedEvent) PersonalDataFields() []string {
+	return []string{"userName"}
+}
+
 func NewUsernameChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// subscribableEventTypes are the event types webhooks can subscribe to.
+// Events containing secrets (e.g. codes, client secrets or idp configs) are not part of it,
+// the encrypted and hashed values of the listed events are removed from the delivered payload
+var subscribableEventTypes = map[eventstore.EventType]bool{
+	user.UserV1AddedType:                 true,
+	user.UserV1RegisteredType:            true,
+	user.HumanAddedType:                  true,
+	user.HumanRegisteredType:             true,
+	user.MachineAddedEventType:           true,
+	user.MachineChangedEventType:         true,
+	user.UserLockedType:                  true,
+	user.UserUnlockedType:                true,
+	user.UserDeactivatedType:             true,
+	user.UserReactivatedType:             true,
+	user.UserRemovedType:                 true,
+	user.UserUserNameChangedType:         true,
+	user.UserDomainClaimedType:           true,
+	user.HumanProfileChangedType:         true,
+	user.HumanEmailChangedType:           true,
+	user.HumanEmailVerifiedType:          true,
+	user.HumanPhoneChangedType:           true,
+	user.HumanPhoneRemovedType:           true,
+	user.HumanPhoneVerifiedType:          true,
+	user.HumanPasswordChangedType:        true,
+	user.HumanPasswordCheckSucceededType: true,
+	user.HumanPasswordCheckFailedType:    true,
+	user.HumanSignedOutType:              true,
+	user.HumanMFAOTPVerifiedType:         true,
+	user.HumanMFAOTPRemovedType:          true,
+	user.MetadataSetType:                 true,
+	user.MetadataRemovedType:             true,
+	user.MetadataRemovedAllType:          true,
+	usergrant.UserGrantAddedType:         true,
+	usergrant.UserGrantChangedType:       true,
+	usergrant.UserGrantRemovedType:       true,
+	usergrant.UserGrantDeactivatedType:   true,
+	usergrant.UserGrantReactivatedType:   true,
+	org.OrgAddedEventType:                true,
+	org.OrgChangedEventType:              true,
+	org.OrgDeactivatedEventType:          true,
+	org.OrgReactivatedEventType:          true,
+	org.OrgRemovedEventType:              true,
+	org.MemberAddedEventType:             true,
+	org.MemberChangedEventType:           true,
+	org.MemberRemovedEventType:           true,
+	project.ProjectAddedType:             true,
+	project.ProjectChangedType:           true,
+	project.ProjectDeactivatedType:       true,
+	project.ProjectReactivatedType:       true,
+	project.ProjectRemovedType:           true,
+	project.ApplicationAddedType:         true,
+	project.ApplicationChangedType:       true,
+	project.ApplicationDeactivatedType:   true,
+	project.ApplicationReactivatedType:   true,
+	project.ApplicationRemovedType:       true,
+	project.MemberAddedType:              true,
+	project.MemberChangedType:            true,
+	project.MemberRemovedType:            true,
+}
+
+// IsSubscribableEventType checks if webhooks can subscribe to the event type
+func IsSubscribableEventType(eventType eventstore.EventType) bool {
+	return subscribableEventTypes[eventType]
+}
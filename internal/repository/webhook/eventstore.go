@@ -0,0 +1,12 @@
+package webhook
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ChangedEventType, ChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, DeliverySucceededEventType, DeliverySucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, DeliveryFailedEventType, DeliveryFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RedeliveryRequestedEventType, RedeliveryRequestedEventMapper)
+}
@@ -0,0 +1,327 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	eventTypePrefix              = eventstore.EventType("webhook.")
+	AddedEventType               = eventTypePrefix + "added"
+	ChangedEventType             = eventTypePrefix + "changed"
+	RemovedEventType             = eventTypePrefix + "removed"
+	deliveryEventTypePrefix      = eventTypePrefix + "delivery."
+	DeliverySucceededEventType   = deliveryEventTypePrefix + "succeeded"
+	DeliveryFailedEventType      = deliveryEventTypePrefix + "failed"
+	RedeliveryRequestedEventType = deliveryEventTypePrefix + "redelivery.requested"
+)
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name       string              `json:"name"`
+	URL        string              `json:"url"`
+	EventTypes []string            `json:"eventTypes"`
+	SigningKey *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name,
+	url string,
+	eventTypes []string,
+	signingKey *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		Name:       name,
+		URL:        url,
+		EventTypes: eventTypes,
+		SigningKey: signingKey,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Ahk4e", "unable to unmarshal webhook added")
+	}
+
+	return e, nil
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name       *string   `json:"name,omitempty"`
+	URL        *string   `json:"url,omitempty"`
+	EventTypes *[]string `json:"eventTypes,omitempty"`
+}
+
+func (e *ChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []Changes,
+) (*ChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "WEBHOOK-Eiqu3", "Errors.NoChangesFound")
+	}
+	changeEvent := &ChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type Changes func(event *ChangedEvent)
+
+func ChangeName(name string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Name = &name
+	}
+}
+
+func ChangeURL(url string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.URL = &url
+	}
+}
+
+func ChangeEventTypes(eventTypes []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.EventTypes = &eventTypes
+	}
+}
+
+func ChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-gie4U", "unable to unmarshal webhook changed")
+	}
+
+	return e, nil
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+	}
+}
+
+func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+// DeliveredEvent identifies the event delivered to the webhook
+type DeliveredEvent struct {
+	EventSequence      uint64                   `json:"eventSequence"`
+	DeliveredEventType eventstore.EventType     `json:"eventType"`
+	AggregateType      eventstore.AggregateType `json:"aggregateType"`
+	AggregateID        string                   `json:"aggregateId"`
+	EventResourceOwner string                   `json:"eventResourceOwner"`
+}
+
+func NewDeliveredEvent(event eventstore.Event) DeliveredEvent {
+	return DeliveredEvent{
+		EventSequence:      event.Sequence(),
+		DeliveredEventType: event.Type(),
+		AggregateType:      event.Aggregate().Type,
+		AggregateID:        event.Aggregate().ID,
+		EventResourceOwner: event.Aggregate().ResourceOwner,
+	}
+}
+
+type DeliverySucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	DeliveredEvent
+
+	Attempts uint64 `json:"attempts"`
+}
+
+func (e *DeliverySucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeliverySucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDeliverySucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delivered DeliveredEvent,
+	attempts uint64,
+) *DeliverySucceededEvent {
+	return &DeliverySucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliverySucceededEventType,
+		),
+		DeliveredEvent: delivered,
+		Attempts:       attempts,
+	}
+}
+
+func DeliverySucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DeliverySucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-ua0Ph", "unable to unmarshal webhook delivery succeeded")
+	}
+
+	return e, nil
+}
+
+// DeliveryFailedEvent is pushed after all delivery attempts of an event failed,
+// the event is kept as dead letter until it is delivered successfully
+type DeliveryFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	DeliveredEvent
+
+	Attempts uint64 `json:"attempts"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func (e *DeliveryFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeliveryFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDeliveryFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delivered DeliveredEvent,
+	attempts uint64,
+	reason string,
+) *DeliveryFailedEvent {
+	return &DeliveryFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveryFailedEventType,
+		),
+		DeliveredEvent: delivered,
+		Attempts:       attempts,
+		Reason:         reason,
+	}
+}
+
+func DeliveryFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DeliveryFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Oht8a", "unable to unmarshal webhook delivery failed")
+	}
+
+	return e, nil
+}
+
+// RedeliveryRequestedEvent requests another delivery of a dead letter
+type RedeliveryRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	DeliveredEvent
+}
+
+func (e *RedeliveryRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RedeliveryRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRedeliveryRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delivered DeliveredEvent,
+) *RedeliveryRequestedEvent {
+	return &RedeliveryRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RedeliveryRequestedEventType,
+		),
+		DeliveredEvent: delivered,
+	}
+}
+
+func RedeliveryRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RedeliveryRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Ra3oo", "unable to unmarshal webhook redelivery requested")
+	}
+
+	return e, nil
+}
@@ -41,6 +41,7 @@ type RefreshTokenView struct {
 	Expiration            time.Time            `json:"-" gorm:"column:expiration"`
 	Sequence              uint64               `json:"-" gorm:"column:sequence"`
 	InstanceID            string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
+	DPoPJKT               string               `json:"-" gorm:"column:dpop_jkt"`
 }
 
 func RefreshTokenViewsToModel(tokens []*RefreshTokenView) []*usr_model.RefreshTokenView {
@@ -68,6 +69,7 @@ func RefreshTokenViewToModel(token *RefreshTokenView) *usr_model.RefreshTokenVie
 		IdleExpiration:        token.IdleExpiration,
 		Expiration:            token.Expiration,
 		Sequence:              token.Sequence,
+		DPoPJKT:               token.DPoPJKT,
 	}
 }
 
@@ -137,6 +139,7 @@ func (t *RefreshTokenView) appendAddedEvent(event *es_models.Event) error {
 	t.Scopes = e.Scopes
 	t.Token = e.TokenID
 	t.UserAgentID = e.UserAgentID
+	t.DPoPJKT = e.DPoPJKT
 	return nil
 }
 